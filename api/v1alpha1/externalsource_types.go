@@ -23,6 +23,8 @@ SOFTWARE.
 package v1alpha1
 
 import (
+	"github.com/fluxcd/pkg/apis/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -30,21 +32,108 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // ExternalSourceSpec defines the desired state of ExternalSource
+// +kubebuilder:validation:XValidation:rule="has(self.interval) != has(self.schedule)",message="exactly one of interval or schedule must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.timeZone) || has(self.schedule)",message="timeZone requires schedule"
 type ExternalSourceSpec struct {
-	// Interval specifies the reconciliation frequency
+	// Interval specifies the reconciliation frequency, mutually exclusive with Schedule. It is
+	// clamped to the minimum and maximum interval of the controller, 1m and none by default.
 	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
 	// +kubebuilder:validation:MinLength=2
-	// +required
-	Interval string `json:"interval"`
+	// +optional
+	Interval string `json:"interval,omitempty"`
+
+	// Schedule is a standard cron expression specifying when to reconcile,
+	// mutually exclusive with Interval
+	// +kubebuilder:validation:Pattern=`^(@(yearly|annually|monthly|weekly|daily|midnight|hourly)|@every\s+\S+|\S+(\s+\S+){4})$`
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// TimeZone is the IANA time zone Schedule is evaluated in, defaults to UTC
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Timeout bounds a whole reconciliation, from the pre-request hooks through the fetch and
+	// post-request hooks to storing the artifact. It defaults to the interval, and to the
+	// controller maximum reconcile timeout (10m unless overridden) for schedules or when the
+	// interval is longer, and must not exceed that maximum. A timed out reconciliation is
+	// retried with backoff.
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
 
 	// Suspend tells the controller to suspend reconciliation for this ExternalSource
 	// +optional
 	Suspend bool `json:"suspend,omitempty"`
 
-	// DestinationPath specifies the relative path within the artifact where the data should be placed
+	// MaintenanceWindows are recurring periods of upstream maintenance during which the source
+	// is not fetched. The Ready condition keeps its status with the Maintenance reason, and the
+	// source is reconciled again when the window ends.
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// DependsOn lists ExternalSources that must be ready with a current artifact before this
+	// source is fetched. The namespace defaults to the namespace of this ExternalSource. While a
+	// dependency is not ready the Ready condition is False with the DependencyNotReady reason.
+	// +optional
+	DependsOn []meta.NamespacedObjectReference `json:"dependsOn,omitempty"`
+
+	// DestinationPath specifies the relative path within the artifact where the data should be
+	// placed, defaults to the controller configuration ("data" unless overridden). It may contain
+	// the placeholders {{ .Name }} and {{ .Namespace }}, resolved against the ExternalSource.
+	// Absolute paths and paths containing '..' are rejected.
+	// +kubebuilder:validation:XValidation:rule="!self.startsWith('/') && !self.contains('..')",message="destinationPath must be relative and must not contain '..'"
+	// +kubebuilder:validation:XValidation:rule=`!self.contains('{{') || self.matches(r'^([^{}]|\{\{\s*(\.Name|\.Namespace)\s*\}\})*$')`,message="destinationPath placeholders may only reference .Name or .Namespace"
 	// +optional
 	DestinationPath string `json:"destinationPath,omitempty"`
 
+	// Decryption decrypts the fetched data before post-request hooks run
+	// +optional
+	Decryption *DecryptionSpec `json:"decryption,omitempty"`
+
+	// Validation checks the data after hooks have run and before it is packaged. Data that
+	// fails validation is not published and the previous artifact is kept.
+	// +optional
+	Validation *ContentValidationSpec `json:"validation,omitempty"`
+
+	// DigestAlgorithm selects the algorithm used to compute the artifact revision and digest,
+	// defaults to the controller configuration (sha256 unless overridden)
+	// +kubebuilder:validation:Enum=sha256;sha512;blake3
+	// +optional
+	DigestAlgorithm string `json:"digestAlgorithm,omitempty"`
+
+	// Canonicalize rewrites JSON and YAML content with sorted keys and uniform formatting
+	// before it is hashed and packaged, so reordered keys or whitespace changes don't
+	// produce a new revision. The artifact contains the canonical form.
+	// +optional
+	Canonicalize bool `json:"canonicalize,omitempty"`
+
+	// ConditionalStrategy selects how unchanged data is detected. With etag the version
+	// reported by the source (ETag, Last-Modified or version endpoint) is used for conditional
+	// requests. With contentHash the data is always fetched in full and compared against the
+	// revision of the current artifact, for sources whose version values are unreliable.
+	// +kubebuilder:validation:Enum=etag;contentHash
+	// +kubebuilder:default=etag
+	// +optional
+	ConditionalStrategy string `json:"conditionalStrategy,omitempty"`
+
+	// StoragePrefix places the artifacts of this source under the given key prefix, nested below
+	// the controller storage prefix, e.g. to satisfy per-team path policies on a shared bucket.
+	// Changing it leaves artifacts stored under the previous prefix in place.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9._-]+(/[A-Za-z0-9._-]+)*/?$`
+	// +kubebuilder:validation:XValidation:rule="!self.matches('(^|/)[.][.]?(/|$)')",message="storagePrefix must not contain '.' or '..' segments"
+	// +optional
+	StoragePrefix string `json:"storagePrefix,omitempty"`
+
+	// HistoryLimit is the number of artifact revisions kept in storage, including the current
+	// one, defaults to the controller configuration (1 unless overridden)
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+
+	// Notify sends a webhook notification when the artifact revision changes
+	// +optional
+	Notify *NotifySpec `json:"notify,omitempty"`
+
 	// MaxRetries specifies the maximum number of retry attempts across all hooks and the request
 	// +kubebuilder:default=3
 	// +optional
@@ -59,9 +148,29 @@ type ExternalSourceSpec struct {
 	Generator GeneratorSpec `json:"generator"`
 }
 
+// MaintenanceWindow defines a recurring period during which the source is not fetched
+type MaintenanceWindow struct {
+	// Schedule is a standard cron expression specifying when the window starts, @every is
+	// not supported as it has no fixed start
+	// +kubebuilder:validation:Pattern=`^(@(yearly|annually|monthly|weekly|daily|midnight|hourly)|\S+(\s+\S+){4})$`
+	// +required
+	Schedule string `json:"schedule"`
+
+	// Duration is how long the window lasts after each start
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// +required
+	Duration string `json:"duration"`
+
+	// TimeZone is the IANA time zone Schedule is evaluated in, defaults to UTC
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
 // HooksSpec defines pre-request and post-request hooks configuration
 type HooksSpec struct {
-	// PreRequest hooks are executed before the HTTP request
+	// PreRequest hooks are executed before every request of the http generator. Each hook
+	// receives the request as {"method", "url", "headers"} JSON on stdin and writes the
+	// headers to set on the request as {"headers": {...}} JSON to stdout, e.g. to add a signature
 	// +optional
 	PreRequest []HookSpec `json:"preRequest,omitempty"`
 
@@ -98,6 +207,19 @@ type HookSpec struct {
 	// Env specifies environment variables for the hook
 	// +optional
 	Env []EnvVar `json:"env,omitempty"`
+
+	// EnvFrom specifies environment variables for the hook sourced from secret keys,
+	// resolved by the controller so values never appear in the spec or hook args
+	// +optional
+	EnvFrom []EnvFromSource `json:"envFrom,omitempty"`
+
+	// MaxMemory caps the resident memory of the hook process, limited by the hook executor whitelist
+	// +optional
+	MaxMemory *resource.Quantity `json:"maxMemory,omitempty"`
+
+	// MaxOutputSize caps the size of the hook output, limited by the hook executor whitelist
+	// +optional
+	MaxOutputSize *resource.Quantity `json:"maxOutputSize,omitempty"`
 }
 
 // EnvVar represents an environment variable
@@ -111,41 +233,469 @@ type EnvVar struct {
 	Value string `json:"value"`
 }
 
+// EnvFromSource represents an environment variable whose value is read from a secret key
+type EnvFromSource struct {
+	// Name of the environment variable
+	// +required
+	Name string `json:"name"`
+
+	// SecretKeyRef references the secret key holding the value, in the ExternalSource namespace
+	// +required
+	SecretKeyRef SecretKeyReference `json:"secretKeyRef"`
+}
+
 // GeneratorSpec defines the source generator configuration
+// +kubebuilder:validation:XValidation:rule="self.type != 'git' || has(self.git)",message="git configuration is required when type is git"
+// +kubebuilder:validation:XValidation:rule="self.type != 'file' || has(self.file)",message="file configuration is required when type is file"
+// +kubebuilder:validation:XValidation:rule="self.type != 's3' || has(self.s3)",message="s3 configuration is required when type is s3"
+// +kubebuilder:validation:XValidation:rule="self.type != 'grpc' || has(self.grpc)",message="grpc configuration is required when type is grpc"
+// +kubebuilder:validation:XValidation:rule="self.type != 'configMap' || has(self.configMap)",message="configMap configuration is required when type is configMap"
+// +kubebuilder:validation:XValidation:rule="self.type != 'secret' || has(self.secret)",message="secret configuration is required when type is secret"
 type GeneratorSpec struct {
 	// Type specifies the generator type
-	// +kubebuilder:validation:Enum=http
+	// +kubebuilder:validation:Enum=http;git;file;s3;grpc;configMap;secret
 	// +required
 	Type string `json:"type"`
 
 	// HTTP specifies HTTP generator configuration
 	// +optional
 	HTTP *HTTPGeneratorSpec `json:"http,omitempty"`
+
+	// Git specifies Git generator configuration
+	// +optional
+	Git *GitGeneratorSpec `json:"git,omitempty"`
+
+	// File specifies file generator configuration
+	// +optional
+	File *FileGeneratorSpec `json:"file,omitempty"`
+
+	// S3 specifies S3 generator configuration
+	// +optional
+	S3 *S3GeneratorSpec `json:"s3,omitempty"`
+
+	// GRPC specifies gRPC generator configuration
+	// +optional
+	GRPC *GRPCGeneratorSpec `json:"grpc,omitempty"`
+
+	// ConfigMap specifies the ConfigMap key in the namespace of the ExternalSource to use as
+	// source data, changes to the ConfigMap trigger a reconciliation
+	// +optional
+	ConfigMap *ConfigMapKeyReference `json:"configMap,omitempty"`
+
+	// Secret specifies the Secret key in the namespace of the ExternalSource to use as
+	// source data, changes to the Secret trigger a reconciliation. The Secret must be
+	// annotated with source.flux.oddkin.co/export: "true", as artifacts are served
+	// without authentication.
+	// +optional
+	Secret *SecretKeyReference `json:"secret,omitempty"`
 }
 
 // HTTPGeneratorSpec defines HTTP source generator configuration
+// +kubebuilder:validation:XValidation:rule="!has(self.body) || (has(self.method) && self.method in ['POST', 'PUT', 'PATCH'])",message="body is only allowed with POST, PUT or PATCH methods"
+// +kubebuilder:validation:XValidation:rule="!(has(self.basicAuthSecretRef) && has(self.bearerTokenSecretRef))",message="only one of basicAuthSecretRef or bearerTokenSecretRef may be set; an Authorization header in headersSecretRef takes precedence over both"
+// +kubebuilder:validation:XValidation:rule="!(has(self.oauth2) && (has(self.basicAuthSecretRef) || has(self.bearerTokenSecretRef)))",message="oauth2 cannot be combined with basicAuthSecretRef or bearerTokenSecretRef"
+// +kubebuilder:validation:XValidation:rule="!(has(self.caBundleSecretRef) && has(self.caBundleConfigMapRef))",message="only one of caBundleSecretRef or caBundleConfigMapRef may be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.range) || !has(self.method) || self.method == 'GET'",message="range is only allowed with the GET method"
+// +kubebuilder:validation:XValidation:rule="!has(self.revisionParam) || has(self.revision)",message="revisionParam requires revision"
 type HTTPGeneratorSpec struct {
-	// URL is the HTTP endpoint to fetch data from
+	// URL is the HTTP endpoint to fetch data from. It may contain Go template placeholders
+	// resolved against the ExternalSource: {{ .Name }}, {{ .Namespace }}, {{ .Labels.key }}
+	// and {{ index .Labels "example.com/key" }}.
 	// +kubebuilder:validation:Format=uri
+	// +kubebuilder:validation:XValidation:rule=`!self.contains('{{') || self.matches(r'^([^{}]|\{\{\s*(\.Name|\.Namespace|\.Labels\.[A-Za-z_][A-Za-z0-9_]*|index \.Labels "[A-Za-z0-9./_-]+")\s*\}\})*$')`,message="url placeholders may only reference .Name, .Namespace or .Labels"
 	// +required
 	URL string `json:"url"`
 
+	// VersionURL is an optional endpoint that returns the current version of the content at
+	// URL, such as a hash. When set, the controller polls it with a GET request and only
+	// fetches URL when the response changes. It supports the same placeholders as URL.
+	// +kubebuilder:validation:Format=uri
+	// +kubebuilder:validation:XValidation:rule=`!self.contains('{{') || self.matches(r'^([^{}]|\{\{\s*(\.Name|\.Namespace|\.Labels\.[A-Za-z_][A-Za-z0-9_]*|index \.Labels "[A-Za-z0-9./_-]+")\s*\}\})*$')`,message="versionURL placeholders may only reference .Name, .Namespace or .Labels"
+	// +optional
+	VersionURL string `json:"versionURL,omitempty"`
+
 	// Method specifies the HTTP method to use
 	// +kubebuilder:default=GET
 	// +optional
 	Method string `json:"method,omitempty"`
 
+	// Headers are non-sensitive HTTP headers sent with the request. Headers from
+	// HeadersSecretRef take precedence when the same header is set in both.
+	// Credentials must use a secret, so Authorization cannot be set inline.
+	// +kubebuilder:validation:XValidation:rule="!self.exists(k, k.lowerAscii() == 'authorization')",message="Authorization must not be set inline, use headersSecretRef, basicAuthSecretRef or bearerTokenSecretRef"
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+
 	// HeadersSecretRef references a secret containing HTTP headers
 	// +optional
-	HeadersSecretRef *SecretReference `json:"headersSecretRef,omitempty"`
+	HeadersSecretRef *HeadersSecretReference `json:"headersSecretRef,omitempty"`
+
+	// Accept is sent as the Accept header to choose the format of sources serving several,
+	// such as application/yaml. An Accept header in Headers or HeadersSecretRef takes
+	// precedence. The Content-Type of the response is recorded with the fetched data.
+	// +optional
+	Accept string `json:"accept,omitempty"`
+
+	// BasicAuthSecretRef references a secret with username and password keys used for
+	// HTTP basic authentication. An Authorization header in HeadersSecretRef takes precedence.
+	// +optional
+	BasicAuthSecretRef *SecretReference `json:"basicAuthSecretRef,omitempty"`
+
+	// BearerTokenSecretRef references a secret with a token key sent as a bearer token.
+	// An Authorization header in HeadersSecretRef takes precedence.
+	// +optional
+	BearerTokenSecretRef *SecretReference `json:"bearerTokenSecretRef,omitempty"`
+
+	// OAuth2 obtains a bearer token with the OAuth2 client credentials grant and sends it
+	// with every request. An Authorization header in HeadersSecretRef takes precedence.
+	// +optional
+	OAuth2 *OAuth2ClientCredentials `json:"oauth2,omitempty"`
 
 	// CABundleSecretRef references a secret containing a CA bundle for TLS verification
 	// +optional
 	CABundleSecretRef *SecretKeyReference `json:"caBundleSecretRef,omitempty"`
 
+	// CABundleConfigMapRef references a ConfigMap containing a CA bundle for TLS verification,
+	// mutually exclusive with CABundleSecretRef
+	// +optional
+	CABundleConfigMapRef *ConfigMapKeyReference `json:"caBundleConfigMapRef,omitempty"`
+
+	// ClientCertSecretRef references a secret with tls.crt and tls.key keys holding
+	// a client certificate and private key for mutual TLS
+	// +optional
+	ClientCertSecretRef *SecretReference `json:"clientCertSecretRef,omitempty"`
+
 	// InsecureSkipVerify skips TLS certificate verification (not recommended for production)
 	// +optional
 	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+
+	// Timeout specifies the maximum duration for the HTTP request, overriding the controller default
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
+
+	// Body is the request body to send with POST, PUT or PATCH requests
+	// +optional
+	Body string `json:"body,omitempty"`
+
+	// BodyEncoding specifies how Body is encoded, either inline text or base64
+	// +kubebuilder:validation:Enum=text;base64
+	// +optional
+	BodyEncoding string `json:"bodyEncoding,omitempty"`
+
+	// QueryParams are added to the query string of the request URL
+	// +optional
+	QueryParams map[string]string `json:"queryParams,omitempty"`
+
+	// Revision pins the source to a version of versioned content, requested with the
+	// RevisionParam query parameter. Once an artifact for the revision is stored the
+	// source is not fetched again until the revision or the spec changes.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// RevisionParam is the query parameter carrying Revision, defaults to version
+	// +optional
+	RevisionParam string `json:"revisionParam,omitempty"`
+
+	// MaxRedirects is the maximum number of redirects to follow, 0 disables redirects
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=10
+	// +optional
+	MaxRedirects *int `json:"maxRedirects,omitempty"`
+
+	// KeepAuthorizationOnRedirect forwards the Authorization header when a redirect
+	// changes host; by default it is stripped so credentials don't leak to other hosts
+	// +optional
+	KeepAuthorizationOnRedirect bool `json:"keepAuthorizationOnRedirect,omitempty"`
+
+	// Retries is how many times a GET or HEAD request failing with a connection error or
+	// a 5xx response is retried within a fetch, 0 disables retries. Client errors are
+	// never retried.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	Retries *int `json:"retries,omitempty"`
+
+	// RetryBackoff is the delay before the first retry, doubled on each further retry
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// +optional
+	RetryBackoff string `json:"retryBackoff,omitempty"`
+
+	// MaxResponseSize is the maximum size of the response body, overriding the controller
+	// default. Larger responses fail without replacing the current artifact.
+	// +optional
+	MaxResponseSize *resource.Quantity `json:"maxResponseSize,omitempty"`
+
+	// DigestHeader is the response header holding a digest of the body, which is verified
+	// before the content is packaged. The value may be in Digest (sha-256=<base64>),
+	// Content-Digest (sha-256=:<base64>:) or plain hex form. When not set the Content-Digest
+	// and Digest headers are checked. A mismatch fails the fetch and is retried.
+	// +optional
+	DigestHeader string `json:"digestHeader,omitempty"`
+
+	// Range fetches only part of the content with an HTTP Range request. When the server
+	// ignores the range the full content is fetched.
+	// +optional
+	Range *HTTPRangeSpec `json:"range,omitempty"`
+
+	// Cursor extracts a value, such as a continuation token, from each response and sends it
+	// with the next request, for APIs that are synced incrementally
+	// +optional
+	Cursor *HTTPCursorSpec `json:"cursor,omitempty"`
+}
+
+// HTTPCursorSpec defines how a cursor is extracted from a response and sent with the next request.
+// The cursor is taken from the response before hooks run and is recorded in status.cursor once
+// the content of the response is stored, so a failed reconcile repeats the request with the
+// previous cursor. The cursor is reset when the spec changes.
+// +kubebuilder:validation:XValidation:rule="has(self.header) != has(self.queryParam)",message="exactly one of header or queryParam must be set"
+type HTTPCursorSpec struct {
+	// JSONPath selects the cursor in the JSON response body, such as {.meta.next}. It must
+	// select a single string, number or boolean; when it selects nothing or null the
+	// current cursor is kept.
+	// +kubebuilder:validation:Pattern=`^\{.+\}$`
+	// +required
+	JSONPath string `json:"jsonPath"`
+
+	// Header is the request header the cursor is sent in
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9!#$%&'*+.^_|~-]+$`
+	// +kubebuilder:validation:XValidation:rule="self.lowerAscii() != 'authorization'",message="the cursor cannot be sent in the Authorization header"
+	// +optional
+	Header string `json:"header,omitempty"`
+
+	// QueryParam is the query parameter the cursor is sent in
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	QueryParam string `json:"queryParam,omitempty"`
+
+	// Initial is sent until a cursor has been extracted, nothing is sent when empty
+	// +optional
+	Initial string `json:"initial,omitempty"`
+}
+
+// HTTPRangeSpec defines the byte range fetched from an HTTP source
+// +kubebuilder:validation:XValidation:rule="!has(self.suffixLength) || !(has(self.offset) || has(self.length) || (has(self.incremental) && self.incremental))",message="suffixLength cannot be combined with offset, length or incremental"
+// +kubebuilder:validation:XValidation:rule="!has(self.mode) || self.mode != 'Append' || (has(self.incremental) && self.incremental)",message="Append mode requires incremental"
+type HTTPRangeSpec struct {
+	// Offset is the first byte fetched
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Offset *int64 `json:"offset,omitempty"`
+
+	// Length is the maximum number of bytes fetched, to the end of the content when not set
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Length *int64 `json:"length,omitempty"`
+
+	// SuffixLength fetches the last bytes of the content, such as the tail of a log file
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SuffixLength *int64 `json:"suffixLength,omitempty"`
+
+	// Incremental continues each fetch from the end of the range last fetched, tracked in
+	// status.range, so only content appended since then is transferred. Offset is only used
+	// for the first fetch.
+	// +optional
+	Incremental bool `json:"incremental,omitempty"`
+
+	// Mode is how fetched content is packaged: Replace packages only the fetched range,
+	// Append adds it to the content of the current artifact
+	// +kubebuilder:validation:Enum=Replace;Append
+	// +kubebuilder:default=Replace
+	// +optional
+	Mode string `json:"mode,omitempty"`
+}
+
+// GitGeneratorSpec defines Git source generator configuration
+type GitGeneratorSpec struct {
+	// URL is the Git repository URL (http, https or ssh)
+	// +kubebuilder:validation:Pattern=`^(https?|ssh)://.*$`
+	// +required
+	URL string `json:"url"`
+
+	// Ref specifies the Git reference to check out, defaults to the remote HEAD
+	// +optional
+	Ref *GitRepositoryRef `json:"ref,omitempty"`
+
+	// Path is the path of the file within the repository to use as source data
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Path string `json:"path"`
+
+	// SecretRef references a secret containing Git credentials, either
+	// username and password for HTTPS, or identity and known_hosts for SSH
+	// +optional
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
+}
+
+// GitRepositoryRef specifies the Git reference to resolve
+// +kubebuilder:validation:XValidation:rule="!(has(self.branch) && has(self.tag))",message="only one of branch or tag may be set"
+type GitRepositoryRef struct {
+	// Branch to check out
+	// +optional
+	Branch string `json:"branch,omitempty"`
+
+	// Tag to check out. A tag pins the source: once an artifact for the tag is stored the
+	// repository is not checked again until the tag or the spec changes.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+}
+
+// FileGeneratorSpec defines file source generator configuration
+type FileGeneratorSpec struct {
+	// Path is the path of the file relative to the controller's file generator root directory,
+	// typically a volume shared with a sidecar
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^[^/]`
+	// +required
+	Path string `json:"path"`
+}
+
+// S3GeneratorSpec defines S3 source generator configuration
+type S3GeneratorSpec struct {
+	// Endpoint is the URL of the S3-compatible service, objects are addressed path-style
+	// +kubebuilder:validation:Pattern=`^https?://.*$`
+	// +required
+	Endpoint string `json:"endpoint"`
+
+	// Bucket is the name of the bucket holding the object
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Bucket string `json:"bucket"`
+
+	// Key is the key of the object to use as source data
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Key string `json:"key"`
+
+	// Region is the region used to sign requests, defaults to us-east-1
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// SecretRef references a secret containing accesskey and secretkey credentials,
+	// requests are sent unsigned when omitted
+	// +optional
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
+}
+
+// GRPCGeneratorSpec defines gRPC source generator configuration
+// +kubebuilder:validation:XValidation:rule="!(has(self.plaintext) && self.plaintext) || !(has(self.caBundleSecretRef) || has(self.clientCertSecretRef))",message="caBundleSecretRef and clientCertSecretRef cannot be used with plaintext"
+type GRPCGeneratorSpec struct {
+	// Target is the server address in gRPC name syntax, such as config.internal:443
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Target string `json:"target"`
+
+	// Method is the fully-qualified unary method to invoke, such as
+	// example.config.v1.ConfigService/GetConfig. The request and response types are
+	// resolved with server reflection, which the server must enable.
+	// +kubebuilder:validation:Pattern=`^/?[A-Za-z_][A-Za-z0-9_.]*/[A-Za-z_][A-Za-z0-9_]*$`
+	// +required
+	Method string `json:"method"`
+
+	// Request is the request message in protobuf JSON form, defaults to an empty message
+	// +optional
+	Request string `json:"request,omitempty"`
+
+	// Plaintext connects without TLS
+	// +optional
+	Plaintext bool `json:"plaintext,omitempty"`
+
+	// CABundleSecretRef references a secret containing a CA bundle for TLS verification
+	// +optional
+	CABundleSecretRef *SecretKeyReference `json:"caBundleSecretRef,omitempty"`
+
+	// ClientCertSecretRef references a secret with tls.crt and tls.key keys holding
+	// a client certificate and private key for mutual TLS
+	// +optional
+	ClientCertSecretRef *SecretReference `json:"clientCertSecretRef,omitempty"`
+
+	// MetadataSecretRef references a secret whose keys are sent as request metadata,
+	// such as authorization
+	// +optional
+	MetadataSecretRef *SecretReference `json:"metadataSecretRef,omitempty"`
+
+	// Timeout specifies the maximum duration for the call, including method resolution (default: 30s)
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// NotifySpec defines the webhook notified when the artifact revision changes
+// +kubebuilder:validation:XValidation:rule="has(self.url) || has(self.secretRef)",message="one of url or secretRef must be set"
+type NotifySpec struct {
+	// URL receives a JSON POST request with the name, namespace, old and new revision
+	// and artifact URL of the source
+	// +kubebuilder:validation:Pattern=`^https?://.*$`
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// SecretRef references a secret with an optional address key that overrides URL,
+	// for webhook URLs that embed credentials, and an optional token key sent as a
+	// bearer token
+	// +optional
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
+}
+
+// ContentValidationSpec defines checks applied to fetched data before it is packaged
+type ContentValidationSpec struct {
+	// Format requires the data to parse as JSON, or as YAML mappings or sequences
+	// +kubebuilder:validation:Enum=json;yaml
+	// +kubebuilder:default=json
+	// +optional
+	Format string `json:"format,omitempty"`
+
+	// SchemaRef references a ConfigMap key holding a JSON schema the data must match.
+	// Each YAML document is validated separately. External schema references are not loaded.
+	// +optional
+	SchemaRef *ConfigMapKeyReference `json:"schemaRef,omitempty"`
+}
+
+// DecryptionSpec defines how fetched data is decrypted
+type DecryptionSpec struct {
+	// Provider is the decryption provider
+	// +kubebuilder:validation:Enum=sops
+	// +required
+	Provider string `json:"provider"`
+
+	// Format of the encrypted document, JSON is detected from the content and
+	// anything else is read as YAML when not set
+	// +kubebuilder:validation:Enum=json;yaml;dotenv;binary
+	// +optional
+	Format string `json:"format,omitempty"`
+
+	// SecretRef references a secret holding the private keys, age identities in
+	// entries ending in .agekey and ASCII armored PGP keys in entries ending in .asc
+	// +required
+	SecretRef SecretReference `json:"secretRef"`
+}
+
+// ConfigMapKeyReference contains the name of a ConfigMap and a key within that ConfigMap
+type ConfigMapKeyReference struct {
+	// Name of the ConfigMap
+	// +required
+	Name string `json:"name"`
+
+	// Key within the ConfigMap
+	// +required
+	Key string `json:"key"`
+}
+
+// OAuth2ClientCredentials configures token acquisition with the OAuth2 client credentials grant
+type OAuth2ClientCredentials struct {
+	// TokenURL is the token endpoint of the authorization server
+	// +kubebuilder:validation:Format=uri
+	// +kubebuilder:validation:Pattern=`^https?://.*$`
+	// +required
+	TokenURL string `json:"tokenURL"`
+
+	// ClientSecretRef references a secret with clientID and clientSecret keys
+	// +required
+	ClientSecretRef SecretReference `json:"clientSecretRef"`
+
+	// Scopes are the scopes requested for the token
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
 }
 
 // SecretReference contains the name of a secret
@@ -155,6 +705,25 @@ type SecretReference struct {
 	Name string `json:"name"`
 }
 
+// HeadersSecretReference references a secret whose keys are sent as HTTP headers. Every key
+// is used unless Keys or KeyPrefix selects a subset.
+// +kubebuilder:validation:XValidation:rule="!(has(self.keys) && has(self.keyPrefix))",message="only one of keys or keyPrefix may be set"
+type HeadersSecretReference struct {
+	// Name of the secret
+	// +required
+	Name string `json:"name"`
+
+	// Keys lists the secret keys to send as headers, each must exist in the secret
+	// +optional
+	Keys []string `json:"keys,omitempty"`
+
+	// KeyPrefix selects the secret keys starting with the prefix, the prefix is removed
+	// to form the header name
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	KeyPrefix string `json:"keyPrefix,omitempty"`
+}
+
 // SecretKeyReference contains the name of a secret and a key within that secret
 type SecretKeyReference struct {
 	// Name of the secret
@@ -178,13 +747,91 @@ type ExternalSourceStatus struct {
 	// +optional
 	Artifact *ArtifactMetadata `json:"artifact,omitempty"`
 
-	// LastHandledETag contains the ETag from the last successful fetch (for HTTP sources)
+	// LastHandledETag contains the ETag, or Last-Modified value when no ETag is sent, from the last successful fetch
 	// +optional
 	LastHandledETag string `json:"lastHandledETag,omitempty"`
 
+	// ResolvedRevision is the pinned revision the current artifact was produced from, with
+	// the commit it resolved to for Git tags, e.g. v1.2.0@sha1:<commit>. Empty for sources
+	// tracking the latest content.
+	// +optional
+	ResolvedRevision string `json:"resolvedRevision,omitempty"`
+
+	// LastFetchTime is when data was last fetched from the source, unchanged when a
+	// conditional fetch reports no changes
+	// +optional
+	LastFetchTime *metav1.Time `json:"lastFetchTime,omitempty"`
+
+	// LastConfirmedTime is when the source last confirmed the data of the current artifact
+	// is current, by a fetch returning data or a conditional fetch reporting no changes
+	// +optional
+	LastConfirmedTime *metav1.Time `json:"lastConfirmedTime,omitempty"`
+
+	// LastFetchDuration is how long the last fetch from the source took
+	// +optional
+	LastFetchDuration *metav1.Duration `json:"lastFetchDuration,omitempty"`
+
+	// ContentSize is the size in bytes of the data returned by the last fetch
+	// +optional
+	ContentSize int64 `json:"contentSize,omitempty"`
+
+	// FreshUntil is when the fetched data becomes stale according to the Cache-Control
+	// max-age or Expires headers of the response. The source is not fetched again before
+	// then unless its spec or a referenced secret changes or a reconcile is requested.
+	// +optional
+	FreshUntil *metav1.Time `json:"freshUntil,omitempty"`
+
+	// Range is the byte range of the source content held by the current artifact when
+	// the HTTP generator fetches a range
+	// +optional
+	Range *ContentRange `json:"range,omitempty"`
+
+	// Cursor is the value extracted with spec.generator.http.cursor from the response held
+	// by the current artifact, sent with the next request
+	// +optional
+	Cursor string `json:"cursor,omitempty"`
+
 	// ObservedGeneration is the last observed generation of the ExternalSource
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Retry tracks consecutive reconciliation failures, cleared on success or spec change
+	// +optional
+	Retry *RetryStatus `json:"retry,omitempty"`
+
+	meta.ReconcileRequestStatus `json:",inline"`
+}
+
+// ContentRange is a byte range of source content
+type ContentRange struct {
+	// Offset is the first byte of the range
+	Offset int64 `json:"offset"`
+
+	// Length is the number of bytes in the range
+	Length int64 `json:"length"`
+
+	// Size is the total size of the content reported by the server, 0 when unknown
+	// +optional
+	Size int64 `json:"size,omitempty"`
+}
+
+// RetryStatus tracks consecutive reconciliation failures for backoff
+type RetryStatus struct {
+	// Count is the number of consecutive failed attempts
+	Count int `json:"count"`
+
+	// LastFailure is the error message of the last failed attempt
+	// +optional
+	LastFailure string `json:"lastFailure,omitempty"`
+
+	// BackoffStartTime is when the first of the consecutive failures occurred
+	// +optional
+	BackoffStartTime *metav1.Time `json:"backoffStartTime,omitempty"`
+
+	// ConsecutiveSuccesses is the number of successful reconciles since the
+	// last failure, the retry state is cleared once it reaches the threshold
+	// +optional
+	ConsecutiveSuccesses int `json:"consecutiveSuccesses,omitempty"`
 }
 
 // ArtifactMetadata contains metadata about an artifact
@@ -209,7 +856,11 @@ type ArtifactMetadata struct {
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Fresh",type="string",JSONPath=".status.conditions[?(@.type==\"DataFresh\")].status"
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message"
+// +kubebuilder:printcolumn:name="Last Fetch",type="date",JSONPath=".status.lastFetchTime"
+// +kubebuilder:printcolumn:name="Size",type="integer",JSONPath=".status.contentSize",priority=1
+// +kubebuilder:printcolumn:name="Fetch Duration",type="string",JSONPath=".status.lastFetchDuration",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // ExternalSource is the Schema for the externalsources API
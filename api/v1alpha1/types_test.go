@@ -285,8 +285,9 @@ func TestHTTPGeneratorSpecWithSecretRefs(t *testing.T) {
 	original := HTTPGeneratorSpec{
 		URL:    "https://api.example.com",
 		Method: "GET",
-		HeadersSecretRef: &SecretReference{
+		HeadersSecretRef: &HeadersSecretReference{
 			Name: "headers-secret",
+			Keys: []string{"X-Api-Key"},
 		},
 		CABundleSecretRef: &SecretKeyReference{
 			Name: "ca-secret",
@@ -299,6 +300,9 @@ func TestHTTPGeneratorSpecWithSecretRefs(t *testing.T) {
 	assert.NotSame(t, &original, copied)
 	assert.NotSame(t, original.HeadersSecretRef, copied.HeadersSecretRef)
 	assert.Equal(t, original.HeadersSecretRef.Name, copied.HeadersSecretRef.Name)
+	assert.Equal(t, original.HeadersSecretRef.Keys, copied.HeadersSecretRef.Keys)
+	copied.HeadersSecretRef.Keys[0] = "modified"
+	assert.Equal(t, "X-Api-Key", original.HeadersSecretRef.Keys[0])
 	assert.NotSame(t, original.CABundleSecretRef, copied.CABundleSecretRef)
 	assert.Equal(t, original.CABundleSecretRef.Key, copied.CABundleSecretRef.Key)
 }
@@ -0,0 +1,230 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package main provides a command that runs an ExternalSource pipeline locally without a cluster.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	sourcev1alpha1 "github.com/oddkinco/flux-externalsource-controller/api/v1alpha1"
+	"github.com/oddkinco/flux-externalsource-controller/internal/artifact"
+	"github.com/oddkinco/flux-externalsource-controller/internal/config"
+	"github.com/oddkinco/flux-externalsource-controller/internal/controller"
+	"github.com/oddkinco/flux-externalsource-controller/internal/hooks"
+)
+
+var (
+	sourcePath    = flag.String("f", "", "Path to the ExternalSource YAML, - reads from stdin")
+	secretsPath   = flag.String("secrets", "", "Path to YAML Secret manifests used to resolve secret references")
+	hookEndpoint  = flag.String("hook-executor", "", "URL of a running externalsource-hook-executor, required when hooks are configured")
+	whitelistPath = flag.String("whitelist", "", "Path to the hook whitelist, required with -hook-executor")
+	hookTimeout   = flag.Duration("hook-timeout", 30*time.Second, "Default timeout for hooks without one")
+	outputPath    = flag.String("o", "", "Write the packaged artifact archive (.tar.gz, or .tar.zst with zstd compression) to this path instead of printing the data")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(),
+			"Usage: %s -f externalsource.yaml [flags]\n\n"+
+				"Fetches the source, runs post-request hooks and packages the artifact locally.\n"+
+				"Nothing is read from or written to a cluster or storage backend.\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *sourcePath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	if err := run(ctx); err != nil {
+		log.Fatalf("Dry run failed: %v", err)
+	}
+}
+
+// run performs the dry run and writes the result
+func run(ctx context.Context) error {
+	externalSource, err := loadExternalSource(*sourcePath)
+	if err != nil {
+		return err
+	}
+
+	secrets, err := loadSecrets(*secretsPath, externalSource.Namespace)
+	if err != nil {
+		return err
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(sourcev1alpha1.AddToScheme(scheme))
+
+	// Secret references resolve against the manifests given on the command line only
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secrets...).Build()
+
+	cfg := config.DefaultConfig()
+	cfg.LoadFromEnvironment()
+
+	// Packaging never touches storage, the artifact is only written locally
+	artifactManager := artifact.NewManager(nil)
+	if err := artifactManager.SetDigestAlgorithm(cfg.Storage.DigestAlgorithm); err != nil {
+		return err
+	}
+	if err := artifactManager.SetCompression(cfg.Storage.Compression); err != nil {
+		return err
+	}
+
+	reconciler := &controller.ExternalSourceReconciler{
+		Client:          k8sClient,
+		Scheme:          scheme,
+		ArtifactManager: artifactManager,
+		Config:          cfg,
+	}
+
+	if *hookEndpoint != "" {
+		if *whitelistPath == "" {
+			return errors.New("-whitelist is required with -hook-executor")
+		}
+		whitelistManager, err := hooks.NewWhitelistManagerForPath(*whitelistPath)
+		if err != nil {
+			return fmt.Errorf("failed to load hook whitelist: %w", err)
+		}
+		reconciler.HookExecutor = hooks.NewSidecarExecutor(*hookEndpoint, whitelistManager, *hookTimeout)
+	}
+
+	result, err := reconciler.DryRun(ctx, externalSource)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Revision: %s", result.Artifact.Revision)
+	log.Printf("Digest algorithm: %s", result.Artifact.Metadata["digestAlgorithm"])
+	log.Printf("Data size: %d bytes, archive size: %d bytes", len(result.Data), len(result.Artifact.Data))
+
+	if *outputPath != "" {
+		if err := os.WriteFile(*outputPath, result.Artifact.Data, 0o644); err != nil {
+			return fmt.Errorf("failed to write artifact: %w", err)
+		}
+		log.Printf("Artifact written to %s", *outputPath)
+		return nil
+	}
+
+	if _, err := os.Stdout.Write(result.Data); err != nil {
+		return fmt.Errorf("failed to write data: %w", err)
+	}
+	return nil
+}
+
+// loadExternalSource reads an ExternalSource manifest, defaulting the namespace
+func loadExternalSource(path string) (*sourcev1alpha1.ExternalSource, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ExternalSource: %w", err)
+	}
+
+	externalSource := &sourcev1alpha1.ExternalSource{}
+	if err := yaml.UnmarshalStrict(data, externalSource); err != nil {
+		return nil, fmt.Errorf("failed to parse ExternalSource: %w", err)
+	}
+	if externalSource.Kind != "" && externalSource.Kind != "ExternalSource" {
+		return nil, fmt.Errorf("expected kind ExternalSource, got %s", externalSource.Kind)
+	}
+	if externalSource.Namespace == "" {
+		externalSource.Namespace = "default"
+	}
+
+	return externalSource, nil
+}
+
+// loadSecrets reads Secret manifests from a multi-document YAML file, secrets without a
+// namespace are placed in the ExternalSource namespace
+func loadSecrets(path, namespace string) ([]client.Object, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open secrets: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var secrets []client.Object
+	decoder := k8syaml.NewYAMLOrJSONDecoder(file, 4096)
+	for {
+		secret := &corev1.Secret{}
+		if err := decoder.Decode(secret); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse secrets: %w", err)
+		}
+		if secret.Name == "" {
+			continue // Empty document
+		}
+		if secret.Kind != "" && secret.Kind != "Secret" {
+			return nil, fmt.Errorf("expected kind Secret, got %s for %s", secret.Kind, secret.Name)
+		}
+		if secret.Namespace == "" {
+			secret.Namespace = namespace
+		}
+
+		// stringData is only merged by the API server, do it here
+		for key, value := range secret.StringData {
+			if secret.Data == nil {
+				secret.Data = make(map[string][]byte)
+			}
+			secret.Data[key] = []byte(value)
+		}
+		secrets = append(secrets, secret)
+	}
+
+	return secrets, nil
+}
@@ -27,20 +27,35 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
 	"github.com/oddkinco/flux-externalsource-controller/internal/hooks"
 )
 
 var (
-	port          = flag.Int("port", 8081, "Port to listen on")
-	whitelistPath = flag.String("whitelist", "/etc/hooks/whitelist.yaml", "Path to whitelist configuration file")
+	port            = flag.Int("port", 8081, "Port to listen on")
+	whitelistPath   = flag.String("whitelist", "/etc/hooks/whitelist.yaml", "Path to whitelist configuration file, or a directory of whitelist files to merge")
+	logFormat       = flag.String("log-format", "text", "Log format, text or json")
+	metricsPort     = flag.Int("metrics-port", 0, "Port to serve /metrics on, 0 serves it on the main port")
+	maxConcurrent   = flag.Int("max-concurrent", 0, "Maximum number of commands executed at the same time, 0 means unlimited")
+	queueTimeout    = flag.Duration("queue-timeout", 0, "How long a request waits for a free execution slot before it is rejected with 429")
+	shutdownTimeout = flag.Duration("shutdown-timeout", 30*time.Second, "How long requests are still served after a termination signal, at least the controller's graceful shutdown timeout")
 )
 
 // ExecuteRequest represents the request to execute a command
@@ -50,6 +65,12 @@ type ExecuteRequest struct {
 	Timeout string            `json:"timeout"`
 	Env     map[string]string `json:"env"`
 	Stdin   string            `json:"stdin"` // base64 encoded
+
+	MaxMemoryBytes int64 `json:"maxMemoryBytes,omitempty"`
+	MaxOutputBytes int64 `json:"maxOutputBytes,omitempty"`
+
+	// CorrelationID identifies the reconcile the command runs for, it is logged and echoed back
+	CorrelationID string `json:"correlationID,omitempty"`
 }
 
 // ExecuteResponse represents the response from command execution
@@ -57,17 +78,33 @@ type ExecuteResponse struct {
 	Stdout   string `json:"stdout"` // base64 encoded
 	Stderr   string `json:"stderr"` // base64 encoded
 	ExitCode int    `json:"exitCode"`
+
+	// LimitExceeded is "memory" or "output" when the command was killed for exceeding a limit
+	LimitExceeded string `json:"limitExceeded,omitempty"`
+
+	// CorrelationID is the correlation ID of the request
+	CorrelationID string `json:"correlationID,omitempty"`
 }
 
+// memoryPollInterval is how often the resident memory of a command is checked
+const memoryPollInterval = 100 * time.Millisecond
+
+// drainTimeout is how long executions still running once the shutdown timeout passed may finish
+const drainTimeout = 5 * time.Second
+
 // Server handles hook execution requests
 type Server struct {
 	whitelistManager hooks.WhitelistManager
+	limiter          *hooks.ExecutionLimiter
+	metrics          *executorMetrics
 }
 
-// NewServer creates a new hook executor server
-func NewServer(whitelistManager hooks.WhitelistManager) *Server {
+// NewServer creates a new hook executor server, limiter and metrics may be nil
+func NewServer(whitelistManager hooks.WhitelistManager, limiter *hooks.ExecutionLimiter, metrics *executorMetrics) *Server {
 	return &Server{
 		whitelistManager: whitelistManager,
+		limiter:          limiter,
+		metrics:          metrics,
 	}
 }
 
@@ -85,9 +122,11 @@ func (s *Server) handleExecute(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	logger := slog.With("correlationID", req.CorrelationID, "command", req.Command)
+
 	// Validate command against whitelist
 	if !s.whitelistManager.IsAllowed(req.Command, req.Args) {
-		log.Printf("Command not whitelisted: %s %v", req.Command, req.Args)
+		logger.Warn("Command not whitelisted", "args", req.Args)
 		http.Error(w, fmt.Sprintf("Command %s is not whitelisted", req.Command), http.StatusForbidden)
 		return
 	}
@@ -114,34 +153,70 @@ func (s *Server) handleExecute(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Apply resource limits, the whitelist limits are both the default and the maximum
+	var limits hooks.ResourceLimits
+	if provider, ok := s.whitelistManager.(hooks.LimitProvider); ok {
+		limits = provider.Limits(req.Command)
+	}
+	limits.MaxMemoryBytes = effectiveLimit(req.MaxMemoryBytes, limits.MaxMemoryBytes)
+	limits.MaxOutputBytes = effectiveLimit(req.MaxOutputBytes, limits.MaxOutputBytes)
+
+	// Wait for an execution slot, a saturated executor asks the controller to retry later
+	release, err := s.limiter.Acquire(r.Context())
+	if errors.Is(err, hooks.ErrExecutorSaturated) {
+		logger.Warn("Rejecting command, executor saturated")
+		s.metrics.recordRejection()
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Request cancelled: %v", err), http.StatusServiceUnavailable)
+		return
+	}
+	defer release()
+
 	// Execute command
-	resp := s.executeCommand(r.Context(), req.Command, req.Args, stdin, req.Env, timeout)
+	startTime := time.Now()
+	finished := s.metrics.startExecution(req.Command)
+	resp := s.executeCommand(r.Context(), req.Command, req.Args, stdin, req.Env, timeout, limits)
+	finished(resp.ExitCode)
+	resp.CorrelationID = req.CorrelationID
+	if resp.LimitExceeded != "" {
+		logger.Warn("Command killed after exceeding resource limit", "limit", resp.LimitExceeded)
+	}
+	logger.Info("Command finished", "exitCode", resp.ExitCode, "duration", time.Since(startTime))
 
 	// Send response
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		log.Printf("Failed to encode response: %v", err)
+		logger.Error("Failed to encode response", "error", err)
 	}
 }
 
 // executeCommand executes a command with the given parameters
-func (s *Server) executeCommand(ctx context.Context, command string, args []string, stdin []byte, env map[string]string, timeout time.Duration) ExecuteResponse {
+func (s *Server) executeCommand(ctx context.Context, command string, args []string, stdin []byte, env map[string]string,
+	timeout time.Duration, limits hooks.ResourceLimits) ExecuteResponse {
 	// Create context with timeout
 	execCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	monitor := &limitMonitor{cancel: cancel}
+
 	// Create command
 	cmd := exec.CommandContext(execCtx, command, args...)
+	cmd.WaitDelay = time.Second
 
 	// Set up stdin
 	if len(stdin) > 0 {
 		cmd.Stdin = bytes.NewReader(stdin)
 	}
 
-	// Set up stdout and stderr buffers
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	// Set up stdout and stderr buffers, capped at the output limit
+	stdout := &limitedBuffer{limit: limits.MaxOutputBytes, monitor: monitor}
+	stderr := &limitedBuffer{limit: limits.MaxOutputBytes, monitor: monitor}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
 
 	// Set environment variables
 	if len(env) > 0 {
@@ -151,8 +226,18 @@ func (s *Server) executeCommand(ctx context.Context, command string, args []stri
 		}
 	}
 
-	// Execute command
-	err := cmd.Run()
+	// Execute command, watching its memory while it runs
+	err := cmd.Start()
+	if err == nil {
+		if limits.MaxMemoryBytes > 0 {
+			watchCtx, stopWatching := context.WithCancel(execCtx)
+			go watchMemory(watchCtx, cmd.Process.Pid, limits.MaxMemoryBytes, monitor)
+			err = cmd.Wait()
+			stopWatching()
+		} else {
+			err = cmd.Wait()
+		}
+	}
 
 	// Determine exit code
 	exitCode := 0
@@ -162,16 +247,128 @@ func (s *Server) executeCommand(ctx context.Context, command string, args []stri
 		} else {
 			// Command failed to start or other error
 			exitCode = 1
-			stderr.WriteString(fmt.Sprintf("\nExecution error: %v", err))
+			stderr.buf.WriteString(fmt.Sprintf("\nExecution error: %v", err))
+		}
+	}
+
+	limitExceeded := monitor.exceeded()
+	if limitExceeded != "" {
+		if exitCode <= 0 {
+			exitCode = 1
 		}
 	}
 
 	// Encode outputs
 	return ExecuteResponse{
-		Stdout:   base64.StdEncoding.EncodeToString(stdout.Bytes()),
-		Stderr:   base64.StdEncoding.EncodeToString(stderr.Bytes()),
-		ExitCode: exitCode,
+		Stdout:        base64.StdEncoding.EncodeToString(stdout.buf.Bytes()),
+		Stderr:        base64.StdEncoding.EncodeToString(stderr.buf.Bytes()),
+		ExitCode:      exitCode,
+		LimitExceeded: limitExceeded,
+	}
+}
+
+// effectiveLimit returns the requested limit capped at the maximum, zero means unlimited
+func effectiveLimit(requested, maximum int64) int64 {
+	if maximum <= 0 {
+		return requested
 	}
+	if requested <= 0 || requested > maximum {
+		return maximum
+	}
+	return requested
+}
+
+// limitMonitor records the first limit a command exceeded and kills it
+type limitMonitor struct {
+	mu     sync.Mutex
+	reason string
+	cancel context.CancelFunc
+}
+
+// exceed records the exceeded limit and cancels the command
+func (m *limitMonitor) exceed(reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.reason == "" {
+		m.reason = reason
+		m.cancel()
+	}
+}
+
+// exceeded returns the name of the exceeded limit, empty if none
+func (m *limitMonitor) exceeded() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.reason
+}
+
+// limitedBuffer buffers command output up to a limit, discarding the rest
+type limitedBuffer struct {
+	buf     bytes.Buffer
+	limit   int64
+	monitor *limitMonitor
+}
+
+// Write implements io.Writer
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.limit > 0 && int64(b.buf.Len()+len(p)) > b.limit {
+		if remaining := b.limit - int64(b.buf.Len()); remaining > 0 {
+			b.buf.Write(p[:remaining])
+		}
+		b.monitor.exceed("output")
+		// Report the full write so the copy goroutine doesn't fail while the process is killed
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+// watchMemory polls the resident memory of a process and kills it above the limit
+func watchMemory(ctx context.Context, pid int, limit int64, monitor *limitMonitor) {
+	ticker := time.NewTicker(memoryPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			rss, err := residentMemory(pid)
+			if err != nil {
+				// Process exited or /proc is unavailable
+				continue
+			}
+			if rss > limit {
+				monitor.exceed("memory")
+				return
+			}
+		}
+	}
+}
+
+// residentMemory returns the resident set size of a process in bytes
+func residentMemory(pid int) (int64, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/status", pid))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if !strings.HasPrefix(line, "VmRSS:") {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(line, "VmRSS:"))
+		if len(fields) == 0 {
+			break
+		}
+		kb, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse VmRSS: %w", err)
+		}
+		return kb * 1024, nil
+	}
+
+	return 0, fmt.Errorf("VmRSS not found for process %d", pid)
 }
 
 // handleHealth handles the /health endpoint
@@ -182,29 +379,101 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// newLogHandler returns the slog handler for the log format
+func newLogHandler(format string) (slog.Handler, error) {
+	switch format {
+	case "text":
+		return slog.NewTextHandler(os.Stderr, nil), nil
+	case "json":
+		return slog.NewJSONHandler(os.Stderr, nil), nil
+	default:
+		return nil, fmt.Errorf("unsupported log format %q, must be text or json", format)
+	}
+}
+
 func main() {
 	flag.Parse()
 
-	log.Printf("Starting externalsource-hook-executor server on port %d", *port)
-	log.Printf("Loading whitelist from %s", *whitelistPath)
+	handler, err := newLogHandler(*logFormat)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	slog.SetDefault(slog.New(handler))
+
+	slog.Info("Starting externalsource-hook-executor server", "port", *port)
+	slog.Info("Loading whitelist", "path", *whitelistPath)
 
 	// Load whitelist
-	whitelistManager, err := hooks.NewFileWhitelistManager(*whitelistPath)
+	whitelistManager, err := hooks.NewWhitelistManagerForPath(*whitelistPath)
 	if err != nil {
-		log.Fatalf("Failed to load whitelist: %v", err)
+		slog.Error("Failed to load whitelist", "error", err)
+		os.Exit(1)
 	}
 
+	// Pick up whitelist changes without a restart
+	go func() {
+		if err := whitelistManager.Watch(context.Background()); err != nil {
+			slog.Warn("Whitelist hot reload disabled", "error", err)
+		}
+	}()
+
+	// Register the executor metrics next to the Go runtime and process metrics
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	metricsHandler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+
 	// Create server
-	server := NewServer(whitelistManager)
+	server := NewServer(whitelistManager, hooks.NewExecutionLimiter(*maxConcurrent, *queueTimeout), newExecutorMetrics(registry))
 
 	// Set up HTTP handlers
 	http.HandleFunc("/execute", server.handleExecute)
 	http.HandleFunc("/health", server.handleHealth)
 
+	// Serve metrics on the main port unless a separate one is configured
+	if *metricsPort == 0 || *metricsPort == *port {
+		http.Handle("/metrics", metricsHandler)
+	} else {
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("/metrics", metricsHandler)
+		metricsAddr := fmt.Sprintf(":%d", *metricsPort)
+		go func() {
+			slog.Info("Serving metrics", "address", metricsAddr)
+			if err := http.ListenAndServe(metricsAddr, metricsMux); err != nil {
+				slog.Error("Metrics server failed", "error", err)
+				os.Exit(1)
+			}
+		}()
+	}
+
 	// Start server
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	addr := fmt.Sprintf(":%d", *port)
-	log.Printf("Listening on %s", addr)
-	if err := http.ListenAndServe(addr, nil); err != nil {
-		log.Fatalf("Server failed: %v", err)
+	httpServer := &http.Server{Addr: addr, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		slog.Info("Listening", "address", addr)
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("Server failed", "error", err)
+			os.Exit(1)
+		}
+	}()
+
+	// The sidecar receives the termination signal together with the controller, whose draining
+	// reconciles still run hooks. Keep serving requests until the controller stopped them,
+	// then let executions still running finish so they don't end in a dropped connection.
+	<-ctx.Done()
+	stop()
+	slog.Info("Termination signal received, serving requests until the shutdown timeout passed",
+		"timeout", *shutdownTimeout)
+	time.Sleep(*shutdownTimeout)
+
+	slog.Info("Shutting down, draining in-flight executions", "timeout", drainTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	err = httpServer.Shutdown(shutdownCtx)
+	cancel()
+	if err != nil {
+		slog.Error("Failed to drain in-flight executions", "error", err)
+		os.Exit(1)
 	}
+	slog.Info("Shutdown complete")
 }
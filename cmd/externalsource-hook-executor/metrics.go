@@ -0,0 +1,97 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// executorMetrics holds the Prometheus metrics of the hook executor
+type executorMetrics struct {
+	executions *prometheus.CounterVec
+	duration   *prometheus.HistogramVec
+	inFlight   prometheus.Gauge
+	rejections prometheus.Counter
+}
+
+// newExecutorMetrics creates the hook executor metrics and registers them with the registerer
+func newExecutorMetrics(registerer prometheus.Registerer) *executorMetrics {
+	m := &executorMetrics{
+		executions: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "externalsource_hook_executor_executions_total",
+				Help: "Total number of commands executed by the hook executor",
+			},
+			[]string{"command", "exit_code"},
+		),
+		duration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "externalsource_hook_executor_execution_duration_seconds",
+				Help:    "Duration of command executions in seconds",
+				Buckets: []float64{0.001, 0.01, 0.1, 0.5, 1, 2.5, 5, 10, 30},
+			},
+			[]string{"command"},
+		),
+		inFlight: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "externalsource_hook_executor_executions_in_flight",
+				Help: "Number of commands currently being executed",
+			},
+		),
+		rejections: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "externalsource_hook_executor_rejections_total",
+				Help: "Total number of requests rejected because the concurrency limit was reached",
+			},
+		),
+	}
+
+	registerer.MustRegister(m.executions, m.duration, m.inFlight, m.rejections)
+	return m
+}
+
+// startExecution records the start of a command execution and returns a function recording
+// its outcome, which must be called once the command finished
+func (m *executorMetrics) startExecution(command string) func(exitCode int) {
+	if m == nil {
+		return func(int) {}
+	}
+
+	m.inFlight.Inc()
+	start := time.Now()
+	return func(exitCode int) {
+		m.inFlight.Dec()
+		m.executions.WithLabelValues(command, strconv.Itoa(exitCode)).Inc()
+		m.duration.WithLabelValues(command).Observe(time.Since(start).Seconds())
+	}
+}
+
+// recordRejection records a request rejected by the concurrency limit
+func (m *executorMetrics) recordRejection() {
+	if m != nil {
+		m.rejections.Inc()
+	}
+}
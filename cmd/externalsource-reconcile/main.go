@@ -0,0 +1,122 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package main provides a command that requests the reconciliation of all ExternalSources
+// matching a label selector.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	sourcev1alpha1 "github.com/oddkinco/flux-externalsource-controller/api/v1alpha1"
+	"github.com/oddkinco/flux-externalsource-controller/internal/controller"
+)
+
+var (
+	selector      = flag.String("l", "", "Label selector of the ExternalSources to reconcile, required")
+	namespace     = flag.String("n", "default", "Namespace of the ExternalSources")
+	allNamespaces = flag.Bool("A", false, "Select ExternalSources in all namespaces")
+	force         = flag.Bool("force", false, "Set the force refetch annotation, storing the artifact again even if the content is unchanged")
+	dryRun        = flag.Bool("dry-run", false, "Only print the ExternalSources that would be annotated, using server-side dry run")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(),
+			"Usage: %s -l selector [-n namespace | -A] [flags]\n\n"+
+				"Annotates every matching ExternalSource with a reconcile request, so they are all\n"+
+				"fetched right away. Uses the current kubeconfig context and its permissions.\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *selector == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	if err := run(ctx); err != nil {
+		log.Fatalf("Reconcile request failed: %v", err)
+	}
+}
+
+// run annotates the selected sources and prints each one
+func run(ctx context.Context) error {
+	labelSelector, err := labels.Parse(*selector)
+	if err != nil {
+		return fmt.Errorf("invalid label selector: %w", err)
+	}
+
+	opts := controller.BulkReconcileOptions{
+		Namespace: *namespace,
+		Selector:  labelSelector,
+		Force:     *force,
+		DryRun:    *dryRun,
+	}
+	if *allNamespaces {
+		opts.Namespace = ""
+	}
+
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(sourcev1alpha1.AddToScheme(scheme))
+
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	annotated, err := controller.RequestReconcile(ctx, k8sClient, opts)
+	suffix := ""
+	if *dryRun {
+		suffix = " (dry run)"
+	}
+	for _, key := range annotated {
+		fmt.Printf("externalsource %s annotated%s\n", key, suffix)
+	}
+	if err != nil {
+		return err
+	}
+	if len(annotated) == 0 {
+		return errors.New("no ExternalSources match the selector")
+	}
+	return nil
+}
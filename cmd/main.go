@@ -28,6 +28,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -77,6 +78,7 @@ func main() {
 	var enableHTTP2 bool
 	var artifactServerPort int
 	var artifactServerEnabled bool
+	var gracefulShutdownTimeout time.Duration
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -99,6 +101,8 @@ func main() {
 		"The port for the artifact HTTP server")
 	flag.BoolVar(&artifactServerEnabled, "artifact-server-enabled", true,
 		"Enable the artifact HTTP server (for memory backend)")
+	flag.DurationVar(&gracefulShutdownTimeout, "graceful-shutdown-timeout", 30*time.Second,
+		"How long in-flight reconciliations may run to completion after a termination signal")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -181,6 +185,8 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "3f356f6a.example.com",
+		// Wait for in-flight reconciles, the reconciler stops them once the timeout expires
+		GracefulShutdownTimeout: &gracefulShutdownTimeout,
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -216,7 +222,10 @@ func main() {
 	// Initialize metrics recorder
 	var metricsRecorder metrics.MetricsRecorder
 	if controllerConfig.Metrics.Enabled {
-		metricsRecorder = metrics.NewPrometheusRecorder()
+		metricsRecorder = metrics.NewPrometheusRecorder(metrics.PrometheusOptions{
+			SourceLabels:                   metrics.SourceLabels(controllerConfig.Metrics.SourceLabels),
+			PerSourceActiveReconciliations: controllerConfig.Metrics.PerSourceActiveReconciliations,
+		})
 	} else {
 		metricsRecorder = metrics.NewNoOpRecorder()
 	}
@@ -277,15 +286,18 @@ func main() {
 			"path", controllerConfig.Storage.PVC.Path,
 			"baseURL", baseURL)
 	}
-	// For S3, let controller create its own backend
+	// For S3 and OCI, let controller create its own backend
 
-	if err := (&controller.ExternalSourceReconciler{
+	reconciler := &controller.ExternalSourceReconciler{
 		Client:          mgr.GetClient(),
 		Scheme:          mgr.GetScheme(),
 		MetricsRecorder: metricsRecorder,
 		Config:          controllerConfig,
 		StorageBackend:  storageBackend, // Share storage backend with artifact server
-	}).SetupWithManager(mgr); err != nil {
+		EventRecorder:   mgr.GetEventRecorderFor("externalsource-controller"),
+		ShutdownTimeout: gracefulShutdownTimeout,
+	}
+	if err := reconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ExternalSource")
 		os.Exit(1)
 	}
@@ -299,10 +311,17 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	// The reconciler creates the S3 and OCI backends during setup, report not ready while storage is unreachable
+	if err := mgr.AddReadyzCheck("storage",
+		storage.ReadyzCheck(reconciler.StorageBackend, storage.DefaultHealthCheckTimeout)); err != nil {
+		setupLog.Error(err, "unable to set up storage ready check")
+		os.Exit(1)
+	}
 
-	// Start artifact HTTP server if using memory or PVC backend and enabled
-	if (controllerConfig.Storage.Backend == "memory" || controllerConfig.Storage.Backend == "pvc") &&
-		controllerConfig.ArtifactServer.Enabled && storageBackend != nil {
+	// Start artifact HTTP server if using memory or PVC backend, or S3 with encrypted artifacts, and enabled
+	servesArtifacts := controllerConfig.Storage.Backend == "memory" || controllerConfig.Storage.Backend == "pvc" ||
+		(controllerConfig.Storage.Backend == "s3" && controllerConfig.Storage.Encryption.Enabled)
+	if servesArtifacts && controllerConfig.ArtifactServer.Enabled && reconciler.StorageBackend != nil {
 		setupLog.Info("Starting artifact HTTP server",
 			"backend", controllerConfig.Storage.Backend,
 			"port", controllerConfig.ArtifactServer.Port,
@@ -310,8 +329,10 @@ func main() {
 			"namespace", controllerConfig.ArtifactServer.ServiceNamespace,
 			"podName", podName)
 
-		// Use the shared storage backend
-		artifactServer := artifact.NewServer(storageBackend, controllerConfig.ArtifactServer.Port)
+		// Use the shared storage backend, which decrypts artifacts when encryption is enabled
+		artifactServer := artifact.NewServer(reconciler.StorageBackend, controllerConfig.ArtifactServer.Port)
+		artifactServer.SetVerifyIntegrity(controllerConfig.ArtifactServer.VerifyIntegrity)
+		artifactServer.SetMetricsRecorder(metricsRecorder)
 
 		// Start artifact server in goroutine
 		go func() {
@@ -0,0 +1,159 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// canonicalizeKey is the context key for per-call canonicalization
+type canonicalizeKey struct{}
+
+// WithCanonicalize returns a context that makes Package and PackageFiles canonicalize
+// content before it is hashed and archived, see Canonicalize
+func WithCanonicalize(ctx context.Context, enabled bool) context.Context {
+	if !enabled {
+		return ctx
+	}
+	return context.WithValue(ctx, canonicalizeKey{}, true)
+}
+
+// canonicalizeFromContext returns data in canonical form when the context asks for it
+func canonicalizeFromContext(ctx context.Context, data []byte) []byte {
+	if enabled, _ := ctx.Value(canonicalizeKey{}).(bool); enabled {
+		return Canonicalize(data)
+	}
+	return data
+}
+
+// Canonicalize rewrites JSON and YAML content with sorted object keys and uniform formatting,
+// so content that only differs in key order, whitespace or comments has the same bytes and
+// revision. JSON is indented with two spaces and YAML documents are written in block style
+// with their scalars unchanged. Anything else, including YAML documents that are not mappings
+// or sequences, is returned as is.
+func Canonicalize(data []byte) []byte {
+	if json.Valid(data) {
+		if canonical, err := canonicalJSON(data); err == nil {
+			return canonical
+		}
+		return data
+	}
+	if canonical, err := canonicalYAML(data); err == nil {
+		return canonical
+	}
+	return data
+}
+
+// canonicalJSON re-encodes a JSON document, which sorts object keys, keeping numbers as written
+func canonicalJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// canonicalYAML rewrites every YAML document with sorted mapping keys and without comments
+func canonicalYAML(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	documents := 0
+	for {
+		var node yaml.Node
+		if err := decoder.Decode(&node); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+
+		root := &node
+		if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+			root = root.Content[0]
+		}
+		if root.Kind != yaml.MappingNode && root.Kind != yaml.SequenceNode {
+			return nil, errors.New("document is not a mapping or sequence")
+		}
+
+		normalizeYAMLNode(&node)
+		if err := encoder.Encode(&node); err != nil {
+			return nil, err
+		}
+		documents++
+	}
+	if documents == 0 {
+		return nil, errors.New("no documents")
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// normalizeYAMLNode sorts mapping keys, drops comments and resets collection styles so the
+// layout of the source doesn't affect the output
+func normalizeYAMLNode(node *yaml.Node) {
+	node.HeadComment, node.LineComment, node.FootComment = "", "", ""
+	if node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode {
+		node.Style = 0
+	}
+
+	for _, child := range node.Content {
+		normalizeYAMLNode(child)
+	}
+
+	if node.Kind == yaml.MappingNode {
+		pairs := make([][2]*yaml.Node, 0, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			pairs = append(pairs, [2]*yaml.Node{node.Content[i], node.Content[i+1]})
+		}
+		sort.SliceStable(pairs, func(i, j int) bool {
+			return pairs[i][0].Value < pairs[j][0].Value
+		})
+		node.Content = node.Content[:0]
+		for _, pair := range pairs {
+			node.Content = append(node.Content, pair[0], pair[1])
+		}
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package artifact
+
+import (
+	"context"
+	"testing"
+
+	"github.com/oddkinco/flux-externalsource-controller/internal/storage"
+)
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "json keys are sorted",
+			input:    `{"b":1,"a":{"d":true,"c":[3,1]}}`,
+			expected: "{\n  \"a\": {\n    \"c\": [\n      3,\n      1\n    ],\n    \"d\": true\n  },\n  \"b\": 1\n}\n",
+		},
+		{
+			name:     "json numbers keep their precision",
+			input:    `{"big":12345678901234567890,"float":1.50}`,
+			expected: "{\n  \"big\": 12345678901234567890,\n  \"float\": 1.50\n}\n",
+		},
+		{
+			name:     "json html characters are not escaped",
+			input:    `{"url":"https://example.com/?a=1&b=<2>"}`,
+			expected: "{\n  \"url\": \"https://example.com/?a=1&b=<2>\"\n}\n",
+		},
+		{
+			name:     "yaml keys are sorted and comments dropped",
+			input:    "# config\nb: two\na:\n    d: [1, 2] # inline\n    c: '3'\n",
+			expected: "a:\n  c: '3'\n  d:\n    - 1\n    - 2\nb: two\n",
+		},
+		{
+			name:     "yaml documents are kept in order",
+			input:    "b: 1\na: 2\n---\n- z\n- y\n",
+			expected: "a: 2\nb: 1\n---\n- z\n- y\n",
+		},
+		{
+			name:     "yaml scalar documents are unchanged",
+			input:    "just a string\n",
+			expected: "just a string\n",
+		},
+		{
+			name:     "plain text is unchanged",
+			input:    "key=value\nother = thing: [\n",
+			expected: "key=value\nother = thing: [\n",
+		},
+		{
+			name:     "empty content is unchanged",
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := string(Canonicalize([]byte(tt.input)))
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestManager_PackageCanonicalize(t *testing.T) {
+	tests := []struct {
+		name  string
+		left  string
+		right string
+	}{
+		{
+			name:  "reordered json keys",
+			left:  `{"name":"app","replicas":3,"labels":{"tier":"web","env":"prod"}}`,
+			right: `{ "labels": { "env": "prod", "tier": "web" }, "replicas": 3, "name": "app" }`,
+		},
+		{
+			name:  "reordered yaml keys",
+			left:  "name: app\nreplicas: 3\nlabels:\n  tier: web\n  env: prod\n",
+			right: "# generated\nlabels: {env: prod, tier: web}\nreplicas: 3\nname: app\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := NewManager(storage.NewMemoryBackend())
+
+			ctx := WithCanonicalize(context.Background(), true)
+			left, err := manager.Package(ctx, []byte(tt.left), "data")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			right, err := manager.Package(ctx, []byte(tt.right), "data")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if left.Revision != right.Revision {
+				t.Errorf("expected the same revision with canonicalize, got %s and %s", left.Revision, right.Revision)
+			}
+			if err := verifyTarGzContent(left.Data, Canonicalize([]byte(tt.left)), "data"); err != nil {
+				t.Errorf("expected the archive to contain the canonical form: %v", err)
+			}
+			if digest, err := Digest(DefaultDigestAlgorithm, Canonicalize([]byte(tt.right))); err != nil || digest != right.Revision {
+				t.Errorf("expected the revision to be the digest of the canonical form, got %s (error: %v)", digest, err)
+			}
+
+			leftFiles, err := manager.PackageFiles(ctx, map[string][]byte{"data": []byte(tt.left)})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			rightFiles, err := manager.PackageFiles(ctx, map[string][]byte{"data": []byte(tt.right)})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if leftFiles.Revision != rightFiles.Revision {
+				t.Errorf("expected the same PackageFiles revision with canonicalize, got %s and %s", leftFiles.Revision, rightFiles.Revision)
+			}
+
+			left, err = manager.Package(context.Background(), []byte(tt.left), "data")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			right, err = manager.Package(context.Background(), []byte(tt.right), "data")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if left.Revision == right.Revision {
+				t.Error("expected different revisions without canonicalize")
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package artifact
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// CompressionGzip compresses artifacts into .tar.gz archives, the default
+	CompressionGzip = "gzip"
+
+	// CompressionZstd compresses artifacts into .tar.zst archives
+	CompressionZstd = "zstd"
+
+	// DefaultCompression is used when no compression is configured
+	DefaultCompression = CompressionGzip
+)
+
+// archiveExtensions maps each compression to the file extension of its archives
+var archiveExtensions = map[string]string{
+	CompressionGzip: ".tar.gz",
+	CompressionZstd: ".tar.zst",
+}
+
+// archiveMediaTypes maps each compression to the media type of its archives
+var archiveMediaTypes = map[string]string{
+	CompressionGzip: "application/gzip",
+	CompressionZstd: "application/zstd",
+}
+
+// ValidateCompression returns an error if the compression is not supported
+func ValidateCompression(compression string) error {
+	if _, ok := archiveExtensions[compression]; !ok {
+		return fmt.Errorf("unsupported artifact compression: %s (must be '%s' or '%s')",
+			compression, CompressionGzip, CompressionZstd)
+	}
+	return nil
+}
+
+// ArchiveExtension returns the file extension of archives with the compression, .tar.gz when
+// it is empty or unknown
+func ArchiveExtension(compression string) string {
+	if extension, ok := archiveExtensions[compression]; ok {
+		return extension
+	}
+	return archiveExtensions[DefaultCompression]
+}
+
+// ArchiveMediaType returns the media type of archives with the compression, application/gzip
+// when it is empty or unknown
+func ArchiveMediaType(compression string) string {
+	if mediaType, ok := archiveMediaTypes[compression]; ok {
+		return mediaType
+	}
+	return archiveMediaTypes[DefaultCompression]
+}
+
+// keyCompression returns the compression of the archive a storage key points to
+func keyCompression(key string) string {
+	for compression, extension := range archiveExtensions {
+		if strings.HasSuffix(key, extension) {
+			return compression
+		}
+	}
+	return DefaultCompression
+}
+
+// isArchiveKey returns true if the storage key points to an artifact archive
+func isArchiveKey(key string) bool {
+	for _, extension := range archiveExtensions {
+		if strings.HasSuffix(key, extension) {
+			return true
+		}
+	}
+	return false
+}
+
+// keyRevision returns the revision of the archive a storage key points to
+func keyRevision(key string) string {
+	name := path.Base(key)
+	for _, extension := range archiveExtensions {
+		if trimmed, ok := strings.CutSuffix(name, extension); ok {
+			return trimmed
+		}
+	}
+	return name
+}
+
+// compressWriter returns a writer compressing into w with the compression
+func compressWriter(w io.Writer, compression string) (io.WriteCloser, error) {
+	switch compression {
+	case CompressionGzip, "":
+		return gzip.NewWriter(w), nil
+	case CompressionZstd:
+		return zstd.NewWriter(w)
+	default:
+		return nil, ValidateCompression(compression)
+	}
+}
+
+// decompressReader returns a reader decompressing an archive, detecting its compression
+// from the leading magic bytes
+func decompressReader(archive []byte) (io.ReadCloser, error) {
+	if bytes.HasPrefix(archive, []byte{0x28, 0xb5, 0x2f, 0xfd}) {
+		decoder, err := zstd.NewReader(bytes.NewReader(archive))
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	}
+	return gzip.NewReader(bytes.NewReader(archive))
+}
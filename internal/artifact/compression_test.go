@@ -0,0 +1,173 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package artifact
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/oddkinco/flux-externalsource-controller/internal/storage"
+)
+
+func TestValidateCompression(t *testing.T) {
+	for _, compression := range []string{CompressionGzip, CompressionZstd} {
+		if err := ValidateCompression(compression); err != nil {
+			t.Errorf("unexpected error for %s: %v", compression, err)
+		}
+	}
+	if err := ValidateCompression("bzip2"); err == nil || !strings.Contains(err.Error(), "unsupported artifact compression") {
+		t.Errorf("expected unsupported compression error, got %v", err)
+	}
+
+	manager := NewManager(storage.NewMemoryBackend())
+	if err := manager.SetCompression("bzip2"); err == nil {
+		t.Error("expected error setting unsupported compression")
+	}
+}
+
+func TestManager_PackageZstd(t *testing.T) {
+	manager := NewManager(storage.NewMemoryBackend())
+	if err := manager.SetCompression(CompressionZstd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data := []byte(strings.Repeat("apiVersion: v1\nkind: ConfigMap\n", 100))
+	artifact, err := manager.Package(context.Background(), data, "manifests/bundle.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if artifact.Metadata["compression"] != CompressionZstd {
+		t.Errorf("expected zstd compression metadata, got %q", artifact.Metadata["compression"])
+	}
+	if artifact.Metadata["mediaType"] != "application/zstd" {
+		t.Errorf("expected application/zstd media type, got %q", artifact.Metadata["mediaType"])
+	}
+
+	// The revision depends on the content only, not on how it is compressed
+	expectedRevision, _ := Digest(DigestSHA256, data)
+	if artifact.Revision != expectedRevision {
+		t.Errorf("expected revision %s, got %s", expectedRevision, artifact.Revision)
+	}
+
+	if err := verifyTarZstContent(artifact.Data, data, "manifests/bundle.yaml"); err != nil {
+		t.Errorf("archive verification failed: %v", err)
+	}
+}
+
+func TestManager_StoreZstd(t *testing.T) {
+	memStorage := storage.NewMemoryBackend()
+	manager := NewManager(memStorage)
+	_ = manager.SetCompression(CompressionZstd)
+	ctx := context.Background()
+
+	artifact, err := manager.Package(ctx, []byte("data"), "config.json")
+	if err != nil {
+		t.Fatalf("failed to package artifact: %v", err)
+	}
+	url, err := manager.Store(ctx, artifact, "default/app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedKey := fmt.Sprintf("artifacts/default/app/%s.tar.zst", artifact.Revision)
+	if url != "memory://localhost/"+expectedKey {
+		t.Errorf("expected URL for %s, got %s", expectedKey, url)
+	}
+
+	data, err := manager.LoadContent(ctx, "default/app", artifact.Revision, CompressionZstd, "config.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != "data" {
+		t.Errorf("expected data, got %q", data)
+	}
+}
+
+func TestManager_CleanupCompressionChange(t *testing.T) {
+	memStorage := storage.NewMemoryBackend()
+	manager := NewManager(memStorage)
+	ctx := context.Background()
+
+	gzipArtifact, _ := manager.Package(ctx, []byte("v1"), "config.json")
+	if _, err := manager.Store(ctx, gzipArtifact, "default/app"); err != nil {
+		t.Fatalf("failed to store artifact: %v", err)
+	}
+
+	// Switching compression stores the next revision under a different extension
+	_ = manager.SetCompression(CompressionZstd)
+	zstdArtifact, _ := manager.Package(ctx, []byte("v2"), "config.json")
+	if _, err := manager.Store(ctx, zstdArtifact, "default/app"); err != nil {
+		t.Fatalf("failed to store artifact: %v", err)
+	}
+
+	if err := manager.Cleanup(ctx, "default/app", zstdArtifact.Revision, 1); err != nil {
+		t.Fatalf("cleanup failed: %v", err)
+	}
+	if memStorage.Size() != 1 {
+		t.Errorf("expected 1 remaining artifact after cleanup, got %d", memStorage.Size())
+	}
+	if _, exists := memStorage.GetData(fmt.Sprintf("artifacts/default/app/%s.tar.zst", zstdArtifact.Revision)); !exists {
+		t.Error("kept artifact was deleted")
+	}
+}
+
+// verifyTarZstContent verifies a .tar.zst archive holds only the expected file
+func verifyTarZstContent(archiveData, expectedData []byte, expectedPath string) error {
+	decoder, err := zstd.NewReader(bytes.NewReader(archiveData))
+	if err != nil {
+		return fmt.Errorf("failed to create zstd reader: %w", err)
+	}
+	defer decoder.Close()
+
+	tarReader := tar.NewReader(decoder)
+
+	header, err := tarReader.Next()
+	if err != nil {
+		return fmt.Errorf("failed to read tar header: %w", err)
+	}
+	if header.Name != expectedPath {
+		return fmt.Errorf("expected file name %s, got %s", expectedPath, header.Name)
+	}
+
+	content, err := io.ReadAll(tarReader)
+	if err != nil {
+		return fmt.Errorf("failed to read file content: %w", err)
+	}
+	if !bytes.Equal(content, expectedData) {
+		return fmt.Errorf("content mismatch: expected %d bytes, got %d", len(expectedData), len(content))
+	}
+
+	if _, err := tarReader.Next(); err != io.EOF {
+		return fmt.Errorf("expected EOF, but found more entries")
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package artifact
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+
+	"github.com/zeebo/blake3"
+)
+
+const (
+	// DigestSHA256 computes revisions with SHA-256, the default
+	DigestSHA256 = "sha256"
+
+	// DigestSHA512 computes revisions with SHA-512
+	DigestSHA512 = "sha512"
+
+	// DigestBLAKE3 computes revisions with BLAKE3 using a 256 bit output
+	DigestBLAKE3 = "blake3"
+
+	// DefaultDigestAlgorithm is used when no algorithm is configured
+	DefaultDigestAlgorithm = DigestSHA256
+
+	// StoredDigestMetadataKey holds the digest the storage backend addresses the stored archive
+	// by, for backends implementing storage.ContentDigester such as OCI
+	StoredDigestMetadataKey = "storedDigest"
+)
+
+// digestAlgorithmKey is the context key for a per-call digest algorithm override
+type digestAlgorithmKey struct{}
+
+// WithDigestAlgorithm returns a context that makes Package and PackageFiles use the
+// given digest algorithm instead of the manager default, an empty value is ignored
+func WithDigestAlgorithm(ctx context.Context, algorithm string) context.Context {
+	if algorithm == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, digestAlgorithmKey{}, algorithm)
+}
+
+// ValidateDigestAlgorithm returns an error if the algorithm is not supported
+func ValidateDigestAlgorithm(algorithm string) error {
+	_, err := newHash(algorithm)
+	return err
+}
+
+// Digest returns the hex encoded digest of the data with the named algorithm, matching the
+// revision Package computes for the same data and algorithm
+func Digest(algorithm string, data []byte) (string, error) {
+	hash, err := newHash(algorithm)
+	if err != nil {
+		return "", err
+	}
+	_, _ = hash.Write(data)
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+// newHash returns a hash for the named digest algorithm
+func newHash(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case DigestSHA256:
+		return sha256.New(), nil
+	case DigestSHA512:
+		return sha512.New(), nil
+	case DigestBLAKE3:
+		return blake3.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported digest algorithm: %s (must be '%s', '%s', or '%s')",
+			algorithm, DigestSHA256, DigestSHA512, DigestBLAKE3)
+	}
+}
@@ -34,11 +34,22 @@ type ArtifactManager interface {
 	// Package creates an artifact from the given data and path
 	Package(ctx context.Context, data []byte, path string) (*Artifact, error)
 
+	// PackageFiles creates an artifact containing each file at its key path
+	PackageFiles(ctx context.Context, files map[string][]byte) (*Artifact, error)
+
 	// Store uploads the artifact to the storage backend and returns the URL
 	Store(ctx context.Context, artifact *Artifact, source string) (string, error)
 
-	// Cleanup removes obsolete artifacts, keeping only the specified revision
-	Cleanup(ctx context.Context, source string, keepRevision string) error
+	// Cleanup removes obsolete artifacts, keeping the specified revision and
+	// up to historyLimit-1 of the most recent previous revisions
+	Cleanup(ctx context.Context, source string, keepRevision string, historyLimit int) error
+}
+
+// ContentLoader is implemented by artifact managers that can read back the data of a
+// stored artifact, which requires a storage backend supporting Retrieve
+type ContentLoader interface {
+	// LoadContent returns the data packaged at path in the stored artifact of the revision
+	LoadContent(ctx context.Context, source, revision, compression, path string) ([]byte, error)
 }
 
 // Artifact represents a packaged artifact
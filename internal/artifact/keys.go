@@ -0,0 +1,74 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// keyPrefixKey is the context key for a per-call storage key prefix
+type keyPrefixKey struct{}
+
+// WithKeyPrefix returns a context that makes Store and Cleanup place the keys of a source
+// under the given prefix, nested below the manager prefix. An empty value is ignored.
+func WithKeyPrefix(ctx context.Context, prefix string) context.Context {
+	if prefix == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, keyPrefixKey{}, prefix)
+}
+
+// StorageKey returns the storage key of a revision of a source, laid out as
+// <prefixes>/artifacts/<source>/<revision>.tar.gz, or .tar.zst with zstd compression
+func StorageKey(source, revision, compression string, prefixes ...string) string {
+	return path.Join(sourceKeyPrefix(source, prefixes...), revision+ArchiveExtension(compression))
+}
+
+// sourceKeyPrefix returns the directory holding all revisions of a source
+func sourceKeyPrefix(source string, prefixes ...string) string {
+	return path.Join(append(prefixes, "artifacts", source)...)
+}
+
+// ValidateKeyPrefix returns an error if the prefix is absolute or could escape the
+// storage layout through '.' or '..' segments
+func ValidateKeyPrefix(prefix string) error {
+	if prefix == "" {
+		return nil
+	}
+	if strings.HasPrefix(prefix, "/") {
+		return fmt.Errorf("invalid storage prefix %q: must not start with a slash", prefix)
+	}
+
+	for _, segment := range strings.Split(strings.TrimSuffix(prefix, "/"), "/") {
+		switch segment {
+		case "..":
+			return fmt.Errorf("invalid storage prefix %q: must not contain '..'", prefix)
+		case "", ".":
+			return fmt.Errorf("invalid storage prefix %q: must not contain empty or '.' segments", prefix)
+		}
+	}
+	return nil
+}
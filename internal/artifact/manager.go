@@ -25,11 +25,12 @@ package artifact
 import (
 	"archive/tar"
 	"bytes"
-	"compress/gzip"
 	"context"
-	"crypto/sha256"
 	"fmt"
+	"hash"
+	"io"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -38,26 +39,89 @@ import (
 
 // Manager implements the ArtifactManager interface
 type Manager struct {
-	storage storage.StorageBackend
+	storage         storage.StorageBackend
+	digestAlgorithm string
+	compression     string
+
+	// maxSize is the largest artifact Store accepts in bytes, 0 means unlimited
+	maxSize int64
+
+	// keyPrefix is prepended to the storage keys of every source
+	keyPrefix string
 }
 
 // NewManager creates a new artifact manager with the given storage backend
 func NewManager(backend storage.StorageBackend) *Manager {
 	return &Manager{
-		storage: backend,
+		storage:         backend,
+		digestAlgorithm: DefaultDigestAlgorithm,
+		compression:     DefaultCompression,
 	}
 }
 
-// Package creates a .tar.gz archive from the given data and calculates SHA256 digest
-func (m *Manager) Package(_ context.Context, data []byte, path string) (*Artifact, error) {
-	// Calculate SHA256 digest for content-based versioning
-	hash := sha256.Sum256(data)
-	revision := fmt.Sprintf("%x", hash)
+// SetDigestAlgorithm sets the default algorithm used to compute artifact revisions
+func (m *Manager) SetDigestAlgorithm(algorithm string) error {
+	if err := ValidateDigestAlgorithm(algorithm); err != nil {
+		return err
+	}
+	m.digestAlgorithm = algorithm
+	return nil
+}
+
+// SetCompression sets the compression of the archives created by Package and PackageFiles
+func (m *Manager) SetCompression(compression string) error {
+	if err := ValidateCompression(compression); err != nil {
+		return err
+	}
+	m.compression = compression
+	return nil
+}
+
+// BackendType reports the type of the storage backend artifacts are stored in
+func (m *Manager) BackendType() string {
+	return storage.BackendType(m.storage)
+}
+
+// SetMaxSize sets the largest artifact in bytes that Store uploads, 0 means unlimited
+func (m *Manager) SetMaxSize(maxSize int64) {
+	m.maxSize = maxSize
+}
+
+// SetKeyPrefix sets the prefix prepended to the storage keys of every source
+func (m *Manager) SetKeyPrefix(prefix string) error {
+	if err := ValidateKeyPrefix(prefix); err != nil {
+		return err
+	}
+	m.keyPrefix = prefix
+	return nil
+}
 
-	// Create .tar.gz archive
-	archiveData, err := m.createTarGzArchive(data, path)
+// keyPrefixes returns the manager prefix followed by the per-call prefix from the context
+func (m *Manager) keyPrefixes(ctx context.Context) ([]string, error) {
+	prefix, _ := ctx.Value(keyPrefixKey{}).(string)
+	if err := ValidateKeyPrefix(prefix); err != nil {
+		return nil, err
+	}
+	return []string{m.keyPrefix, prefix}, nil
+}
+
+// Package creates a compressed tar archive from the given data and calculates its digest
+func (m *Manager) Package(ctx context.Context, data []byte, path string) (*Artifact, error) {
+	// The archive holds the canonical form so its digest matches the revision
+	data = canonicalizeFromContext(ctx, data)
+
+	// Calculate digest for content-based versioning
+	algorithm, hash, err := m.newHash(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create tar.gz archive: %w", err)
+		return nil, err
+	}
+	_, _ = hash.Write(data)
+	revision := fmt.Sprintf("%x", hash.Sum(nil))
+
+	// Create the compressed tar archive
+	archiveData, err := m.createArchive(data, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s archive: %w", m.compression, err)
 	}
 
 	artifact := &Artifact{
@@ -65,19 +129,47 @@ func (m *Manager) Package(_ context.Context, data []byte, path string) (*Artifac
 		Path:     path,
 		Revision: revision,
 		Metadata: map[string]string{
-			"created":     time.Now().UTC().Format(time.RFC3339),
-			"size":        fmt.Sprintf("%d", len(archiveData)),
-			"contentHash": revision,
+			"created":         time.Now().UTC().Format(time.RFC3339),
+			"size":            fmt.Sprintf("%d", len(archiveData)),
+			"contentHash":     revision,
+			"digestAlgorithm": algorithm,
+			"compression":     m.compression,
+			"mediaType":       ArchiveMediaType(m.compression),
 		},
 	}
 
 	return artifact, nil
 }
 
+// newHash returns the digest algorithm from the context, or the manager default, and a hash for it
+func (m *Manager) newHash(ctx context.Context) (string, hash.Hash, error) {
+	algorithm := m.digestAlgorithm
+	if override, ok := ctx.Value(digestAlgorithmKey{}).(string); ok {
+		algorithm = override
+	}
+	if algorithm == "" {
+		algorithm = DefaultDigestAlgorithm
+	}
+
+	h, err := newHash(algorithm)
+	if err != nil {
+		return "", nil, err
+	}
+	return algorithm, h, nil
+}
+
 // Store uploads the artifact to the storage backend and returns the URL
 func (m *Manager) Store(ctx context.Context, artifact *Artifact, source string) (string, error) {
 	// Generate source-specific storage key based on revision
-	key := fmt.Sprintf("artifacts/%s/%s.tar.gz", source, artifact.Revision)
+	prefixes, err := m.keyPrefixes(ctx)
+	if err != nil {
+		return "", err
+	}
+	key := StorageKey(source, artifact.Revision, artifact.Metadata["compression"], prefixes...)
+
+	if m.maxSize > 0 && int64(len(artifact.Data)) > m.maxSize {
+		return "", fmt.Errorf("artifact of %d bytes exceeds maximum size of %d bytes", len(artifact.Data), m.maxSize)
+	}
 
 	// Upload to storage backend
 	url, err := m.storage.Store(ctx, key, artifact.Data)
@@ -85,24 +177,87 @@ func (m *Manager) Store(ctx context.Context, artifact *Artifact, source string)
 		return "", fmt.Errorf("failed to store artifact: %w", err)
 	}
 
+	// Record the digest backends address the stored archive by, so it is published for
+	// consumers to verify instead of the revision
+	if digester, ok := m.storage.(storage.ContentDigester); ok {
+		if artifact.Metadata == nil {
+			artifact.Metadata = make(map[string]string)
+		}
+		artifact.Metadata[StoredDigestMetadataKey] = digester.ContentDigest(artifact.Data)
+	}
+
 	return url, nil
 }
 
-// Cleanup removes obsolete artifacts, keeping only the specified revision
-func (m *Manager) Cleanup(ctx context.Context, source string, keepRevision string) error {
+// LoadContent returns the data packaged at path in the stored artifact of the revision
+func (m *Manager) LoadContent(ctx context.Context, source, revision, compression, path string) ([]byte, error) {
+	prefixes, err := m.keyPrefixes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cleanPath, err := cleanArchivePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := m.storage.Retrieve(ctx, StorageKey(source, revision, compression, prefixes...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve artifact: %w", err)
+	}
+
+	reader, err := decompressReader(archive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifact archive: %w", err)
+	}
+	defer func() { _ = reader.Close() }()
+
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("artifact has no file at %s", cleanPath)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read artifact archive: %w", err)
+		}
+		if header.Name != cleanPath {
+			continue
+		}
+
+		data, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from artifact archive: %w", cleanPath, err)
+		}
+		return data, nil
+	}
+}
+
+// Cleanup removes obsolete artifacts, keeping the specified revision and, when the
+// storage backend reports modification times, up to historyLimit-1 previous revisions
+func (m *Manager) Cleanup(ctx context.Context, source string, keepRevision string, historyLimit int) error {
+	prefixes, err := m.keyPrefixes(ctx)
+	if err != nil {
+		return err
+	}
+
 	// Use source-specific prefix to avoid affecting other sources
-	prefix := fmt.Sprintf("artifacts/%s/", source)
+	prefix := sourceKeyPrefix(source, prefixes...) + "/"
 	keys, err := m.storage.List(ctx, prefix)
 	if err != nil {
 		return fmt.Errorf("failed to list artifacts for cleanup: %w", err)
 	}
 
-	// Delete all artifacts except the one we want to keep
-	keepKey := fmt.Sprintf("artifacts/%s/%s.tar.gz", source, keepRevision)
+	// Delete all artifacts except the one we want to keep and the retained history. The
+	// revision is matched whatever the compression, as it may have changed since it was stored.
+	keep, err := m.historyKeys(ctx, prefix, keepRevision, historyLimit)
+	if err != nil {
+		return err
+	}
+
 	var cleanupErrors []error
 
 	for _, key := range keys {
-		if key != keepKey {
+		if !keep[key] && keyRevision(key) != keepRevision {
 			if err := m.storage.Delete(ctx, key); err != nil {
 				// Collect errors but continue cleanup
 				cleanupErrors = append(cleanupErrors, fmt.Errorf("failed to delete %s: %w", key, err))
@@ -119,54 +274,179 @@ func (m *Manager) Cleanup(ctx context.Context, source string, keepRevision strin
 	return nil
 }
 
-// createTarGzArchive creates a .tar.gz archive with proper directory structure
-func (m *Manager) createTarGzArchive(data []byte, destinationPath string) ([]byte, error) {
+// historyKeys returns the most recently modified artifacts under prefix to retain
+// besides keepRevision, so that at most historyLimit revisions survive cleanup. Backends
+// that cannot report modification times retain only keepRevision.
+func (m *Manager) historyKeys(ctx context.Context, prefix, keepRevision string, historyLimit int) (map[string]bool, error) {
+	keep := make(map[string]bool)
+	lister, ok := m.storage.(storage.ObjectInfoLister)
+	if historyLimit <= 1 || !ok {
+		return keep, nil
+	}
+
+	objects, err := lister.ListObjects(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifact history for cleanup: %w", err)
+	}
+
+	// Newest first, falling back to key order so equal timestamps are deterministic
+	sort.Slice(objects, func(i, j int) bool {
+		if !objects[i].LastModified.Equal(objects[j].LastModified) {
+			return objects[i].LastModified.After(objects[j].LastModified)
+		}
+		return objects[i].Key > objects[j].Key
+	})
+
+	for _, object := range objects {
+		if len(keep) >= historyLimit-1 {
+			break
+		}
+		if !isArchiveKey(object.Key) || keyRevision(object.Key) == keepRevision {
+			continue
+		}
+		keep[object.Key] = true
+	}
+
+	return keep, nil
+}
+
+// PackageFiles creates a compressed tar archive containing each file at its key path
+func (m *Manager) PackageFiles(ctx context.Context, files map[string][]byte) (*Artifact, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files to package")
+	}
+
+	// Normalize paths and sort them so the archive and revision are deterministic
+	entries := make([]archiveEntry, 0, len(files))
+	seen := make(map[string]string, len(files))
+	for path, data := range files {
+		cleanPath, err := cleanArchivePath(path)
+		if err != nil {
+			return nil, err
+		}
+		if previous, exists := seen[cleanPath]; exists {
+			return nil, fmt.Errorf("duplicate destination path: %s and %s both resolve to %s", previous, path, cleanPath)
+		}
+		seen[cleanPath] = path
+		entries = append(entries, archiveEntry{path: cleanPath, data: canonicalizeFromContext(ctx, data)})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].path < entries[j].path
+	})
+
+	// Hash each path and its content so renaming a file changes the revision
+	algorithm, hash, err := m.newHash(ctx)
+	if err != nil {
+		return nil, err
+	}
+	writeFilesDigest(hash, entries)
+	revision := fmt.Sprintf("%x", hash.Sum(nil))
+
+	archiveData, err := m.writeArchive(entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s archive: %w", m.compression, err)
+	}
+
+	artifact := &Artifact{
+		Data:     archiveData,
+		Revision: revision,
+		Metadata: map[string]string{
+			"created":         time.Now().UTC().Format(time.RFC3339),
+			"size":            fmt.Sprintf("%d", len(archiveData)),
+			"contentHash":     revision,
+			"digestAlgorithm": algorithm,
+			"compression":     m.compression,
+			"mediaType":       ArchiveMediaType(m.compression),
+			"files":           fmt.Sprintf("%d", len(entries)),
+		},
+	}
+
+	return artifact, nil
+}
+
+// archiveEntry is a single file written to an artifact archive
+type archiveEntry struct {
+	path string
+	data []byte
+}
+
+// ValidatePath returns an error if a path within an artifact is absolute or could escape the
+// archive root. The CRD applies the same rule to spec.destinationPath at admission.
+func ValidatePath(path string) error {
+	if strings.HasPrefix(path, "/") || strings.Contains(path, "..") {
+		return fmt.Errorf("invalid destination path: %s: must be relative and must not contain '..'", path)
+	}
+	return nil
+}
+
+// cleanArchivePath validates and normalizes a destination path, defaulting to "data"
+func cleanArchivePath(destinationPath string) (string, error) {
+	if err := ValidatePath(destinationPath); err != nil {
+		return "", err
+	}
+
+	cleanPath := filepath.Clean(destinationPath)
+	if cleanPath == "." {
+		cleanPath = "data"
+	}
+
+	return cleanPath, nil
+}
+
+// createArchive creates a compressed tar archive with proper directory structure
+func (m *Manager) createArchive(data []byte, destinationPath string) ([]byte, error) {
+	cleanPath, err := cleanArchivePath(destinationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.writeArchive([]archiveEntry{{path: cleanPath, data: data}})
+}
+
+// writeArchive writes the entries into a tar archive compressed with the manager
+// compression, in the given order
+func (m *Manager) writeArchive(entries []archiveEntry) ([]byte, error) {
 	var buf bytes.Buffer
 
-	// Create gzip writer
-	gzWriter := gzip.NewWriter(&buf)
+	// Create compression writer
+	compressor, err := compressWriter(&buf, m.compression)
+	if err != nil {
+		return nil, err
+	}
 	defer func() {
-		if err := gzWriter.Close(); err != nil { //nolint:staticcheck // SA9003: Intentionally empty - we don't want to fail packaging due to close errors
+		if err := compressor.Close(); err != nil { //nolint:staticcheck // SA9003: Intentionally empty - we don't want to fail packaging due to close errors
 			// Log error but don't fail the operation
 		}
 	}()
 
 	// Create tar writer
-	tarWriter := tar.NewWriter(gzWriter)
+	tarWriter := tar.NewWriter(compressor)
 	defer func() {
 		if err := tarWriter.Close(); err != nil { //nolint:staticcheck // SA9003: Intentionally empty - we don't want to fail packaging due to close errors
 			// Log error but don't fail the operation
 		}
 	}()
 
-	// Normalize the destination path
-	cleanPath := filepath.Clean(destinationPath)
-	if cleanPath == "." || cleanPath == "/" {
-		cleanPath = "data"
-	}
-
-	// Ensure path doesn't start with / or contain ..
-	cleanPath = strings.TrimPrefix(cleanPath, "/")
-	if strings.Contains(cleanPath, "..") {
-		return nil, fmt.Errorf("invalid destination path: %s", destinationPath)
-	}
-
-	// Create tar header
-	header := &tar.Header{
-		Name:    cleanPath,
-		Mode:    0644,
-		Size:    int64(len(data)),
-		ModTime: time.Now(),
-	}
+	// A fixed modification time keeps archives of the same content byte-identical
+	modTime := time.Unix(0, 0)
+	for _, entry := range entries {
+		// Create tar header
+		header := &tar.Header{
+			Name:    entry.path,
+			Mode:    0644,
+			Size:    int64(len(entry.data)),
+			ModTime: modTime,
+		}
 
-	// Write header
-	if err := tarWriter.WriteHeader(header); err != nil {
-		return nil, fmt.Errorf("failed to write tar header: %w", err)
-	}
+		// Write header
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return nil, fmt.Errorf("failed to write tar header: %w", err)
+		}
 
-	// Write data
-	if _, err := tarWriter.Write(data); err != nil {
-		return nil, fmt.Errorf("failed to write data to tar: %w", err)
+		// Write data
+		if _, err := tarWriter.Write(entry.data); err != nil {
+			return nil, fmt.Errorf("failed to write data to tar: %w", err)
+		}
 	}
 
 	// Close writers to flush data
@@ -174,8 +454,8 @@ func (m *Manager) createTarGzArchive(data []byte, destinationPath string) ([]byt
 		return nil, fmt.Errorf("failed to close tar writer: %w", err)
 	}
 
-	if err := gzWriter.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close gzip writer: %w", err)
+	if err := compressor.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close %s writer: %w", m.compression, err)
 	}
 
 	return buf.Bytes(), nil
@@ -24,13 +24,16 @@ package artifact
 
 import (
 	"archive/tar"
+	"bytes"
 	"compress/gzip"
 	"context"
 	"crypto/sha256"
+	"crypto/sha512"
 	"fmt"
 	"io"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/oddkinco/flux-externalsource-controller/internal/storage"
 )
@@ -78,11 +81,10 @@ func TestManager_Package(t *testing.T) {
 			expectError: true,
 		},
 		{
-			name:         "invalid path with absolute path",
-			data:         []byte("absolute data"),
-			path:         "/etc/config.json",
-			expectError:  false,
-			expectedPath: "etc/config.json", // Leading slash should be stripped
+			name:        "invalid path with absolute path",
+			data:        []byte("absolute data"),
+			path:        "/etc/config.json",
+			expectError: true,
 		},
 	}
 
@@ -139,6 +141,230 @@ func TestManager_Package(t *testing.T) {
 	}
 }
 
+func TestManager_PackageFiles(t *testing.T) {
+	manager := NewManager(storage.NewMemoryBackend())
+
+	files := map[string][]byte{
+		"manifests/service.yaml":    []byte("kind: Service"),
+		"manifests/deployment.yaml": []byte("kind: Deployment"),
+		"kustomization.yaml":        []byte("kind: Kustomization"),
+	}
+
+	artifact, err := manager.PackageFiles(context.Background(), files)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Revision must not depend on map iteration order
+	for i := 0; i < 10; i++ {
+		again, err := manager.PackageFiles(context.Background(), map[string][]byte{
+			"kustomization.yaml":        []byte("kind: Kustomization"),
+			"manifests/deployment.yaml": []byte("kind: Deployment"),
+			"manifests/service.yaml":    []byte("kind: Service"),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if again.Revision != artifact.Revision {
+			t.Fatalf("expected stable revision %s, got %s", artifact.Revision, again.Revision)
+		}
+	}
+
+	// Moving content to a different path must change the revision
+	renamed, err := manager.PackageFiles(context.Background(), map[string][]byte{
+		"kustomization.yaml":     []byte("kind: Kustomization"),
+		"manifests/deploy.yaml":  []byte("kind: Deployment"),
+		"manifests/service.yaml": []byte("kind: Service"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if renamed.Revision == artifact.Revision {
+		t.Error("expected revision to change when a file is renamed")
+	}
+
+	if artifact.Metadata["files"] != "3" {
+		t.Errorf("expected files metadata 3, got %s", artifact.Metadata["files"])
+	}
+
+	// Verify archive entries are written in sorted order at their paths
+	gzReader, err := gzip.NewReader(strings.NewReader(string(artifact.Data)))
+	if err != nil {
+		t.Fatalf("failed to create gzip reader: %v", err)
+	}
+	tarReader := tar.NewReader(gzReader)
+
+	expected := []struct{ path, content string }{
+		{"kustomization.yaml", "kind: Kustomization"},
+		{"manifests/deployment.yaml", "kind: Deployment"},
+		{"manifests/service.yaml", "kind: Service"},
+	}
+	for _, want := range expected {
+		header, err := tarReader.Next()
+		if err != nil {
+			t.Fatalf("failed to read tar header: %v", err)
+		}
+		content, err := io.ReadAll(tarReader)
+		if err != nil {
+			t.Fatalf("failed to read file content: %v", err)
+		}
+		if header.Name != want.path || string(content) != want.content {
+			t.Errorf("expected %s with %q, got %s with %q", want.path, want.content, header.Name, string(content))
+		}
+	}
+	if _, err := tarReader.Next(); err != io.EOF {
+		t.Errorf("expected end of archive, got %v", err)
+	}
+}
+
+func TestManager_PackageFiles_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string][]byte
+	}{
+		{
+			name:  "no files",
+			files: map[string][]byte{},
+		},
+		{
+			name: "path traversal in one entry",
+			files: map[string][]byte{
+				"config.yaml":    []byte("ok"),
+				"../secret.yaml": []byte("escape"),
+			},
+		},
+		{
+			name: "absolute path in one entry",
+			files: map[string][]byte{
+				"config.yaml":  []byte("ok"),
+				"/secret.yaml": []byte("absolute"),
+			},
+		},
+		{
+			name: "paths resolving to the same file",
+			files: map[string][]byte{
+				"config.yaml":   []byte("a"),
+				"./config.yaml": []byte("b"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := NewManager(storage.NewMemoryBackend())
+			if _, err := manager.PackageFiles(context.Background(), tt.files); err == nil {
+				t.Error("expected error but got none")
+			}
+		})
+	}
+}
+
+func TestManager_PackageDigestAlgorithm(t *testing.T) {
+	data := []byte(`{"key": "value"}`)
+	sha512Sum := sha512.Sum512(data)
+
+	tests := []struct {
+		name             string
+		managerAlgorithm string
+		contextAlgorithm string
+		expectAlgorithm  string
+		expectRevision   string
+	}{
+		{
+			name:            "default is sha256",
+			expectAlgorithm: DigestSHA256,
+			expectRevision:  fmt.Sprintf("%x", sha256.Sum256(data)),
+		},
+		{
+			name:             "manager default sha512",
+			managerAlgorithm: DigestSHA512,
+			expectAlgorithm:  DigestSHA512,
+			expectRevision:   fmt.Sprintf("%x", sha512Sum[:]),
+		},
+		{
+			name:             "context overrides manager default",
+			managerAlgorithm: DigestSHA512,
+			contextAlgorithm: DigestSHA256,
+			expectAlgorithm:  DigestSHA256,
+			expectRevision:   fmt.Sprintf("%x", sha256.Sum256(data)),
+		},
+		{
+			name:             "blake3",
+			contextAlgorithm: DigestBLAKE3,
+			expectAlgorithm:  DigestBLAKE3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := NewManager(storage.NewMemoryBackend())
+			if tt.managerAlgorithm != "" {
+				if err := manager.SetDigestAlgorithm(tt.managerAlgorithm); err != nil {
+					t.Fatalf("failed to set digest algorithm: %v", err)
+				}
+			}
+
+			ctx := WithDigestAlgorithm(context.Background(), tt.contextAlgorithm)
+			artifact, err := manager.Package(ctx, data, "data.json")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.expectRevision != "" && artifact.Revision != tt.expectRevision {
+				t.Errorf("expected revision %s, got %s", tt.expectRevision, artifact.Revision)
+			}
+			if digest, err := Digest(tt.expectAlgorithm, data); err != nil || digest != artifact.Revision {
+				t.Errorf("expected Digest to return revision %s, got %s (error: %v)", artifact.Revision, digest, err)
+			}
+			if artifact.Metadata["contentHash"] != artifact.Revision {
+				t.Errorf("expected contentHash %s, got %s", artifact.Revision, artifact.Metadata["contentHash"])
+			}
+			if artifact.Metadata["digestAlgorithm"] != tt.expectAlgorithm {
+				t.Errorf("expected digestAlgorithm %s, got %s", tt.expectAlgorithm, artifact.Metadata["digestAlgorithm"])
+			}
+
+			files, err := manager.PackageFiles(ctx, map[string][]byte{"data.json": data})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if files.Metadata["digestAlgorithm"] != tt.expectAlgorithm {
+				t.Errorf("expected PackageFiles digestAlgorithm %s, got %s", tt.expectAlgorithm, files.Metadata["digestAlgorithm"])
+			}
+		})
+	}
+}
+
+func TestManager_PackageDeterministic(t *testing.T) {
+	manager := NewManager(storage.NewMemoryBackend())
+
+	// Registries address content by digest, so the same data must produce the same archive
+	first, err := manager.Package(context.Background(), []byte(`{"key":"value"}`), "data.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	time.Sleep(1100 * time.Millisecond)
+	second, err := manager.Package(context.Background(), []byte(`{"key":"value"}`), "data.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(first.Data, second.Data) {
+		t.Error("expected packaging the same data twice to produce identical archives")
+	}
+}
+
+func TestManager_PackageUnsupportedDigestAlgorithm(t *testing.T) {
+	manager := NewManager(storage.NewMemoryBackend())
+
+	if err := manager.SetDigestAlgorithm("md5"); err == nil {
+		t.Error("expected error setting unsupported digest algorithm")
+	}
+
+	ctx := WithDigestAlgorithm(context.Background(), "md5")
+	if _, err := manager.Package(ctx, []byte("data"), "data"); err == nil || !strings.Contains(err.Error(), "unsupported digest algorithm") {
+		t.Errorf("expected unsupported digest algorithm error, got %v", err)
+	}
+}
+
 func TestManager_Store(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -209,6 +435,61 @@ func TestManager_Store(t *testing.T) {
 	}
 }
 
+func TestManager_LoadContent(t *testing.T) {
+	manager := NewManager(storage.NewMemoryBackend())
+	ctx := context.Background()
+
+	artifact, err := manager.Package(ctx, []byte("previous content"), "./data/config.json")
+	if err != nil {
+		t.Fatalf("failed to package artifact: %v", err)
+	}
+	if _, err := manager.Store(ctx, artifact, "default/test"); err != nil {
+		t.Fatalf("failed to store artifact: %v", err)
+	}
+
+	data, err := manager.LoadContent(ctx, "default/test", artifact.Revision, CompressionGzip, "data/config.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != "previous content" {
+		t.Errorf("expected previous content, got %q", data)
+	}
+
+	if _, err := manager.LoadContent(ctx, "default/test", artifact.Revision, CompressionGzip, "other.json"); err == nil {
+		t.Error("expected error loading missing path")
+	}
+	if _, err := manager.LoadContent(ctx, "default/test", "unknown", CompressionGzip, "data/config.json"); err == nil {
+		t.Error("expected error loading unknown revision")
+	}
+}
+
+func TestManager_StoreMaxSize(t *testing.T) {
+	memStorage := storage.NewMemoryBackend()
+	manager := NewManager(memStorage)
+
+	artifact, err := manager.Package(context.Background(), []byte(strings.Repeat("x", 4096)), "config.json")
+	if err != nil {
+		t.Fatalf("failed to package artifact: %v", err)
+	}
+
+	manager.SetMaxSize(int64(len(artifact.Data)) - 1)
+	if _, err := manager.Store(context.Background(), artifact, "test-source"); err == nil {
+		t.Fatal("expected error for artifact over the size limit")
+	} else if !strings.Contains(err.Error(), "exceeds maximum size") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	key := fmt.Sprintf("artifacts/test-source/%s.tar.gz", artifact.Revision)
+	if _, exists := memStorage.GetData(key); exists {
+		t.Error("artifact over the size limit should not be stored")
+	}
+
+	manager.SetMaxSize(int64(len(artifact.Data)))
+	if _, err := manager.Store(context.Background(), artifact, "test-source"); err != nil {
+		t.Errorf("unexpected error at the size limit: %v", err)
+	}
+}
+
 func TestManager_Cleanup(t *testing.T) {
 	memStorage := storage.NewMemoryBackend()
 	manager := NewManager(memStorage)
@@ -249,7 +530,7 @@ func TestManager_Cleanup(t *testing.T) {
 
 	// Keep the second artifact, cleanup others
 	keepRevision := storedArtifacts[1].Revision
-	err := manager.Cleanup(ctx, source, keepRevision)
+	err := manager.Cleanup(ctx, source, keepRevision, 1)
 	if err != nil {
 		t.Errorf("cleanup failed: %v", err)
 	}
@@ -301,7 +582,7 @@ func TestManager_CleanupWithMultipleSources(t *testing.T) {
 	}
 
 	// Cleanup first source
-	err := manager.Cleanup(ctx, sources[0], keepRevisions[0])
+	err := manager.Cleanup(ctx, sources[0], keepRevisions[0], 1)
 	if err != nil {
 		t.Errorf("cleanup failed: %v", err)
 	}
@@ -312,7 +593,7 @@ func TestManager_CleanupWithMultipleSources(t *testing.T) {
 	}
 
 	// Cleanup second source
-	err = manager.Cleanup(ctx, sources[1], keepRevisions[1])
+	err = manager.Cleanup(ctx, sources[1], keepRevisions[1], 1)
 	if err != nil {
 		t.Errorf("cleanup failed: %v", err)
 	}
@@ -323,6 +604,273 @@ func TestManager_CleanupWithMultipleSources(t *testing.T) {
 	}
 }
 
+// digestingBackend addresses stored objects by their SHA-256 digest like an OCI registry
+type digestingBackend struct {
+	*storage.MemoryBackend
+}
+
+// ContentDigest implements storage.ContentDigester
+func (digestingBackend) ContentDigest(data []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+}
+
+func TestManager_StoreRecordsStoredDigest(t *testing.T) {
+	manager := NewManager(digestingBackend{storage.NewMemoryBackend()})
+
+	artifact, err := manager.Package(context.Background(), []byte("data"), "config.json")
+	if err != nil {
+		t.Fatalf("failed to package artifact: %v", err)
+	}
+	if _, err := manager.Store(context.Background(), artifact, "default/config"); err != nil {
+		t.Fatalf("failed to store artifact: %v", err)
+	}
+
+	// The backend addresses the archive, not the content the revision is computed from
+	expected := fmt.Sprintf("sha256:%x", sha256.Sum256(artifact.Data))
+	if got := artifact.Metadata[StoredDigestMetadataKey]; got != expected {
+		t.Errorf("expected stored digest %s, got %s", expected, got)
+	}
+	if expected == "sha256:"+artifact.Revision {
+		t.Error("expected the archive digest to differ from the revision")
+	}
+
+	// Backends that don't address objects by digest record none
+	manager = NewManager(storage.NewMemoryBackend())
+	artifact, err = manager.Package(context.Background(), []byte("data"), "config.json")
+	if err != nil {
+		t.Fatalf("failed to package artifact: %v", err)
+	}
+	if _, err := manager.Store(context.Background(), artifact, "default/config"); err != nil {
+		t.Fatalf("failed to store artifact: %v", err)
+	}
+	if _, ok := artifact.Metadata[StoredDigestMetadataKey]; ok {
+		t.Error("expected no stored digest for the memory backend")
+	}
+}
+
+func TestManager_StoreWithKeyPrefix(t *testing.T) {
+	memStorage := storage.NewMemoryBackend()
+	manager := NewManager(memStorage)
+	if err := manager.SetKeyPrefix("cluster-a/"); err != nil {
+		t.Fatalf("failed to set key prefix: %v", err)
+	}
+
+	artifact, err := manager.Package(context.Background(), []byte("data"), "config.json")
+	if err != nil {
+		t.Fatalf("failed to package artifact: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		expectedKey string
+	}{
+		{
+			name:        "manager prefix",
+			ctx:         context.Background(),
+			expectedKey: "cluster-a/artifacts/default/config/" + artifact.Revision + ".tar.gz",
+		},
+		{
+			name:        "per-source prefix nested below the manager prefix",
+			ctx:         WithKeyPrefix(context.Background(), "team-a"),
+			expectedKey: "cluster-a/team-a/artifacts/default/config/" + artifact.Revision + ".tar.gz",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := manager.Store(tt.ctx, artifact, "default/config"); err != nil {
+				t.Fatalf("failed to store artifact: %v", err)
+			}
+			if _, err := memStorage.Retrieve(context.Background(), tt.expectedKey); err != nil {
+				t.Errorf("expected artifact at %s: %v", tt.expectedKey, err)
+			}
+		})
+	}
+
+	// An invalid per-source prefix is rejected before anything is stored
+	_, err = manager.Store(WithKeyPrefix(context.Background(), "team-a/../team-b"), artifact, "default/config")
+	if err == nil || !strings.Contains(err.Error(), "invalid storage prefix") {
+		t.Errorf("expected invalid storage prefix error, got %v", err)
+	}
+}
+
+func TestManager_CleanupWithKeyPrefix(t *testing.T) {
+	memStorage := storage.NewMemoryBackend()
+	manager := NewManager(memStorage)
+	ctx := context.Background()
+	teamCtx := WithKeyPrefix(ctx, "team-a")
+
+	// Keys of the same source outside the prefix and of other sources under it must survive
+	unrelated := []string{
+		"artifacts/default/config/old.tar.gz",
+		"team-b/artifacts/default/config/old.tar.gz",
+		"team-a/artifacts/default/config-2/old.tar.gz",
+	}
+	for _, key := range unrelated {
+		if _, err := memStorage.Store(ctx, key, []byte("unrelated")); err != nil {
+			t.Fatalf("failed to store %s: %v", key, err)
+		}
+	}
+
+	var keepRevision string
+	for i := 0; i < 3; i++ {
+		artifact, err := manager.Package(ctx, []byte(fmt.Sprintf("data-%d", i)), "config.json")
+		if err != nil {
+			t.Fatalf("failed to package artifact: %v", err)
+		}
+		if _, err := manager.Store(teamCtx, artifact, "default/config"); err != nil {
+			t.Fatalf("failed to store artifact: %v", err)
+		}
+		keepRevision = artifact.Revision
+	}
+
+	if err := manager.Cleanup(teamCtx, "default/config", keepRevision, 1); err != nil {
+		t.Fatalf("cleanup failed: %v", err)
+	}
+
+	keys, err := memStorage.List(ctx, "")
+	if err != nil {
+		t.Fatalf("failed to list keys: %v", err)
+	}
+	expected := append([]string{"team-a/artifacts/default/config/" + keepRevision + ".tar.gz"}, unrelated...)
+	if len(keys) != len(expected) {
+		t.Fatalf("expected keys %v after cleanup, got %v", expected, keys)
+	}
+	for _, key := range expected {
+		if _, err := memStorage.Retrieve(ctx, key); err != nil {
+			t.Errorf("expected %s to survive cleanup: %v", key, err)
+		}
+	}
+}
+
+func TestValidateKeyPrefix(t *testing.T) {
+	tests := []struct {
+		prefix  string
+		wantErr bool
+	}{
+		{prefix: ""},
+		{prefix: "team-a"},
+		{prefix: "cluster-a/team-a/"},
+		{prefix: "/team-a", wantErr: true},
+		{prefix: "..", wantErr: true},
+		{prefix: "team-a/../team-b", wantErr: true},
+		{prefix: "team-a//team-b", wantErr: true},
+		{prefix: "./team-a", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			err := ValidateKeyPrefix(tt.prefix)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateKeyPrefix(%q) error = %v, wantErr %v", tt.prefix, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// historyBackend is a memory backend that reports fixed modification times
+type historyBackend struct {
+	*storage.MemoryBackend
+	modified map[string]time.Time
+}
+
+func (h *historyBackend) ListObjects(ctx context.Context, prefix string) ([]storage.ObjectInfo, error) {
+	keys, err := h.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]storage.ObjectInfo, 0, len(keys))
+	for _, key := range keys {
+		objects = append(objects, storage.ObjectInfo{Key: key, LastModified: h.modified[key]})
+	}
+	return objects, nil
+}
+
+func TestManager_CleanupHistoryLimit(t *testing.T) {
+	ctx := context.Background()
+	source := "test-source"
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		historyLimit int
+		keepIndex    int
+		wantKept     []int
+	}{
+		{"limit of one keeps only the current revision", 1, 4, []int{4}},
+		{"keeps the newest revisions", 3, 4, []int{2, 3, 4}},
+		{"current revision counts towards the limit when older", 2, 0, []int{0, 4}},
+		{"limit above the number of revisions keeps all", 10, 4, []int{0, 1, 2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &historyBackend{
+				MemoryBackend: storage.NewMemoryBackend(),
+				modified:      make(map[string]time.Time),
+			}
+			manager := NewManager(backend)
+
+			keys := make([]string, 0, 5)
+			revisions := make([]string, 0, 5)
+			for i := 0; i < 5; i++ {
+				artifact, err := manager.Package(ctx, []byte(fmt.Sprintf("data-%d", i)), "config.json")
+				if err != nil {
+					t.Fatalf("failed to package artifact: %v", err)
+				}
+				if _, err := manager.Store(ctx, artifact, source); err != nil {
+					t.Fatalf("failed to store artifact: %v", err)
+				}
+				key := fmt.Sprintf("artifacts/%s/%s.tar.gz", source, artifact.Revision)
+				backend.modified[key] = base.Add(time.Duration(i) * time.Minute)
+				keys = append(keys, key)
+				revisions = append(revisions, artifact.Revision)
+			}
+
+			if err := manager.Cleanup(ctx, source, revisions[tt.keepIndex], tt.historyLimit); err != nil {
+				t.Fatalf("cleanup failed: %v", err)
+			}
+
+			if backend.Size() != len(tt.wantKept) {
+				t.Errorf("expected %d remaining artifacts, got %d", len(tt.wantKept), backend.Size())
+			}
+			for _, i := range tt.wantKept {
+				if _, exists := backend.GetData(keys[i]); !exists {
+					t.Errorf("expected artifact %d to be kept", i)
+				}
+			}
+		})
+	}
+}
+
+func TestManager_CleanupHistoryLimitWithoutModificationTimes(t *testing.T) {
+	memStorage := storage.NewMemoryBackend()
+	manager := NewManager(&struct{ storage.StorageBackend }{memStorage})
+	ctx := context.Background()
+
+	var keepRevision string
+	for i := 0; i < 3; i++ {
+		artifact, err := manager.Package(ctx, []byte(fmt.Sprintf("data-%d", i)), "config.json")
+		if err != nil {
+			t.Fatalf("failed to package artifact: %v", err)
+		}
+		if _, err := manager.Store(ctx, artifact, "test-source"); err != nil {
+			t.Fatalf("failed to store artifact: %v", err)
+		}
+		keepRevision = artifact.Revision
+	}
+
+	if err := manager.Cleanup(ctx, "test-source", keepRevision, 3); err != nil {
+		t.Fatalf("cleanup failed: %v", err)
+	}
+
+	// Without modification times only the current revision can be kept
+	if memStorage.Size() != 1 {
+		t.Errorf("expected 1 remaining artifact, got %d", memStorage.Size())
+	}
+}
+
 // verifyTarGzContent extracts and verifies the content of a tar.gz archive
 func verifyTarGzContent(archiveData, expectedData []byte, expectedPath string) error {
 	// Create gzip reader
@@ -29,6 +29,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/oddkinco/flux-externalsource-controller/internal/metrics"
 	"github.com/oddkinco/flux-externalsource-controller/internal/storage"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -38,6 +39,12 @@ type Server struct {
 	storage    storage.StorageBackend
 	port       int
 	httpServer *http.Server
+
+	// verifyIntegrity recomputes the revision of archives before serving them
+	verifyIntegrity bool
+
+	// metricsRecorder records integrity checks, may be nil
+	metricsRecorder metrics.MetricsRecorder
 }
 
 // NewServer creates a new artifact HTTP server
@@ -48,6 +55,17 @@ func NewServer(backend storage.StorageBackend, port int) *Server {
 	}
 }
 
+// SetVerifyIntegrity enables checking that archives still match the revision in their key
+// before serving them, at the cost of decompressing and hashing every served archive
+func (s *Server) SetVerifyIntegrity(enabled bool) {
+	s.verifyIntegrity = enabled
+}
+
+// SetMetricsRecorder sets the recorder for integrity checks
+func (s *Server) SetMetricsRecorder(recorder metrics.MetricsRecorder) {
+	s.metricsRecorder = recorder
+}
+
 // Start starts the HTTP server
 func (s *Server) Start(ctx context.Context) error {
 	log := logf.FromContext(ctx)
@@ -108,7 +126,7 @@ func (s *Server) serveArtifact(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Extract artifact key from URL path
-	// Expected format: /artifacts/namespace/name/revision.tar.gz
+	// Expected format: /artifacts/namespace/name/revision.tar.gz or revision.tar.zst
 	path := strings.TrimPrefix(r.URL.Path, "/")
 	if path == "" {
 		http.Error(w, "Artifact key not specified", http.StatusBadRequest)
@@ -134,8 +152,22 @@ func (s *Server) serveArtifact(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Refuse to serve archives whose content no longer matches their revision
+	if s.verifyIntegrity && isArchiveKey(path) {
+		start := time.Now()
+		err := VerifyArchive(path, data)
+		if s.metricsRecorder != nil {
+			s.metricsRecorder.RecordArtifactOperation("verify", storage.BackendType(s.storage), err == nil, time.Since(start))
+		}
+		if err != nil {
+			log.Error(err, "Refusing to serve corrupted artifact", "key", path)
+			http.Error(w, "Artifact integrity check failed", http.StatusInternalServerError)
+			return
+		}
+	}
+
 	// Set appropriate headers
-	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Type", ArchiveMediaType(keyCompression(path)))
 	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", path))
 	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
 
@@ -23,13 +23,16 @@ SOFTWARE.
 package artifact
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
 	"time"
 
+	"github.com/oddkinco/flux-externalsource-controller/internal/metrics"
 	"github.com/oddkinco/flux-externalsource-controller/internal/storage"
 )
 
@@ -124,6 +127,32 @@ func TestServer_ServeArtifact(t *testing.T) {
 	}
 }
 
+func TestServer_ServeEncryptedArtifact(t *testing.T) {
+	ctx := context.Background()
+	key := make([]byte, storage.EncryptionKeySize)
+	backend, err := storage.NewEncryptedBackend(storage.NewMemoryBackend(), key, "")
+	if err != nil {
+		t.Fatalf("failed to create encrypted backend: %v", err)
+	}
+
+	testKey := "artifacts/namespace/name/abc123.tar.gz"
+	testData := []byte("test artifact data")
+	if _, err := backend.Store(ctx, testKey, testData); err != nil {
+		t.Fatalf("failed to store test data: %v", err)
+	}
+
+	server := NewServer(backend, 8080)
+	w := httptest.NewRecorder()
+	server.serveArtifact(w, httptest.NewRequest(http.MethodGet, "/"+testKey, nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if got := w.Body.String(); got != string(testData) {
+		t.Errorf("expected decrypted data %q, got %q", testData, got)
+	}
+}
+
 func TestServer_Shutdown(t *testing.T) {
 	backend := storage.NewMemoryBackend()
 	server := NewServer(backend, 0) // Use port 0 for automatic assignment
@@ -159,3 +188,78 @@ func TestServer_ShutdownWithoutStart(t *testing.T) {
 		t.Errorf("Shutdown() error = %v", err)
 	}
 }
+
+// verifyRecorder records the outcome of integrity checks
+type verifyRecorder struct {
+	metrics.NoOpRecorder
+	results []bool
+}
+
+func (r *verifyRecorder) RecordArtifactOperation(operation, _ string, success bool, _ time.Duration) {
+	if operation == "verify" {
+		r.results = append(r.results, success)
+	}
+}
+
+func TestServer_VerifyIntegrity(t *testing.T) {
+	ctx := context.Background()
+	backend := storage.NewMemoryBackend()
+	manager := NewManager(backend)
+
+	packaged, err := manager.Package(ctx, []byte(`{"key": "value"}`), "config.json")
+	if err != nil {
+		t.Fatalf("failed to package artifact: %v", err)
+	}
+	if _, err := manager.Store(ctx, packaged, "namespace/name"); err != nil {
+		t.Fatalf("failed to store artifact: %v", err)
+	}
+	key := StorageKey("namespace/name", packaged.Revision, DefaultCompression)
+
+	recorder := &verifyRecorder{}
+	server := NewServer(backend, 8080)
+	server.SetVerifyIntegrity(true)
+	server.SetMetricsRecorder(recorder)
+
+	serve := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		server.serveArtifact(w, httptest.NewRequest(http.MethodGet, "/"+key, nil))
+		return w
+	}
+
+	// An intact artifact is served
+	if w := serve(); w.Code != http.StatusOK || w.Body.String() != string(packaged.Data) {
+		t.Fatalf("expected intact artifact to be served, got status %d", w.Code)
+	}
+
+	// Flipped bytes make the archive unreadable
+	corrupted := bytes.Clone(packaged.Data)
+	corrupted[len(corrupted)/2] ^= 0xff
+	if _, err := backend.Store(ctx, key, corrupted); err != nil {
+		t.Fatalf("failed to corrupt artifact: %v", err)
+	}
+	if w := serve(); w.Code != http.StatusInternalServerError {
+		t.Errorf("expected corrupted artifact to be rejected, got status %d", w.Code)
+	}
+
+	// A valid archive with different content no longer matches the revision
+	tampered, err := manager.Package(ctx, []byte(`{"key": "tampered"}`), "config.json")
+	if err != nil {
+		t.Fatalf("failed to package artifact: %v", err)
+	}
+	if _, err := backend.Store(ctx, key, tampered.Data); err != nil {
+		t.Fatalf("failed to tamper with artifact: %v", err)
+	}
+	if w := serve(); w.Code != http.StatusInternalServerError {
+		t.Errorf("expected tampered artifact to be rejected, got status %d", w.Code)
+	}
+
+	if want := []bool{true, false, false}; !slices.Equal(recorder.results, want) {
+		t.Errorf("expected verify results %v, got %v", want, recorder.results)
+	}
+
+	// With verification disabled the stored bytes are served as they are
+	server.SetVerifyIntegrity(false)
+	if w := serve(); w.Code != http.StatusOK {
+		t.Errorf("expected artifact to be served without verification, got status %d", w.Code)
+	}
+}
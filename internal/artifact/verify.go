@@ -0,0 +1,106 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package artifact
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+)
+
+// ErrIntegrity is returned when a stored artifact no longer matches the revision it was stored under
+var ErrIntegrity = errors.New("artifact integrity check failed")
+
+// VerifyArchive recomputes the revision of a stored archive from its content and returns an
+// error wrapping ErrIntegrity if it doesn't match the revision in the storage key. The digest
+// algorithm isn't part of the key, so every algorithm producing digests of the same length is tried.
+func VerifyArchive(key string, archive []byte) error {
+	revision := keyRevision(key)
+
+	entries, err := readArchive(archive)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrIntegrity, key, err)
+	}
+
+	for _, algorithm := range []string{DigestSHA256, DigestSHA512, DigestBLAKE3} {
+		h, err := newHash(algorithm)
+		if err != nil {
+			return err
+		}
+		if h.Size()*2 != len(revision) {
+			continue
+		}
+
+		// Package hashes the data of its single file, PackageFiles the paths and data of all files
+		if len(entries) == 1 {
+			_, _ = h.Write(entries[0].data)
+			if fmt.Sprintf("%x", h.Sum(nil)) == revision {
+				return nil
+			}
+			h.Reset()
+		}
+		writeFilesDigest(h, entries)
+		if fmt.Sprintf("%x", h.Sum(nil)) == revision {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: content of %s does not match revision %s", ErrIntegrity, key, revision)
+}
+
+// writeFilesDigest writes the path, size and data of each entry to the hash, in the given order
+func writeFilesDigest(h hash.Hash, entries []archiveEntry) {
+	for _, entry := range entries {
+		_, _ = fmt.Fprintf(h, "%s\x00%d\x00", entry.path, len(entry.data))
+		_, _ = h.Write(entry.data)
+	}
+}
+
+// readArchive returns the files of a compressed tar archive in archive order
+func readArchive(archive []byte) ([]archiveEntry, error) {
+	reader, err := decompressReader(archive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer func() { _ = reader.Close() }()
+
+	var entries []archiveEntry
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		data, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from archive: %w", header.Name, err)
+		}
+		entries = append(entries, archiveEntry{path: header.Name, data: data})
+	}
+}
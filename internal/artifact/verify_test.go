@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package artifact
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/oddkinco/flux-externalsource-controller/internal/storage"
+)
+
+func TestVerifyArchive(t *testing.T) {
+	ctx := context.Background()
+
+	for _, algorithm := range []string{DigestSHA256, DigestSHA512, DigestBLAKE3} {
+		for _, compression := range []string{CompressionGzip, CompressionZstd} {
+			t.Run(algorithm+"/"+compression, func(t *testing.T) {
+				manager := NewManager(storage.NewMemoryBackend())
+				if err := manager.SetDigestAlgorithm(algorithm); err != nil {
+					t.Fatalf("SetDigestAlgorithm() error = %v", err)
+				}
+				if err := manager.SetCompression(compression); err != nil {
+					t.Fatalf("SetCompression() error = %v", err)
+				}
+
+				single, err := manager.Package(ctx, []byte("data"), "config.json")
+				if err != nil {
+					t.Fatalf("Package() error = %v", err)
+				}
+				files, err := manager.PackageFiles(ctx, map[string][]byte{"a.json": []byte("a"), "b/c.json": []byte("c")})
+				if err != nil {
+					t.Fatalf("PackageFiles() error = %v", err)
+				}
+
+				for _, packaged := range []*Artifact{single, files} {
+					key := StorageKey("namespace/name", packaged.Revision, compression)
+					if err := VerifyArchive(key, packaged.Data); err != nil {
+						t.Errorf("VerifyArchive() error = %v", err)
+					}
+				}
+
+				// The content of one artifact doesn't match the revision of the other
+				key := StorageKey("namespace/name", single.Revision, compression)
+				if err := VerifyArchive(key, files.Data); !errors.Is(err, ErrIntegrity) {
+					t.Errorf("expected ErrIntegrity, got %v", err)
+				}
+			})
+		}
+	}
+
+	if err := VerifyArchive("artifacts/namespace/name/abc123.tar.gz", []byte("not an archive")); !errors.Is(err, ErrIntegrity) {
+		t.Errorf("expected ErrIntegrity for an unreadable archive, got %v", err)
+	}
+}
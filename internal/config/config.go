@@ -26,8 +26,14 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/oddkinco/flux-externalsource-controller/internal/artifact"
+	"github.com/oddkinco/flux-externalsource-controller/internal/storage"
 )
 
 // Config holds the configuration for the ExternalSource controller
@@ -49,11 +55,17 @@ type Config struct {
 
 	// ArtifactServer configuration
 	ArtifactServer ArtifactServerConfig `json:"artifactServer"`
+
+	// FileGenerator configuration
+	FileGenerator FileGeneratorConfig `json:"fileGenerator"`
+
+	// Controller concurrency and rate limiting configuration
+	Controller ControllerConfig `json:"controller"`
 }
 
 // StorageConfig holds storage backend configuration
 type StorageConfig struct {
-	// Backend type: "s3", "memory", or "pvc"
+	// Backend type: "s3", "memory", "pvc", or "oci"
 	Backend string `json:"backend"`
 
 	// S3 configuration (used when Backend is "s3")
@@ -61,6 +73,39 @@ type StorageConfig struct {
 
 	// PVC configuration (used when Backend is "pvc")
 	PVC PVCConfig `json:"pvc"`
+
+	// OCI configuration (used when Backend is "oci")
+	OCI OCIConfig `json:"oci"`
+
+	// DigestAlgorithm used for artifact revisions: "sha256", "sha512", or "blake3"
+	DigestAlgorithm string `json:"digestAlgorithm"`
+
+	// Compression of artifact archives: "gzip" (.tar.gz) or "zstd" (.tar.zst)
+	Compression string `json:"compression"`
+
+	// HistoryLimit is the number of artifact revisions kept per source, including the current
+	// one, 0 is treated as 1
+	HistoryLimit int `json:"historyLimit"`
+
+	// KeyPrefix is prepended to the storage key of every artifact, e.g. to satisfy bucket path
+	// policies when the storage is shared. Sources may nest their own prefix below it.
+	KeyPrefix string `json:"keyPrefix,omitempty"`
+
+	// DefaultDestinationPath is the path of the data within the artifact for sources that don't
+	// set one, "data" when empty. It may contain the {{ .Name }} and {{ .Namespace }} placeholders.
+	DefaultDestinationPath string `json:"defaultDestinationPath,omitempty"`
+
+	// Encryption configures encryption of artifacts at rest
+	Encryption EncryptionConfig `json:"encryption"`
+}
+
+// EncryptionConfig holds artifact encryption configuration
+type EncryptionConfig struct {
+	// Enabled encrypts artifacts with AES-GCM before they are stored
+	Enabled bool `json:"enabled"`
+
+	// KeyPath is the path to a mounted secret holding the base64 encoded AES-256 key
+	KeyPath string `json:"keyPath"`
 }
 
 // S3Config holds S3-compatible storage configuration
@@ -85,12 +130,48 @@ type S3Config struct {
 
 	// Path style for S3 requests (required for some S3-compatible services like MinIO)
 	PathStyle bool `json:"pathStyle"`
+
+	// Publish presigned GET URLs instead of plain object URLs
+	PresignURLs bool `json:"presignURLs"`
+
+	// How long presigned URLs stay valid (at most 7 days)
+	PresignExpiry time.Duration `json:"presignExpiry"`
+
+	// Upload artifacts larger than this many bytes with a multipart upload (0 disables)
+	MultipartThreshold int64 `json:"multipartThreshold"`
+
+	// Size in bytes of each part of a multipart upload (at least 5MiB)
+	MultipartPartSize int64 `json:"multipartPartSize"`
 }
 
 // PVCConfig holds PVC storage configuration
 type PVCConfig struct {
 	// Path to the directory where artifacts are stored
 	Path string `json:"path"`
+
+	// GCInterval is how often stale artifacts are garbage collected
+	GCInterval time.Duration `json:"gcInterval"`
+
+	// GCMaxAge removes unreferenced artifacts older than this (0 disables)
+	GCMaxAge time.Duration `json:"gcMaxAge"`
+
+	// GCMaxRevisions keeps at most this many artifacts per source (0 disables)
+	GCMaxRevisions int `json:"gcMaxRevisions"`
+}
+
+// OCIConfig holds OCI registry storage configuration
+type OCIConfig struct {
+	// Registry host, e.g. ghcr.io
+	Registry string `json:"registry"`
+
+	// Repository artifacts are pushed under
+	Repository string `json:"repository"`
+
+	// Use plain HTTP to talk to the registry
+	Insecure bool `json:"insecure"`
+
+	// Path to a mounted docker config secret used for registry authentication
+	DockerConfigPath string `json:"dockerConfigPath"`
 }
 
 // HTTPConfig holds HTTP client configuration
@@ -98,6 +179,17 @@ type HTTPConfig struct {
 	// Default timeout for HTTP requests
 	Timeout time.Duration `json:"timeout"`
 
+	// ConnectTimeout bounds establishing a connection, so dead hosts fail fast while the
+	// overall Timeout still applies to slow responses (0 means only Timeout applies)
+	ConnectTimeout time.Duration `json:"connectTimeout"`
+
+	// TLSHandshakeTimeout bounds the TLS handshake (0 means only Timeout applies)
+	TLSHandshakeTimeout time.Duration `json:"tlsHandshakeTimeout"`
+
+	// ResponseHeaderTimeout bounds the wait for the response headers after the request is
+	// sent, not the time reading the body (0 means only Timeout applies)
+	ResponseHeaderTimeout time.Duration `json:"responseHeaderTimeout"`
+
 	// Maximum number of idle connections
 	MaxIdleConns int `json:"maxIdleConns"`
 
@@ -112,6 +204,25 @@ type HTTPConfig struct {
 
 	// User agent string for HTTP requests
 	UserAgent string `json:"userAgent"`
+
+	// MaxResponseSize is the maximum size in bytes of a fetched response body and
+	// of a stored artifact (0 means unlimited)
+	MaxResponseSize int64 `json:"maxResponseSize"`
+
+	// DefaultHeaders are sent with every HTTP generator request, headers configured
+	// on an ExternalSource take precedence
+	DefaultHeaders map[string]string `json:"defaultHeaders,omitempty"`
+
+	// CircuitBreakerThreshold is the number of consecutive failures to a host after which
+	// requests to it are short-circuited (0 disables the circuit breaker)
+	CircuitBreakerThreshold int `json:"circuitBreakerThreshold"`
+
+	// CircuitBreakerCooldown is how long requests to a host are short-circuited before a
+	// trial request is let through
+	CircuitBreakerCooldown time.Duration `json:"circuitBreakerCooldown"`
+
+	// DenyInsecureSkipVerify rejects ExternalSources that disable TLS certificate verification
+	DenyInsecureSkipVerify bool `json:"denyInsecureSkipVerify"`
 }
 
 // RetryConfig holds retry configuration
@@ -127,6 +238,15 @@ type RetryConfig struct {
 
 	// Jitter factor for randomizing retry delays (0.0 to 1.0)
 	JitterFactor float64 `json:"jitterFactor"`
+
+	// StalledInterval is how often a source is polled once it has exhausted
+	// its retries and is marked stalled (0 uses the source's interval)
+	StalledInterval time.Duration `json:"stalledInterval"`
+
+	// SuccessThreshold is the number of consecutive successful reconciles
+	// required before the retry backoff is cleared (0 or 1 clears it on the
+	// first success)
+	SuccessThreshold int `json:"successThreshold"`
 }
 
 // HooksConfig holds hooks execution configuration
@@ -148,6 +268,15 @@ type MetricsConfig struct {
 
 	// Metrics collection interval
 	Interval time.Duration `json:"interval"`
+
+	// SourceLabels selects the labels identifying a source on per-source metrics: "name"
+	// for namespace and name, "namespace" for the namespace only or "none" to aggregate
+	// by source type, limiting cardinality for large numbers of sources
+	SourceLabels string `json:"sourceLabels"`
+
+	// PerSourceActiveReconciliations keeps the namespace and name labels on the active
+	// reconciliations gauge regardless of SourceLabels
+	PerSourceActiveReconciliations bool `json:"perSourceActiveReconciliations"`
 }
 
 // ArtifactServerConfig holds artifact HTTP server configuration
@@ -163,35 +292,105 @@ type ArtifactServerConfig struct {
 
 	// ServiceNamespace is the Kubernetes namespace where the service is deployed
 	ServiceNamespace string `json:"serviceNamespace"`
+
+	// VerifyIntegrity recomputes the revision of each archive before serving it and
+	// refuses to serve archives that no longer match
+	VerifyIntegrity bool `json:"verifyIntegrity"`
+}
+
+// FileGeneratorConfig holds file generator configuration
+type FileGeneratorConfig struct {
+	// Root is the directory file generator paths are resolved against.
+	// The file generator is disabled when empty.
+	Root string `json:"root"`
+}
+
+// ControllerConfig holds reconciliation concurrency and fetch rate limiting configuration
+type ControllerConfig struct {
+	// MaxConcurrentReconciles is the number of ExternalSources reconciled in parallel
+	MaxConcurrentReconciles int `json:"maxConcurrentReconciles"`
+
+	// FetchRateLimit bounds requests to external sources per second across all
+	// generators (0 means unlimited)
+	FetchRateLimit float64 `json:"fetchRateLimit"`
+
+	// FetchRateBurst is the number of source requests allowed above FetchRateLimit in a burst
+	FetchRateBurst int `json:"fetchRateBurst"`
+
+	// StartupSpread delays the first reconciliation after a restart of sources that already have
+	// an artifact by up to this fraction of their interval, so fetches fan out over time instead of
+	// all starting at once (0 disables the spread)
+	StartupSpread float64 `json:"startupSpread"`
+
+	// MinInterval is the shortest reconciliation interval, shorter source intervals are raised to it
+	MinInterval time.Duration `json:"minInterval"`
+
+	// MaxInterval is the longest reconciliation interval, longer source intervals are lowered to
+	// it (0 means no maximum)
+	MaxInterval time.Duration `json:"maxInterval"`
+
+	// FailureEventInterval is how often a failure identical to the last one reported for a
+	// source is reported again through a Warning event and an error log (0 reports every failure)
+	FailureEventInterval time.Duration `json:"failureEventInterval"`
+
+	// DependencyRequeueInterval is how often an ExternalSource waiting for its dependencies is
+	// checked again, in addition to the checks triggered by changes to the dependencies
+	DependencyRequeueInterval time.Duration `json:"dependencyRequeueInterval"`
+
+	// MaxReconcileTimeout is the longest timeout of a whole reconciliation, used for sources
+	// without a timeout whose interval is longer or that use a schedule. Longer source
+	// timeouts are rejected.
+	MaxReconcileTimeout time.Duration `json:"maxReconcileTimeout"`
+
+	// StuckReconcileThreshold is how long a reconciliation may run before it is reported as
+	// stuck (0 uses twice MaxReconcileTimeout)
+	StuckReconcileThreshold time.Duration `json:"stuckReconcileThreshold"`
+
+	// CancelStuckReconciles cancels the context of reconciliations reported as stuck
+	CancelStuckReconciles bool `json:"cancelStuckReconciles"`
 }
 
 // DefaultConfig returns a configuration with sensible defaults
 func DefaultConfig() *Config {
 	return &Config{
 		Storage: StorageConfig{
-			Backend: "memory", // Default to memory for development
+			Backend:         "memory", // Default to memory for development
+			DigestAlgorithm: "sha256",
+			Compression:     "gzip",
+			HistoryLimit:    1,
 			S3: S3Config{
-				Region:    "us-east-1",
-				UseSSL:    true,
-				PathStyle: false,
+				Region:             "us-east-1",
+				UseSSL:             true,
+				PathStyle:          false,
+				PresignExpiry:      1 * time.Hour,
+				MultipartThreshold: 64 << 20,
+				MultipartPartSize:  16 << 20,
 			},
 			PVC: PVCConfig{
-				Path: "/data/artifacts",
+				Path:       "/data/artifacts",
+				GCInterval: 1 * time.Hour,
 			},
 		},
 		HTTP: HTTPConfig{
-			Timeout:             30 * time.Second,
-			MaxIdleConns:        100,
-			MaxIdleConnsPerHost: 10,
-			MaxConnsPerHost:     100,
-			IdleConnTimeout:     90 * time.Second,
-			UserAgent:           "externalsource-controller/1.0",
+			Timeout:                 30 * time.Second,
+			ConnectTimeout:          10 * time.Second,
+			TLSHandshakeTimeout:     10 * time.Second,
+			MaxIdleConns:            100,
+			MaxIdleConnsPerHost:     10,
+			MaxConnsPerHost:         100,
+			IdleConnTimeout:         90 * time.Second,
+			UserAgent:               "externalsource-controller/1.0",
+			MaxResponseSize:         100 * 1024 * 1024,
+			CircuitBreakerThreshold: 5,
+			CircuitBreakerCooldown:  1 * time.Minute,
 		},
 		Retry: RetryConfig{
-			MaxAttempts:  10,
-			BaseDelay:    1 * time.Second,
-			MaxDelay:     5 * time.Minute,
-			JitterFactor: 0.25,
+			MaxAttempts:      10,
+			BaseDelay:        1 * time.Second,
+			MaxDelay:         5 * time.Minute,
+			JitterFactor:     0.25,
+			StalledInterval:  1 * time.Hour,
+			SuccessThreshold: 1,
 		},
 		Hooks: HooksConfig{
 			WhitelistPath:   "/etc/hooks/whitelist.yaml",
@@ -199,14 +398,24 @@ func DefaultConfig() *Config {
 			DefaultTimeout:  30 * time.Second,
 		},
 		Metrics: MetricsConfig{
-			Enabled:  true,
-			Interval: 15 * time.Second,
+			Enabled:      true,
+			Interval:     15 * time.Second,
+			SourceLabels: "name",
 		},
 		ArtifactServer: ArtifactServerConfig{
 			Enabled:          true,
 			Port:             8080,
 			ServiceName:      "externalsource-artifacts",
 			ServiceNamespace: "flux-system",
+			VerifyIntegrity:  true,
+		},
+		Controller: ControllerConfig{
+			MaxConcurrentReconciles:   1,
+			FetchRateBurst:            10,
+			MinInterval:               1 * time.Minute,
+			FailureEventInterval:      1 * time.Hour,
+			DependencyRequeueInterval: 30 * time.Second,
+			MaxReconcileTimeout:       10 * time.Minute,
 		},
 	}
 }
@@ -219,6 +428,8 @@ func (c *Config) LoadFromEnvironment() {
 	c.loadHooksFromEnv()
 	c.loadMetricsFromEnv()
 	c.loadArtifactServerFromEnv()
+	c.loadFileGeneratorFromEnv()
+	c.loadControllerFromEnv()
 }
 
 // loadStorageFromEnv loads storage configuration from environment variables
@@ -226,6 +437,23 @@ func (c *Config) loadStorageFromEnv() {
 	if backend := os.Getenv("STORAGE_BACKEND"); backend != "" {
 		c.Storage.Backend = backend
 	}
+	if algorithm := os.Getenv("STORAGE_DIGEST_ALGORITHM"); algorithm != "" {
+		c.Storage.DigestAlgorithm = algorithm
+	}
+	if compression := os.Getenv("STORAGE_COMPRESSION"); compression != "" {
+		c.Storage.Compression = compression
+	}
+	if historyLimitStr := os.Getenv("ARTIFACT_HISTORY_LIMIT"); historyLimitStr != "" {
+		if historyLimit, err := strconv.Atoi(historyLimitStr); err == nil {
+			c.Storage.HistoryLimit = historyLimit
+		}
+	}
+	if keyPrefix := os.Getenv("STORAGE_KEY_PREFIX"); keyPrefix != "" {
+		c.Storage.KeyPrefix = keyPrefix
+	}
+	if destinationPath := os.Getenv("DEFAULT_DESTINATION_PATH"); destinationPath != "" {
+		c.Storage.DefaultDestinationPath = destinationPath
+	}
 
 	// S3 configuration
 	if endpoint := os.Getenv("S3_ENDPOINT"); endpoint != "" {
@@ -253,11 +481,72 @@ func (c *Config) loadStorageFromEnv() {
 			c.Storage.S3.PathStyle = pathStyle
 		}
 	}
+	if presignStr := os.Getenv("S3_PRESIGN_URLS"); presignStr != "" {
+		if presign, err := strconv.ParseBool(presignStr); err == nil {
+			c.Storage.S3.PresignURLs = presign
+		}
+	}
+	if expiryStr := os.Getenv("S3_PRESIGN_EXPIRY"); expiryStr != "" {
+		if expiry, err := time.ParseDuration(expiryStr); err == nil {
+			c.Storage.S3.PresignExpiry = expiry
+		}
+	}
+	if thresholdStr := os.Getenv("S3_MULTIPART_THRESHOLD"); thresholdStr != "" {
+		if threshold, err := strconv.ParseInt(thresholdStr, 10, 64); err == nil {
+			c.Storage.S3.MultipartThreshold = threshold
+		}
+	}
+	if partSizeStr := os.Getenv("S3_MULTIPART_PART_SIZE"); partSizeStr != "" {
+		if partSize, err := strconv.ParseInt(partSizeStr, 10, 64); err == nil {
+			c.Storage.S3.MultipartPartSize = partSize
+		}
+	}
 
 	// PVC configuration
 	if path := os.Getenv("PVC_STORAGE_PATH"); path != "" {
 		c.Storage.PVC.Path = path
 	}
+	if gcIntervalStr := os.Getenv("PVC_GC_INTERVAL"); gcIntervalStr != "" {
+		if gcInterval, err := time.ParseDuration(gcIntervalStr); err == nil {
+			c.Storage.PVC.GCInterval = gcInterval
+		}
+	}
+	if gcMaxAgeStr := os.Getenv("PVC_GC_MAX_AGE"); gcMaxAgeStr != "" {
+		if gcMaxAge, err := time.ParseDuration(gcMaxAgeStr); err == nil {
+			c.Storage.PVC.GCMaxAge = gcMaxAge
+		}
+	}
+	if gcMaxRevisionsStr := os.Getenv("PVC_GC_MAX_REVISIONS"); gcMaxRevisionsStr != "" {
+		if gcMaxRevisions, err := strconv.Atoi(gcMaxRevisionsStr); err == nil {
+			c.Storage.PVC.GCMaxRevisions = gcMaxRevisions
+		}
+	}
+
+	// OCI configuration
+	if registry := os.Getenv("OCI_REGISTRY"); registry != "" {
+		c.Storage.OCI.Registry = registry
+	}
+	if repository := os.Getenv("OCI_REPOSITORY"); repository != "" {
+		c.Storage.OCI.Repository = repository
+	}
+	if insecureStr := os.Getenv("OCI_INSECURE"); insecureStr != "" {
+		if insecure, err := strconv.ParseBool(insecureStr); err == nil {
+			c.Storage.OCI.Insecure = insecure
+		}
+	}
+	if dockerConfigPath := os.Getenv("OCI_DOCKER_CONFIG_PATH"); dockerConfigPath != "" {
+		c.Storage.OCI.DockerConfigPath = dockerConfigPath
+	}
+
+	// Encryption configuration
+	if enabledStr := os.Getenv("STORAGE_ENCRYPTION_ENABLED"); enabledStr != "" {
+		if enabled, err := strconv.ParseBool(enabledStr); err == nil {
+			c.Storage.Encryption.Enabled = enabled
+		}
+	}
+	if keyPath := os.Getenv("STORAGE_ENCRYPTION_KEY_PATH"); keyPath != "" {
+		c.Storage.Encryption.KeyPath = keyPath
+	}
 }
 
 // loadHTTPFromEnv loads HTTP configuration from environment variables
@@ -267,6 +556,21 @@ func (c *Config) loadHTTPFromEnv() {
 			c.HTTP.Timeout = timeout
 		}
 	}
+	if connectTimeoutStr := os.Getenv("HTTP_CONNECT_TIMEOUT"); connectTimeoutStr != "" {
+		if connectTimeout, err := time.ParseDuration(connectTimeoutStr); err == nil {
+			c.HTTP.ConnectTimeout = connectTimeout
+		}
+	}
+	if handshakeTimeoutStr := os.Getenv("HTTP_TLS_HANDSHAKE_TIMEOUT"); handshakeTimeoutStr != "" {
+		if handshakeTimeout, err := time.ParseDuration(handshakeTimeoutStr); err == nil {
+			c.HTTP.TLSHandshakeTimeout = handshakeTimeout
+		}
+	}
+	if headerTimeoutStr := os.Getenv("HTTP_RESPONSE_HEADER_TIMEOUT"); headerTimeoutStr != "" {
+		if headerTimeout, err := time.ParseDuration(headerTimeoutStr); err == nil {
+			c.HTTP.ResponseHeaderTimeout = headerTimeout
+		}
+	}
 	if maxIdleConnsStr := os.Getenv("HTTP_MAX_IDLE_CONNS"); maxIdleConnsStr != "" {
 		if maxIdleConns, err := strconv.Atoi(maxIdleConnsStr); err == nil {
 			c.HTTP.MaxIdleConns = maxIdleConns
@@ -290,6 +594,29 @@ func (c *Config) loadHTTPFromEnv() {
 	if userAgent := os.Getenv("HTTP_USER_AGENT"); userAgent != "" {
 		c.HTTP.UserAgent = userAgent
 	}
+	if maxResponseSizeStr := os.Getenv("HTTP_MAX_RESPONSE_SIZE"); maxResponseSizeStr != "" {
+		if maxResponseSize, err := strconv.ParseInt(maxResponseSizeStr, 10, 64); err == nil {
+			c.HTTP.MaxResponseSize = maxResponseSize
+		}
+	}
+	if defaultHeaders := os.Getenv("HTTP_DEFAULT_HEADERS"); defaultHeaders != "" {
+		c.HTTP.DefaultHeaders = parseHeaders(defaultHeaders)
+	}
+	if thresholdStr := os.Getenv("HTTP_CIRCUIT_BREAKER_THRESHOLD"); thresholdStr != "" {
+		if threshold, err := strconv.Atoi(thresholdStr); err == nil {
+			c.HTTP.CircuitBreakerThreshold = threshold
+		}
+	}
+	if cooldownStr := os.Getenv("HTTP_CIRCUIT_BREAKER_COOLDOWN"); cooldownStr != "" {
+		if cooldown, err := time.ParseDuration(cooldownStr); err == nil {
+			c.HTTP.CircuitBreakerCooldown = cooldown
+		}
+	}
+	if denyStr := os.Getenv("HTTP_DENY_INSECURE_SKIP_VERIFY"); denyStr != "" {
+		if deny, err := strconv.ParseBool(denyStr); err == nil {
+			c.HTTP.DenyInsecureSkipVerify = deny
+		}
+	}
 }
 
 // loadRetryFromEnv loads retry configuration from environment variables
@@ -314,6 +641,16 @@ func (c *Config) loadRetryFromEnv() {
 			c.Retry.JitterFactor = jitterFactor
 		}
 	}
+	if stalledIntervalStr := os.Getenv("RETRY_STALLED_INTERVAL"); stalledIntervalStr != "" {
+		if stalledInterval, err := time.ParseDuration(stalledIntervalStr); err == nil {
+			c.Retry.StalledInterval = stalledInterval
+		}
+	}
+	if successThresholdStr := os.Getenv("RETRY_SUCCESS_THRESHOLD"); successThresholdStr != "" {
+		if successThreshold, err := strconv.Atoi(successThresholdStr); err == nil {
+			c.Retry.SuccessThreshold = successThreshold
+		}
+	}
 }
 
 // loadHooksFromEnv loads hooks configuration from environment variables
@@ -343,6 +680,14 @@ func (c *Config) loadMetricsFromEnv() {
 			c.Metrics.Interval = interval
 		}
 	}
+	if sourceLabels := os.Getenv("METRICS_SOURCE_LABELS"); sourceLabels != "" {
+		c.Metrics.SourceLabels = sourceLabels
+	}
+	if perSourceStr := os.Getenv("METRICS_PER_SOURCE_ACTIVE_RECONCILIATIONS"); perSourceStr != "" {
+		if perSource, err := strconv.ParseBool(perSourceStr); err == nil {
+			c.Metrics.PerSourceActiveReconciliations = perSource
+		}
+	}
 }
 
 // loadArtifactServerFromEnv loads artifact server configuration from environment variables
@@ -357,6 +702,11 @@ func (c *Config) loadArtifactServerFromEnv() {
 			c.ArtifactServer.Port = port
 		}
 	}
+	if verifyStr := os.Getenv("ARTIFACT_SERVER_VERIFY_INTEGRITY"); verifyStr != "" {
+		if verify, err := strconv.ParseBool(verifyStr); err == nil {
+			c.ArtifactServer.VerifyIntegrity = verify
+		}
+	}
 	if serviceName := os.Getenv("SERVICE_NAME"); serviceName != "" {
 		c.ArtifactServer.ServiceName = serviceName
 	}
@@ -365,11 +715,105 @@ func (c *Config) loadArtifactServerFromEnv() {
 	}
 }
 
+// loadFileGeneratorFromEnv loads file generator configuration from environment variables
+func (c *Config) loadFileGeneratorFromEnv() {
+	if root := os.Getenv("FILE_GENERATOR_ROOT"); root != "" {
+		c.FileGenerator.Root = root
+	}
+}
+
+// loadControllerFromEnv loads concurrency and rate limiting configuration from environment variables
+func (c *Config) loadControllerFromEnv() {
+	if maxConcurrentStr := os.Getenv("MAX_CONCURRENT_RECONCILES"); maxConcurrentStr != "" {
+		if maxConcurrent, err := strconv.Atoi(maxConcurrentStr); err == nil {
+			c.Controller.MaxConcurrentReconciles = maxConcurrent
+		}
+	}
+	if rateLimitStr := os.Getenv("FETCH_RATE_LIMIT"); rateLimitStr != "" {
+		if rateLimit, err := strconv.ParseFloat(rateLimitStr, 64); err == nil {
+			c.Controller.FetchRateLimit = rateLimit
+		}
+	}
+	if burstStr := os.Getenv("FETCH_RATE_BURST"); burstStr != "" {
+		if burst, err := strconv.Atoi(burstStr); err == nil {
+			c.Controller.FetchRateBurst = burst
+		}
+	}
+	if spreadStr := os.Getenv("STARTUP_SPREAD"); spreadStr != "" {
+		if spread, err := strconv.ParseFloat(spreadStr, 64); err == nil {
+			c.Controller.StartupSpread = spread
+		}
+	}
+	if minIntervalStr := os.Getenv("MIN_INTERVAL"); minIntervalStr != "" {
+		if minInterval, err := time.ParseDuration(minIntervalStr); err == nil {
+			c.Controller.MinInterval = minInterval
+		}
+	}
+	if maxIntervalStr := os.Getenv("MAX_INTERVAL"); maxIntervalStr != "" {
+		if maxInterval, err := time.ParseDuration(maxIntervalStr); err == nil {
+			c.Controller.MaxInterval = maxInterval
+		}
+	}
+	if intervalStr := os.Getenv("FAILURE_EVENT_INTERVAL"); intervalStr != "" {
+		if interval, err := time.ParseDuration(intervalStr); err == nil {
+			c.Controller.FailureEventInterval = interval
+		}
+	}
+
+	if intervalStr := os.Getenv("DEPENDENCY_REQUEUE_INTERVAL"); intervalStr != "" {
+		if interval, err := time.ParseDuration(intervalStr); err == nil {
+			c.Controller.DependencyRequeueInterval = interval
+		}
+	}
+
+	if timeoutStr := os.Getenv("MAX_RECONCILE_TIMEOUT"); timeoutStr != "" {
+		if timeout, err := time.ParseDuration(timeoutStr); err == nil {
+			c.Controller.MaxReconcileTimeout = timeout
+		}
+	}
+
+	if thresholdStr := os.Getenv("STUCK_RECONCILE_THRESHOLD"); thresholdStr != "" {
+		if threshold, err := time.ParseDuration(thresholdStr); err == nil {
+			c.Controller.StuckReconcileThreshold = threshold
+		}
+	}
+
+	if cancelStr := os.Getenv("CANCEL_STUCK_RECONCILES"); cancelStr != "" {
+		if cancel, err := strconv.ParseBool(cancelStr); err == nil {
+			c.Controller.CancelStuckReconciles = cancel
+		}
+	}
+}
+
 // Validate validates the configuration
 func (c *Config) Validate() error {
 	// Validate storage configuration
-	if c.Storage.Backend != "s3" && c.Storage.Backend != "memory" && c.Storage.Backend != "pvc" {
-		return fmt.Errorf("invalid storage backend: %s (must be 's3', 'memory', or 'pvc')", c.Storage.Backend)
+	if c.Storage.Backend != "s3" && c.Storage.Backend != "memory" && c.Storage.Backend != "pvc" && c.Storage.Backend != "oci" {
+		return fmt.Errorf("invalid storage backend: %s (must be 's3', 'memory', 'pvc', or 'oci')", c.Storage.Backend)
+	}
+
+	switch c.Storage.DigestAlgorithm {
+	case "", "sha256", "sha512", "blake3":
+	default:
+		return fmt.Errorf("invalid storage digest algorithm: %s (must be 'sha256', 'sha512', or 'blake3')", c.Storage.DigestAlgorithm)
+	}
+
+	switch c.Storage.Compression {
+	case "", "gzip", "zstd":
+	default:
+		return fmt.Errorf("invalid storage compression: %s (must be 'gzip' or 'zstd')", c.Storage.Compression)
+	}
+
+	if c.Storage.HistoryLimit < 0 {
+		return fmt.Errorf("artifact history limit must be non-negative, got %d", c.Storage.HistoryLimit)
+	}
+
+	if err := artifact.ValidateKeyPrefix(c.Storage.KeyPrefix); err != nil {
+		return err
+	}
+
+	if err := validateDestinationPath(c.Storage.DefaultDestinationPath); err != nil {
+		return err
 	}
 
 	if c.Storage.Backend == "s3" {
@@ -385,18 +829,66 @@ func (c *Config) Validate() error {
 		if c.Storage.S3.SecretAccessKey == "" {
 			return fmt.Errorf("S3 secret access key is required when using S3 storage backend")
 		}
+		if c.Storage.S3.PresignURLs && (c.Storage.S3.PresignExpiry <= 0 || c.Storage.S3.PresignExpiry > 7*24*time.Hour) {
+			return fmt.Errorf("S3 presign expiry must be between 0 and 168h, got %v", c.Storage.S3.PresignExpiry)
+		}
+		if c.Storage.S3.MultipartThreshold < 0 {
+			return fmt.Errorf("S3 multipart threshold must not be negative")
+		}
+		if c.Storage.S3.MultipartThreshold > 0 && c.Storage.S3.MultipartPartSize < storage.MinMultipartPartSize {
+			return fmt.Errorf("S3 multipart part size must be at least %d bytes, got %d",
+				storage.MinMultipartPartSize, c.Storage.S3.MultipartPartSize)
+		}
 	}
 
 	if c.Storage.Backend == "pvc" {
 		if c.Storage.PVC.Path == "" {
 			return fmt.Errorf("PVC storage path is required when using PVC storage backend")
 		}
+		if c.Storage.PVC.GCInterval < 0 || c.Storage.PVC.GCMaxAge < 0 {
+			return fmt.Errorf("PVC garbage collection interval and max age must be non-negative")
+		}
+		if c.Storage.PVC.GCMaxRevisions < 0 {
+			return fmt.Errorf("PVC garbage collection max revisions must be non-negative")
+		}
+	}
+
+	if c.Storage.Backend == "oci" {
+		if c.Storage.OCI.Registry == "" {
+			return fmt.Errorf("OCI registry is required when using OCI storage backend")
+		}
+		if c.Storage.OCI.Repository == "" {
+			return fmt.Errorf("OCI repository is required when using OCI storage backend")
+		}
+	}
+
+	if c.Storage.Encryption.Enabled {
+		if c.Storage.Encryption.KeyPath == "" {
+			return fmt.Errorf("encryption key path is required when artifact encryption is enabled")
+		}
+		// Flux pulls OCI artifacts from the registry and S3 artifacts are only decrypted when
+		// served by the artifact server
+		if c.Storage.Backend == "oci" {
+			return fmt.Errorf("artifact encryption is not supported with the OCI storage backend")
+		}
+		if c.Storage.Backend == "s3" && !c.ArtifactServer.Enabled {
+			return fmt.Errorf("artifact server must be enabled to serve encrypted artifacts from S3")
+		}
 	}
 
 	// Validate HTTP configuration
 	if c.HTTP.Timeout <= 0 {
 		return fmt.Errorf("HTTP timeout must be positive")
 	}
+	if c.HTTP.ConnectTimeout < 0 {
+		return fmt.Errorf("HTTP connect timeout must be non-negative")
+	}
+	if c.HTTP.TLSHandshakeTimeout < 0 {
+		return fmt.Errorf("HTTP TLS handshake timeout must be non-negative")
+	}
+	if c.HTTP.ResponseHeaderTimeout < 0 {
+		return fmt.Errorf("HTTP response header timeout must be non-negative")
+	}
 	if c.HTTP.MaxIdleConns < 0 {
 		return fmt.Errorf("HTTP max idle connections must be non-negative")
 	}
@@ -409,6 +901,15 @@ func (c *Config) Validate() error {
 	if c.HTTP.IdleConnTimeout <= 0 {
 		return fmt.Errorf("HTTP idle connection timeout must be positive")
 	}
+	if c.HTTP.MaxResponseSize < 0 {
+		return fmt.Errorf("HTTP max response size must be non-negative")
+	}
+	if c.HTTP.CircuitBreakerThreshold < 0 {
+		return fmt.Errorf("HTTP circuit breaker threshold must be non-negative")
+	}
+	if c.HTTP.CircuitBreakerThreshold > 0 && c.HTTP.CircuitBreakerCooldown <= 0 {
+		return fmt.Errorf("HTTP circuit breaker cooldown must be positive when the circuit breaker is enabled")
+	}
 
 	// Validate retry configuration
 	if c.Retry.MaxAttempts < 0 {
@@ -426,6 +927,12 @@ func (c *Config) Validate() error {
 	if c.Retry.JitterFactor < 0 || c.Retry.JitterFactor > 1 {
 		return fmt.Errorf("retry jitter factor must be between 0 and 1")
 	}
+	if c.Retry.StalledInterval < 0 {
+		return fmt.Errorf("retry stalled interval must be non-negative")
+	}
+	if c.Retry.SuccessThreshold < 0 {
+		return fmt.Errorf("retry success threshold must be non-negative")
+	}
 
 	// Validate hooks configuration
 	if c.Hooks.WhitelistPath == "" {
@@ -442,6 +949,11 @@ func (c *Config) Validate() error {
 	if c.Metrics.Interval <= 0 {
 		return fmt.Errorf("metrics interval must be positive")
 	}
+	switch c.Metrics.SourceLabels {
+	case "", "name", "namespace", "none":
+	default:
+		return fmt.Errorf("invalid metrics source labels: %s (must be 'name', 'namespace', or 'none')", c.Metrics.SourceLabels)
+	}
 
 	// Validate artifact server configuration
 	if c.ArtifactServer.Port < 1 || c.ArtifactServer.Port > 65535 {
@@ -454,5 +966,84 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("artifact server service namespace must be specified")
 	}
 
+	// Validate file generator configuration
+	if c.FileGenerator.Root != "" && !filepath.IsAbs(c.FileGenerator.Root) {
+		return fmt.Errorf("file generator root must be an absolute path: %s", c.FileGenerator.Root)
+	}
+
+	// Validate controller configuration
+	if c.Controller.MaxConcurrentReconciles < 1 {
+		return fmt.Errorf("max concurrent reconciles must be at least 1")
+	}
+	if c.Controller.FetchRateLimit < 0 {
+		return fmt.Errorf("fetch rate limit must not be negative")
+	}
+	if c.Controller.FetchRateLimit > 0 && c.Controller.FetchRateBurst < 1 {
+		return fmt.Errorf("fetch rate burst must be at least 1 when a fetch rate limit is set")
+	}
+	if c.Controller.StartupSpread < 0 || c.Controller.StartupSpread > 1 {
+		return fmt.Errorf("startup spread must be between 0 and 1")
+	}
+	if c.Controller.MinInterval < 0 {
+		return fmt.Errorf("minimum interval must not be negative")
+	}
+	if c.Controller.MaxInterval < 0 {
+		return fmt.Errorf("maximum interval must not be negative")
+	}
+	if c.Controller.MaxInterval > 0 && c.Controller.MinInterval > c.Controller.MaxInterval {
+		return fmt.Errorf("minimum interval must not exceed maximum interval")
+	}
+	if c.Controller.FailureEventInterval < 0 {
+		return fmt.Errorf("failure event interval must not be negative")
+	}
+
+	if c.Controller.DependencyRequeueInterval <= 0 {
+		return fmt.Errorf("dependency requeue interval must be positive")
+	}
+
+	if c.Controller.MaxReconcileTimeout <= 0 {
+		return fmt.Errorf("max reconcile timeout must be positive")
+	}
+
+	if c.Controller.StuckReconcileThreshold < 0 {
+		return fmt.Errorf("stuck reconcile threshold must not be negative")
+	}
+	if c.Controller.StuckReconcileThreshold > 0 && c.Controller.StuckReconcileThreshold <= c.Controller.MaxReconcileTimeout {
+		return fmt.Errorf("stuck reconcile threshold must exceed the max reconcile timeout")
+	}
+
+	return nil
+}
+
+// parseHeaders parses a comma separated list of Name=value pairs, malformed entries are ignored
+func parseHeaders(value string) map[string]string {
+	headers := make(map[string]string)
+	for _, entry := range strings.Split(value, ",") {
+		name, headerValue, found := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			continue
+		}
+		headers[name] = strings.TrimSpace(headerValue)
+	}
+	return headers
+}
+
+// destinationPathTemplate matches destination paths whose placeholders only reference the
+// name and namespace of the source
+var destinationPathTemplate = regexp.MustCompile(`^([^{}]|\{\{\s*(\.Name|\.Namespace)\s*\}\})*$`)
+
+// validateDestinationPath returns an error if the default destination path is absolute,
+// could escape the artifact root or uses unsupported placeholders
+func validateDestinationPath(path string) error {
+	if path == "" {
+		return nil
+	}
+	if err := artifact.ValidatePath(path); err != nil {
+		return fmt.Errorf("invalid default destination path %q: must be relative and must not contain '..'", path)
+	}
+	if !destinationPathTemplate.MatchString(path) {
+		return fmt.Errorf("invalid default destination path %q: placeholders may only reference .Name or .Namespace", path)
+	}
 	return nil
 }
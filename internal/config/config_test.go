@@ -14,23 +14,37 @@ func TestDefaultConfig(t *testing.T) {
 
 	// Test storage defaults
 	assert.Equal(t, "memory", config.Storage.Backend)
+	assert.Equal(t, "sha256", config.Storage.DigestAlgorithm)
+	assert.Equal(t, "gzip", config.Storage.Compression)
 	assert.Equal(t, "us-east-1", config.Storage.S3.Region)
 	assert.True(t, config.Storage.S3.UseSSL)
 	assert.False(t, config.Storage.S3.PathStyle)
+	assert.False(t, config.Storage.S3.PresignURLs)
+	assert.Equal(t, 1*time.Hour, config.Storage.S3.PresignExpiry)
+	assert.Equal(t, int64(64<<20), config.Storage.S3.MultipartThreshold)
+	assert.Equal(t, int64(16<<20), config.Storage.S3.MultipartPartSize)
 
 	// Test HTTP defaults
 	assert.Equal(t, 30*time.Second, config.HTTP.Timeout)
+	assert.Equal(t, 10*time.Second, config.HTTP.ConnectTimeout)
+	assert.Equal(t, 10*time.Second, config.HTTP.TLSHandshakeTimeout)
+	assert.Zero(t, config.HTTP.ResponseHeaderTimeout)
 	assert.Equal(t, 100, config.HTTP.MaxIdleConns)
 	assert.Equal(t, 10, config.HTTP.MaxIdleConnsPerHost)
 	assert.Equal(t, 100, config.HTTP.MaxConnsPerHost)
 	assert.Equal(t, 90*time.Second, config.HTTP.IdleConnTimeout)
 	assert.Equal(t, "externalsource-controller/1.0", config.HTTP.UserAgent)
+	assert.Equal(t, int64(100*1024*1024), config.HTTP.MaxResponseSize)
+	assert.Equal(t, 5, config.HTTP.CircuitBreakerThreshold)
+	assert.Equal(t, 1*time.Minute, config.HTTP.CircuitBreakerCooldown)
 
 	// Test retry defaults
 	assert.Equal(t, 10, config.Retry.MaxAttempts)
 	assert.Equal(t, 1*time.Second, config.Retry.BaseDelay)
 	assert.Equal(t, 5*time.Minute, config.Retry.MaxDelay)
 	assert.Equal(t, 0.25, config.Retry.JitterFactor)
+	assert.Equal(t, 1*time.Hour, config.Retry.StalledInterval)
+	assert.Equal(t, 1, config.Retry.SuccessThreshold)
 
 	// Test hooks defaults
 	assert.Equal(t, "/etc/hooks/whitelist.yaml", config.Hooks.WhitelistPath)
@@ -40,6 +54,22 @@ func TestDefaultConfig(t *testing.T) {
 	// Test metrics defaults
 	assert.True(t, config.Metrics.Enabled)
 	assert.Equal(t, 15*time.Second, config.Metrics.Interval)
+
+	// Test file generator defaults
+	assert.Empty(t, config.FileGenerator.Root)
+
+	// Test artifact server defaults
+	assert.True(t, config.ArtifactServer.VerifyIntegrity)
+
+	// Test controller defaults
+	assert.Equal(t, 1, config.Controller.MaxConcurrentReconciles)
+	assert.Zero(t, config.Controller.FetchRateLimit)
+	assert.Equal(t, 10, config.Controller.FetchRateBurst)
+	assert.Equal(t, time.Minute, config.Controller.MinInterval)
+	assert.Equal(t, time.Hour, config.Controller.FailureEventInterval)
+	assert.Equal(t, 30*time.Second, config.Controller.DependencyRequeueInterval)
+	assert.Equal(t, 10*time.Minute, config.Controller.MaxReconcileTimeout)
+	assert.Zero(t, config.Controller.MaxInterval)
 }
 
 func TestLoadFromEnvironment(t *testing.T) {
@@ -47,11 +77,14 @@ func TestLoadFromEnvironment(t *testing.T) {
 	originalEnv := make(map[string]string)
 	envVars := []string{
 		"STORAGE_BACKEND", "S3_BUCKET", "S3_REGION", "S3_ENDPOINT", "S3_USE_SSL", "S3_PATH_STYLE",
+		"S3_PRESIGN_URLS", "S3_PRESIGN_EXPIRY", "S3_MULTIPART_THRESHOLD", "S3_MULTIPART_PART_SIZE",
 		"HTTP_TIMEOUT", "HTTP_MAX_IDLE_CONNS", "HTTP_MAX_IDLE_CONNS_PER_HOST", "HTTP_MAX_CONNS_PER_HOST",
-		"HTTP_IDLE_CONN_TIMEOUT", "HTTP_USER_AGENT",
+		"HTTP_IDLE_CONN_TIMEOUT", "HTTP_CONNECT_TIMEOUT", "HTTP_TLS_HANDSHAKE_TIMEOUT", "HTTP_RESPONSE_HEADER_TIMEOUT", "HTTP_USER_AGENT", "HTTP_MAX_RESPONSE_SIZE", "HTTP_DEFAULT_HEADERS",
 		"RETRY_MAX_ATTEMPTS", "RETRY_BASE_DELAY", "RETRY_MAX_DELAY", "RETRY_JITTER_FACTOR",
 		"HOOK_WHITELIST_PATH", "HOOK_EXECUTOR_ENDPOINT", "HOOK_DEFAULT_TIMEOUT",
 		"METRICS_ENABLED", "METRICS_INTERVAL",
+		"FILE_GENERATOR_ROOT", "ARTIFACT_SERVER_VERIFY_INTEGRITY",
+		"MAX_CONCURRENT_RECONCILES", "FETCH_RATE_LIMIT", "FETCH_RATE_BURST",
 	}
 
 	for _, env := range envVars {
@@ -79,12 +112,16 @@ func TestLoadFromEnvironment(t *testing.T) {
 		{
 			name: "storage configuration",
 			envVars: map[string]string{
-				"STORAGE_BACKEND": "s3",
-				"S3_BUCKET":       "test-bucket",
-				"S3_REGION":       "us-west-2",
-				"S3_ENDPOINT":     "https://s3.example.com",
-				"S3_USE_SSL":      "false",
-				"S3_PATH_STYLE":   "true",
+				"STORAGE_BACKEND":        "s3",
+				"S3_BUCKET":              "test-bucket",
+				"S3_REGION":              "us-west-2",
+				"S3_ENDPOINT":            "https://s3.example.com",
+				"S3_USE_SSL":             "false",
+				"S3_PATH_STYLE":          "true",
+				"S3_PRESIGN_URLS":        "true",
+				"S3_PRESIGN_EXPIRY":      "12h",
+				"S3_MULTIPART_THRESHOLD": "104857600",
+				"S3_MULTIPART_PART_SIZE": "8388608",
 			},
 			validate: func(t *testing.T, config *Config) {
 				assert.Equal(t, "s3", config.Storage.Backend)
@@ -93,40 +130,64 @@ func TestLoadFromEnvironment(t *testing.T) {
 				assert.Equal(t, "https://s3.example.com", config.Storage.S3.Endpoint)
 				assert.False(t, config.Storage.S3.UseSSL)
 				assert.True(t, config.Storage.S3.PathStyle)
+				assert.True(t, config.Storage.S3.PresignURLs)
+				assert.Equal(t, 12*time.Hour, config.Storage.S3.PresignExpiry)
+				assert.Equal(t, int64(100<<20), config.Storage.S3.MultipartThreshold)
+				assert.Equal(t, int64(8<<20), config.Storage.S3.MultipartPartSize)
 			},
 		},
 		{
 			name: "http configuration",
 			envVars: map[string]string{
-				"HTTP_TIMEOUT":                 "60s",
-				"HTTP_MAX_IDLE_CONNS":          "200",
-				"HTTP_MAX_IDLE_CONNS_PER_HOST": "20",
-				"HTTP_MAX_CONNS_PER_HOST":      "200",
-				"HTTP_IDLE_CONN_TIMEOUT":       "120s",
-				"HTTP_USER_AGENT":              "test-agent/2.0",
+				"HTTP_TIMEOUT":                   "60s",
+				"HTTP_MAX_IDLE_CONNS":            "200",
+				"HTTP_MAX_IDLE_CONNS_PER_HOST":   "20",
+				"HTTP_MAX_CONNS_PER_HOST":        "200",
+				"HTTP_IDLE_CONN_TIMEOUT":         "120s",
+				"HTTP_USER_AGENT":                "test-agent/2.0",
+				"HTTP_MAX_RESPONSE_SIZE":         "2097152",
+				"HTTP_DEFAULT_HEADERS":           "X-Org-Id=platform, X-Trace=on,invalid",
+				"HTTP_CIRCUIT_BREAKER_THRESHOLD": "3",
+				"HTTP_CIRCUIT_BREAKER_COOLDOWN":  "2m",
+				"HTTP_DENY_INSECURE_SKIP_VERIFY": "true",
+				"HTTP_CONNECT_TIMEOUT":           "3s",
+				"HTTP_TLS_HANDSHAKE_TIMEOUT":     "4s",
+				"HTTP_RESPONSE_HEADER_TIMEOUT":   "20s",
 			},
 			validate: func(t *testing.T, config *Config) {
 				assert.Equal(t, 60*time.Second, config.HTTP.Timeout)
+				assert.Equal(t, 3*time.Second, config.HTTP.ConnectTimeout)
+				assert.Equal(t, 4*time.Second, config.HTTP.TLSHandshakeTimeout)
+				assert.Equal(t, 20*time.Second, config.HTTP.ResponseHeaderTimeout)
 				assert.Equal(t, 200, config.HTTP.MaxIdleConns)
 				assert.Equal(t, 20, config.HTTP.MaxIdleConnsPerHost)
 				assert.Equal(t, 200, config.HTTP.MaxConnsPerHost)
 				assert.Equal(t, 120*time.Second, config.HTTP.IdleConnTimeout)
 				assert.Equal(t, "test-agent/2.0", config.HTTP.UserAgent)
+				assert.Equal(t, int64(2097152), config.HTTP.MaxResponseSize)
+				assert.Equal(t, map[string]string{"X-Org-Id": "platform", "X-Trace": "on"}, config.HTTP.DefaultHeaders)
+				assert.Equal(t, 3, config.HTTP.CircuitBreakerThreshold)
+				assert.Equal(t, 2*time.Minute, config.HTTP.CircuitBreakerCooldown)
+				assert.True(t, config.HTTP.DenyInsecureSkipVerify)
 			},
 		},
 		{
 			name: "retry configuration",
 			envVars: map[string]string{
-				"RETRY_MAX_ATTEMPTS":  "5",
-				"RETRY_BASE_DELAY":    "2s",
-				"RETRY_MAX_DELAY":     "10m",
-				"RETRY_JITTER_FACTOR": "0.5",
+				"RETRY_MAX_ATTEMPTS":      "5",
+				"RETRY_BASE_DELAY":        "2s",
+				"RETRY_MAX_DELAY":         "10m",
+				"RETRY_JITTER_FACTOR":     "0.5",
+				"RETRY_STALLED_INTERVAL":  "3h",
+				"RETRY_SUCCESS_THRESHOLD": "3",
 			},
 			validate: func(t *testing.T, config *Config) {
 				assert.Equal(t, 5, config.Retry.MaxAttempts)
 				assert.Equal(t, 2*time.Second, config.Retry.BaseDelay)
 				assert.Equal(t, 10*time.Minute, config.Retry.MaxDelay)
 				assert.Equal(t, 0.5, config.Retry.JitterFactor)
+				assert.Equal(t, 3*time.Hour, config.Retry.StalledInterval)
+				assert.Equal(t, 3, config.Retry.SuccessThreshold)
 			},
 		},
 		{
@@ -145,12 +206,63 @@ func TestLoadFromEnvironment(t *testing.T) {
 		{
 			name: "metrics configuration",
 			envVars: map[string]string{
-				"METRICS_ENABLED":  "false",
-				"METRICS_INTERVAL": "30s",
+				"METRICS_ENABLED":                           "false",
+				"METRICS_INTERVAL":                          "30s",
+				"METRICS_SOURCE_LABELS":                     "namespace",
+				"METRICS_PER_SOURCE_ACTIVE_RECONCILIATIONS": "true",
 			},
 			validate: func(t *testing.T, config *Config) {
 				assert.False(t, config.Metrics.Enabled)
 				assert.Equal(t, 30*time.Second, config.Metrics.Interval)
+				assert.Equal(t, "namespace", config.Metrics.SourceLabels)
+				assert.True(t, config.Metrics.PerSourceActiveReconciliations)
+			},
+		},
+		{
+			name: "file generator configuration",
+			envVars: map[string]string{
+				"FILE_GENERATOR_ROOT": "/var/run/sidecar",
+			},
+			validate: func(t *testing.T, config *Config) {
+				assert.Equal(t, "/var/run/sidecar", config.FileGenerator.Root)
+			},
+		},
+		{
+			name: "artifact server configuration",
+			envVars: map[string]string{
+				"ARTIFACT_SERVER_VERIFY_INTEGRITY": "false",
+			},
+			validate: func(t *testing.T, config *Config) {
+				assert.False(t, config.ArtifactServer.VerifyIntegrity)
+			},
+		},
+		{
+			name: "controller configuration",
+			envVars: map[string]string{
+				"MAX_CONCURRENT_RECONCILES":   "8",
+				"FETCH_RATE_LIMIT":            "2.5",
+				"FETCH_RATE_BURST":            "5",
+				"STARTUP_SPREAD":              "0.5",
+				"MIN_INTERVAL":                "15s",
+				"MAX_INTERVAL":                "1h",
+				"FAILURE_EVENT_INTERVAL":      "10m",
+				"DEPENDENCY_REQUEUE_INTERVAL": "1m",
+				"MAX_RECONCILE_TIMEOUT":       "20m",
+				"STUCK_RECONCILE_THRESHOLD":   "30m",
+				"CANCEL_STUCK_RECONCILES":     "true",
+			},
+			validate: func(t *testing.T, config *Config) {
+				assert.Equal(t, 8, config.Controller.MaxConcurrentReconciles)
+				assert.Equal(t, 2.5, config.Controller.FetchRateLimit)
+				assert.Equal(t, 5, config.Controller.FetchRateBurst)
+				assert.Equal(t, 0.5, config.Controller.StartupSpread)
+				assert.Equal(t, 15*time.Second, config.Controller.MinInterval)
+				assert.Equal(t, time.Hour, config.Controller.MaxInterval)
+				assert.Equal(t, 10*time.Minute, config.Controller.FailureEventInterval)
+				assert.Equal(t, time.Minute, config.Controller.DependencyRequeueInterval)
+				assert.Equal(t, 20*time.Minute, config.Controller.MaxReconcileTimeout)
+				assert.Equal(t, 30*time.Minute, config.Controller.StuckReconcileThreshold)
+				assert.True(t, config.Controller.CancelStuckReconciles)
 			},
 		},
 	}
@@ -199,6 +311,28 @@ func TestValidate(t *testing.T) {
 			expectError: true,
 			errorMsg:    "invalid storage backend",
 		},
+		{
+			name: "invalid storage digest algorithm",
+			config: &Config{
+				Storage: StorageConfig{
+					Backend:         "memory",
+					DigestAlgorithm: "md5",
+				},
+			},
+			expectError: true,
+			errorMsg:    "invalid storage digest algorithm",
+		},
+		{
+			name: "invalid storage compression",
+			config: &Config{
+				Storage: StorageConfig{
+					Backend:     "memory",
+					Compression: "bzip2",
+				},
+			},
+			expectError: true,
+			errorMsg:    "invalid storage compression",
+		},
 		{
 			name: "missing S3 endpoint when using S3",
 			config: &Config{
@@ -212,6 +346,163 @@ func TestValidate(t *testing.T) {
 			expectError: true,
 			errorMsg:    "S3 endpoint is required",
 		},
+		{
+			name: "S3 presign expiry over seven days",
+			config: &Config{
+				Storage: StorageConfig{
+					Backend: "s3",
+					S3: S3Config{
+						Endpoint:        "s3.amazonaws.com",
+						Bucket:          "test-bucket",
+						AccessKeyID:     "access",
+						SecretAccessKey: "secret",
+						PresignURLs:     true,
+						PresignExpiry:   8 * 24 * time.Hour,
+					},
+				},
+			},
+			expectError: true,
+			errorMsg:    "S3 presign expiry must be between",
+		},
+		{
+			name: "S3 multipart part size below the S3 minimum",
+			config: &Config{
+				Storage: StorageConfig{
+					Backend: "s3",
+					S3: S3Config{
+						Endpoint:           "s3.amazonaws.com",
+						Bucket:             "test-bucket",
+						AccessKeyID:        "access",
+						SecretAccessKey:    "secret",
+						MultipartThreshold: 64 << 20,
+						MultipartPartSize:  1 << 20,
+					},
+				},
+			},
+			expectError: true,
+			errorMsg:    "S3 multipart part size must be at least 5242880 bytes",
+		},
+		{
+			name: "negative artifact history limit",
+			config: &Config{
+				Storage: StorageConfig{
+					Backend:      "memory",
+					HistoryLimit: -1,
+				},
+			},
+			expectError: true,
+			errorMsg:    "artifact history limit must be non-negative",
+		},
+		{
+			name: "storage key prefix with parent segment",
+			config: func() *Config {
+				config := DefaultConfig()
+				config.Storage.KeyPrefix = "team-a/../team-b"
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "invalid storage prefix",
+		},
+		{
+			name: "storage key prefix with leading slash",
+			config: func() *Config {
+				config := DefaultConfig()
+				config.Storage.KeyPrefix = "/team-a"
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "must not start with a slash",
+		},
+		{
+			name: "default destination path with parent segment",
+			config: func() *Config {
+				config := DefaultConfig()
+				config.Storage.DefaultDestinationPath = "../{{ .Name }}.json"
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "invalid default destination path",
+		},
+		{
+			name: "default destination path with unsupported placeholder",
+			config: func() *Config {
+				config := DefaultConfig()
+				config.Storage.DefaultDestinationPath = "{{ .Labels.app }}.json"
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "placeholders may only reference .Name or .Namespace",
+		},
+		{
+			name: "encryption without key path",
+			config: &Config{
+				Storage: StorageConfig{
+					Backend:    "memory",
+					Encryption: EncryptionConfig{Enabled: true},
+				},
+			},
+			expectError: true,
+			errorMsg:    "encryption key path is required",
+		},
+		{
+			name: "encryption with OCI backend",
+			config: &Config{
+				Storage: StorageConfig{
+					Backend: "oci",
+					OCI: OCIConfig{
+						Registry:   "ghcr.io",
+						Repository: "org/artifacts",
+					},
+					Encryption: EncryptionConfig{Enabled: true, KeyPath: "/etc/encryption/key"},
+				},
+			},
+			expectError: true,
+			errorMsg:    "artifact encryption is not supported with the OCI storage backend",
+		},
+		{
+			name: "encryption with S3 backend requires artifact server",
+			config: &Config{
+				Storage: StorageConfig{
+					Backend: "s3",
+					S3: S3Config{
+						Endpoint:        "s3.amazonaws.com",
+						Bucket:          "test-bucket",
+						AccessKeyID:     "access",
+						SecretAccessKey: "secret",
+					},
+					Encryption: EncryptionConfig{Enabled: true, KeyPath: "/etc/encryption/key"},
+				},
+			},
+			expectError: true,
+			errorMsg:    "artifact server must be enabled to serve encrypted artifacts from S3",
+		},
+		{
+			name: "negative PVC GC max revisions",
+			config: &Config{
+				Storage: StorageConfig{
+					Backend: "pvc",
+					PVC: PVCConfig{
+						Path:           "/data/artifacts",
+						GCMaxRevisions: -1,
+					},
+				},
+			},
+			expectError: true,
+			errorMsg:    "max revisions must be non-negative",
+		},
+		{
+			name: "missing OCI repository when using OCI",
+			config: &Config{
+				Storage: StorageConfig{
+					Backend: "oci",
+					OCI: OCIConfig{
+						Registry: "ghcr.io",
+					},
+				},
+			},
+			expectError: true,
+			errorMsg:    "OCI repository is required",
+		},
 		{
 			name: "invalid HTTP timeout",
 			config: &Config{
@@ -223,6 +514,26 @@ func TestValidate(t *testing.T) {
 			expectError: true,
 			errorMsg:    "HTTP timeout must be positive",
 		},
+		{
+			name: "negative HTTP connect timeout",
+			config: func() *Config {
+				config := DefaultConfig()
+				config.HTTP.ConnectTimeout = -1 * time.Second
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "HTTP connect timeout must be non-negative",
+		},
+		{
+			name: "negative HTTP response header timeout",
+			config: func() *Config {
+				config := DefaultConfig()
+				config.HTTP.ResponseHeaderTimeout = -1 * time.Second
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "HTTP response header timeout must be non-negative",
+		},
 		{
 			name: "invalid retry max attempts",
 			config: &Config{
@@ -261,6 +572,168 @@ func TestValidate(t *testing.T) {
 			expectError: true,
 			errorMsg:    "metrics interval must be positive",
 		},
+		{
+			name: "relative file generator root",
+			config: func() *Config {
+				config := DefaultConfig()
+				config.FileGenerator.Root = "sidecar"
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "file generator root must be an absolute path",
+		},
+		{
+			name: "zero max concurrent reconciles",
+			config: func() *Config {
+				config := DefaultConfig()
+				config.Controller.MaxConcurrentReconciles = 0
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "max concurrent reconciles must be at least 1",
+		},
+		{
+			name: "invalid metrics source labels",
+			config: func() *Config {
+				config := DefaultConfig()
+				config.Metrics.SourceLabels = "source"
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "invalid metrics source labels: source",
+		},
+		{
+			name: "negative retry success threshold",
+			config: func() *Config {
+				config := DefaultConfig()
+				config.Retry.SuccessThreshold = -1
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "retry success threshold must be non-negative",
+		},
+		{
+			name: "negative circuit breaker threshold",
+			config: func() *Config {
+				config := DefaultConfig()
+				config.HTTP.CircuitBreakerThreshold = -1
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "HTTP circuit breaker threshold must be non-negative",
+		},
+		{
+			name: "circuit breaker without cooldown",
+			config: func() *Config {
+				config := DefaultConfig()
+				config.HTTP.CircuitBreakerCooldown = 0
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "HTTP circuit breaker cooldown must be positive",
+		},
+		{
+			name: "negative fetch rate limit",
+			config: func() *Config {
+				config := DefaultConfig()
+				config.Controller.FetchRateLimit = -1
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "fetch rate limit must not be negative",
+		},
+		{
+			name: "startup spread above one",
+			config: func() *Config {
+				config := DefaultConfig()
+				config.Controller.StartupSpread = 1.5
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "startup spread must be between 0 and 1",
+		},
+		{
+			name: "minimum interval above maximum interval",
+			config: func() *Config {
+				config := DefaultConfig()
+				config.Controller.MinInterval = 10 * time.Minute
+				config.Controller.MaxInterval = 5 * time.Minute
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "minimum interval must not exceed maximum interval",
+		},
+		{
+			name: "negative failure event interval",
+			config: func() *Config {
+				config := DefaultConfig()
+				config.Controller.FailureEventInterval = -time.Minute
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "failure event interval must not be negative",
+		},
+		{
+			name: "zero dependency requeue interval",
+			config: func() *Config {
+				config := DefaultConfig()
+				config.Controller.DependencyRequeueInterval = 0
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "dependency requeue interval must be positive",
+		},
+		{
+			name: "zero max reconcile timeout",
+			config: func() *Config {
+				config := DefaultConfig()
+				config.Controller.MaxReconcileTimeout = 0
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "max reconcile timeout must be positive",
+		},
+		{
+			name: "negative stuck reconcile threshold",
+			config: func() *Config {
+				config := DefaultConfig()
+				config.Controller.StuckReconcileThreshold = -time.Minute
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "stuck reconcile threshold must not be negative",
+		},
+		{
+			name: "stuck reconcile threshold within the max reconcile timeout",
+			config: func() *Config {
+				config := DefaultConfig()
+				config.Controller.StuckReconcileThreshold = config.Controller.MaxReconcileTimeout
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "stuck reconcile threshold must exceed the max reconcile timeout",
+		},
+		{
+			name: "negative minimum interval",
+			config: func() *Config {
+				config := DefaultConfig()
+				config.Controller.MinInterval = -time.Second
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "minimum interval must not be negative",
+		},
+		{
+			name: "fetch rate limit without burst",
+			config: func() *Config {
+				config := DefaultConfig()
+				config.Controller.FetchRateLimit = 5
+				config.Controller.FetchRateBurst = 0
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "fetch rate burst must be at least 1",
+		},
 	}
 
 	for _, tt := range tests {
@@ -89,6 +89,8 @@ func (l *ConfigMapLoader) loadFromData(data map[string]string, config *Config) e
 	l.loadRetryConfig(data, config)
 	l.loadHooksConfig(data, config)
 	l.loadMetricsConfig(data, config)
+	l.loadFileGeneratorConfig(data, config)
+	l.loadControllerConfig(data, config)
 
 	return nil
 }
@@ -98,6 +100,23 @@ func (l *ConfigMapLoader) loadStorageConfig(data map[string]string, config *Conf
 	if backend, exists := data["storage.backend"]; exists {
 		config.Storage.Backend = backend
 	}
+	if algorithm, exists := data["storage.digestAlgorithm"]; exists {
+		config.Storage.DigestAlgorithm = algorithm
+	}
+	if compression, exists := data["storage.compression"]; exists {
+		config.Storage.Compression = compression
+	}
+	if historyLimitStr, exists := data["storage.historyLimit"]; exists {
+		if historyLimit, err := strconv.Atoi(historyLimitStr); err == nil {
+			config.Storage.HistoryLimit = historyLimit
+		}
+	}
+	if keyPrefix, exists := data["storage.keyPrefix"]; exists {
+		config.Storage.KeyPrefix = keyPrefix
+	}
+	if destinationPath, exists := data["storage.defaultDestinationPath"]; exists {
+		config.Storage.DefaultDestinationPath = destinationPath
+	}
 
 	// S3 configuration
 	if endpoint, exists := data["storage.s3.endpoint"]; exists {
@@ -125,6 +144,69 @@ func (l *ConfigMapLoader) loadStorageConfig(data map[string]string, config *Conf
 			config.Storage.S3.PathStyle = pathStyle
 		}
 	}
+	if presignStr, exists := data["storage.s3.presignURLs"]; exists {
+		if presign, err := strconv.ParseBool(presignStr); err == nil {
+			config.Storage.S3.PresignURLs = presign
+		}
+	}
+	if expiryStr, exists := data["storage.s3.presignExpiry"]; exists {
+		if expiry, err := time.ParseDuration(expiryStr); err == nil {
+			config.Storage.S3.PresignExpiry = expiry
+		}
+	}
+	if thresholdStr, exists := data["storage.s3.multipartThreshold"]; exists {
+		if threshold, err := strconv.ParseInt(thresholdStr, 10, 64); err == nil {
+			config.Storage.S3.MultipartThreshold = threshold
+		}
+	}
+	if partSizeStr, exists := data["storage.s3.multipartPartSize"]; exists {
+		if partSize, err := strconv.ParseInt(partSizeStr, 10, 64); err == nil {
+			config.Storage.S3.MultipartPartSize = partSize
+		}
+	}
+
+	// PVC configuration
+	if gcIntervalStr, exists := data["storage.pvc.gcInterval"]; exists {
+		if gcInterval, err := time.ParseDuration(gcIntervalStr); err == nil {
+			config.Storage.PVC.GCInterval = gcInterval
+		}
+	}
+	if gcMaxAgeStr, exists := data["storage.pvc.gcMaxAge"]; exists {
+		if gcMaxAge, err := time.ParseDuration(gcMaxAgeStr); err == nil {
+			config.Storage.PVC.GCMaxAge = gcMaxAge
+		}
+	}
+	if gcMaxRevisionsStr, exists := data["storage.pvc.gcMaxRevisions"]; exists {
+		if gcMaxRevisions, err := strconv.Atoi(gcMaxRevisionsStr); err == nil {
+			config.Storage.PVC.GCMaxRevisions = gcMaxRevisions
+		}
+	}
+
+	// OCI configuration
+	if registry, exists := data["storage.oci.registry"]; exists {
+		config.Storage.OCI.Registry = registry
+	}
+	if repository, exists := data["storage.oci.repository"]; exists {
+		config.Storage.OCI.Repository = repository
+	}
+	if insecureStr, exists := data["storage.oci.insecure"]; exists {
+		if insecure, err := strconv.ParseBool(insecureStr); err == nil {
+			config.Storage.OCI.Insecure = insecure
+		}
+	}
+	if dockerConfigPath, exists := data["storage.oci.dockerConfigPath"]; exists {
+		config.Storage.OCI.DockerConfigPath = dockerConfigPath
+	}
+
+	// Encryption configuration
+	if enabledStr, exists := data["storage.encryption.enabled"]; exists {
+		if enabled, err := strconv.ParseBool(enabledStr); err == nil {
+			config.Storage.Encryption.Enabled = enabled
+		}
+	}
+	if keyPath, exists := data["storage.encryption.keyPath"]; exists {
+		config.Storage.Encryption.KeyPath = keyPath
+	}
 }
 
 // loadHTTPConfig loads HTTP configuration from ConfigMap data
@@ -134,6 +216,21 @@ func (l *ConfigMapLoader) loadHTTPConfig(data map[string]string, config *Config)
 			config.HTTP.Timeout = timeout
 		}
 	}
+	if connectTimeoutStr, exists := data["http.connectTimeout"]; exists {
+		if connectTimeout, err := time.ParseDuration(connectTimeoutStr); err == nil {
+			config.HTTP.ConnectTimeout = connectTimeout
+		}
+	}
+	if handshakeTimeoutStr, exists := data["http.tlsHandshakeTimeout"]; exists {
+		if handshakeTimeout, err := time.ParseDuration(handshakeTimeoutStr); err == nil {
+			config.HTTP.TLSHandshakeTimeout = handshakeTimeout
+		}
+	}
+	if headerTimeoutStr, exists := data["http.responseHeaderTimeout"]; exists {
+		if headerTimeout, err := time.ParseDuration(headerTimeoutStr); err == nil {
+			config.HTTP.ResponseHeaderTimeout = headerTimeout
+		}
+	}
 	if maxIdleConnsStr, exists := data["http.maxIdleConns"]; exists {
 		if maxIdleConns, err := strconv.Atoi(maxIdleConnsStr); err == nil {
 			config.HTTP.MaxIdleConns = maxIdleConns
@@ -157,6 +254,29 @@ func (l *ConfigMapLoader) loadHTTPConfig(data map[string]string, config *Config)
 	if userAgent, exists := data["http.userAgent"]; exists {
 		config.HTTP.UserAgent = userAgent
 	}
+	if maxResponseSizeStr, exists := data["http.maxResponseSize"]; exists {
+		if maxResponseSize, err := strconv.ParseInt(maxResponseSizeStr, 10, 64); err == nil {
+			config.HTTP.MaxResponseSize = maxResponseSize
+		}
+	}
+	if defaultHeaders, exists := data["http.defaultHeaders"]; exists {
+		config.HTTP.DefaultHeaders = parseHeaders(defaultHeaders)
+	}
+	if thresholdStr, exists := data["http.circuitBreakerThreshold"]; exists {
+		if threshold, err := strconv.Atoi(thresholdStr); err == nil {
+			config.HTTP.CircuitBreakerThreshold = threshold
+		}
+	}
+	if cooldownStr, exists := data["http.circuitBreakerCooldown"]; exists {
+		if cooldown, err := time.ParseDuration(cooldownStr); err == nil {
+			config.HTTP.CircuitBreakerCooldown = cooldown
+		}
+	}
+	if denyStr, exists := data["http.denyInsecureSkipVerify"]; exists {
+		if deny, err := strconv.ParseBool(denyStr); err == nil {
+			config.HTTP.DenyInsecureSkipVerify = deny
+		}
+	}
 }
 
 // loadRetryConfig loads retry configuration from ConfigMap data
@@ -181,6 +301,16 @@ func (l *ConfigMapLoader) loadRetryConfig(data map[string]string, config *Config
 			config.Retry.JitterFactor = jitterFactor
 		}
 	}
+	if stalledIntervalStr, exists := data["retry.stalledInterval"]; exists {
+		if stalledInterval, err := time.ParseDuration(stalledIntervalStr); err == nil {
+			config.Retry.StalledInterval = stalledInterval
+		}
+	}
+	if successThresholdStr, exists := data["retry.successThreshold"]; exists {
+		if successThreshold, err := strconv.Atoi(successThresholdStr); err == nil {
+			config.Retry.SuccessThreshold = successThreshold
+		}
+	}
 }
 
 // loadHooksConfig loads hooks configuration from ConfigMap data
@@ -210,4 +340,82 @@ func (l *ConfigMapLoader) loadMetricsConfig(data map[string]string, config *Conf
 			config.Metrics.Interval = interval
 		}
 	}
+	if sourceLabels, exists := data["metrics.sourceLabels"]; exists {
+		config.Metrics.SourceLabels = sourceLabels
+	}
+	if perSourceStr, exists := data["metrics.perSourceActiveReconciliations"]; exists {
+		if perSource, err := strconv.ParseBool(perSourceStr); err == nil {
+			config.Metrics.PerSourceActiveReconciliations = perSource
+		}
+	}
+}
+
+// loadFileGeneratorConfig loads file generator configuration from ConfigMap data
+func (l *ConfigMapLoader) loadFileGeneratorConfig(data map[string]string, config *Config) {
+	if root, exists := data["fileGenerator.root"]; exists {
+		config.FileGenerator.Root = root
+	}
+}
+
+// loadControllerConfig loads concurrency and rate limiting configuration from ConfigMap data
+func (l *ConfigMapLoader) loadControllerConfig(data map[string]string, config *Config) {
+	if maxConcurrentStr, exists := data["controller.maxConcurrentReconciles"]; exists {
+		if maxConcurrent, err := strconv.Atoi(maxConcurrentStr); err == nil {
+			config.Controller.MaxConcurrentReconciles = maxConcurrent
+		}
+	}
+	if rateLimitStr, exists := data["controller.fetchRateLimit"]; exists {
+		if rateLimit, err := strconv.ParseFloat(rateLimitStr, 64); err == nil {
+			config.Controller.FetchRateLimit = rateLimit
+		}
+	}
+	if burstStr, exists := data["controller.fetchRateBurst"]; exists {
+		if burst, err := strconv.Atoi(burstStr); err == nil {
+			config.Controller.FetchRateBurst = burst
+		}
+	}
+	if spreadStr, exists := data["controller.startupSpread"]; exists {
+		if spread, err := strconv.ParseFloat(spreadStr, 64); err == nil {
+			config.Controller.StartupSpread = spread
+		}
+	}
+	if minIntervalStr, exists := data["controller.minInterval"]; exists {
+		if minInterval, err := time.ParseDuration(minIntervalStr); err == nil {
+			config.Controller.MinInterval = minInterval
+		}
+	}
+	if maxIntervalStr, exists := data["controller.maxInterval"]; exists {
+		if maxInterval, err := time.ParseDuration(maxIntervalStr); err == nil {
+			config.Controller.MaxInterval = maxInterval
+		}
+	}
+	if intervalStr, exists := data["controller.failureEventInterval"]; exists {
+		if interval, err := time.ParseDuration(intervalStr); err == nil {
+			config.Controller.FailureEventInterval = interval
+		}
+	}
+
+	if intervalStr, exists := data["controller.dependencyRequeueInterval"]; exists {
+		if interval, err := time.ParseDuration(intervalStr); err == nil {
+			config.Controller.DependencyRequeueInterval = interval
+		}
+	}
+
+	if timeoutStr, exists := data["controller.maxReconcileTimeout"]; exists {
+		if timeout, err := time.ParseDuration(timeoutStr); err == nil {
+			config.Controller.MaxReconcileTimeout = timeout
+		}
+	}
+
+	if thresholdStr, exists := data["controller.stuckReconcileThreshold"]; exists {
+		if threshold, err := time.ParseDuration(thresholdStr); err == nil {
+			config.Controller.StuckReconcileThreshold = threshold
+		}
+	}
+
+	if cancelStr, exists := data["controller.cancelStuckReconciles"]; exists {
+		if cancel, err := strconv.ParseBool(cancelStr); err == nil {
+			config.Controller.CancelStuckReconciles = cancel
+		}
+	}
 }
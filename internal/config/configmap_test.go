@@ -54,11 +54,14 @@ func TestConfigMapLoader_LoadConfig(t *testing.T) {
 
 					// HTTP configuration
 					"http.timeout":             "60s",
+					"http.connectTimeout":      "5s",
+					"http.tlsHandshakeTimeout": "6s",
 					"http.maxIdleConns":        "200",
 					"http.maxIdleConnsPerHost": "20",
 					"http.maxConnsPerHost":     "200",
 					"http.idleConnTimeout":     "120s",
 					"http.userAgent":           "test-agent/1.0",
+					"http.defaultHeaders":      "X-Org-Id=platform",
 
 					// Retry configuration
 					"retry.maxAttempts":  "5",
@@ -87,11 +90,14 @@ func TestConfigMapLoader_LoadConfig(t *testing.T) {
 
 				// Validate HTTP config
 				assert.Equal(t, 60*time.Second, config.HTTP.Timeout)
+				assert.Equal(t, 5*time.Second, config.HTTP.ConnectTimeout)
+				assert.Equal(t, 6*time.Second, config.HTTP.TLSHandshakeTimeout)
 				assert.Equal(t, 200, config.HTTP.MaxIdleConns)
 				assert.Equal(t, 20, config.HTTP.MaxIdleConnsPerHost)
 				assert.Equal(t, 200, config.HTTP.MaxConnsPerHost)
 				assert.Equal(t, 120*time.Second, config.HTTP.IdleConnTimeout)
 				assert.Equal(t, "test-agent/1.0", config.HTTP.UserAgent)
+				assert.Equal(t, map[string]string{"X-Org-Id": "platform"}, config.HTTP.DefaultHeaders)
 
 				// Validate retry config
 				assert.Equal(t, 5, config.Retry.MaxAttempts)
@@ -226,12 +232,16 @@ func TestConfigMapLoader_LoadStorageConfig(t *testing.T) {
 	config := DefaultConfig()
 
 	data := map[string]string{
-		"storage.backend":      "s3",
-		"storage.s3.bucket":    "test-bucket",
-		"storage.s3.region":    "eu-west-1",
-		"storage.s3.endpoint":  "https://custom.s3.com",
-		"storage.s3.useSSL":    "false",
-		"storage.s3.pathStyle": "true",
+		"storage.backend":               "s3",
+		"storage.s3.bucket":             "test-bucket",
+		"storage.s3.region":             "eu-west-1",
+		"storage.s3.endpoint":           "https://custom.s3.com",
+		"storage.s3.useSSL":             "false",
+		"storage.s3.pathStyle":          "true",
+		"storage.s3.presignURLs":        "true",
+		"storage.s3.presignExpiry":      "30m",
+		"storage.s3.multipartThreshold": "0",
+		"storage.s3.multipartPartSize":  "33554432",
 	}
 
 	loader.loadStorageConfig(data, config)
@@ -242,6 +252,111 @@ func TestConfigMapLoader_LoadStorageConfig(t *testing.T) {
 	assert.Equal(t, "https://custom.s3.com", config.Storage.S3.Endpoint)
 	assert.False(t, config.Storage.S3.UseSSL)
 	assert.True(t, config.Storage.S3.PathStyle)
+	assert.True(t, config.Storage.S3.PresignURLs)
+	assert.Equal(t, 30*time.Minute, config.Storage.S3.PresignExpiry)
+	assert.Zero(t, config.Storage.S3.MultipartThreshold)
+	assert.Equal(t, int64(32<<20), config.Storage.S3.MultipartPartSize)
+}
+
+func TestConfigMapLoader_LoadPVCGCConfig(t *testing.T) {
+	loader := &ConfigMapLoader{}
+	config := DefaultConfig()
+
+	data := map[string]string{
+		"storage.pvc.gcInterval":     "30m",
+		"storage.pvc.gcMaxAge":       "168h",
+		"storage.pvc.gcMaxRevisions": "3",
+	}
+
+	loader.loadStorageConfig(data, config)
+
+	assert.Equal(t, 30*time.Minute, config.Storage.PVC.GCInterval)
+	assert.Equal(t, 168*time.Hour, config.Storage.PVC.GCMaxAge)
+	assert.Equal(t, 3, config.Storage.PVC.GCMaxRevisions)
+}
+
+func TestConfigMapLoader_LoadDigestAlgorithm(t *testing.T) {
+	loader := &ConfigMapLoader{}
+	config := DefaultConfig()
+
+	loader.loadStorageConfig(map[string]string{"storage.digestAlgorithm": "sha512"}, config)
+
+	assert.Equal(t, "sha512", config.Storage.DigestAlgorithm)
+}
+
+func TestConfigMapLoader_LoadCompression(t *testing.T) {
+	loader := &ConfigMapLoader{}
+	config := DefaultConfig()
+
+	loader.loadStorageConfig(map[string]string{"storage.compression": "zstd"}, config)
+
+	assert.Equal(t, "zstd", config.Storage.Compression)
+}
+
+func TestConfigMapLoader_LoadHistoryLimit(t *testing.T) {
+	loader := &ConfigMapLoader{}
+	config := DefaultConfig()
+	assert.Equal(t, 1, config.Storage.HistoryLimit)
+
+	loader.loadStorageConfig(map[string]string{"storage.historyLimit": "5"}, config)
+
+	assert.Equal(t, 5, config.Storage.HistoryLimit)
+}
+
+func TestConfigMapLoader_LoadKeyPrefix(t *testing.T) {
+	loader := &ConfigMapLoader{}
+	config := DefaultConfig()
+	assert.Empty(t, config.Storage.KeyPrefix)
+
+	loader.loadStorageConfig(map[string]string{"storage.keyPrefix": "cluster-a"}, config)
+
+	assert.Equal(t, "cluster-a", config.Storage.KeyPrefix)
+}
+
+func TestConfigMapLoader_LoadDefaultDestinationPath(t *testing.T) {
+	loader := &ConfigMapLoader{}
+	config := DefaultConfig()
+	assert.Empty(t, config.Storage.DefaultDestinationPath)
+
+	loader.loadStorageConfig(map[string]string{"storage.defaultDestinationPath": "{{ .Namespace }}/{{ .Name }}.json"}, config)
+
+	assert.Equal(t, "{{ .Namespace }}/{{ .Name }}.json", config.Storage.DefaultDestinationPath)
+	assert.NoError(t, config.Validate())
+}
+
+func TestConfigMapLoader_LoadEncryptionConfig(t *testing.T) {
+	loader := &ConfigMapLoader{}
+	config := DefaultConfig()
+	assert.False(t, config.Storage.Encryption.Enabled)
+
+	loader.loadStorageConfig(map[string]string{
+		"storage.encryption.enabled": "true",
+		"storage.encryption.keyPath": "/etc/encryption/key",
+	}, config)
+
+	assert.True(t, config.Storage.Encryption.Enabled)
+	assert.Equal(t, "/etc/encryption/key", config.Storage.Encryption.KeyPath)
+}
+
+func TestConfigMapLoader_LoadOCIStorageConfig(t *testing.T) {
+	loader := &ConfigMapLoader{}
+	config := DefaultConfig()
+
+	data := map[string]string{
+		"storage.backend":              "oci",
+		"storage.oci.registry":         "ghcr.io",
+		"storage.oci.repository":       "org/artifacts",
+		"storage.oci.insecure":         "true",
+		"storage.oci.dockerConfigPath": "/etc/oci/.dockerconfigjson",
+	}
+
+	loader.loadStorageConfig(data, config)
+
+	assert.Equal(t, "oci", config.Storage.Backend)
+	assert.Equal(t, "ghcr.io", config.Storage.OCI.Registry)
+	assert.Equal(t, "org/artifacts", config.Storage.OCI.Repository)
+	assert.True(t, config.Storage.OCI.Insecure)
+	assert.Equal(t, "/etc/oci/.dockerconfigjson", config.Storage.OCI.DockerConfigPath)
 }
 
 func TestConfigMapLoader_LoadHTTPConfig(t *testing.T) {
@@ -249,12 +364,16 @@ func TestConfigMapLoader_LoadHTTPConfig(t *testing.T) {
 	config := DefaultConfig()
 
 	data := map[string]string{
-		"http.timeout":             "45s",
-		"http.maxIdleConns":        "150",
-		"http.maxIdleConnsPerHost": "15",
-		"http.maxConnsPerHost":     "150",
-		"http.idleConnTimeout":     "100s",
-		"http.userAgent":           "custom-agent/2.0",
+		"http.timeout":                 "45s",
+		"http.maxIdleConns":            "150",
+		"http.maxIdleConnsPerHost":     "15",
+		"http.maxConnsPerHost":         "150",
+		"http.idleConnTimeout":         "100s",
+		"http.userAgent":               "custom-agent/2.0",
+		"http.maxResponseSize":         "1048576",
+		"http.circuitBreakerThreshold": "8",
+		"http.circuitBreakerCooldown":  "30s",
+		"http.denyInsecureSkipVerify":  "true",
 	}
 
 	loader.loadHTTPConfig(data, config)
@@ -265,6 +384,10 @@ func TestConfigMapLoader_LoadHTTPConfig(t *testing.T) {
 	assert.Equal(t, 150, config.HTTP.MaxConnsPerHost)
 	assert.Equal(t, 100*time.Second, config.HTTP.IdleConnTimeout)
 	assert.Equal(t, "custom-agent/2.0", config.HTTP.UserAgent)
+	assert.Equal(t, int64(1048576), config.HTTP.MaxResponseSize)
+	assert.Equal(t, 8, config.HTTP.CircuitBreakerThreshold)
+	assert.Equal(t, 30*time.Second, config.HTTP.CircuitBreakerCooldown)
+	assert.True(t, config.HTTP.DenyInsecureSkipVerify)
 }
 
 func TestConfigMapLoader_LoadRetryConfig(t *testing.T) {
@@ -272,10 +395,12 @@ func TestConfigMapLoader_LoadRetryConfig(t *testing.T) {
 	config := DefaultConfig()
 
 	data := map[string]string{
-		"retry.maxAttempts":  "7",
-		"retry.baseDelay":    "3s",
-		"retry.maxDelay":     "15m",
-		"retry.jitterFactor": "0.3",
+		"retry.maxAttempts":      "7",
+		"retry.baseDelay":        "3s",
+		"retry.maxDelay":         "15m",
+		"retry.jitterFactor":     "0.3",
+		"retry.stalledInterval":  "2h",
+		"retry.successThreshold": "4",
 	}
 
 	loader.loadRetryConfig(data, config)
@@ -284,6 +409,8 @@ func TestConfigMapLoader_LoadRetryConfig(t *testing.T) {
 	assert.Equal(t, 3*time.Second, config.Retry.BaseDelay)
 	assert.Equal(t, 15*time.Minute, config.Retry.MaxDelay)
 	assert.Equal(t, 0.3, config.Retry.JitterFactor)
+	assert.Equal(t, 2*time.Hour, config.Retry.StalledInterval)
+	assert.Equal(t, 4, config.Retry.SuccessThreshold)
 }
 
 func TestConfigMapLoader_LoadHooksConfig(t *testing.T) {
@@ -308,12 +435,62 @@ func TestConfigMapLoader_LoadMetricsConfig(t *testing.T) {
 	config := DefaultConfig()
 
 	data := map[string]string{
-		"metrics.enabled":  "false",
-		"metrics.interval": "45s",
+		"metrics.enabled":                        "false",
+		"metrics.interval":                       "45s",
+		"metrics.sourceLabels":                   "none",
+		"metrics.perSourceActiveReconciliations": "true",
 	}
 
 	loader.loadMetricsConfig(data, config)
 
 	assert.False(t, config.Metrics.Enabled)
 	assert.Equal(t, 45*time.Second, config.Metrics.Interval)
+	assert.Equal(t, "none", config.Metrics.SourceLabels)
+	assert.True(t, config.Metrics.PerSourceActiveReconciliations)
+}
+
+func TestConfigMapLoader_LoadFileGeneratorConfig(t *testing.T) {
+	loader := &ConfigMapLoader{}
+	config := DefaultConfig()
+
+	data := map[string]string{
+		"fileGenerator.root": "/var/run/sidecar",
+	}
+
+	loader.loadFileGeneratorConfig(data, config)
+
+	assert.Equal(t, "/var/run/sidecar", config.FileGenerator.Root)
+}
+
+func TestConfigMapLoader_LoadControllerConfig(t *testing.T) {
+	loader := &ConfigMapLoader{}
+	config := DefaultConfig()
+
+	data := map[string]string{
+		"controller.maxConcurrentReconciles":   "4",
+		"controller.fetchRateLimit":            "0.5",
+		"controller.fetchRateBurst":            "2",
+		"controller.startupSpread":             "0.25",
+		"controller.minInterval":               "30s",
+		"controller.maxInterval":               "2h",
+		"controller.failureEventInterval":      "15m",
+		"controller.dependencyRequeueInterval": "45s",
+		"controller.maxReconcileTimeout":       "5m",
+		"controller.stuckReconcileThreshold":   "12m",
+		"controller.cancelStuckReconciles":     "true",
+	}
+
+	loader.loadControllerConfig(data, config)
+
+	assert.Equal(t, 4, config.Controller.MaxConcurrentReconciles)
+	assert.Equal(t, 0.5, config.Controller.FetchRateLimit)
+	assert.Equal(t, 2, config.Controller.FetchRateBurst)
+	assert.Equal(t, 0.25, config.Controller.StartupSpread)
+	assert.Equal(t, 30*time.Second, config.Controller.MinInterval)
+	assert.Equal(t, 2*time.Hour, config.Controller.MaxInterval)
+	assert.Equal(t, 15*time.Minute, config.Controller.FailureEventInterval)
+	assert.Equal(t, 45*time.Second, config.Controller.DependencyRequeueInterval)
+	assert.Equal(t, 5*time.Minute, config.Controller.MaxReconcileTimeout)
+	assert.Equal(t, 12*time.Minute, config.Controller.StuckReconcileThreshold)
+	assert.True(t, config.Controller.CancelStuckReconciles)
 }
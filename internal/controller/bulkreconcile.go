@@ -0,0 +1,103 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	sourcev1alpha1 "github.com/oddkinco/flux-externalsource-controller/api/v1alpha1"
+)
+
+// BulkReconcileOptions selects the ExternalSources RequestReconcile annotates
+type BulkReconcileOptions struct {
+	// Namespace limits the sources to a namespace, empty selects all namespaces
+	Namespace string
+
+	// Selector selects the sources by label
+	Selector labels.Selector
+
+	// Force sets the force refetch annotation instead of a reconcile request, so unchanged
+	// content is stored again
+	Force bool
+
+	// DryRun sends the patches as server-side dry runs, nothing is changed
+	DryRun bool
+}
+
+// RequestReconcile annotates every ExternalSource matching the options with a reconcile
+// request, as `flux reconcile` does for a single object, so each is fetched right away
+// without conditional requests. Requests are made with the permissions of the client, so
+// the caller needs list and patch access to the selected sources. Sources that fail to be
+// annotated don't stop the others, their errors are joined. The annotated sources are
+// returned.
+func RequestReconcile(ctx context.Context, c client.Client, opts BulkReconcileOptions) ([]types.NamespacedName, error) {
+	if opts.Selector == nil || opts.Selector.Empty() {
+		return nil, errors.New("a label selector is required")
+	}
+
+	var sources sourcev1alpha1.ExternalSourceList
+	if err := c.List(ctx, &sources,
+		client.InNamespace(opts.Namespace),
+		client.MatchingLabelsSelector{Selector: opts.Selector},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list ExternalSources: %w", err)
+	}
+
+	var patchOpts []client.PatchOption
+	if opts.DryRun {
+		patchOpts = append(patchOpts, client.DryRunAll)
+	}
+
+	requestedAt := time.Now().Format(time.RFC3339Nano)
+	annotated := make([]types.NamespacedName, 0, len(sources.Items))
+	var errs []error
+	for i := range sources.Items {
+		externalSource := &sources.Items[i]
+		patch := client.MergeFrom(externalSource.DeepCopy())
+		if externalSource.Annotations == nil {
+			externalSource.Annotations = make(map[string]string)
+		}
+		if opts.Force {
+			externalSource.Annotations[ForceRefetchAnnotation] = requestedAt
+		} else {
+			externalSource.Annotations[fluxmeta.ReconcileRequestAnnotation] = requestedAt
+		}
+
+		key := client.ObjectKeyFromObject(externalSource)
+		if err := c.Patch(ctx, externalSource, patch, patchOpts...); err != nil {
+			errs = append(errs, fmt.Errorf("failed to annotate ExternalSource %s: %w", key, err))
+			continue
+		}
+		annotated = append(annotated, key)
+	}
+
+	return annotated, errors.Join(errs...)
+}
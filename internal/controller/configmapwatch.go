@@ -0,0 +1,127 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sourcev1alpha1 "github.com/oddkinco/flux-externalsource-controller/api/v1alpha1"
+)
+
+// configMapRefIndexKey indexes ExternalSources by the names of the ConfigMaps they reference
+const configMapRefIndexKey = ".spec.configMapRefs"
+
+// referencedConfigMaps returns the sorted names of the ConfigMaps in its namespace the
+// ExternalSource reads its source data, CA bundle or validation schema from
+func referencedConfigMaps(externalSource *sourcev1alpha1.ExternalSource) []string {
+	names := make(map[string]struct{})
+	add := func(name string) {
+		if name != "" {
+			names[name] = struct{}{}
+		}
+	}
+
+	spec := externalSource.Spec
+	if spec.Generator.ConfigMap != nil {
+		add(spec.Generator.ConfigMap.Name)
+	}
+	if gen := spec.Generator.HTTP; gen != nil && gen.CABundleConfigMapRef != nil {
+		add(gen.CABundleConfigMapRef.Name)
+	}
+	if spec.Validation != nil && spec.Validation.SchemaRef != nil {
+		add(spec.Validation.SchemaRef.Name)
+	}
+
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// indexConfigMapRefs is the field indexer for configMapRefIndexKey
+func indexConfigMapRefs(obj client.Object) []string {
+	externalSource, ok := obj.(*sourcev1alpha1.ExternalSource)
+	if !ok {
+		return nil
+	}
+	return referencedConfigMaps(externalSource)
+}
+
+// configMapDataChanged passes created ConfigMaps, so sources failing on a missing ConfigMap
+// recover once it exists, and ConfigMap updates that change the ConfigMap contents. Resyncs,
+// metadata updates and the ConfigMaps listed on startup don't trigger a reconcile.
+func configMapDataChanged() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return !e.IsInInitialList },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldConfigMap, ok := e.ObjectOld.(*corev1.ConfigMap)
+			if !ok {
+				return false
+			}
+			newConfigMap, ok := e.ObjectNew.(*corev1.ConfigMap)
+			if !ok {
+				return false
+			}
+			return !equality.Semantic.DeepEqual(oldConfigMap.Data, newConfigMap.Data) ||
+				!equality.Semantic.DeepEqual(oldConfigMap.BinaryData, newConfigMap.BinaryData)
+		},
+	}
+}
+
+// sourcesForConfigMap enqueues the ExternalSources referencing a changed ConfigMap and marks
+// them so their next reconcile fetches the source even if it reports no changes, so a new CA
+// bundle or validation schema is applied to the current upstream data right away.
+func (r *ExternalSourceReconciler) sourcesForConfigMap(ctx context.Context, obj client.Object) []reconcile.Request {
+	var sources sourcev1alpha1.ExternalSourceList
+	if err := r.List(ctx, &sources,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{configMapRefIndexKey: obj.GetName()},
+	); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list ExternalSources referencing ConfigMap",
+			"configMap", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+
+	change := fmt.Sprintf("ConfigMap/%s@%s", obj.GetName(), obj.GetResourceVersion())
+	requests := make([]reconcile.Request, 0, len(sources.Items))
+	for i := range sources.Items {
+		key := client.ObjectKeyFromObject(&sources.Items[i])
+		r.changedRefSources.Store(key, change)
+		requests = append(requests, reconcile.Request{NamespacedName: key})
+	}
+	return requests
+}
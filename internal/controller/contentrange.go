@@ -0,0 +1,102 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	sourcev1alpha1 "github.com/oddkinco/flux-externalsource-controller/api/v1alpha1"
+	"github.com/oddkinco/flux-externalsource-controller/internal/artifact"
+	"github.com/oddkinco/flux-externalsource-controller/internal/generator"
+)
+
+// rangeModeAppend appends fetched ranges to the content of the current artifact
+const rangeModeAppend = "Append"
+
+// rangeStart returns the offset the next range request starts at: the end of the range held
+// by the current artifact for incremental ranges, else the offset from the spec
+func rangeStart(externalSource *sourcev1alpha1.ExternalSource) int64 {
+	rangeSpec := externalSource.Spec.Generator.HTTP.Range
+	if current := externalSource.Status.Range; rangeSpec.Incremental && current != nil && externalSource.Status.Artifact != nil {
+		return current.Offset + current.Length
+	}
+	return rangeSpecOffset(externalSource)
+}
+
+// rangeSpecOffset returns the offset from the range spec, 0 when not set
+func rangeSpecOffset(externalSource *sourcev1alpha1.ExternalSource) int64 {
+	if offset := externalSource.Spec.Generator.HTTP.Range.Offset; offset != nil {
+		return *offset
+	}
+	return 0
+}
+
+// rangeAppending returns true when the next fetched range continues the current artifact
+// and is appended to its content
+func rangeAppending(externalSource *sourcev1alpha1.ExternalSource) bool {
+	httpSpec := externalSource.Spec.Generator.HTTP
+	if httpSpec == nil || httpSpec.Range == nil {
+		return false
+	}
+	return httpSpec.Range.Incremental && httpSpec.Range.Mode == rangeModeAppend &&
+		externalSource.Status.Range != nil && externalSource.Status.Artifact != nil
+}
+
+// loadRangeBase returns the content of the current artifact that fetched ranges are appended to
+func (r *ExternalSourceReconciler) loadRangeBase(ctx context.Context, externalSource *sourcev1alpha1.ExternalSource) ([]byte, error) {
+	loader, ok := r.ArtifactManager.(artifact.ContentLoader)
+	if !ok {
+		return nil, fmt.Errorf("artifact manager cannot load stored content")
+	}
+	destinationPath, err := r.destinationPath(externalSource)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceKey := fmt.Sprintf("%s/%s", externalSource.Namespace, externalSource.Name)
+	loadCtx := artifact.WithKeyPrefix(ctx, externalSource.Spec.StoragePrefix)
+	current := externalSource.Status.Artifact
+	return loader.LoadContent(loadCtx, sourceKey, current.Revision, current.Metadata["compression"], destinationPath)
+}
+
+// contentRange returns the byte range of the source content held by the new artifact, nil
+// when no range is configured
+func contentRange(externalSource *sourcev1alpha1.ExternalSource, sourceData *generator.SourceData, appended bool) *sourcev1alpha1.ContentRange {
+	httpSpec := externalSource.Spec.Generator.HTTP
+	if httpSpec == nil || httpSpec.Range == nil {
+		return nil
+	}
+
+	fetched := sourceData.Range
+	if fetched == nil {
+		// The server ignored the range and sent the full content
+		size := int64(len(sourceData.Data))
+		return &sourcev1alpha1.ContentRange{Offset: 0, Length: size, Size: size}
+	}
+	if appended {
+		current := externalSource.Status.Range
+		return &sourcev1alpha1.ContentRange{Offset: current.Offset, Length: current.Length + fetched.Length, Size: fetched.Size}
+	}
+	return &sourcev1alpha1.ContentRange{Offset: fetched.Offset, Length: fetched.Length, Size: fetched.Size}
+}
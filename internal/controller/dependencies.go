@@ -0,0 +1,138 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sourcev1alpha1 "github.com/oddkinco/flux-externalsource-controller/api/v1alpha1"
+)
+
+// DependencyNotReadyReason indicates the ExternalSource waits for one of its dependencies
+const DependencyNotReadyReason = "DependencyNotReady"
+
+// dependsOnIndexKey indexes ExternalSources by the namespace/name of the ExternalSources they depend on
+const dependsOnIndexKey = ".spec.dependsOn"
+
+// dependencyKey resolves a dependency reference, defaulting the namespace to the one of the
+// depending ExternalSource
+func dependencyKey(externalSource *sourcev1alpha1.ExternalSource, ref meta.NamespacedObjectReference) types.NamespacedName {
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = externalSource.Namespace
+	}
+	return types.NamespacedName{Namespace: namespace, Name: ref.Name}
+}
+
+// indexDependsOn is the field indexer for dependsOnIndexKey
+func indexDependsOn(obj client.Object) []string {
+	externalSource, ok := obj.(*sourcev1alpha1.ExternalSource)
+	if !ok || len(externalSource.Spec.DependsOn) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(externalSource.Spec.DependsOn))
+	for _, ref := range externalSource.Spec.DependsOn {
+		keys = append(keys, dependencyKey(externalSource, ref).String())
+	}
+	return keys
+}
+
+// dependencyReady reports whether an ExternalSource is ready with an artifact for its current spec
+func dependencyReady(externalSource *sourcev1alpha1.ExternalSource) bool {
+	if externalSource.Status.Artifact == nil {
+		return false
+	}
+	ready := apimeta.FindStatusCondition(externalSource.Status.Conditions, ReadyCondition)
+	return ready != nil && ready.Status == metav1.ConditionTrue && ready.ObservedGeneration == externalSource.Generation
+}
+
+// checkDependencies returns a message naming the first dependency of the ExternalSource that is
+// not ready, or an empty message when all dependencies are ready
+func (r *ExternalSourceReconciler) checkDependencies(ctx context.Context, externalSource *sourcev1alpha1.ExternalSource) (string, error) {
+	for _, ref := range externalSource.Spec.DependsOn {
+		key := dependencyKey(externalSource, ref)
+		var dependency sourcev1alpha1.ExternalSource
+		if err := r.Get(ctx, key, &dependency); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Sprintf("Dependency %s not found", key), nil
+			}
+			return "", fmt.Errorf("failed to get dependency %s: %w", key, err)
+		}
+		if !dependencyReady(&dependency) {
+			return fmt.Sprintf("Dependency %s is not ready", key), nil
+		}
+	}
+	return "", nil
+}
+
+// dependencyReadinessChanged only passes ExternalSource updates that change whether the
+// ExternalSource is ready as a dependency
+func dependencyReadinessChanged() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldSource, ok := e.ObjectOld.(*sourcev1alpha1.ExternalSource)
+			if !ok {
+				return false
+			}
+			newSource, ok := e.ObjectNew.(*sourcev1alpha1.ExternalSource)
+			if !ok {
+				return false
+			}
+			return dependencyReady(oldSource) != dependencyReady(newSource)
+		},
+	}
+}
+
+// sourcesForDependency enqueues the ExternalSources depending on an ExternalSource whose
+// readiness changed
+func (r *ExternalSourceReconciler) sourcesForDependency(ctx context.Context, obj client.Object) []reconcile.Request {
+	var sources sourcev1alpha1.ExternalSourceList
+	if err := r.List(ctx, &sources,
+		client.MatchingFields{dependsOnIndexKey: client.ObjectKeyFromObject(obj).String()},
+	); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list ExternalSources depending on ExternalSource",
+			"externalSource", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(sources.Items))
+	for i := range sources.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&sources.Items[i])})
+	}
+	return requests
+}
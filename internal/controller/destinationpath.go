@@ -0,0 +1,78 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	sourcev1alpha1 "github.com/oddkinco/flux-externalsource-controller/api/v1alpha1"
+)
+
+// defaultDestinationPath is used when neither the source nor the controller sets a path
+const defaultDestinationPath = "data"
+
+// destinationPath returns the path of the data within the artifact: the path from the spec,
+// else the controller default, else "data", with its placeholders resolved
+func (r *ExternalSourceReconciler) destinationPath(externalSource *sourcev1alpha1.ExternalSource) (string, error) {
+	path := externalSource.Spec.DestinationPath
+	if path == "" && r.Config != nil {
+		path = r.Config.Storage.DefaultDestinationPath
+	}
+	if path == "" {
+		return defaultDestinationPath, nil
+	}
+	return renderDestinationPath(externalSource, path)
+}
+
+// renderDestinationPath resolves the {{ .Name }} and {{ .Namespace }} placeholders in a
+// destination path against the ExternalSource
+func renderDestinationPath(externalSource *sourcev1alpha1.ExternalSource, path string) (string, error) {
+	if !strings.Contains(path, "{{") {
+		return path, nil
+	}
+
+	var renderErr error
+	rendered := urlPlaceholder.ReplaceAllStringFunc(path, func(placeholder string) string {
+		switch expression := urlPlaceholder.FindStringSubmatch(placeholder)[1]; expression {
+		case ".Name":
+			return externalSource.Name
+		case ".Namespace":
+			return externalSource.Namespace
+		default:
+			if renderErr == nil {
+				renderErr = fmt.Errorf("invalid destination path placeholder {{ %s }}: only .Name and .Namespace may be referenced", expression)
+			}
+			return ""
+		}
+	})
+	if renderErr != nil {
+		return "", renderErr
+	}
+
+	if strings.Contains(rendered, "{{") || strings.Contains(rendered, "}}") {
+		return "", fmt.Errorf("invalid destination path template %q: unbalanced braces", path)
+	}
+
+	return rendered, nil
+}
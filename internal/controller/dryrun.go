@@ -0,0 +1,109 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	sourcev1alpha1 "github.com/oddkinco/flux-externalsource-controller/api/v1alpha1"
+	"github.com/oddkinco/flux-externalsource-controller/internal/artifact"
+	"github.com/oddkinco/flux-externalsource-controller/internal/generator"
+)
+
+// DryRunResult holds the output of a dry run
+type DryRunResult struct {
+	// Data is the fetched data after post-request hooks, as placed in the artifact
+	Data []byte
+
+	// Artifact is the packaged artifact, it is not stored
+	Artifact *artifact.Artifact
+}
+
+// DryRun fetches data for the ExternalSource, runs its post-request hooks and packages the
+// result the same way reconcile does, without updating status, storing the artifact or
+// creating the ExternalArtifact. Conditional requests are not used, the source is always fetched.
+func (r *ExternalSourceReconciler) DryRun(ctx context.Context, externalSource *sourcev1alpha1.ExternalSource) (*DryRunResult, error) {
+	ctx = withCorrelationID(ctx, client.ObjectKeyFromObject(externalSource))
+
+	if r.GeneratorFactory == nil {
+		r.GeneratorFactory = generator.NewFactory()
+		if err := r.registerGenerators(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.checkPolicy(externalSource); err != nil {
+		return nil, err
+	}
+
+	generatorConfig, err := r.createGeneratorConfig(externalSource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create generator config: %w", err)
+	}
+
+	sourceGenerator, err := r.GeneratorFactory.CreateGenerator(externalSource.Spec.Generator.Type)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create source generator: %w", err)
+	}
+
+	sourceData, err := sourceGenerator.Generate(ctx, *generatorConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate source data: %w", err)
+	}
+
+	data, err := r.decryptContent(ctx, externalSource, sourceData.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	if externalSource.Spec.Hooks != nil && len(externalSource.Spec.Hooks.PostRequest) > 0 {
+		if r.HookExecutor == nil {
+			return nil, fmt.Errorf("post-request hooks are configured but no hook executor is available")
+		}
+		data, err = r.executeHooks(ctx, externalSource, data, externalSource.Spec.Hooks.PostRequest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute post-request hooks: %w", err)
+		}
+	}
+
+	if err := r.validateContent(ctx, externalSource, data); err != nil {
+		return nil, err
+	}
+
+	destinationPath, err := r.destinationPath(externalSource)
+	if err != nil {
+		return nil, err
+	}
+
+	packageCtx := artifact.WithDigestAlgorithm(ctx, externalSource.Spec.DigestAlgorithm)
+	packageCtx = artifact.WithCanonicalize(packageCtx, externalSource.Spec.Canonicalize)
+	packagedArtifact, err := r.ArtifactManager.Package(packageCtx, data, destinationPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to package artifact: %w", err)
+	}
+
+	return &DryRunResult{Data: data, Artifact: packagedArtifact}, nil
+}
@@ -24,30 +24,55 @@ SOFTWARE.
 package controller
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"math"
 	"math/rand"
+	"net"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	"github.com/robfig/cron/v3"
 	sourcev1alpha1 "github.com/oddkinco/flux-externalsource-controller/api/v1alpha1"
 	"github.com/oddkinco/flux-externalsource-controller/internal/artifact"
 	"github.com/oddkinco/flux-externalsource-controller/internal/config"
+	"github.com/oddkinco/flux-externalsource-controller/internal/decryption"
 	"github.com/oddkinco/flux-externalsource-controller/internal/generator"
 	"github.com/oddkinco/flux-externalsource-controller/internal/hooks"
 	"github.com/oddkinco/flux-externalsource-controller/internal/metrics"
+	"github.com/oddkinco/flux-externalsource-controller/internal/notification"
 	"github.com/oddkinco/flux-externalsource-controller/internal/storage"
+	"github.com/oddkinco/flux-externalsource-controller/internal/validation"
 )
 
 // ExternalSourceReconciler reconciles a ExternalSource object
@@ -60,16 +85,41 @@ type ExternalSourceReconciler struct {
 	MetricsRecorder  metrics.MetricsRecorder
 	Config           *config.Config
 	StorageBackend   storage.StorageBackend // Optional: can be set externally to share with artifact server
+	Notifier         notification.Notifier
+	EventRecorder    record.EventRecorder // Optional: warnings are only logged when nil
+
+	// ShutdownTimeout is how long in-flight reconciles may keep running after the manager
+	// is stopped, 0 cancels them immediately
+	ShutdownTimeout time.Duration
+
+	// fetchLimiter bounds requests to external sources across all reconciles, nil when unlimited
+	fetchLimiter *rate.Limiter
+
+	// reconciledSinceStart holds the keys of the sources reconciled since the controller started
+	reconciledSinceStart sync.Map
+
+	// changedRefSources holds the keys of the sources whose referenced secrets or ConfigMaps
+	// changed since their last successful reconcile, mapped to the change
+	changedRefSources sync.Map
+
+	// reportedFailures holds the last failure reported for each failing source
+	reportedFailures sync.Map
+
+	// inFlightReconciles holds the running reconciles checked by the watchdog, keyed by source
+	inFlightReconciles sync.Map
 }
 
 const (
 	// ExternalSourceFinalizer is the finalizer used by the ExternalSource controller
 	ExternalSourceFinalizer = "source.flux.oddkin.co/externalsource-finalizer"
 
-	// Annotation keys for retry tracking
-	retryCountAnnotation   = "source.flux.oddkin.co/retry-count"
-	lastFailureAnnotation  = "source.flux.oddkin.co/last-failure"
-	backoffStartAnnotation = "source.flux.oddkin.co/backoff-start"
+	// ForceRefetchAnnotation requests a single fetch that bypasses conditional requests and
+	// unchanged content detection, so the artifact is stored again even when the data is
+	// unchanged. The controller removes it once the fetch succeeded.
+	ForceRefetchAnnotation = "source.flux.oddkin.co/force-refetch"
+
+	// conditionalStrategyContentHash detects unchanged data by comparing it against the current artifact
+	conditionalStrategyContentHash = "contentHash"
 )
 
 // Condition types for ExternalSource
@@ -88,6 +138,10 @@ const (
 
 	// StalledCondition indicates reconciliation has been stalled due to errors
 	StalledCondition = "Stalled"
+
+	// DataFreshCondition indicates the last fetch succeeded, so the artifact reflects the
+	// source as of the current interval
+	DataFreshCondition = "DataFresh"
 )
 
 // Condition reasons
@@ -103,6 +157,12 @@ const (
 
 	// SuspendedReason indicates the resource is suspended
 	SuspendedReason = "Suspended"
+
+	// MaintenanceReason indicates fetching is paused during a maintenance window
+	MaintenanceReason = "Maintenance"
+
+	// DegradedReason indicates the last successful artifact is served while fetching fails
+	DegradedReason = "Degraded"
 )
 
 // +kubebuilder:rbac:groups=source.flux.oddkin.co,resources=externalsources,verbs=get;list;watch;create;update;patch;delete
@@ -117,9 +177,25 @@ const (
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 func (r *ExternalSourceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx = withCorrelationID(ctx, req.NamespacedName)
 	log := logf.FromContext(ctx)
 	startTime := time.Now()
 
+	// Don't start new work once the manager is shutting down, the source is reconciled
+	// again after the restart
+	if ctx.Err() != nil {
+		log.Info("Controller is shutting down, skipping reconciliation")
+		return ctrl.Result{}, nil
+	}
+
+	// Let an in-flight reconcile finish writing its status during shutdown
+	ctx, cancel := r.drainContext(ctx)
+	defer cancel()
+
+	// Let the watchdog report and optionally cancel a reconcile that hangs
+	tracked, done := r.trackReconcile(ctx, req.NamespacedName)
+	defer done()
+
 	// Track active reconciliations
 	if r.MetricsRecorder != nil {
 		r.MetricsRecorder.IncActiveReconciliations(req.Namespace, req.Name)
@@ -154,27 +230,118 @@ func (r *ExternalSourceReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	// Handle suspension
 	if externalSource.Spec.Suspend {
 		log.Info("ExternalSource is suspended, skipping reconciliation")
+		// Drop any backoff in progress so a resumed source fetches promptly instead of
+		// continuing from the retry count it had before it was suspended
+		r.clearRetryCount(&externalSource)
 		r.setReadyCondition(&externalSource, metav1.ConditionFalse, SuspendedReason, "ExternalSource is suspended")
+		r.setDataFreshCondition(&externalSource, false, SuspendedReason, "Fetching is suspended")
+		if err := r.Status().Update(ctx, &externalSource); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Sources violating the controller policy are not fetched until their spec changes
+	if err := r.checkPolicy(&externalSource); err != nil {
+		log.Info("ExternalSource violates controller policy, skipping reconciliation", "error", err.Error())
+		r.recordEvent(&externalSource, corev1.EventTypeWarning, PolicyViolationReason, err.Error())
+		r.setReadyCondition(&externalSource, metav1.ConditionFalse, PolicyViolationReason, err.Error())
+		if err := r.Status().Update(ctx, &externalSource); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Determine when to reconcile next from the schedule or interval
+	interval, err := nextRequeue(externalSource.Spec, time.Now())
+	if err != nil {
+		log.Error(err, "Failed to determine reconciliation interval")
+		r.setReadyCondition(&externalSource, metav1.ConditionFalse, "ConfigurationError",
+			fmt.Sprintf("Configuration error (will not retry until spec changes): %v", err))
+		if err := r.Status().Update(ctx, &externalSource); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Keep intervals within the bounds set by the controller configuration
+	if externalSource.Spec.Schedule == "" {
+		if clamped := r.clampInterval(interval); clamped != interval {
+			log.Info("Interval outside the allowed range, clamping", "interval", interval, "clamped", clamped)
+			r.recordEvent(&externalSource, corev1.EventTypeWarning, "IntervalClamped",
+				fmt.Sprintf("Interval %s is outside the range allowed by the controller, using %s", interval, clamped))
+			interval = clamped
+		}
+	}
+
+	// Bound the whole reconciliation so a slow chain of hooks, fetch and store can't hold a worker indefinitely
+	timeout, err := r.reconcileTimeout(externalSource.Spec, interval)
+	if err != nil {
+		log.Error(err, "Failed to determine reconciliation timeout")
+		r.setReadyCondition(&externalSource, metav1.ConditionFalse, "ConfigurationError",
+			fmt.Sprintf("Configuration error (will not retry until spec changes): %v", err))
 		if err := r.Status().Update(ctx, &externalSource); err != nil {
 			return ctrl.Result{}, err
 		}
 		return ctrl.Result{}, nil
 	}
 
-	// Parse interval
-	interval, err := time.ParseDuration(externalSource.Spec.Interval)
+	// Don't fetch during upstream maintenance, keeping the Ready status from before the window
+	windowEnd, err := maintenanceWindowEnd(externalSource.Spec.MaintenanceWindows, time.Now())
 	if err != nil {
-		log.Error(err, "Failed to parse interval")
-		r.setReadyCondition(&externalSource, metav1.ConditionFalse, FailedReason, fmt.Sprintf("Invalid interval: %v", err))
+		log.Error(err, "Failed to evaluate maintenance windows")
+		r.setReadyCondition(&externalSource, metav1.ConditionFalse, "ConfigurationError",
+			fmt.Sprintf("Configuration error (will not retry until spec changes): %v", err))
 		if err := r.Status().Update(ctx, &externalSource); err != nil {
 			return ctrl.Result{}, err
 		}
 		return ctrl.Result{}, nil
 	}
+	if !windowEnd.IsZero() {
+		log.Info("Maintenance window active, skipping reconciliation", "until", windowEnd)
+		r.holdReadyCondition(&externalSource, MaintenanceReason,
+			fmt.Sprintf("Fetching paused for maintenance until %s", windowEnd.UTC().Format(time.RFC3339)))
+		r.setDataFreshCondition(&externalSource, false, MaintenanceReason,
+			fmt.Sprintf("Fetching paused for maintenance until %s", windowEnd.UTC().Format(time.RFC3339)))
+		if err := r.Status().Update(ctx, &externalSource); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: time.Until(windowEnd)}, nil
+	}
 
-	// Ensure minimum interval of 1 minute
-	if interval < time.Minute {
-		interval = time.Minute
+	// Wait for the sources this one depends on, without treating it as a failure
+	message, err := r.checkDependencies(ctx, &externalSource)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if message != "" {
+		log.Info("Dependencies not ready, requeueing", "reason", message,
+			"after", r.Config.Controller.DependencyRequeueInterval)
+		r.setReadyCondition(&externalSource, metav1.ConditionFalse, DependencyNotReadyReason, message)
+		if err := r.Status().Update(ctx, &externalSource); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: r.Config.Controller.DependencyRequeueInterval}, nil
+	}
+
+	// Fan out the first fetches after a restart instead of fetching every source at once
+	if delay := r.startupDelay(&externalSource, interval); delay > 0 {
+		log.Info("Delaying first reconciliation after controller start to spread load",
+			"delay", delay.Round(time.Second), "interval", interval)
+		return ctrl.Result{RequeueAfter: delay}, nil
+	}
+
+	// Reset retry state if the spec has changed
+	if r.shouldResetRetryCount(&externalSource) {
+		r.clearRetryCount(&externalSource)
+	}
+
+	// A spec change may point at different content, so the freshness of the old data no longer applies
+	if externalSource.Generation != externalSource.Status.ObservedGeneration {
+		externalSource.Status.FreshUntil = nil
+		externalSource.Status.Range = nil
+		externalSource.Status.ResolvedRevision = ""
+		externalSource.Status.Cursor = ""
 	}
 
 	// Update observed generation
@@ -191,7 +358,26 @@ func (r *ExternalSourceReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	previousArtifact := externalSource.Status.Artifact
 
 	// Perform reconciliation
-	_, err = r.reconcile(ctx, &externalSource)
+	refChange, refChanged := r.changedRefSources.Load(req.NamespacedName)
+	reconcileCtx, cancel := context.WithTimeout(ctx, timeout)
+	reconcileCtx, stopWatchdog := tracked.cancellable(reconcileCtx)
+	_, err = r.reconcile(reconcileCtx, &externalSource)
+	if err != nil && errors.Is(reconcileCtx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("%w after %s: %w", errReconcileTimeout, timeout, err)
+	} else if err != nil && errors.Is(context.Cause(reconcileCtx), errReconcileStuck) {
+		err = fmt.Errorf("%w: %w", errReconcileStuck, err)
+	}
+	stopWatchdog()
+	cancel()
+	if err == nil && refChanged {
+		r.clearReferenceChange(req.NamespacedName, refChange)
+	}
+
+	// Record the handled reconcile request whether or not the reconcile succeeded, so a failed
+	// request doesn't force another fetch on every retry
+	if requestedAt, ok := fluxmeta.ReconcileAnnotationValue(externalSource.GetAnnotations()); ok {
+		externalSource.Status.SetLastHandledReconcileRequest(requestedAt)
+	}
 
 	// Record reconciliation metrics
 	sourceType := externalSource.Spec.Generator.Type
@@ -206,35 +392,38 @@ func (r *ExternalSourceReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			reconciliationSuccess,
 			reconciliationDuration,
 		)
+		r.recordContentMetrics(&externalSource)
+		r.MetricsRecorder.RecordDataFresh(externalSource.Namespace, externalSource.Name, reconciliationSuccess)
 	}
 
 	if err != nil {
-		log.Error(err, "Reconciliation failed")
-
-		// Reset retry count if spec has changed
-		if r.shouldResetRetryCount(&externalSource) {
-			r.clearRetryCount(&externalSource)
-		}
+		r.setDataFreshCondition(&externalSource, false, FailedReason, fmt.Sprintf("Last fetch failed: %v", err))
 
 		// Determine if this is a transient error that should be retried
 		retryDelay := r.calculateRetryDelay(&externalSource, err)
 		errorType := r.classifyError(err)
 
 		if retryDelay > 0 && errorType == TransientError {
-			retryCount := r.getRetryCount(&externalSource)
-			backoffDuration := r.getBackoffDuration(&externalSource)
-
-			// Maintain last successful artifact during transient failures (graceful degradation)
+			r.reportFailure(ctx, &externalSource, FailedReason, err)
+			log.V(1).Info("Retrying with backoff", "attempt", r.getRetryCount(&externalSource)+1,
+				"max_attempts", r.Config.Retry.MaxAttempts, "backoff", r.getBackoffDuration(&externalSource).Truncate(time.Second),
+				"retry_in", retryDelay.Truncate(time.Second))
+
+			// Maintain last successful artifact during transient failures (graceful degradation).
+			// The source stays Ready while it serves that artifact, DataFresh reports the failure.
+			// The messages leave out the attempt count, kept in status.retry, so they only change
+			// with the error.
 			if previousArtifact != nil {
 				externalSource.Status.Artifact = previousArtifact
-				log.Info("Maintaining last successful artifact during transient failure",
+				log.V(1).Info("Maintaining last successful artifact during transient failure",
 					"artifact_url", previousArtifact.URL, "revision", previousArtifact.Revision)
+				r.setReadyCondition(&externalSource, metav1.ConditionTrue, DegradedReason,
+					fmt.Sprintf("Serving last successful artifact, reconciliation failed and is retried with backoff: %v", err.Error()))
+			} else {
+				r.setReadyCondition(&externalSource, metav1.ConditionFalse, FailedReason,
+					fmt.Sprintf("Reconciliation failed, retrying with backoff. Last successful artifact maintained: %v", err.Error()))
 			}
 
-			r.setReadyCondition(&externalSource, metav1.ConditionFalse, FailedReason,
-				fmt.Sprintf("Reconciliation failed (attempt %d/%d, in backoff for %v), retrying in %v. Last successful artifact maintained: %v",
-					retryCount+1, r.Config.Retry.MaxAttempts, backoffDuration.Truncate(time.Second), retryDelay.Truncate(time.Second), err.Error()))
-
 			r.incrementRetryCount(&externalSource, err)
 
 			if statusErr := r.Status().Update(ctx, &externalSource); statusErr != nil {
@@ -259,6 +448,7 @@ func (r *ExternalSourceReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 				r.setCondition(&externalSource, StalledCondition, metav1.ConditionTrue, reason, message)
 			}
 
+			r.reportFailure(ctx, &externalSource, reason, err)
 			r.setReadyCondition(&externalSource, metav1.ConditionFalse, reason, message)
 
 			if statusErr := r.Status().Update(ctx, &externalSource); statusErr != nil {
@@ -270,23 +460,155 @@ func (r *ExternalSourceReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 				return ctrl.Result{}, nil
 			}
 
+			// Stalled sources poll less often until they recover or the spec changes
+			if r.hasCondition(&externalSource, StalledCondition, metav1.ConditionTrue) {
+				return ctrl.Result{RequeueAfter: r.stalledInterval(interval)}, nil
+			}
+
 			return ctrl.Result{RequeueAfter: interval}, nil
 		}
 	}
 
-	// Clear retry count on successful reconciliation
-	r.clearRetryCount(&externalSource)
+	// Clear retry count once the source has been stable for long enough
+	r.recordSuccess(&externalSource)
+	r.clearReportedFailure(req.NamespacedName)
+	externalSource.Status.ResolvedRevision = resolvedRevision(&externalSource)
+	r.setDataFreshCondition(&externalSource, true, SucceededReason, "Last fetch succeeded")
 
 	// Update status
 	if err := r.Status().Update(ctx, &externalSource); err != nil {
 		return ctrl.Result{}, err
 	}
 
+	// The forced refetch is done, remove the annotation so the next reconcile is conditional again
+	if err := r.clearForceRefetch(ctx, &externalSource); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to remove %s annotation: %w", ForceRefetchAnnotation, err)
+	}
+
 	log.Info("Reconciliation completed", "requeue_after", interval)
 
 	return ctrl.Result{RequeueAfter: interval}, nil
 }
 
+// referencedArtifactKeys returns the storage keys of the artifacts currently referenced by ExternalSources,
+// and the history limit of each source so garbage collection keeps the revisions retained for rollbacks
+func (r *ExternalSourceReconciler) referencedArtifactKeys(ctx context.Context) (*storage.GCReferences, error) {
+	var externalSources sourcev1alpha1.ExternalSourceList
+	if err := r.List(ctx, &externalSources); err != nil {
+		return nil, err
+	}
+
+	references := &storage.GCReferences{
+		Keys:          make(map[string]bool, len(externalSources.Items)),
+		HistoryLimits: make(map[string]int),
+	}
+	for i := range externalSources.Items {
+		externalSource := &externalSources.Items[i]
+		if externalSource.Status.Artifact == nil || externalSource.Status.Artifact.Revision == "" {
+			continue
+		}
+		sourceKey := fmt.Sprintf("%s/%s", externalSource.Namespace, externalSource.Name)
+		key := artifact.StorageKey(sourceKey, externalSource.Status.Artifact.Revision,
+			externalSource.Status.Artifact.Metadata["compression"],
+			r.Config.Storage.KeyPrefix, externalSource.Spec.StoragePrefix)
+		references.Keys[key] = true
+		if limit := r.historyLimit(externalSource); limit > 1 {
+			references.HistoryLimits[path.Dir(key)] = limit
+		}
+	}
+
+	return references, nil
+}
+
+// nextRequeue returns the duration until the next reconciliation, using the next
+// tick of the cron schedule when set and the fixed interval otherwise
+func nextRequeue(spec sourcev1alpha1.ExternalSourceSpec, now time.Time) (time.Duration, error) {
+	if spec.Schedule == "" {
+		interval, err := time.ParseDuration(spec.Interval)
+		if err != nil {
+			return 0, fmt.Errorf("invalid interval: %w", err)
+		}
+
+		return interval, nil
+	}
+
+	location := time.UTC
+	if spec.TimeZone != "" {
+		var err error
+		location, err = time.LoadLocation(spec.TimeZone)
+		if err != nil {
+			return 0, fmt.Errorf("invalid time zone %q: %w", spec.TimeZone, err)
+		}
+	}
+
+	schedule, err := cron.ParseStandard(spec.Schedule)
+	if err != nil {
+		return 0, fmt.Errorf("invalid schedule %q: %w", spec.Schedule, err)
+	}
+
+	return schedule.Next(now.In(location)).Sub(now), nil
+}
+
+// clampInterval raises an interval to the configured minimum and lowers it to the configured maximum
+func (r *ExternalSourceReconciler) clampInterval(interval time.Duration) time.Duration {
+	if interval < r.Config.Controller.MinInterval {
+		return r.Config.Controller.MinInterval
+	}
+	if r.Config.Controller.MaxInterval > 0 && interval > r.Config.Controller.MaxInterval {
+		return r.Config.Controller.MaxInterval
+	}
+	return interval
+}
+
+// errReconcileTimeout wraps the error of a reconciliation that exceeded its timeout
+var errReconcileTimeout = errors.New("reconciliation timed out")
+
+// reconcileTimeout returns the timeout of a whole reconciliation, the source timeout when set
+// or else the interval, bounded by the controller maximum reconcile timeout
+func (r *ExternalSourceReconciler) reconcileTimeout(spec sourcev1alpha1.ExternalSourceSpec, interval time.Duration) (time.Duration, error) {
+	maxTimeout := r.Config.Controller.MaxReconcileTimeout
+	if spec.Timeout == "" {
+		if spec.Schedule == "" && interval < maxTimeout {
+			return interval, nil
+		}
+		return maxTimeout, nil
+	}
+
+	timeout, err := time.ParseDuration(spec.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout: %w", err)
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("invalid timeout %s: must be positive", spec.Timeout)
+	}
+	if timeout > maxTimeout {
+		return 0, fmt.Errorf("invalid timeout %s: exceeds the controller maximum of %s", spec.Timeout, maxTimeout)
+	}
+	return timeout, nil
+}
+
+// withCorrelationID tags the context with a correlation ID that is sent to the hook executor
+// sidecar and added to the log context, so one reconcile can be traced across the controller
+// and sidecar logs. The reconcile ID assigned by controller-runtime is reused when there is one.
+func withCorrelationID(ctx context.Context, key types.NamespacedName) context.Context {
+	log := logf.FromContext(ctx)
+	correlationID := string(controller.ReconcileIDFromContext(ctx))
+	if correlationID == "" {
+		// Outside of the controller the logger doesn't identify the ExternalSource yet
+		correlationID = string(uuid.NewUUID())
+		log = log.WithValues("namespace", key.Namespace, "name", key.Name)
+	}
+	log = log.WithValues("correlationID", correlationID)
+	return hooks.WithCorrelationID(logf.IntoContext(ctx, log), correlationID)
+}
+
+// recordEvent emits an event for the ExternalSource when an event recorder is set
+func (r *ExternalSourceReconciler) recordEvent(externalSource *sourcev1alpha1.ExternalSource, eventType, reason, message string) {
+	if r.EventRecorder != nil {
+		r.EventRecorder.Event(externalSource, eventType, reason, message)
+	}
+}
+
 // reconcile performs the main reconciliation logic
 //
 //nolint:unparam // ctrl.Result is always nil but required by interface contract for future extensibility
@@ -305,16 +627,66 @@ func (r *ExternalSourceReconciler) reconcile(ctx context.Context, externalSource
 		return ctrl.Result{}, fmt.Errorf("failed to create source generator: %w", err)
 	}
 
+	// A force refetch annotation, an unhandled reconcile request or a change to a referenced
+	// secret or ConfigMap forces a full fetch
+	forceFetch := isForceRefetchRequested(externalSource) || r.isReconcileRequested(externalSource)
+	if isForceRefetchRequested(externalSource) {
+		log.Info("Force refetch requested, bypassing conditional fetch and unchanged content detection")
+	} else if forceFetch {
+		log.Info("Reconcile requested, bypassing conditional fetch")
+	} else if r.isReferenceChanged(externalSource) {
+		forceFetch = true
+		log.Info("Referenced secret or ConfigMap changed, bypassing conditional fetch")
+	}
+
+	// With the contentHash strategy versions reported by the source are ignored and the
+	// fetched data is compared against the current artifact instead
+	contentHash := externalSource.Spec.ConditionalStrategy == conditionalStrategyContentHash
+
+	// Pass the last handled version so generators can issue conditional requests
+	if externalSource.Status.Artifact != nil && !forceFetch && !contentHash {
+		generatorConfig.LastModified = externalSource.Status.LastHandledETag
+	}
+
+	// Generators that handle conditional requests in Generate don't need a separate check
+	conditionalRequest := false
+	if conditional, ok := sourceGenerator.(generator.ConditionalRequestGenerator); ok {
+		conditionalRequest = conditional.SupportsConditionalRequest() && generatorConfig.LastModified != ""
+	}
+
+	// A source pinned to a revision already stored has nothing new to fetch
+	if pinned := pinnedRevision(externalSource); pinned != "" && !forceFetch && isPinnedRevisionStored(externalSource) {
+		log.Info("Pinned revision already stored, skipping fetch", "revision", pinned)
+		r.setProgressCondition(externalSource, FetchingCondition, false, SucceededReason, "Pinned revision already stored")
+		r.setReadyCondition(externalSource, metav1.ConditionTrue, SucceededReason, "ExternalSource is ready")
+		return ctrl.Result{}, nil
+	}
+
+	// Reuse the current artifact while the source declared the data fresh
+	if freshUntil := externalSource.Status.FreshUntil; freshUntil != nil && externalSource.Status.Artifact != nil &&
+		!forceFetch && time.Now().Before(freshUntil.Time) {
+		log.Info("Data is still fresh, skipping fetch", "freshUntil", freshUntil.Time)
+		r.setProgressCondition(externalSource, FetchingCondition, false, SucceededReason, "Data is still fresh")
+		r.setReadyCondition(externalSource, metav1.ConditionTrue, SucceededReason, "ExternalSource is ready")
+		return ctrl.Result{}, nil
+	}
+
 	// Check if we can use conditional fetching
 	shouldFetch := true
-	if sourceGenerator.SupportsConditionalFetch() && externalSource.Status.LastHandledETag != "" {
+	if sourceGenerator.SupportsConditionalFetch() && externalSource.Status.LastHandledETag != "" && !conditionalRequest && !forceFetch && !contentHash {
 		r.setProgressCondition(externalSource, FetchingCondition, true, ProgressingReason, "Checking for updates")
 
+		if err := r.waitForFetch(ctx, externalSource.Spec.Generator.Type); err != nil {
+			return ctrl.Result{}, err
+		}
+
 		currentETag, err := sourceGenerator.GetLastModified(ctx, *generatorConfig)
 		if err != nil {
 			log.Info("Failed to get last modified, proceeding with full fetch", "error", err)
 		} else if currentETag != "" && currentETag == externalSource.Status.LastHandledETag {
 			log.Info("No changes detected, skipping fetch", "etag", currentETag)
+			confirmedTime := metav1.Now()
+			externalSource.Status.LastConfirmedTime = &confirmedTime
 			r.setProgressCondition(externalSource, FetchingCondition, false, SucceededReason, "No changes detected")
 			r.setReadyCondition(externalSource, metav1.ConditionTrue, SucceededReason, "ExternalSource is ready")
 			return ctrl.Result{}, nil
@@ -325,6 +697,25 @@ func (r *ExternalSourceReconciler) reconcile(ctx context.Context, externalSource
 		// Fetch data from source
 		r.setProgressCondition(externalSource, FetchingCondition, true, ProgressingReason, "Fetching data from external source")
 
+		if err := r.waitForFetch(ctx, externalSource.Spec.Generator.Type); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		// The previous data is no longer known to be fresh once it is refetched
+		externalSource.Status.FreshUntil = nil
+
+		// Appending needs the content of the current artifact, without it the range is
+		// fetched again from its start and replaces the content
+		appendRange := rangeAppending(externalSource)
+		var rangeBase []byte
+		if appendRange {
+			if rangeBase, err = r.loadRangeBase(ctx, externalSource); err != nil {
+				log.Info("Failed to load current artifact content, fetching range from its start", "error", err)
+				appendRange = false
+				generatorConfig.Config["rangeOffset"] = rangeSpecOffset(externalSource)
+			}
+		}
+
 		fetchStartTime := time.Now()
 		sourceData, err := sourceGenerator.Generate(ctx, *generatorConfig)
 		fetchDuration := time.Since(fetchStartTime)
@@ -339,10 +730,33 @@ func (r *ExternalSourceReconciler) reconcile(ctx context.Context, externalSource
 			return ctrl.Result{}, fmt.Errorf("failed to generate source data: %w", err)
 		}
 
+		if sourceData.NotModified {
+			// Keep the last fetch status fields, they describe the data currently in the artifact
+			log.Info("No changes detected, skipping artifact update", "etag", generatorConfig.LastModified)
+			confirmedTime := metav1.NewTime(fetchStartTime)
+			externalSource.Status.LastConfirmedTime = &confirmedTime
+			setFreshUntil(externalSource, sourceData.FreshUntil)
+			r.setProgressCondition(externalSource, FetchingCondition, false, SucceededReason, "No changes detected")
+			r.setReadyCondition(externalSource, metav1.ConditionTrue, SucceededReason, "ExternalSource is ready")
+			return ctrl.Result{}, nil
+		}
+
 		r.setProgressCondition(externalSource, FetchingCondition, false, SucceededReason, "Successfully fetched data")
 
+		// Record when the data currently being processed was fetched
+		fetchTime := metav1.NewTime(fetchStartTime)
+		externalSource.Status.LastFetchTime = &fetchTime
+		externalSource.Status.LastConfirmedTime = &fetchTime
+		externalSource.Status.LastFetchDuration = &metav1.Duration{Duration: fetchDuration}
+		externalSource.Status.ContentSize = int64(len(sourceData.Data))
+
+		// Decrypt the data before anything else sees it
+		processedData, err := r.decryptContent(ctx, externalSource, sourceData.Data)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
 		// Execute post-request hooks if specified
-		processedData := sourceData.Data
 		if externalSource.Spec.Hooks != nil && len(externalSource.Spec.Hooks.PostRequest) > 0 {
 			r.setProgressCondition(externalSource, ExecutingHooksCondition, true, ProgressingReason, "Executing post-request hooks")
 
@@ -356,22 +770,45 @@ func (r *ExternalSourceReconciler) reconcile(ctx context.Context, externalSource
 			r.setProgressCondition(externalSource, ExecutingHooksCondition, false, SucceededReason, "Successfully executed post-request hooks")
 		}
 
+		// Append a range continuing the current artifact to its content
+		appended := appendRange && sourceData.Range != nil && sourceData.Range.Offset == rangeStart(externalSource)
+		if appended {
+			processedData = append(rangeBase, processedData...)
+		}
+
+		if contentHash && !forceFetch && contentUnchanged(externalSource, processedData) {
+			log.Info("Content unchanged, skipping artifact update", "revision", externalSource.Status.Artifact.Revision)
+			setFreshUntil(externalSource, sourceData.FreshUntil)
+			setCursor(externalSource, sourceData.Cursor)
+			r.clearProgressConditions(externalSource)
+			r.setReadyCondition(externalSource, metav1.ConditionTrue, SucceededReason, "ExternalSource is ready")
+			return ctrl.Result{}, nil
+		}
+
+		// Validate content before packaging so a bad response never replaces the last good artifact
+		if err := r.validateContent(ctx, externalSource, processedData); err != nil {
+			return ctrl.Result{}, err
+		}
+
 		// Package and store artifact
 		r.setProgressCondition(externalSource, StoringCondition, true, ProgressingReason, "Packaging and storing artifact")
 
-		destinationPath := externalSource.Spec.DestinationPath
-		if destinationPath == "" {
-			destinationPath = "data"
+		destinationPath, err := r.destinationPath(externalSource)
+		if err != nil {
+			r.setProgressCondition(externalSource, StoringCondition, false, FailedReason, err.Error())
+			return ctrl.Result{}, err
 		}
 
 		// Package artifact
 		packageStartTime := time.Now()
-		packagedArtifact, err := r.ArtifactManager.Package(ctx, processedData, destinationPath)
+		packageCtx := artifact.WithDigestAlgorithm(ctx, externalSource.Spec.DigestAlgorithm)
+		packageCtx = artifact.WithCanonicalize(packageCtx, externalSource.Spec.Canonicalize)
+		packagedArtifact, err := r.ArtifactManager.Package(packageCtx, processedData, destinationPath)
 		packageDuration := time.Since(packageStartTime)
 
 		// Record packaging metrics
 		if r.MetricsRecorder != nil {
-			r.MetricsRecorder.RecordArtifactOperation("package", err == nil, packageDuration)
+			r.MetricsRecorder.RecordArtifactOperation("package", r.artifactBackendType(), err == nil, packageDuration)
 		}
 
 		if err != nil {
@@ -382,12 +819,13 @@ func (r *ExternalSourceReconciler) reconcile(ctx context.Context, externalSource
 		// Store artifact and get URL
 		sourceKey := fmt.Sprintf("%s/%s", externalSource.Namespace, externalSource.Name)
 		storeStartTime := time.Now()
-		artifactURL, err := r.ArtifactManager.Store(ctx, packagedArtifact, sourceKey)
+		storeCtx := artifact.WithKeyPrefix(ctx, externalSource.Spec.StoragePrefix)
+		artifactURL, err := r.ArtifactManager.Store(storeCtx, packagedArtifact, sourceKey)
 		storeDuration := time.Since(storeStartTime)
 
 		// Record storage metrics
 		if r.MetricsRecorder != nil {
-			r.MetricsRecorder.RecordArtifactOperation("store", err == nil, storeDuration)
+			r.MetricsRecorder.RecordArtifactOperation("store", r.artifactBackendType(), err == nil, storeDuration)
 		}
 
 		if err != nil {
@@ -397,7 +835,19 @@ func (r *ExternalSourceReconciler) reconcile(ctx context.Context, externalSource
 
 		r.setProgressCondition(externalSource, StoringCondition, false, SucceededReason, "Successfully stored artifact")
 
+		// Publish the new revision only once every step before it has succeeded. Until the
+		// ExternalArtifact is updated the status and stored artifacts keep describing the
+		// previous revision, so a failure here leaves consumers on the last good artifact and
+		// the next reconcile fetches again instead of treating the source as unchanged.
+		if err := r.reconcileExternalArtifact(ctx, externalSource, artifactURL, packagedArtifact.Revision, packagedArtifact.Metadata); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to reconcile ExternalArtifact: %w", err)
+		}
+
 		// Update status with new artifact information
+		var previousRevision string
+		if externalSource.Status.Artifact != nil {
+			previousRevision = externalSource.Status.Artifact.Revision
+		}
 		externalSource.Status.Artifact = &sourcev1alpha1.ArtifactMetadata{
 			URL:            artifactURL,
 			Revision:       packagedArtifact.Revision,
@@ -409,16 +859,21 @@ func (r *ExternalSourceReconciler) reconcile(ctx context.Context, externalSource
 		if sourceData.LastModified != "" {
 			externalSource.Status.LastHandledETag = sourceData.LastModified
 		}
-
-		// Clean up old artifacts
-		if err := r.ArtifactManager.Cleanup(ctx, sourceKey, packagedArtifact.Revision); err != nil {
+		setFreshUntil(externalSource, sourceData.FreshUntil)
+		externalSource.Status.Range = contentRange(externalSource, sourceData, appended)
+		setCursor(externalSource, sourceData.Cursor)
+
+		// Clean up old artifacts, keeping the configured number of revisions. This runs after
+		// the ExternalArtifact moved to the new revision so the previous one stays available
+		// while it is still referenced.
+		if err := r.ArtifactManager.Cleanup(storeCtx, sourceKey, packagedArtifact.Revision, r.historyLimit(externalSource)); err != nil {
 			log.Error(err, "Failed to cleanup old artifacts", "source", sourceKey, "keepRevision", packagedArtifact.Revision)
 			// Don't fail reconciliation for cleanup errors
 		}
 
-		// Create or update ExternalArtifact child resource
-		if err := r.reconcileExternalArtifact(ctx, externalSource, artifactURL, packagedArtifact.Revision, packagedArtifact.Metadata); err != nil {
-			return ctrl.Result{}, fmt.Errorf("failed to reconcile ExternalArtifact: %w", err)
+		// Notify after the ExternalArtifact points at the new revision
+		if packagedArtifact.Revision != previousRevision {
+			r.notifyRevisionChange(ctx, externalSource, previousRevision, packagedArtifact.Revision, artifactURL)
 		}
 
 		log.Info("Successfully processed external source", "url", artifactURL, "revision", packagedArtifact.Revision)
@@ -431,6 +886,104 @@ func (r *ExternalSourceReconciler) reconcile(ctx context.Context, externalSource
 	return ctrl.Result{}, nil
 }
 
+// notifyRevisionChange sends the configured webhook notification for a new artifact revision.
+// Failures are logged and recorded but never fail reconciliation.
+func (r *ExternalSourceReconciler) notifyRevisionChange(ctx context.Context, externalSource *sourcev1alpha1.ExternalSource, oldRevision, revision, artifactURL string) {
+	notify := externalSource.Spec.Notify
+	if notify == nil || r.Notifier == nil {
+		return
+	}
+	log := logf.FromContext(ctx)
+
+	webhook := notification.Webhook{URL: notify.URL}
+	if notify.SecretRef != nil && notify.SecretRef.Name != "" {
+		secret := &corev1.Secret{}
+		secretKey := client.ObjectKey{Namespace: externalSource.Namespace, Name: notify.SecretRef.Name}
+		if err := r.Get(ctx, secretKey, secret); err != nil {
+			log.Error(err, "Failed to load notification secret", "secret", notify.SecretRef.Name)
+			r.recordNotification(externalSource, false)
+			return
+		}
+		if address := string(secret.Data["address"]); address != "" {
+			webhook.URL = address
+		}
+		webhook.Token = string(secret.Data["token"])
+	}
+	if webhook.URL == "" {
+		secretName := ""
+		if notify.SecretRef != nil {
+			secretName = notify.SecretRef.Name
+		}
+		log.Error(fmt.Errorf("no webhook URL configured"), "Failed to send artifact change notification",
+			"secret", secretName)
+		r.recordNotification(externalSource, false)
+		return
+	}
+
+	err := r.Notifier.Notify(ctx, webhook, notification.Event{
+		Name:        externalSource.Name,
+		Namespace:   externalSource.Namespace,
+		OldRevision: oldRevision,
+		Revision:    revision,
+		URL:         artifactURL,
+	})
+	if err != nil {
+		log.Error(err, "Failed to send artifact change notification", "revision", revision)
+	} else {
+		log.Info("Sent artifact change notification", "oldRevision", oldRevision, "revision", revision)
+	}
+	r.recordNotification(externalSource, err == nil)
+}
+
+// recordNotification records the outcome of a notification when metrics are enabled
+func (r *ExternalSourceReconciler) recordNotification(externalSource *sourcev1alpha1.ExternalSource, success bool) {
+	if r.MetricsRecorder != nil {
+		r.MetricsRecorder.RecordNotification(externalSource.Namespace, externalSource.Name, success)
+	}
+}
+
+// setFreshUntil records when the data of the current artifact becomes stale, clearing it
+// when the source didn't say
+func setFreshUntil(externalSource *sourcev1alpha1.ExternalSource, freshUntil time.Time) {
+	if freshUntil.IsZero() {
+		externalSource.Status.FreshUntil = nil
+		return
+	}
+	t := metav1.NewTime(freshUntil)
+	externalSource.Status.FreshUntil = &t
+}
+
+// setCursor records the cursor sent with the next request, keeping the current one when the
+// response held none
+func setCursor(externalSource *sourcev1alpha1.ExternalSource, cursor string) {
+	if cursor != "" {
+		externalSource.Status.Cursor = cursor
+	}
+}
+
+// isReconcileRequested returns true if the reconcile request annotation holds a value that hasn't been handled yet
+func (r *ExternalSourceReconciler) isReconcileRequested(externalSource *sourcev1alpha1.ExternalSource) bool {
+	requestedAt, ok := fluxmeta.ReconcileAnnotationValue(externalSource.GetAnnotations())
+	return ok && requestedAt != "" && requestedAt != externalSource.Status.GetLastHandledReconcileRequest()
+}
+
+// isForceRefetchRequested returns true if the ExternalSource carries the force refetch annotation
+func isForceRefetchRequested(externalSource *sourcev1alpha1.ExternalSource) bool {
+	_, ok := externalSource.GetAnnotations()[ForceRefetchAnnotation]
+	return ok
+}
+
+// clearForceRefetch removes the force refetch annotation after the fetch it requested succeeded
+func (r *ExternalSourceReconciler) clearForceRefetch(ctx context.Context, externalSource *sourcev1alpha1.ExternalSource) error {
+	if !isForceRefetchRequested(externalSource) {
+		return nil
+	}
+
+	patch := client.MergeFrom(externalSource.DeepCopy())
+	delete(externalSource.Annotations, ForceRefetchAnnotation)
+	return r.Patch(ctx, externalSource, patch)
+}
+
 // createGeneratorConfig creates a generator configuration from the ExternalSource spec
 func (r *ExternalSourceReconciler) createGeneratorConfig(externalSource *sourcev1alpha1.ExternalSource) (*generator.GeneratorConfig, error) {
 	genConfig := &generator.GeneratorConfig{
@@ -449,7 +1002,20 @@ func (r *ExternalSourceReconciler) createGeneratorConfig(externalSource *sourcev
 		}
 
 		httpSpec := externalSource.Spec.Generator.HTTP
-		genConfig.Config["url"] = httpSpec.URL
+		requestURL, err := renderURLTemplate(externalSource, httpSpec.URL)
+		if err != nil {
+			return nil, err
+		}
+		genConfig.Config["url"] = requestURL
+		genConfig.RequestSigner = r.requestSigner(externalSource)
+
+		if httpSpec.VersionURL != "" {
+			versionURL, err := renderURLTemplate(externalSource, httpSpec.VersionURL)
+			if err != nil {
+				return nil, err
+			}
+			genConfig.Config["versionURL"] = versionURL
+		}
 
 		if httpSpec.Method != "" {
 			genConfig.Config["method"] = httpSpec.Method
@@ -459,8 +1025,87 @@ func (r *ExternalSourceReconciler) createGeneratorConfig(externalSource *sourcev
 			genConfig.Config["insecureSkipVerify"] = true
 		}
 
+		if httpSpec.Timeout != "" {
+			genConfig.Config["timeout"] = httpSpec.Timeout
+		}
+
+		if httpSpec.MaxRedirects != nil {
+			genConfig.Config["maxRedirects"] = *httpSpec.MaxRedirects
+		}
+
+		if httpSpec.KeepAuthorizationOnRedirect {
+			genConfig.Config["keepAuthorizationOnRedirect"] = true
+		}
+
+		if httpSpec.Retries != nil {
+			genConfig.Config["retries"] = *httpSpec.Retries
+		}
+
+		if httpSpec.RetryBackoff != "" {
+			genConfig.Config["retryBackoff"] = httpSpec.RetryBackoff
+		}
+
+		if httpSpec.MaxResponseSize != nil {
+			genConfig.Config["maxResponseSize"] = httpSpec.MaxResponseSize.Value()
+		}
+
+		if httpSpec.DigestHeader != "" {
+			genConfig.Config["digestHeader"] = httpSpec.DigestHeader
+		}
+
+		if rangeSpec := httpSpec.Range; rangeSpec != nil {
+			if rangeSpec.SuffixLength != nil {
+				genConfig.Config["rangeSuffix"] = *rangeSpec.SuffixLength
+			}
+			if rangeSpec.Length != nil {
+				genConfig.Config["rangeLength"] = *rangeSpec.Length
+			}
+			if offset := rangeStart(externalSource); offset > 0 {
+				genConfig.Config["rangeOffset"] = offset
+			}
+		}
+
+		if len(httpSpec.Headers) > 0 {
+			genConfig.Config["headers"] = httpSpec.Headers
+		}
+
+		if httpSpec.Accept != "" {
+			genConfig.Config["accept"] = httpSpec.Accept
+		}
+
+		if cursor := httpSpec.Cursor; cursor != nil {
+			genConfig.Config["cursorJSONPath"] = cursor.JSONPath
+			genConfig.Config["cursorHeader"] = cursor.Header
+			genConfig.Config["cursorQueryParam"] = cursor.QueryParam
+			if value := cmp.Or(externalSource.Status.Cursor, cursor.Initial); value != "" {
+				genConfig.Config["cursor"] = value
+			}
+		}
+
 		if httpSpec.HeadersSecretRef != nil && httpSpec.HeadersSecretRef.Name != "" {
 			genConfig.Config["headersSecretName"] = httpSpec.HeadersSecretRef.Name
+			if len(httpSpec.HeadersSecretRef.Keys) > 0 {
+				genConfig.Config["headersSecretKeys"] = httpSpec.HeadersSecretRef.Keys
+			}
+			if httpSpec.HeadersSecretRef.KeyPrefix != "" {
+				genConfig.Config["headersSecretKeyPrefix"] = httpSpec.HeadersSecretRef.KeyPrefix
+			}
+		}
+
+		if httpSpec.BasicAuthSecretRef != nil && httpSpec.BasicAuthSecretRef.Name != "" {
+			genConfig.Config["basicAuthSecretName"] = httpSpec.BasicAuthSecretRef.Name
+		}
+
+		if httpSpec.BearerTokenSecretRef != nil && httpSpec.BearerTokenSecretRef.Name != "" {
+			genConfig.Config["bearerTokenSecretName"] = httpSpec.BearerTokenSecretRef.Name
+		}
+
+		if httpSpec.OAuth2 != nil {
+			genConfig.Config["oauth2TokenURL"] = httpSpec.OAuth2.TokenURL
+			genConfig.Config["oauth2SecretName"] = httpSpec.OAuth2.ClientSecretRef.Name
+			if len(httpSpec.OAuth2.Scopes) > 0 {
+				genConfig.Config["oauth2Scopes"] = httpSpec.OAuth2.Scopes
+			}
 		}
 
 		if httpSpec.CABundleSecretRef != nil && httpSpec.CABundleSecretRef.Name != "" {
@@ -470,11 +1115,185 @@ func (r *ExternalSourceReconciler) createGeneratorConfig(externalSource *sourcev
 			}
 		}
 
+		if httpSpec.CABundleConfigMapRef != nil && httpSpec.CABundleConfigMapRef.Name != "" {
+			genConfig.Config["caBundleConfigMapName"] = httpSpec.CABundleConfigMapRef.Name
+			genConfig.Config["caBundleConfigMapKey"] = httpSpec.CABundleConfigMapRef.Key
+		}
+
+		if httpSpec.ClientCertSecretRef != nil && httpSpec.ClientCertSecretRef.Name != "" {
+			genConfig.Config["clientCertSecretName"] = httpSpec.ClientCertSecretRef.Name
+		}
+
+		if httpSpec.Body != "" {
+			genConfig.Config["body"] = httpSpec.Body
+			if httpSpec.BodyEncoding != "" {
+				genConfig.Config["bodyEncoding"] = httpSpec.BodyEncoding
+			}
+		}
+
+		if len(httpSpec.QueryParams) > 0 || httpSpec.Revision != "" {
+			queryParams := make(map[string]string, len(httpSpec.QueryParams)+1)
+			maps.Copy(queryParams, httpSpec.QueryParams)
+			// A pinned revision is requested through its query parameter
+			if httpSpec.Revision != "" {
+				queryParams[revisionParam(httpSpec)] = httpSpec.Revision
+			}
+			genConfig.Config["queryParams"] = queryParams
+		}
+
+	case "git":
+		if externalSource.Spec.Generator.Git == nil {
+			return nil, fmt.Errorf("git configuration is required for git generator")
+		}
+
+		gitSpec := externalSource.Spec.Generator.Git
+		genConfig.Config["url"] = gitSpec.URL
+		genConfig.Config["path"] = gitSpec.Path
+
+		if gitSpec.Ref != nil {
+			if gitSpec.Ref.Branch != "" {
+				genConfig.Config["branch"] = gitSpec.Ref.Branch
+			}
+			if gitSpec.Ref.Tag != "" {
+				genConfig.Config["tag"] = gitSpec.Ref.Tag
+			}
+		}
+
+		if gitSpec.SecretRef != nil && gitSpec.SecretRef.Name != "" {
+			genConfig.Config["secretName"] = gitSpec.SecretRef.Name
+		}
+
+	case "file":
+		if externalSource.Spec.Generator.File == nil {
+			return nil, fmt.Errorf("file configuration is required for file generator")
+		}
+
+		genConfig.Config["path"] = externalSource.Spec.Generator.File.Path
+
+	case "s3":
+		if externalSource.Spec.Generator.S3 == nil {
+			return nil, fmt.Errorf("s3 configuration is required for s3 generator")
+		}
+
+		s3Spec := externalSource.Spec.Generator.S3
+		genConfig.Config["endpoint"] = s3Spec.Endpoint
+		genConfig.Config["bucket"] = s3Spec.Bucket
+		genConfig.Config["key"] = s3Spec.Key
+
+		if s3Spec.Region != "" {
+			genConfig.Config["region"] = s3Spec.Region
+		}
+
+		if s3Spec.SecretRef != nil && s3Spec.SecretRef.Name != "" {
+			genConfig.Config["secretName"] = s3Spec.SecretRef.Name
+		}
+
+	case "grpc":
+		if externalSource.Spec.Generator.GRPC == nil {
+			return nil, fmt.Errorf("grpc configuration is required for grpc generator")
+		}
+
+		grpcSpec := externalSource.Spec.Generator.GRPC
+		genConfig.Config["target"] = grpcSpec.Target
+		genConfig.Config["method"] = grpcSpec.Method
+
+		if grpcSpec.Request != "" {
+			genConfig.Config["request"] = grpcSpec.Request
+		}
+
+		if grpcSpec.Plaintext {
+			genConfig.Config["plaintext"] = true
+		}
+
+		if grpcSpec.Timeout != "" {
+			genConfig.Config["timeout"] = grpcSpec.Timeout
+		}
+
+		if grpcSpec.CABundleSecretRef != nil && grpcSpec.CABundleSecretRef.Name != "" {
+			genConfig.Config["caBundleSecretName"] = grpcSpec.CABundleSecretRef.Name
+			if grpcSpec.CABundleSecretRef.Key != "" {
+				genConfig.Config["caBundleSecretKey"] = grpcSpec.CABundleSecretRef.Key
+			}
+		}
+
+		if grpcSpec.ClientCertSecretRef != nil && grpcSpec.ClientCertSecretRef.Name != "" {
+			genConfig.Config["clientCertSecretName"] = grpcSpec.ClientCertSecretRef.Name
+		}
+
+		if grpcSpec.MetadataSecretRef != nil && grpcSpec.MetadataSecretRef.Name != "" {
+			genConfig.Config["metadataSecretName"] = grpcSpec.MetadataSecretRef.Name
+		}
+
+	case "configMap":
+		if externalSource.Spec.Generator.ConfigMap == nil {
+			return nil, fmt.Errorf("configMap configuration is required for configMap generator")
+		}
+
+		genConfig.Config["name"] = externalSource.Spec.Generator.ConfigMap.Name
+		genConfig.Config["key"] = externalSource.Spec.Generator.ConfigMap.Key
+
+	case "secret":
+		if externalSource.Spec.Generator.Secret == nil {
+			return nil, fmt.Errorf("secret configuration is required for secret generator")
+		}
+
+		genConfig.Config["name"] = externalSource.Spec.Generator.Secret.Name
+		genConfig.Config["key"] = externalSource.Spec.Generator.Secret.Key
+
 	default:
 		return nil, fmt.Errorf("unsupported generator type: %s", externalSource.Spec.Generator.Type)
 	}
 
-	return genConfig, nil
+	return genConfig, nil
+}
+
+// historyLimit returns the number of artifact revisions to keep for the source, the spec
+// overrides the controller configuration
+func (r *ExternalSourceReconciler) historyLimit(externalSource *sourcev1alpha1.ExternalSource) int {
+	if externalSource.Spec.HistoryLimit != nil {
+		return int(*externalSource.Spec.HistoryLimit)
+	}
+	if r.Config == nil {
+		return 1
+	}
+	return r.Config.Storage.HistoryLimit
+}
+
+// contentUnchanged reports whether the data has the revision of the current artifact, computed
+// with the digest algorithm the artifact was packaged with and canonicalized when the source asks for it
+func contentUnchanged(externalSource *sourcev1alpha1.ExternalSource, data []byte) bool {
+	current := externalSource.Status.Artifact
+	if current == nil || current.Revision == "" {
+		return false
+	}
+	algorithm := current.Metadata["digestAlgorithm"]
+	if algorithm == "" {
+		algorithm = artifact.DefaultDigestAlgorithm
+	}
+	// A changed algorithm produces a new revision for the same data
+	if externalSource.Spec.DigestAlgorithm != "" && externalSource.Spec.DigestAlgorithm != algorithm {
+		return false
+	}
+	if externalSource.Spec.Canonicalize {
+		data = artifact.Canonicalize(data)
+	}
+	revision, err := artifact.Digest(algorithm, data)
+	return err == nil && revision == current.Revision
+}
+
+// artifactDigest returns the digest consumers verify the artifact by. That is the digest the
+// storage backend addresses the archive by when it records one, such as the OCI layer digest,
+// and otherwise the revision prefixed with the algorithm recorded in the artifact metadata.
+// Artifacts packaged before the algorithm was recorded use sha256.
+func artifactDigest(revision string, metadata map[string]string) string {
+	if digest := metadata[artifact.StoredDigestMetadataKey]; digest != "" {
+		return digest
+	}
+	algorithm := metadata["digestAlgorithm"]
+	if algorithm == "" {
+		algorithm = artifact.DefaultDigestAlgorithm
+	}
+	return algorithm + ":" + revision
 }
 
 // reconcileExternalArtifact creates or updates the ExternalArtifact child resource
@@ -507,12 +1326,13 @@ func (r *ExternalSourceReconciler) reconcileExternalArtifact(ctx context.Context
 		URL:            artifactURL,
 		Path:           artifactURL, // Path can be the same as URL for external artifacts
 		Revision:       revision,
-		Digest:         "sha256:" + revision, // Format as sha256 digest
+		Digest:         artifactDigest(revision, metadata),
 		LastUpdateTime: metav1.Now(),
 		Metadata:       artifactMetadata,
 	}
 
 	// Create new ExternalArtifact if it doesn't exist (when err is NotFound)
+	refetch := false
 	if err != nil && client.IgnoreNotFound(err) == nil {
 		newArtifact := &sourcev1.ExternalArtifact{
 			ObjectMeta: metav1.ObjectMeta{
@@ -535,30 +1355,46 @@ func (r *ExternalSourceReconciler) reconcileExternalArtifact(ctx context.Context
 		}
 
 		log.Info("Creating ExternalArtifact", "name", artifactName, "url", artifactURL, "revision", revision)
-		if err := r.Create(ctx, newArtifact); err != nil {
+		err := r.Create(ctx, newArtifact)
+		if err == nil {
+			// Update status after creation (status subresources cannot be set during creation)
+			// The object needs to be fully persisted before we can update its status
+			return r.updateExternalArtifactStatusWithRetry(ctx, artifactKey, artifactName, artifact, artifactURL)
+		}
+		if !apierrors.IsAlreadyExists(err) {
 			return fmt.Errorf("failed to create ExternalArtifact: %w", err)
 		}
 
-		// Update status after creation (status subresources cannot be set during creation)
-		// The object needs to be fully persisted before we can update its status
-		return r.updateExternalArtifactStatusWithRetry(ctx, artifactKey, artifactName, artifact, artifactURL)
+		// A concurrent reconcile created it first, update that one instead
+		log.Info("ExternalArtifact already exists, updating it", "name", artifactName)
+		refetch = true
 	}
 
-	// Update existing ExternalArtifact if needed
-	needsUpdate := existingArtifact.Status.Artifact == nil ||
-		existingArtifact.Status.Artifact.URL != artifactURL ||
-		existingArtifact.Status.Artifact.Revision != revision ||
-		(existingArtifact.Status.Artifact != nil && !mapsEqual(existingArtifact.Status.Artifact.Metadata, artifactMetadata))
+	// Update existing ExternalArtifact if needed, starting over from a fresh copy when a
+	// concurrent update conflicts
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if refetch {
+			if err := r.Get(ctx, artifactKey, existingArtifact); err != nil {
+				return fmt.Errorf("failed to get ExternalArtifact: %w", err)
+			}
+		}
+		refetch = true
+
+		needsUpdate := existingArtifact.Status.Artifact == nil ||
+			existingArtifact.Status.Artifact.URL != artifactURL ||
+			existingArtifact.Status.Artifact.Revision != revision ||
+			!mapsEqual(existingArtifact.Status.Artifact.Metadata, artifactMetadata)
+		if !needsUpdate {
+			return nil
+		}
 
-	if needsUpdate {
 		log.Info("Updating ExternalArtifact", "name", artifactName, "url", artifactURL, "revision", revision)
 		existingArtifact.Status.Artifact = artifact
 		if err := r.Status().Update(ctx, existingArtifact); err != nil {
 			return fmt.Errorf("failed to update ExternalArtifact status: %w", err)
 		}
-	}
-
-	return nil
+		return nil
+	})
 }
 
 // updateExternalArtifactStatusWithRetry updates the ExternalArtifact status with retry logic
@@ -663,12 +1499,20 @@ func (r *ExternalSourceReconciler) reconcileDelete(ctx context.Context, external
 	// Clean up artifacts from storage
 	if externalSource.Status.Artifact != nil {
 		sourceKey := fmt.Sprintf("%s/%s", externalSource.Namespace, externalSource.Name)
-		if err := r.ArtifactManager.Cleanup(ctx, sourceKey, ""); err != nil {
+		cleanupCtx := artifact.WithKeyPrefix(ctx, externalSource.Spec.StoragePrefix)
+		if err := r.ArtifactManager.Cleanup(cleanupCtx, sourceKey, "", 0); err != nil {
 			log.Error(err, "Failed to cleanup artifacts from storage", "source", sourceKey)
 			// Don't fail deletion for cleanup errors, just log them
 		}
 	}
 
+	if r.MetricsRecorder != nil {
+		r.MetricsRecorder.DeleteSourceMetrics(externalSource.Namespace, externalSource.Name)
+	}
+	r.reconciledSinceStart.Delete(client.ObjectKeyFromObject(externalSource))
+	r.changedRefSources.Delete(client.ObjectKeyFromObject(externalSource))
+	r.clearReportedFailure(client.ObjectKeyFromObject(externalSource))
+
 	// Clean up child ExternalArtifact resources (handled automatically by owner references)
 	// The Kubernetes garbage collector will delete the ExternalArtifact when the ExternalSource is deleted
 
@@ -681,6 +1525,33 @@ func (r *ExternalSourceReconciler) reconcileDelete(ctx context.Context, external
 	return ctrl.Result{}, nil
 }
 
+// recordContentMetrics records the size of the current artifact and the age of its data.
+// The age is recorded after failed reconciliations too, so it keeps growing while the source is unreachable.
+func (r *ExternalSourceReconciler) recordContentMetrics(externalSource *sourcev1alpha1.ExternalSource) {
+	if externalSource.Status.Artifact != nil {
+		if size, err := strconv.ParseInt(externalSource.Status.Artifact.Metadata["size"], 10, 64); err == nil {
+			r.MetricsRecorder.RecordArtifactSize(externalSource.Namespace, externalSource.Name, size)
+		}
+	}
+
+	// Sources that have not fetched since the confirmation time was introduced fall back to the fetch time
+	confirmedTime := externalSource.Status.LastConfirmedTime
+	if confirmedTime == nil {
+		confirmedTime = externalSource.Status.LastFetchTime
+	}
+	if confirmedTime != nil {
+		r.MetricsRecorder.RecordContentAge(externalSource.Namespace, externalSource.Name, time.Since(confirmedTime.Time))
+	}
+}
+
+// artifactBackendType returns the storage backend type used to label artifact operation metrics
+func (r *ExternalSourceReconciler) artifactBackendType() string {
+	if reporter, ok := r.ArtifactManager.(storage.TypeReporter); ok {
+		return reporter.BackendType()
+	}
+	return storage.UnknownBackendType
+}
+
 // setCondition sets a condition on the ExternalSource status
 func (r *ExternalSourceReconciler) setCondition(externalSource *sourcev1alpha1.ExternalSource, conditionType string, status metav1.ConditionStatus, reason, message string) {
 	condition := metav1.Condition{
@@ -700,6 +1571,25 @@ func (r *ExternalSourceReconciler) setReadyCondition(externalSource *sourcev1alp
 	r.setCondition(externalSource, ReadyCondition, status, reason, message)
 }
 
+// setDataFreshCondition sets the DataFresh condition reporting whether the last fetch succeeded
+func (r *ExternalSourceReconciler) setDataFreshCondition(externalSource *sourcev1alpha1.ExternalSource, fresh bool, reason, message string) {
+	status := metav1.ConditionFalse
+	if fresh {
+		status = metav1.ConditionTrue
+	}
+	r.setCondition(externalSource, DataFreshCondition, status, reason, message)
+}
+
+// holdReadyCondition changes the reason and message of the Ready condition without changing
+// its status, which is Unknown when the ExternalSource has not been reconciled yet
+func (r *ExternalSourceReconciler) holdReadyCondition(externalSource *sourcev1alpha1.ExternalSource, reason, message string) {
+	status := metav1.ConditionUnknown
+	if ready := apimeta.FindStatusCondition(externalSource.Status.Conditions, ReadyCondition); ready != nil {
+		status = ready.Status
+	}
+	r.setReadyCondition(externalSource, status, reason, message)
+}
+
 // setProgressCondition sets a progress condition (Fetching, Transforming, Storing)
 func (r *ExternalSourceReconciler) setProgressCondition(externalSource *sourcev1alpha1.ExternalSource, conditionType string, inProgress bool, reason, message string) {
 	status := metav1.ConditionFalse
@@ -750,15 +1640,90 @@ func (r *ExternalSourceReconciler) classifyError(err error) ErrorType {
 		return TransientError // Should not happen, but safe default
 	}
 
+	// An unreachable hook sidecar is expected to recover, its error text may
+	// otherwise match one of the permanent patterns below
+	if hooks.IsTransportError(err) {
+		return TransientError
+	}
+
+	// A reconciliation that ran out of time may finish on the next attempt, whatever step it was in
+	if errors.Is(err, errReconcileTimeout) || errors.Is(err, context.DeadlineExceeded) {
+		return TransientError
+	}
+
+	// A reconciliation cancelled by the watchdog is retried, it may not hang the next time
+	if errors.Is(err, errReconcileStuck) {
+		return TransientError
+	}
+
+	// Conflicts with concurrent writers left over after retrying clear up on the next attempt
+	if apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err) {
+		return TransientError
+	}
+
+	// Token endpoint failures are retried, a rejected token request would otherwise be
+	// classified as permanent by its status code
+	if generator.IsOAuth2TokenError(err) {
+		return TransientError
+	}
+
+	// A Secret is only published once its owner opted in, which takes a change of the Secret
+	// that triggers a reconcile
+	if generator.IsSecretNotExportedError(err) {
+		return ConfigurationError
+	}
+
+	// A short-circuited request is retried once the breaker lets a trial request through, its
+	// host and failure count may contain a permanent status code
+	if errors.Is(err, generator.ErrCircuitOpen) {
+		return TransientError
+	}
+
+	// A truncated response is retried, its hex digests may contain a permanent status code
+	if generator.IsDigestMismatchError(err) {
+		return TransientError
+	}
+
+	// A source host that does not exist is almost always a mistyped URL. Other resolver
+	// failures, and lookups of other endpoints such as storage, hooks or in-cluster Services
+	// during a rollout, are expected to recover.
+	if generator.IsSourceHostNotFoundError(err) {
+		return ConfigurationError
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return TransientError
+	}
+
+	// gRPC status codes identify the failure more reliably than the message text
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.Unavailable, codes.DeadlineExceeded:
+			return TransientError
+		case codes.NotFound, codes.PermissionDenied:
+			return PermanentError
+		}
+	}
+
 	errStr := err.Error()
 
 	// Configuration errors - don't retry until spec changes
 	configErrors := []string{
 		"invalid interval",
+		"invalid schedule",
+		"invalid time zone",
 		"unsupported generator type",
 		"configuration is required",
 		"invalid URL",
 		"invalid CEL expression",
+		"unsupported digest algorithm",
+		"invalid hook secret reference",
+		"invalid client certificate",
+		"invalid storage prefix",
+		"invalid destination path",
+		"invalid timeout",
+		"invalid CA bundle reference",
+		"invalid cursor",
 	}
 
 	for _, configErr := range configErrors {
@@ -775,6 +1740,10 @@ func (r *ExternalSourceReconciler) classifyError(err error) ErrorType {
 		"not found",
 		"unauthorized",
 		"forbidden",
+		"resource limit",
+		"content validation failed",
+		"decryption failed",
+		"exceeds maximum size",
 	}
 
 	for _, permErr := range permanentErrors {
@@ -807,6 +1776,36 @@ func findSubstring(s, substr string) bool {
 	return false
 }
 
+// resourceSeed returns a deterministic random seed derived from the namespace and name of a source
+func resourceSeed(externalSource *sourcev1alpha1.ExternalSource) int64 {
+	seed := int64(0)
+	for _, b := range []byte(externalSource.Namespace + "/" + externalSource.Name) {
+		seed = seed*31 + int64(b)
+	}
+	return seed
+}
+
+// startupDelay returns how long to delay the first reconciliation of a source after the controller
+// started, a fraction of its interval derived from its name so fetches fan out over time. Sources
+// without an artifact, with a changed spec or with a requested reconciliation or refetch are not delayed.
+func (r *ExternalSourceReconciler) startupDelay(externalSource *sourcev1alpha1.ExternalSource, interval time.Duration) time.Duration {
+	if r.Config == nil || r.Config.Controller.StartupSpread <= 0 {
+		return 0
+	}
+
+	if _, seen := r.reconciledSinceStart.LoadOrStore(client.ObjectKeyFromObject(externalSource), true); seen {
+		return 0
+	}
+	if externalSource.Status.Artifact == nil ||
+		externalSource.Status.ObservedGeneration != externalSource.Generation ||
+		r.isReconcileRequested(externalSource) || isForceRefetchRequested(externalSource) {
+		return 0
+	}
+
+	rng := rand.New(rand.NewSource(resourceSeed(externalSource)))
+	return time.Duration(float64(interval) * r.Config.Controller.StartupSpread * rng.Float64())
+}
+
 // calculateRetryDelay calculates the retry delay using exponential backoff with jitter
 func (r *ExternalSourceReconciler) calculateRetryDelay(externalSource *sourcev1alpha1.ExternalSource, err error) time.Duration {
 	// Classify the error
@@ -833,11 +1832,7 @@ func (r *ExternalSourceReconciler) calculateRetryDelay(externalSource *sourcev1a
 
 	// Add jitter to prevent thundering herd
 	// Use a deterministic seed based on the resource to ensure consistent jitter
-	seed := int64(0)
-	for _, b := range []byte(externalSource.Namespace + "/" + externalSource.Name) {
-		seed = seed*31 + int64(b)
-	}
-	rng := rand.New(rand.NewSource(seed + int64(retryCount)))
+	rng := rand.New(rand.NewSource(resourceSeed(externalSource) + int64(retryCount)))
 
 	jitter := time.Duration(float64(delay) * r.Config.Retry.JitterFactor * (2*rng.Float64() - 1))
 	delay += jitter
@@ -850,72 +1845,74 @@ func (r *ExternalSourceReconciler) calculateRetryDelay(externalSource *sourcev1a
 	return delay
 }
 
-// getRetryCount gets the current retry count from annotations
-func (r *ExternalSourceReconciler) getRetryCount(externalSource *sourcev1alpha1.ExternalSource) int {
-	if externalSource.Annotations == nil {
-		return 0
-	}
-
-	retryCountStr, exists := externalSource.Annotations[retryCountAnnotation]
-	if !exists {
-		return 0
+// stalledInterval returns the requeue interval for a stalled source, which is
+// never shorter than the source's own interval
+func (r *ExternalSourceReconciler) stalledInterval(interval time.Duration) time.Duration {
+	if r.Config.Retry.StalledInterval <= interval {
+		return interval
 	}
+	return r.Config.Retry.StalledInterval
+}
 
-	var retryCount int
-	if _, err := fmt.Sscanf(retryCountStr, "%d", &retryCount); err != nil {
+// getRetryCount gets the current retry count from status
+func (r *ExternalSourceReconciler) getRetryCount(externalSource *sourcev1alpha1.ExternalSource) int {
+	if externalSource.Status.Retry == nil {
 		return 0
 	}
 
-	return retryCount
+	return externalSource.Status.Retry.Count
 }
 
 // incrementRetryCount increments the retry count and tracks failure information
 func (r *ExternalSourceReconciler) incrementRetryCount(externalSource *sourcev1alpha1.ExternalSource, err error) {
-	if externalSource.Annotations == nil {
-		externalSource.Annotations = make(map[string]string)
+	retry := externalSource.Status.Retry
+	if retry == nil {
+		// Set backoff start time on first failure
+		now := metav1.Now()
+		retry = &sourcev1alpha1.RetryStatus{BackoffStartTime: &now}
+		externalSource.Status.Retry = retry
 	}
 
-	retryCount := r.getRetryCount(externalSource)
-	newRetryCount := retryCount + 1
+	retry.Count++
+	retry.LastFailure = err.Error()
+	retry.ConsecutiveSuccesses = 0
+}
 
-	externalSource.Annotations[retryCountAnnotation] = fmt.Sprintf("%d", newRetryCount)
-	externalSource.Annotations[lastFailureAnnotation] = err.Error()
+// recordSuccess counts a successful reconcile towards the stability window and
+// clears the retry state once enough consecutive successes have been seen, so a
+// flapping source keeps backing off instead of starting over after every success
+func (r *ExternalSourceReconciler) recordSuccess(externalSource *sourcev1alpha1.ExternalSource) {
+	retry := externalSource.Status.Retry
+	if retry == nil {
+		return
+	}
 
-	// Set backoff start time on first failure
-	if retryCount == 0 {
-		externalSource.Annotations[backoffStartAnnotation] = time.Now().Format(time.RFC3339)
+	retry.ConsecutiveSuccesses++
+	if retry.ConsecutiveSuccesses >= r.Config.Retry.SuccessThreshold {
+		r.clearRetryCount(externalSource)
+		return
 	}
+
+	// The source is working again, so it is no longer stalled even though the
+	// backoff is kept until the window is met
+	apimeta.RemoveStatusCondition(&externalSource.Status.Conditions, StalledCondition)
 }
 
-// clearRetryCount clears all retry-related annotations and conditions
+// clearRetryCount clears the retry status and stalled condition
 func (r *ExternalSourceReconciler) clearRetryCount(externalSource *sourcev1alpha1.ExternalSource) {
-	if externalSource.Annotations != nil {
-		delete(externalSource.Annotations, retryCountAnnotation)
-		delete(externalSource.Annotations, lastFailureAnnotation)
-		delete(externalSource.Annotations, backoffStartAnnotation)
+	externalSource.Status.Retry = nil
 
-		// Remove stalled condition if it exists
-		apimeta.RemoveStatusCondition(&externalSource.Status.Conditions, StalledCondition)
-	}
+	// Remove stalled condition if it exists
+	apimeta.RemoveStatusCondition(&externalSource.Status.Conditions, StalledCondition)
 }
 
 // getBackoffDuration returns how long the resource has been in backoff
 func (r *ExternalSourceReconciler) getBackoffDuration(externalSource *sourcev1alpha1.ExternalSource) time.Duration {
-	if externalSource.Annotations == nil {
-		return 0
-	}
-
-	backoffStartStr, exists := externalSource.Annotations[backoffStartAnnotation]
-	if !exists {
-		return 0
-	}
-
-	backoffStart, err := time.Parse(time.RFC3339, backoffStartStr)
-	if err != nil {
+	if externalSource.Status.Retry == nil || externalSource.Status.Retry.BackoffStartTime == nil {
 		return 0
 	}
 
-	return time.Since(backoffStart)
+	return time.Since(externalSource.Status.Retry.BackoffStartTime.Time)
 }
 
 // shouldResetRetryCount determines if retry count should be reset based on spec changes
@@ -950,6 +1947,13 @@ func (r *ExternalSourceReconciler) executeHooks(ctx context.Context, externalSou
 	}
 	totalRetries := 0
 
+	// Resolve secret environment variables for every hook up front so a missing
+	// secret fails the reconcile before any hook has run
+	hookSpecs, secretValues, err := r.resolveHookEnv(ctx, externalSource.Namespace, hookSpecs)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, hookSpec := range hookSpecs {
 		hookName := hookSpec.Name
 		retryPolicy := hookSpec.RetryPolicy
@@ -975,6 +1979,7 @@ func (r *ExternalSourceReconciler) executeHooks(ctx context.Context, externalSou
 		for attempts < maxAttempts {
 			hookStartTime := time.Now()
 			output, hookErr = r.HookExecutor.Execute(ctx, data, hookSpec)
+			hookErr = redactSecretValues(hookErr, secretValues)
 			hookDuration := time.Since(hookStartTime)
 
 			// Record hook execution metrics
@@ -988,6 +1993,12 @@ func (r *ExternalSourceReconciler) executeHooks(ctx context.Context, externalSou
 				break
 			}
 
+			// The sidecar could not be reached, the hook never ran so the retry policy does
+			// not apply; surface the error and let the reconcile back off
+			if hooks.IsTransportError(hookErr) {
+				return nil, fmt.Errorf("hook %s could not be executed: %w", hookName, hookErr)
+			}
+
 			attempts++
 			totalRetries++
 
@@ -1014,6 +2025,137 @@ func (r *ExternalSourceReconciler) executeHooks(ctx context.Context, externalSou
 	return data, nil
 }
 
+// decryptContent decrypts data with the keys from the decryption secret. Errors never
+// include the data, decrypted or not.
+func (r *ExternalSourceReconciler) decryptContent(ctx context.Context, externalSource *sourcev1alpha1.ExternalSource, data []byte) ([]byte, error) {
+	spec := externalSource.Spec.Decryption
+	if spec == nil {
+		return data, nil
+	}
+
+	if spec.Provider != decryption.ProviderSOPS {
+		return nil, fmt.Errorf("decryption failed: unsupported provider %q", spec.Provider)
+	}
+
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Namespace: externalSource.Namespace, Name: spec.SecretRef.Name}
+	if err := r.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf("decryption failed: failed to get secret %s/%s: %w", key.Namespace, key.Name, err)
+	}
+
+	decrypted, err := decryption.DecryptSOPS(data, spec.Format, secret.Data)
+	if err != nil {
+		return nil, fmt.Errorf("decryption failed: %w", err)
+	}
+
+	return decrypted, nil
+}
+
+// validateContent checks data against the validation spec, loading the schema from its ConfigMap
+func (r *ExternalSourceReconciler) validateContent(ctx context.Context, externalSource *sourcev1alpha1.ExternalSource, data []byte) error {
+	spec := externalSource.Spec.Validation
+	if spec == nil {
+		return nil
+	}
+
+	var schema []byte
+	if spec.SchemaRef != nil {
+		configMap := &corev1.ConfigMap{}
+		key := client.ObjectKey{Namespace: externalSource.Namespace, Name: spec.SchemaRef.Name}
+		if err := r.Get(ctx, key, configMap); err != nil {
+			return fmt.Errorf("failed to get validation schema ConfigMap %s/%s: %w", key.Namespace, key.Name, err)
+		}
+		value, exists := configMap.Data[spec.SchemaRef.Key]
+		if !exists {
+			return fmt.Errorf("failed to load validation schema: key %s not found in ConfigMap %s/%s",
+				spec.SchemaRef.Key, key.Namespace, key.Name)
+		}
+		schema = []byte(value)
+	}
+
+	if err := validation.Validate(data, spec.Format, schema); err != nil {
+		return fmt.Errorf("content validation failed: %w", err)
+	}
+
+	return nil
+}
+
+// resolveHookEnv returns copies of the hook specs with EnvFrom secret keys appended to Env,
+// along with the resolved values so they can be redacted from hook errors.
+// Errors name the secret and key but never include secret values.
+func (r *ExternalSourceReconciler) resolveHookEnv(ctx context.Context, namespace string, hookSpecs []sourcev1alpha1.HookSpec) ([]sourcev1alpha1.HookSpec, []string, error) {
+	resolved := make([]sourcev1alpha1.HookSpec, len(hookSpecs))
+	secrets := make(map[string]*corev1.Secret)
+	var secretValues []string
+
+	for i, hookSpec := range hookSpecs {
+		resolved[i] = hookSpec
+		if len(hookSpec.EnvFrom) == 0 {
+			continue
+		}
+
+		env := make([]sourcev1alpha1.EnvVar, 0, len(hookSpec.Env)+len(hookSpec.EnvFrom))
+		env = append(env, hookSpec.Env...)
+
+		for _, envFrom := range hookSpec.EnvFrom {
+			ref := envFrom.SecretKeyRef
+			secret, ok := secrets[ref.Name]
+			if !ok {
+				secret = &corev1.Secret{}
+				if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+					if apierrors.IsNotFound(err) {
+						return nil, nil, fmt.Errorf("invalid hook secret reference: hook %s references secret %s/%s which does not exist",
+							hookSpec.Name, namespace, ref.Name)
+					}
+					return nil, nil, fmt.Errorf("failed to get secret %s/%s for hook %s: %w", namespace, ref.Name, hookSpec.Name, err)
+				}
+				secrets[ref.Name] = secret
+			}
+
+			value, exists := secret.Data[ref.Key]
+			if !exists {
+				return nil, nil, fmt.Errorf("invalid hook secret reference: hook %s references key %s missing from secret %s/%s",
+					hookSpec.Name, ref.Key, namespace, ref.Name)
+			}
+			env = append(env, sourcev1alpha1.EnvVar{Name: envFrom.Name, Value: string(value)})
+			if len(value) > 0 {
+				secretValues = append(secretValues, string(value))
+			}
+		}
+
+		resolved[i].Env = env
+	}
+
+	return resolved, secretValues, nil
+}
+
+// redactedError replaces secret values in an error message while preserving the error chain
+type redactedError struct {
+	message string
+	err     error
+}
+
+func (e *redactedError) Error() string { return e.message }
+
+func (e *redactedError) Unwrap() error { return e.err }
+
+// redactSecretValues masks secret values in err, a hook may echo its environment on failure
+func redactSecretValues(err error, secretValues []string) error {
+	if err == nil || len(secretValues) == 0 {
+		return err
+	}
+
+	message := err.Error()
+	redacted := message
+	for _, value := range secretValues {
+		redacted = strings.ReplaceAll(redacted, value, "[REDACTED]")
+	}
+	if redacted == message {
+		return err
+	}
+	return &redactedError{message: redacted, err: err}
+}
+
 // needsRecovery determines if the controller needs to perform recovery after restart
 func (r *ExternalSourceReconciler) needsRecovery(externalSource *sourcev1alpha1.ExternalSource) bool {
 	// Check if there are any in-progress conditions that suggest the controller was interrupted
@@ -1067,6 +2209,34 @@ func (r *ExternalSourceReconciler) performRecovery(ctx context.Context, external
 	apimeta.RemoveStatusCondition(&externalSource.Status.Conditions, StalledCondition)
 }
 
+// encryptStorageBackend wraps the backend so artifacts are encrypted at rest with the key
+// from the mounted secret. Memory and PVC artifacts are already served by the artifact
+// server, S3 artifact URLs are pointed at it so Flux receives them decrypted.
+func (r *ExternalSourceReconciler) encryptStorageBackend(backend storage.StorageBackend) (storage.StorageBackend, error) {
+	keyData, err := os.ReadFile(r.Config.Storage.Encryption.KeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifact encryption key: %w", err)
+	}
+	key, err := storage.ParseEncryptionKey(keyData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid artifact encryption key: %w", err)
+	}
+
+	var baseURL string
+	if r.Config.Storage.Backend == "s3" {
+		baseURL = fmt.Sprintf("http://%s.%s.svc.cluster.local:%d",
+			r.Config.ArtifactServer.ServiceName,
+			r.Config.ArtifactServer.ServiceNamespace,
+			r.Config.ArtifactServer.Port)
+	}
+
+	encrypted, err := storage.NewEncryptedBackend(backend, key, baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create encrypted storage backend: %w", err)
+	}
+	return encrypted, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ExternalSourceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// Initialize components if not already set
@@ -1076,11 +2246,22 @@ func (r *ExternalSourceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 	if r.HookExecutor == nil {
 		// Load whitelist manager
-		whitelistManager, err := hooks.NewFileWhitelistManager(r.Config.Hooks.WhitelistPath)
+		whitelistManager, err := hooks.NewWhitelistManagerForPath(r.Config.Hooks.WhitelistPath)
 		if err != nil {
 			return fmt.Errorf("failed to load hook whitelist: %w", err)
 		}
 
+		// Keep the whitelist in sync with the one the sidecar reloads
+		whitelistLog := mgr.GetLogger().WithName("hook-whitelist")
+		if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			if err := whitelistManager.Watch(ctx); err != nil {
+				whitelistLog.Error(err, "Hook whitelist hot reload disabled")
+			}
+			return nil
+		})); err != nil {
+			return fmt.Errorf("failed to add hook whitelist watcher: %w", err)
+		}
+
 		// Create hook executor
 		r.HookExecutor = hooks.NewSidecarExecutor(
 			r.Config.Hooks.SidecarEndpoint,
@@ -1100,12 +2281,16 @@ func (r *ExternalSourceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			switch r.Config.Storage.Backend {
 			case "s3":
 				storageBackend = storage.NewS3Backend(storage.S3Config{
-					Endpoint:  r.Config.Storage.S3.Endpoint,
-					Bucket:    r.Config.Storage.S3.Bucket,
-					Region:    r.Config.Storage.S3.Region,
-					AccessKey: r.Config.Storage.S3.AccessKeyID,
-					SecretKey: r.Config.Storage.S3.SecretAccessKey,
-					UseSSL:    r.Config.Storage.S3.UseSSL,
+					Endpoint:           r.Config.Storage.S3.Endpoint,
+					Bucket:             r.Config.Storage.S3.Bucket,
+					Region:             r.Config.Storage.S3.Region,
+					AccessKey:          r.Config.Storage.S3.AccessKeyID,
+					SecretKey:          r.Config.Storage.S3.SecretAccessKey,
+					UseSSL:             r.Config.Storage.S3.UseSSL,
+					PresignURLs:        r.Config.Storage.S3.PresignURLs,
+					PresignExpiry:      r.Config.Storage.S3.PresignExpiry,
+					MultipartThreshold: r.Config.Storage.S3.MultipartThreshold,
+					MultipartPartSize:  r.Config.Storage.S3.MultipartPartSize,
 				})
 			case "memory":
 				// Build base URL for memory backend if artifact server is enabled
@@ -1131,6 +2316,24 @@ func (r *ExternalSourceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				if err != nil {
 					return fmt.Errorf("failed to create PVC storage backend: %w", err)
 				}
+			case "oci":
+				var dockerConfig []byte
+				var err error
+				if path := r.Config.Storage.OCI.DockerConfigPath; path != "" {
+					dockerConfig, err = os.ReadFile(path)
+					if err != nil {
+						return fmt.Errorf("failed to read OCI docker config: %w", err)
+					}
+				}
+				storageBackend, err = storage.NewOCIBackend(storage.OCIConfig{
+					Registry:         r.Config.Storage.OCI.Registry,
+					Repository:       r.Config.Storage.OCI.Repository,
+					Insecure:         r.Config.Storage.OCI.Insecure,
+					DockerConfigJSON: dockerConfig,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to create OCI storage backend: %w", err)
+				}
 			default:
 				return fmt.Errorf("unsupported storage backend: %s", r.Config.Storage.Backend)
 			}
@@ -1138,26 +2341,210 @@ func (r *ExternalSourceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			r.StorageBackend = storageBackend
 		}
 
-		r.ArtifactManager = artifact.NewManager(storageBackend)
+		if r.Config.Storage.Encryption.Enabled {
+			if _, encrypted := storageBackend.(*storage.EncryptedBackend); !encrypted {
+				var err error
+				storageBackend, err = r.encryptStorageBackend(storageBackend)
+				if err != nil {
+					return err
+				}
+				r.StorageBackend = storageBackend
+			}
+		}
+
+		artifactManager := artifact.NewManager(storageBackend)
+		artifactManager.SetMaxSize(r.Config.HTTP.MaxResponseSize)
+		if r.Config.Storage.DigestAlgorithm != "" {
+			if err := artifactManager.SetDigestAlgorithm(r.Config.Storage.DigestAlgorithm); err != nil {
+				return fmt.Errorf("failed to configure artifact digest algorithm: %w", err)
+			}
+		}
+		if r.Config.Storage.Compression != "" {
+			if err := artifactManager.SetCompression(r.Config.Storage.Compression); err != nil {
+				return fmt.Errorf("failed to configure artifact compression: %w", err)
+			}
+		}
+		if err := artifactManager.SetKeyPrefix(r.Config.Storage.KeyPrefix); err != nil {
+			return fmt.Errorf("failed to configure artifact storage prefix: %w", err)
+		}
+		r.ArtifactManager = artifactManager
 	}
 
-	// Register built-in generators with HTTP client configuration
-	if err := r.GeneratorFactory.RegisterGenerator("http", func() generator.SourceGenerator {
-		return generator.NewHTTPGeneratorWithConfig(r.Client, &generator.HTTPClientConfig{
-			Timeout:             r.Config.HTTP.Timeout,
-			MaxIdleConns:        r.Config.HTTP.MaxIdleConns,
-			MaxIdleConnsPerHost: r.Config.HTTP.MaxIdleConnsPerHost,
-			MaxConnsPerHost:     r.Config.HTTP.MaxConnsPerHost,
-			IdleConnTimeout:     r.Config.HTTP.IdleConnTimeout,
-			UserAgent:           r.Config.HTTP.UserAgent,
-		})
-	}); err != nil {
-		return fmt.Errorf("failed to register HTTP generator: %w", err)
+	if r.Notifier == nil {
+		r.Notifier = notification.NewWebhookNotifier(notification.DefaultTimeout, r.Config.HTTP.UserAgent)
+	}
+
+	// Garbage collect stale artifacts left on the PVC by restarts or sources deleted out of band
+	gcBackend := r.StorageBackend
+	if encrypted, ok := gcBackend.(*storage.EncryptedBackend); ok {
+		gcBackend = encrypted.Unwrap()
+	}
+	if pvcBackend, ok := gcBackend.(*storage.PVCBackend); ok {
+		gcConfig := storage.PVCGCConfig{
+			Interval:     r.Config.Storage.PVC.GCInterval,
+			MaxAge:       r.Config.Storage.PVC.GCMaxAge,
+			MaxRevisions: r.Config.Storage.PVC.GCMaxRevisions,
+		}
+		gcLog := mgr.GetLogger().WithName("pvc-gc")
+		if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			pvcBackend.RunGarbageCollector(ctx, gcConfig, r.referencedArtifactKeys, func(err error) {
+				gcLog.Error(err, "PVC artifact garbage collection failed")
+			})
+			return nil
+		})); err != nil {
+			return fmt.Errorf("failed to add PVC garbage collector: %w", err)
+		}
+	}
+
+	if err := r.registerGenerators(); err != nil {
+		return err
+	}
+
+	watchdogLog := mgr.GetLogger().WithName("reconcile-watchdog")
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		r.runWatchdog(logf.IntoContext(ctx, watchdogLog))
+		return nil
+	})); err != nil {
+		return fmt.Errorf("failed to add reconcile watchdog: %w", err)
+	}
+
+	if r.Config.Controller.FetchRateLimit > 0 {
+		r.fetchLimiter = rate.NewLimiter(rate.Limit(r.Config.Controller.FetchRateLimit), r.Config.Controller.FetchRateBurst)
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &sourcev1alpha1.ExternalSource{},
+		secretRefIndexKey, indexSecretRefs); err != nil {
+		return fmt.Errorf("failed to index ExternalSource secret references: %w", err)
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &sourcev1alpha1.ExternalSource{},
+		configMapRefIndexKey, indexConfigMapRefs); err != nil {
+		return fmt.Errorf("failed to index ExternalSource ConfigMap references: %w", err)
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &sourcev1alpha1.ExternalSource{},
+		dependsOnIndexKey, indexDependsOn); err != nil {
+		return fmt.Errorf("failed to index ExternalSource dependencies: %w", err)
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&sourcev1alpha1.ExternalSource{}).
 		Owns(&sourcev1.ExternalArtifact{}).
+		Watches(&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.sourcesForSecret),
+			builder.WithPredicates(secretDataChanged()),
+		).
+		Watches(&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.sourcesForConfigMap),
+			builder.WithPredicates(configMapDataChanged()),
+		).
+		Watches(&sourcev1alpha1.ExternalSource{},
+			handler.EnqueueRequestsFromMapFunc(r.sourcesForDependency),
+			builder.WithPredicates(dependencyReadinessChanged()),
+		).
 		Named("externalsource").
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.Config.Controller.MaxConcurrentReconciles,
+		}).
 		Complete(r)
 }
+
+// waitForFetch blocks until the global fetch rate limiter admits a source request,
+// recording a metric when the request had to wait
+func (r *ExternalSourceReconciler) waitForFetch(ctx context.Context, sourceType string) error {
+	if r.fetchLimiter == nil || r.fetchLimiter.Allow() {
+		return nil
+	}
+
+	if r.MetricsRecorder != nil {
+		r.MetricsRecorder.RecordFetchRateLimited(sourceType)
+	}
+
+	if err := r.fetchLimiter.Wait(ctx); err != nil {
+		return fmt.Errorf("failed waiting for fetch rate limiter: %w", err)
+	}
+	return nil
+}
+
+// recordCircuitBreakerState logs and records a state change of the circuit breaker for an upstream host
+func (r *ExternalSourceReconciler) recordCircuitBreakerState(host string, open bool) {
+	if open {
+		logf.Log.Info("Circuit breaker opened, short-circuiting requests", "host", host,
+			"cooldown", r.Config.HTTP.CircuitBreakerCooldown)
+	} else {
+		logf.Log.Info("Circuit breaker closed", "host", host)
+	}
+
+	if r.MetricsRecorder != nil {
+		r.MetricsRecorder.RecordCircuitBreakerState(host, open)
+	}
+}
+
+// registerGenerators registers the built-in generators with the HTTP client configuration
+func (r *ExternalSourceReconciler) registerGenerators() error {
+	// The circuit breaker is shared by all HTTP generator instances so failures are counted per host
+	var circuitBreaker *generator.CircuitBreaker
+	if r.Config.HTTP.CircuitBreakerThreshold > 0 {
+		circuitBreaker = generator.NewCircuitBreaker(r.Config.HTTP.CircuitBreakerThreshold,
+			r.Config.HTTP.CircuitBreakerCooldown, r.recordCircuitBreakerState)
+	}
+
+	// OAuth2 tokens are shared by every source using the same client
+	tokenCache := generator.NewOAuth2TokenCache(r.Config.HTTP.Timeout)
+
+	if err := r.GeneratorFactory.RegisterGenerator("http", func() generator.SourceGenerator {
+		return generator.NewHTTPGeneratorWithConfig(r.Client, &generator.HTTPClientConfig{
+			Timeout:               r.Config.HTTP.Timeout,
+			ConnectTimeout:        r.Config.HTTP.ConnectTimeout,
+			TLSHandshakeTimeout:   r.Config.HTTP.TLSHandshakeTimeout,
+			ResponseHeaderTimeout: r.Config.HTTP.ResponseHeaderTimeout,
+			MaxIdleConns:          r.Config.HTTP.MaxIdleConns,
+			MaxIdleConnsPerHost:   r.Config.HTTP.MaxIdleConnsPerHost,
+			MaxConnsPerHost:       r.Config.HTTP.MaxConnsPerHost,
+			IdleConnTimeout:       r.Config.HTTP.IdleConnTimeout,
+			UserAgent:             r.Config.HTTP.UserAgent,
+			MaxResponseSize:       r.Config.HTTP.MaxResponseSize,
+			DefaultHeaders:        r.Config.HTTP.DefaultHeaders,
+			CircuitBreaker:        circuitBreaker,
+			OAuth2TokenCache:      tokenCache,
+		})
+	}); err != nil {
+		return fmt.Errorf("failed to register HTTP generator: %w", err)
+	}
+
+	if err := r.GeneratorFactory.RegisterGenerator("git", func() generator.SourceGenerator {
+		return generator.NewGitGenerator(r.Client)
+	}); err != nil {
+		return fmt.Errorf("failed to register Git generator: %w", err)
+	}
+
+	if err := r.GeneratorFactory.RegisterGenerator("file", func() generator.SourceGenerator {
+		return generator.NewFileGenerator(r.Config.FileGenerator.Root)
+	}); err != nil {
+		return fmt.Errorf("failed to register file generator: %w", err)
+	}
+
+	if err := r.GeneratorFactory.RegisterGenerator("s3", func() generator.SourceGenerator {
+		return generator.NewS3Generator(r.Client)
+	}); err != nil {
+		return fmt.Errorf("failed to register S3 generator: %w", err)
+	}
+
+	if err := r.GeneratorFactory.RegisterGenerator("grpc", func() generator.SourceGenerator {
+		return generator.NewGRPCGenerator(r.Client)
+	}); err != nil {
+		return fmt.Errorf("failed to register gRPC generator: %w", err)
+	}
+
+	if err := r.GeneratorFactory.RegisterGenerator("configMap", func() generator.SourceGenerator {
+		return generator.NewConfigMapGenerator(r.Client)
+	}); err != nil {
+		return fmt.Errorf("failed to register ConfigMap generator: %w", err)
+	}
+
+	if err := r.GeneratorFactory.RegisterGenerator("secret", func() generator.SourceGenerator {
+		return generator.NewSecretGenerator(r.Client)
+	}); err != nil {
+		return fmt.Errorf("failed to register Secret generator: %w", err)
+	}
+
+	return nil
+}
@@ -24,24 +24,48 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	sourcev1alpha1 "github.com/oddkinco/flux-externalsource-controller/api/v1alpha1"
 	"github.com/oddkinco/flux-externalsource-controller/internal/artifact"
 	"github.com/oddkinco/flux-externalsource-controller/internal/config"
 	"github.com/oddkinco/flux-externalsource-controller/internal/generator"
 	"github.com/oddkinco/flux-externalsource-controller/internal/hooks"
+	"github.com/oddkinco/flux-externalsource-controller/internal/notification"
+	"github.com/oddkinco/flux-externalsource-controller/internal/storage"
 )
 
 // createTestConfig creates a default configuration for testing
@@ -91,7 +115,7 @@ var _ = Describe("ExternalSource Controller", func() {
 							HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
 								URL:    "https://secure-api.example.com/config",
 								Method: "POST",
-								HeadersSecretRef: &sourcev1alpha1.SecretReference{
+								HeadersSecretRef: &sourcev1alpha1.HeadersSecretReference{
 									Name: "api-headers",
 								},
 								CABundleSecretRef: &sourcev1alpha1.SecretKeyReference{
@@ -109,6 +133,111 @@ var _ = Describe("ExternalSource Controller", func() {
 				Expect(k8sClient.Delete(ctx, externalSource)).To(Succeed())
 			})
 
+			It("should accept a valid Git generator configuration", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "valid-git-source",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval: "5m",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "git",
+							Git: &sourcev1alpha1.GitGeneratorSpec{
+								URL: "https://github.com/example/config.git",
+								Ref: &sourcev1alpha1.GitRepositoryRef{
+									Branch: "main",
+								},
+								Path: "config/app.json",
+								SecretRef: &sourcev1alpha1.SecretReference{
+									Name: "git-credentials",
+								},
+							},
+						},
+					},
+				}
+
+				Expect(k8sClient.Create(ctx, externalSource)).To(Succeed())
+
+				// Cleanup
+				Expect(k8sClient.Delete(ctx, externalSource)).To(Succeed())
+			})
+
+			It("should accept a valid file generator configuration", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "valid-file-source",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval: "5m",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "file",
+							File: &sourcev1alpha1.FileGeneratorSpec{
+								Path: "sidecar/config.json",
+							},
+						},
+					},
+				}
+
+				Expect(k8sClient.Create(ctx, externalSource)).To(Succeed())
+
+				// Cleanup
+				Expect(k8sClient.Delete(ctx, externalSource)).To(Succeed())
+			})
+
+			It("should accept an HTTP URL with template placeholders", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "templated-url-source",
+						Namespace: "default",
+						Labels:    map[string]string{"environment": "production"},
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval: "5m",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "http",
+							HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+								URL:        `https://api.example.com/{{ .Namespace }}/{{ .Labels.environment }}/{{ index .Labels "app.kubernetes.io/name" }}`,
+								VersionURL: "https://api.example.com/{{ .Namespace }}/{{ .Labels.environment }}/version",
+							},
+						},
+					},
+				}
+
+				Expect(k8sClient.Create(ctx, externalSource)).To(Succeed())
+
+				// Cleanup
+				Expect(k8sClient.Delete(ctx, externalSource)).To(Succeed())
+			})
+
+			It("should accept a valid S3 generator configuration", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "valid-s3-source",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval: "5m",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "s3",
+							S3: &sourcev1alpha1.S3GeneratorSpec{
+								Endpoint:  "https://s3.eu-west-1.amazonaws.com",
+								Bucket:    "team-configs",
+								Key:       "environments/prod/config.json",
+								Region:    "eu-west-1",
+								SecretRef: &sourcev1alpha1.SecretReference{Name: "s3-credentials"},
+							},
+						},
+					},
+				}
+
+				Expect(k8sClient.Create(ctx, externalSource)).To(Succeed())
+
+				// Cleanup
+				Expect(k8sClient.Delete(ctx, externalSource)).To(Succeed())
+			})
+
 			It("should accept configuration with transformation", func() {
 				externalSource := &sourcev1alpha1.ExternalSource{
 					ObjectMeta: metav1.ObjectMeta{
@@ -189,6 +318,72 @@ var _ = Describe("ExternalSource Controller", func() {
 				Expect(err).To(HaveOccurred())
 			})
 
+			It("should accept a cron schedule instead of an interval", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "scheduled-source",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Schedule: "*/15 9-17 * * 1-5",
+						TimeZone: "Europe/London",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "http",
+							HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+								URL: "https://api.example.com/config",
+							},
+						},
+					},
+				}
+
+				Expect(k8sClient.Create(ctx, externalSource)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, externalSource)).To(Succeed())
+			})
+
+			It("should reject both interval and schedule", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "interval-and-schedule",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval: "5m",
+						Schedule: "0 * * * *",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "http",
+							HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+								URL: "https://api.example.com/config",
+							},
+						},
+					},
+				}
+
+				err := k8sClient.Create(ctx, externalSource)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("exactly one of interval or schedule"))
+			})
+
+			It("should reject a malformed cron schedule", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "invalid-schedule",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Schedule: "every hour",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "http",
+							HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+								URL: "https://api.example.com/config",
+							},
+						},
+					},
+				}
+
+				err := k8sClient.Create(ctx, externalSource)
+				Expect(err).To(HaveOccurred())
+			})
+
 			It("should reject missing generator field", func() {
 				externalSource := &sourcev1alpha1.ExternalSource{
 					ObjectMeta: metav1.ObjectMeta{
@@ -266,10 +461,10 @@ var _ = Describe("ExternalSource Controller", func() {
 				Expect(err).To(HaveOccurred())
 			})
 
-			It("should reject invalid transformation type", func() {
+			It("should reject HTTP request body with GET method", func() {
 				externalSource := &sourcev1alpha1.ExternalSource{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:      "invalid-hook-policy",
+						Name:      "http-get-with-body",
 						Namespace: "default",
 					},
 					Spec: sourcev1alpha1.ExternalSourceSpec{
@@ -277,17 +472,33 @@ var _ = Describe("ExternalSource Controller", func() {
 						Generator: sourcev1alpha1.GeneratorSpec{
 							Type: "http",
 							HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
-								URL: "https://api.example.com/config",
+								URL:    "https://api.example.com/config",
+								Method: "GET",
+								Body:   `{"query": "config"}`,
 							},
 						},
-						Hooks: &sourcev1alpha1.HooksSpec{
-							PostRequest: []sourcev1alpha1.HookSpec{
-								{
-									Name:        "invalid-hook",
-									Command:     "jq",
-									Args:        []string{".data"},
-									RetryPolicy: "invalid", // Not in enum
-								},
+					},
+				}
+
+				err := k8sClient.Create(ctx, externalSource)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("body is only allowed"))
+			})
+
+			It("should reject both basic auth and bearer token secret references", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "http-conflicting-auth",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval: "5m",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "http",
+							HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+								URL:                  "https://api.example.com/config",
+								BasicAuthSecretRef:   &sourcev1alpha1.SecretReference{Name: "basic-auth"},
+								BearerTokenSecretRef: &sourcev1alpha1.SecretReference{Name: "bearer-token"},
 							},
 						},
 					},
@@ -295,317 +506,467 @@ var _ = Describe("ExternalSource Controller", func() {
 
 				err := k8sClient.Create(ctx, externalSource)
 				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("only one of basicAuthSecretRef or bearerTokenSecretRef"))
 			})
 
-		})
-	})
-
-	Context("When reconciling a resource", func() {
-		ctx := context.Background()
+			It("should reject a CA bundle from both a secret and a ConfigMap", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "http-conflicting-ca-bundle",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval: "5m",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "http",
+							HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+								URL:                  "https://api.example.com/config",
+								CABundleSecretRef:    &sourcev1alpha1.SecretKeyReference{Name: "ca", Key: "ca.crt"},
+								CABundleConfigMapRef: &sourcev1alpha1.ConfigMapKeyReference{Name: "ca", Key: "ca.crt"},
+							},
+						},
+					},
+				}
 
-		It("should successfully reconcile the resource", func() {
-			resourceName := "test-reconcile-success"
-			typeNamespacedName := types.NamespacedName{
-				Name:      resourceName,
-				Namespace: "default",
-			}
+				err := k8sClient.Create(ctx, externalSource)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("only one of caBundleSecretRef or caBundleConfigMapRef may be set"))
+			})
 
-			By("creating a valid ExternalSource resource")
-			resource := &sourcev1alpha1.ExternalSource{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      resourceName,
-					Namespace: "default",
-				},
-				Spec: sourcev1alpha1.ExternalSourceSpec{
-					Interval: "5m",
-					Generator: sourcev1alpha1.GeneratorSpec{
-						Type: "http",
-						HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
-							URL: "https://api.example.com/config",
+			It("should reject a storage prefix that escapes the artifact layout", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "storage-prefix-traversal",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval:      "5m",
+						StoragePrefix: "team-a/../team-b",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "http",
+							HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+								URL: "https://api.example.com/config",
+							},
 						},
 					},
-				},
-			}
-			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
-
-			By("reconciling the created resource")
-			mockFactory := NewMockGeneratorFactory()
-			mockHookExecutor := &MockHookExecutor{}
-			mockArtifactManager := &MockArtifactManager{}
+				}
 
-			// Register mock HTTP generator
-			Expect(mockFactory.RegisterGenerator("http", func() generator.SourceGenerator {
-				return &MockSourceGenerator{}
-			})).To(Succeed())
+				err := k8sClient.Create(ctx, externalSource)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("storagePrefix must not contain"))
+			})
 
-			controllerReconciler := &ExternalSourceReconciler{
-				Client:           k8sClient,
-				Scheme:           k8sClient.Scheme(),
-				Config:           createTestConfig(),
-				GeneratorFactory: mockFactory,
-				HookExecutor:     mockHookExecutor,
-				ArtifactManager:  mockArtifactManager,
-			}
+			It("should reject OAuth2 combined with a bearer token secret reference", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "http-conflicting-oauth2",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval: "5m",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "http",
+							HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+								URL:                  "https://api.example.com/config",
+								BearerTokenSecretRef: &sourcev1alpha1.SecretReference{Name: "bearer-token"},
+								OAuth2: &sourcev1alpha1.OAuth2ClientCredentials{
+									TokenURL:        "https://auth.example.com/token",
+									ClientSecretRef: sourcev1alpha1.SecretReference{Name: "oauth2-client"},
+								},
+							},
+						},
+					},
+				}
 
-			// First reconcile adds finalizer and requeues
-			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
-				NamespacedName: typeNamespacedName,
+				err := k8sClient.Create(ctx, externalSource)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("oauth2 cannot be combined"))
 			})
-			Expect(err).NotTo(HaveOccurred())
-			Expect(result.RequeueAfter).To(BeZero())
 
-			// Second reconcile performs actual reconciliation
-			result, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
-				NamespacedName: typeNamespacedName,
-			})
-			Expect(err).NotTo(HaveOccurred())
-			Expect(result.RequeueAfter).To(Equal(5 * time.Minute)) // 5 minutes
+			It("should reject an inline Authorization header", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "http-inline-authorization",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval: "5m",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "http",
+							HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+								URL:     "https://api.example.com/config",
+								Headers: map[string]string{"authorization": "Bearer inline"},
+							},
+						},
+					},
+				}
 
-			By("verifying the status was updated")
-			var updatedResource sourcev1alpha1.ExternalSource
-			Expect(k8sClient.Get(ctx, typeNamespacedName, &updatedResource)).To(Succeed())
-			Expect(updatedResource.Status.ObservedGeneration).To(Equal(updatedResource.Generation))
-			Expect(updatedResource.Status.Conditions).NotTo(BeEmpty())
+				err := k8sClient.Create(ctx, externalSource)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Authorization must not be set inline"))
+			})
 
-			By("cleaning up the resource")
-			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
-		})
+			It("should reject Git generator without git configuration", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "git-no-config",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval: "5m",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "git",
+						},
+					},
+				}
 
-		It("should handle suspended resources", func() {
-			resourceName := "test-reconcile-suspended"
-			typeNamespacedName := types.NamespacedName{
-				Name:      resourceName,
-				Namespace: "default",
-			}
+				err := k8sClient.Create(ctx, externalSource)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("git configuration is required"))
+			})
 
-			By("creating a suspended ExternalSource resource")
-			resource := &sourcev1alpha1.ExternalSource{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      resourceName,
-					Namespace: "default",
-				},
-				Spec: sourcev1alpha1.ExternalSourceSpec{
-					Interval: "5m",
-					Suspend:  true,
-					Generator: sourcev1alpha1.GeneratorSpec{
-						Type: "http",
-						HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
-							URL: "https://api.example.com/config",
+			It("should reject file generator without file configuration", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "file-no-config",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval: "5m",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "file",
 						},
 					},
-				},
-			}
-			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+				}
 
-			By("reconciling the suspended resource")
-			mockFactory := NewMockGeneratorFactory()
-			mockHookExecutor := &MockHookExecutor{}
-			mockArtifactManager := &MockArtifactManager{}
+				err := k8sClient.Create(ctx, externalSource)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("file configuration is required"))
+			})
 
-			controllerReconciler := &ExternalSourceReconciler{
-				Client:           k8sClient,
-				Scheme:           k8sClient.Scheme(),
-				Config:           createTestConfig(),
-				GeneratorFactory: mockFactory,
-				HookExecutor:     mockHookExecutor,
-				ArtifactManager:  mockArtifactManager,
-			}
+			It("should reject S3 generator without s3 configuration", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "s3-no-config",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval: "5m",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "s3",
+						},
+					},
+				}
 
-			// First reconcile adds finalizer and requeues
-			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
-				NamespacedName: typeNamespacedName,
+				err := k8sClient.Create(ctx, externalSource)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("s3 configuration is required"))
 			})
-			Expect(err).NotTo(HaveOccurred())
-			Expect(result.RequeueAfter).To(BeZero())
 
-			// Second reconcile handles suspension
-			result, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
-				NamespacedName: typeNamespacedName,
-			})
-			Expect(err).NotTo(HaveOccurred())
-			Expect(result.RequeueAfter).To(BeZero())
+			It("should reject gRPC generator without grpc configuration", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "grpc-no-config",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval: "5m",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "grpc",
+						},
+					},
+				}
 
-			By("verifying the suspended condition")
-			var updatedResource sourcev1alpha1.ExternalSource
-			Expect(k8sClient.Get(ctx, typeNamespacedName, &updatedResource)).To(Succeed())
+				err := k8sClient.Create(ctx, externalSource)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("grpc configuration is required"))
+			})
 
-			readyCondition := findCondition(updatedResource.Status.Conditions, ReadyCondition)
-			Expect(readyCondition).NotTo(BeNil())
-			Expect(readyCondition.Status).To(Equal(metav1.ConditionFalse))
-			Expect(readyCondition.Reason).To(Equal(SuspendedReason))
+			It("should reject ConfigMap generator without configMap configuration", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "configmap-no-config",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval: "5m",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "configMap",
+						},
+					},
+				}
 
-			By("cleaning up the resource")
-			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
-		})
-	})
-})
+				err := k8sClient.Create(ctx, externalSource)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("configMap configuration is required"))
+			})
 
-// Mock implementations for testing
+			It("should reject HTTP URL placeholders beyond name, namespace and labels", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "templated-url-function",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval: "5m",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "http",
+							HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+								URL: `https://api.example.com/{{ printf "%s" .Name }}`,
+							},
+						},
+					},
+				}
 
-// MockSourceGenerator implements generator.SourceGenerator for testing
-type MockSourceGenerator struct {
-	GenerateFunc                 func(ctx context.Context, config generator.GeneratorConfig) (*generator.SourceData, error)
-	SupportsConditionalFetchFunc func() bool
-	GetLastModifiedFunc          func(ctx context.Context, config generator.GeneratorConfig) (string, error)
-}
+				err := k8sClient.Create(ctx, externalSource)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("url placeholders may only reference"))
+			})
 
-func (m *MockSourceGenerator) Generate(ctx context.Context, genConfig generator.GeneratorConfig) (*generator.SourceData, error) {
-	if m.GenerateFunc != nil {
-		return m.GenerateFunc(ctx, genConfig)
-	}
-	return &generator.SourceData{
-		Data:         []byte(`{"test": "data"}`),
-		LastModified: "test-etag",
-		Metadata:     map[string]string{"content-type": "application/json"},
-	}, nil
-}
+			It("should reject destination path placeholders beyond name and namespace", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "templated-destination-path",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval:        "5m",
+						DestinationPath: "{{ .Labels.app }}.json",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "http",
+							HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+								URL: "https://api.example.com/data",
+							},
+						},
+					},
+				}
 
-func (m *MockSourceGenerator) SupportsConditionalFetch() bool {
-	if m.SupportsConditionalFetchFunc != nil {
-		return m.SupportsConditionalFetchFunc()
-	}
-	return true
-}
+				err := k8sClient.Create(ctx, externalSource)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("destinationPath placeholders may only reference"))
+			})
 
-func (m *MockSourceGenerator) GetLastModified(ctx context.Context, genConfig generator.GeneratorConfig) (string, error) {
-	if m.GetLastModifiedFunc != nil {
-		return m.GetLastModifiedFunc(ctx, genConfig)
-	}
-	return "test-etag", nil
-}
+			It("should reject a destination path escaping the artifact root", func() {
+				for _, path := range []string{"../config.json", "/etc/config.json"} {
+					externalSource := &sourcev1alpha1.ExternalSource{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "escaping-destination-path",
+							Namespace: "default",
+						},
+						Spec: sourcev1alpha1.ExternalSourceSpec{
+							Interval:        "5m",
+							DestinationPath: path,
+							Generator: sourcev1alpha1.GeneratorSpec{
+								Type: "http",
+								HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+									URL: "https://api.example.com/data",
+								},
+							},
+						},
+					}
 
-// MockGeneratorFactory implements generator.SourceGeneratorFactory for testing
-type MockGeneratorFactory struct {
-	CreateGeneratorFunc   func(generatorType string) (generator.SourceGenerator, error)
-	RegisterGeneratorFunc func(generatorType string, factory func() generator.SourceGenerator) error
-	SupportedTypesFunc    func() []string
-	generators            map[string]func() generator.SourceGenerator
-}
+					err := k8sClient.Create(ctx, externalSource)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("destinationPath must be relative and must not contain '..'"))
+				}
+			})
 
-func NewMockGeneratorFactory() *MockGeneratorFactory {
-	return &MockGeneratorFactory{
-		generators: make(map[string]func() generator.SourceGenerator),
-	}
-}
+			It("should reject an appending range that is not incremental", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "append-range",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval: "5m",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "http",
+							HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+								URL:   "https://api.example.com/app.log",
+								Range: &sourcev1alpha1.HTTPRangeSpec{Mode: "Append"},
+							},
+						},
+					},
+				}
 
-func (m *MockGeneratorFactory) CreateGenerator(generatorType string) (generator.SourceGenerator, error) {
-	if m.CreateGeneratorFunc != nil {
-		return m.CreateGeneratorFunc(generatorType)
-	}
-	if factory, exists := m.generators[generatorType]; exists {
-		return factory(), nil
-	}
-	return nil, fmt.Errorf("unsupported generator type: %s", generatorType)
-}
+				err := k8sClient.Create(ctx, externalSource)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Append mode requires incremental"))
+			})
 
-func (m *MockGeneratorFactory) RegisterGenerator(generatorType string, factory func() generator.SourceGenerator) error {
-	if m.RegisterGeneratorFunc != nil {
-		return m.RegisterGeneratorFunc(generatorType, factory)
-	}
-	m.generators[generatorType] = factory
-	return nil
-}
+			It("should reject headers secret with both keys and keyPrefix", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "headers-keys-and-prefix",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval: "5m",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "http",
+							HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+								URL: "https://api.example.com/data",
+								HeadersSecretRef: &sourcev1alpha1.HeadersSecretReference{
+									Name:      "shared-secret",
+									Keys:      []string{"X-Api-Key"},
+									KeyPrefix: "header-",
+								},
+							},
+						},
+					},
+				}
 
-func (m *MockGeneratorFactory) SupportedTypes() []string {
-	if m.SupportedTypesFunc != nil {
-		return m.SupportedTypesFunc()
-	}
-	supportedTypes := make([]string, 0, len(m.generators))
-	for t := range m.generators {
-		supportedTypes = append(supportedTypes, t)
-	}
-	return supportedTypes
-}
+				err := k8sClient.Create(ctx, externalSource)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("only one of keys or keyPrefix may be set"))
+			})
 
-// MockHookExecutor implements hooks.HookExecutor for testing
-type MockHookExecutor struct {
-	ExecuteFunc func(ctx context.Context, input []byte, hook sourcev1alpha1.HookSpec) ([]byte, error)
-}
+			It("should reject Git generator with both branch and tag", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "git-branch-and-tag",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval: "5m",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "git",
+							Git: &sourcev1alpha1.GitGeneratorSpec{
+								URL: "https://github.com/example/config.git",
+								Ref: &sourcev1alpha1.GitRepositoryRef{
+									Branch: "main",
+									Tag:    "v1.0.0",
+								},
+								Path: "config/app.json",
+							},
+						},
+					},
+				}
 
-// Ensure MockHookExecutor implements hooks.HookExecutor
-var _ hooks.HookExecutor = (*MockHookExecutor)(nil)
+				err := k8sClient.Create(ctx, externalSource)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("only one of branch or tag"))
+			})
 
-func (m *MockHookExecutor) Execute(ctx context.Context, input []byte, hook sourcev1alpha1.HookSpec) ([]byte, error) {
-	if m.ExecuteFunc != nil {
-		return m.ExecuteFunc(ctx, input, hook)
-	}
-	// Default behavior just returns the input
-	return input, nil
-}
+			It("should reject a revision parameter without a revision", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "invalid-revision-param",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval: "5m",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "http",
+							HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+								URL:           "https://api.example.com/config",
+								RevisionParam: "release",
+							},
+						},
+					},
+				}
 
-// MockArtifactManager implements artifact.ArtifactManager for testing
-type MockArtifactManager struct {
-	PackageFunc func(ctx context.Context, data []byte, path string) (*artifact.Artifact, error)
-	StoreFunc   func(ctx context.Context, artifact *artifact.Artifact, source string) (string, error)
-	CleanupFunc func(ctx context.Context, source string, keepRevision string) error
-}
+				err := k8sClient.Create(ctx, externalSource)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("revisionParam requires revision"))
+			})
 
-func (m *MockArtifactManager) Package(ctx context.Context, data []byte, path string) (*artifact.Artifact, error) {
-	if m.PackageFunc != nil {
-		return m.PackageFunc(ctx, data, path)
-	}
-	return &artifact.Artifact{
-		Data:     data,
-		Path:     path,
-		Revision: "test-revision-123",
-		Metadata: map[string]string{"size": fmt.Sprintf("%d", len(data))},
-	}, nil
-}
+			It("should reject a cursor sent in both a header and a query parameter", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "invalid-cursor",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval: "5m",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "http",
+							HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+								URL: "https://api.example.com/events",
+								Cursor: &sourcev1alpha1.HTTPCursorSpec{
+									JSONPath:   "{.next}",
+									Header:     "X-Cursor",
+									QueryParam: "after",
+								},
+							},
+						},
+					},
+				}
 
-func (m *MockArtifactManager) Store(ctx context.Context, art *artifact.Artifact, source string) (string, error) {
-	if m.StoreFunc != nil {
-		return m.StoreFunc(ctx, art, source)
-	}
-	return fmt.Sprintf("https://storage.example.com/%s/%s", source, art.Revision), nil
-}
+				err := k8sClient.Create(ctx, externalSource)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("exactly one of header or queryParam must be set"))
+			})
 
-func (m *MockArtifactManager) Cleanup(ctx context.Context, source string, keepRevision string) error {
-	if m.CleanupFunc != nil {
-		return m.CleanupFunc(ctx, source, keepRevision)
-	}
-	return nil
-}
+			It("should reject a maintenance window with an @every schedule", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "invalid-maintenance-window",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval: "5m",
+						MaintenanceWindows: []sourcev1alpha1.MaintenanceWindow{
+							{Schedule: "@every 1h", Duration: "10m"},
+						},
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "http",
+							HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+								URL: "https://api.example.com/config",
+							},
+						},
+					},
+				}
 
-// Integration tests for reconciliation logic
-var _ = Describe("ExternalSource Controller Integration", func() {
-	Context("Complete reconciliation flow", func() {
-		var (
-			ctx                 context.Context
-			mockFactory         *MockGeneratorFactory
-			mockHookExecutor    *MockHookExecutor
-			mockArtifactManager *MockArtifactManager
-			reconciler          *ExternalSourceReconciler
-		)
+				err := k8sClient.Create(ctx, externalSource)
+				Expect(err).To(HaveOccurred())
+			})
 
-		BeforeEach(func() {
-			ctx = context.Background()
-			mockFactory = NewMockGeneratorFactory()
-			mockHookExecutor = &MockHookExecutor{}
-			mockArtifactManager = &MockArtifactManager{}
+			It("should reject invalid transformation type", func() {
+				externalSource := &sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "invalid-hook-policy",
+						Namespace: "default",
+					},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Interval: "5m",
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "http",
+							HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+								URL: "https://api.example.com/config",
+							},
+						},
+						Hooks: &sourcev1alpha1.HooksSpec{
+							PostRequest: []sourcev1alpha1.HookSpec{
+								{
+									Name:        "invalid-hook",
+									Command:     "jq",
+									Args:        []string{".data"},
+									RetryPolicy: "invalid", // Not in enum
+								},
+							},
+						},
+					},
+				}
 
-			reconciler = &ExternalSourceReconciler{
-				Client:           k8sClient,
-				Scheme:           k8sClient.Scheme(),
-				Config:           createTestConfig(),
-				GeneratorFactory: mockFactory,
-				HookExecutor:     mockHookExecutor,
-				ArtifactManager:  mockArtifactManager,
-			}
+				err := k8sClient.Create(ctx, externalSource)
+				Expect(err).To(HaveOccurred())
+			})
 
-			// Register mock HTTP generator
-			Expect(mockFactory.RegisterGenerator("http", func() generator.SourceGenerator {
-				return &MockSourceGenerator{}
-			})).To(Succeed())
 		})
+	})
 
-		It("should successfully complete full reconciliation flow", func() {
-			resourceName := "test-full-reconciliation"
+	Context("When reconciling a resource", func() {
+		ctx := context.Background()
+
+		It("should successfully reconcile the resource", func() {
+			resourceName := "test-reconcile-success"
 			typeNamespacedName := types.NamespacedName{
 				Name:      resourceName,
 				Namespace: "default",
 			}
 
-			By("creating an ExternalSource resource")
+			By("creating a valid ExternalSource resource")
 			resource := &sourcev1alpha1.ExternalSource{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      resourceName,
@@ -619,80 +980,61 @@ var _ = Describe("ExternalSource Controller Integration", func() {
 							URL: "https://api.example.com/config",
 						},
 					},
-					Hooks: &sourcev1alpha1.HooksSpec{
-						PostRequest: []sourcev1alpha1.HookSpec{
-							{
-								Name:        "extract-config",
-								Command:     "jq",
-								Args:        []string{".config"},
-								Timeout:     "30s",
-								RetryPolicy: "fail",
-							},
-						},
-					},
-					MaxRetries:      3,
-					DestinationPath: "config/app.json",
 				},
 			}
 			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
 
-			By("performing reconciliation")
-			// First reconcile adds finalizer
-			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			By("reconciling the created resource")
+			mockFactory := NewMockGeneratorFactory()
+			mockHookExecutor := &MockHookExecutor{}
+			mockArtifactManager := &MockArtifactManager{}
+
+			// Register mock HTTP generator
+			Expect(mockFactory.RegisterGenerator("http", func() generator.SourceGenerator {
+				return &MockSourceGenerator{}
+			})).To(Succeed())
+
+			controllerReconciler := &ExternalSourceReconciler{
+				Client:           k8sClient,
+				Scheme:           k8sClient.Scheme(),
+				Config:           createTestConfig(),
+				GeneratorFactory: mockFactory,
+				HookExecutor:     mockHookExecutor,
+				ArtifactManager:  mockArtifactManager,
+			}
+
+			// First reconcile adds finalizer and requeues
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(result.RequeueAfter).To(BeZero())
 
-			// Second reconcile performs actual work
-			result, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			// Second reconcile performs actual reconciliation
+			result, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+			Expect(result.RequeueAfter).To(Equal(5 * time.Minute)) // 5 minutes
 
-			By("verifying the status was updated correctly")
+			By("verifying the status was updated")
 			var updatedResource sourcev1alpha1.ExternalSource
 			Expect(k8sClient.Get(ctx, typeNamespacedName, &updatedResource)).To(Succeed())
-
-			// Check conditions
-			readyCondition := findCondition(updatedResource.Status.Conditions, ReadyCondition)
-			Expect(readyCondition).NotTo(BeNil())
-			Expect(readyCondition.Status).To(Equal(metav1.ConditionTrue))
-			Expect(readyCondition.Reason).To(Equal(SucceededReason))
-
-			// Check artifact metadata
-			Expect(updatedResource.Status.Artifact).NotTo(BeNil())
-			Expect(updatedResource.Status.Artifact.URL).To(ContainSubstring("storage.example.com"))
-			Expect(updatedResource.Status.Artifact.Revision).To(Equal("test-revision-123"))
-
-			// Check ETag was stored
-			Expect(updatedResource.Status.LastHandledETag).To(Equal("test-etag"))
-
-			By("verifying ExternalArtifact child resource was created")
-			var externalArtifact sourcev1.ExternalArtifact
-			artifactKey := types.NamespacedName{
-				Name:      resourceName,
-				Namespace: "default",
-			}
-			Expect(k8sClient.Get(ctx, artifactKey, &externalArtifact)).To(Succeed())
-			// Verify sourceRef points to the ExternalSource
-			Expect(externalArtifact.Spec.SourceRef).NotTo(BeNil())
-			Expect(externalArtifact.Spec.SourceRef.Kind).To(Equal("ExternalSource"))
-			Expect(externalArtifact.Spec.SourceRef.Name).To(Equal(resourceName))
-			// Verify artifact data is in status
-			Expect(externalArtifact.Status.Artifact).NotTo(BeNil())
-			Expect(externalArtifact.Status.Artifact.URL).To(Equal(updatedResource.Status.Artifact.URL))
-			Expect(externalArtifact.Status.Artifact.Revision).To(Equal(updatedResource.Status.Artifact.Revision))
+			Expect(updatedResource.Status.ObservedGeneration).To(Equal(updatedResource.Generation))
+			Expect(updatedResource.Status.Conditions).NotTo(BeEmpty())
 
 			By("cleaning up the resource")
 			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
 		})
 
-		It("should handle conditional fetching with unchanged ETag", func() {
-			resourceName := "test-conditional-fetch"
+		It("should handle suspended resources", func() {
+			resourceName := "test-reconcile-suspended"
 			typeNamespacedName := types.NamespacedName{
 				Name:      resourceName,
 				Namespace: "default",
 			}
 
-			By("creating an ExternalSource resource with existing ETag")
+			By("creating a suspended ExternalSource resource")
 			resource := &sourcev1alpha1.ExternalSource{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      resourceName,
@@ -700,6 +1042,7 @@ var _ = Describe("ExternalSource Controller Integration", func() {
 				},
 				Spec: sourcev1alpha1.ExternalSourceSpec{
 					Interval: "5m",
+					Suspend:  true,
 					Generator: sourcev1alpha1.GeneratorSpec{
 						Type: "http",
 						HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
@@ -707,52 +1050,237 @@ var _ = Describe("ExternalSource Controller Integration", func() {
 						},
 					},
 				},
-				Status: sourcev1alpha1.ExternalSourceStatus{
-					LastHandledETag: "test-etag", // Same as mock will return
-				},
 			}
 			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
 
-			By("performing reconciliation")
-			// First reconcile adds finalizer
-			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			By("reconciling the suspended resource")
+			mockFactory := NewMockGeneratorFactory()
+			mockHookExecutor := &MockHookExecutor{}
+			mockArtifactManager := &MockArtifactManager{}
+
+			controllerReconciler := &ExternalSourceReconciler{
+				Client:           k8sClient,
+				Scheme:           k8sClient.Scheme(),
+				Config:           createTestConfig(),
+				GeneratorFactory: mockFactory,
+				HookExecutor:     mockHookExecutor,
+				ArtifactManager:  mockArtifactManager,
+			}
+
+			// First reconcile adds finalizer and requeues
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(result.RequeueAfter).To(BeZero())
 
-			// Second reconcile should skip fetch due to unchanged ETag
-			result, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			// Second reconcile handles suspension
+			result, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+			Expect(result.RequeueAfter).To(BeZero())
 
-			By("verifying no new artifact was created")
+			By("verifying the suspended condition")
 			var updatedResource sourcev1alpha1.ExternalSource
 			Expect(k8sClient.Get(ctx, typeNamespacedName, &updatedResource)).To(Succeed())
 
 			readyCondition := findCondition(updatedResource.Status.Conditions, ReadyCondition)
 			Expect(readyCondition).NotTo(BeNil())
-			Expect(readyCondition.Status).To(Equal(metav1.ConditionTrue))
-			Expect(readyCondition.Message).To(Equal("ExternalSource is ready"))
+			Expect(readyCondition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(readyCondition.Reason).To(Equal(SuspendedReason))
 
 			By("cleaning up the resource")
 			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
 		})
+	})
+})
 
-		It("should handle generator errors with retry logic", func() {
-			resourceName := "test-generator-error"
+// Mock implementations for testing
+
+// MockSourceGenerator implements generator.SourceGenerator for testing
+type MockSourceGenerator struct {
+	GenerateFunc                 func(ctx context.Context, config generator.GeneratorConfig) (*generator.SourceData, error)
+	SupportsConditionalFetchFunc func() bool
+	GetLastModifiedFunc          func(ctx context.Context, config generator.GeneratorConfig) (string, error)
+}
+
+func (m *MockSourceGenerator) Generate(ctx context.Context, genConfig generator.GeneratorConfig) (*generator.SourceData, error) {
+	if m.GenerateFunc != nil {
+		return m.GenerateFunc(ctx, genConfig)
+	}
+	return &generator.SourceData{
+		Data:         []byte(`{"test": "data"}`),
+		LastModified: "test-etag",
+		Metadata:     map[string]string{"content-type": "application/json"},
+	}, nil
+}
+
+func (m *MockSourceGenerator) SupportsConditionalFetch() bool {
+	if m.SupportsConditionalFetchFunc != nil {
+		return m.SupportsConditionalFetchFunc()
+	}
+	return true
+}
+
+func (m *MockSourceGenerator) GetLastModified(ctx context.Context, genConfig generator.GeneratorConfig) (string, error) {
+	if m.GetLastModifiedFunc != nil {
+		return m.GetLastModifiedFunc(ctx, genConfig)
+	}
+	return "test-etag", nil
+}
+
+// MockConditionalSourceGenerator is a MockSourceGenerator that handles conditional requests in Generate
+type MockConditionalSourceGenerator struct {
+	MockSourceGenerator
+}
+
+func (m *MockConditionalSourceGenerator) SupportsConditionalRequest() bool {
+	return true
+}
+
+// MockGeneratorFactory implements generator.SourceGeneratorFactory for testing
+type MockGeneratorFactory struct {
+	CreateGeneratorFunc   func(generatorType string) (generator.SourceGenerator, error)
+	RegisterGeneratorFunc func(generatorType string, factory func() generator.SourceGenerator) error
+	SupportedTypesFunc    func() []string
+	generators            map[string]func() generator.SourceGenerator
+}
+
+func NewMockGeneratorFactory() *MockGeneratorFactory {
+	return &MockGeneratorFactory{
+		generators: make(map[string]func() generator.SourceGenerator),
+	}
+}
+
+func (m *MockGeneratorFactory) CreateGenerator(generatorType string) (generator.SourceGenerator, error) {
+	if m.CreateGeneratorFunc != nil {
+		return m.CreateGeneratorFunc(generatorType)
+	}
+	if factory, exists := m.generators[generatorType]; exists {
+		return factory(), nil
+	}
+	return nil, fmt.Errorf("unsupported generator type: %s", generatorType)
+}
+
+func (m *MockGeneratorFactory) RegisterGenerator(generatorType string, factory func() generator.SourceGenerator) error {
+	if m.RegisterGeneratorFunc != nil {
+		return m.RegisterGeneratorFunc(generatorType, factory)
+	}
+	m.generators[generatorType] = factory
+	return nil
+}
+
+func (m *MockGeneratorFactory) SupportedTypes() []string {
+	if m.SupportedTypesFunc != nil {
+		return m.SupportedTypesFunc()
+	}
+	supportedTypes := make([]string, 0, len(m.generators))
+	for t := range m.generators {
+		supportedTypes = append(supportedTypes, t)
+	}
+	return supportedTypes
+}
+
+// MockHookExecutor implements hooks.HookExecutor for testing
+type MockHookExecutor struct {
+	ExecuteFunc func(ctx context.Context, input []byte, hook sourcev1alpha1.HookSpec) ([]byte, error)
+}
+
+// Ensure MockHookExecutor implements hooks.HookExecutor
+var _ hooks.HookExecutor = (*MockHookExecutor)(nil)
+
+func (m *MockHookExecutor) Execute(ctx context.Context, input []byte, hook sourcev1alpha1.HookSpec) ([]byte, error) {
+	if m.ExecuteFunc != nil {
+		return m.ExecuteFunc(ctx, input, hook)
+	}
+	// Default behavior just returns the input
+	return input, nil
+}
+
+// MockArtifactManager implements artifact.ArtifactManager for testing
+type MockArtifactManager struct {
+	PackageFunc      func(ctx context.Context, data []byte, path string) (*artifact.Artifact, error)
+	PackageFilesFunc func(ctx context.Context, files map[string][]byte) (*artifact.Artifact, error)
+	StoreFunc        func(ctx context.Context, artifact *artifact.Artifact, source string) (string, error)
+	CleanupFunc      func(ctx context.Context, source string, keepRevision string, historyLimit int) error
+}
+
+func (m *MockArtifactManager) Package(ctx context.Context, data []byte, path string) (*artifact.Artifact, error) {
+	if m.PackageFunc != nil {
+		return m.PackageFunc(ctx, data, path)
+	}
+	return &artifact.Artifact{
+		Data:     data,
+		Path:     path,
+		Revision: "test-revision-123",
+		Metadata: map[string]string{"size": fmt.Sprintf("%d", len(data))},
+	}, nil
+}
+
+func (m *MockArtifactManager) PackageFiles(ctx context.Context, files map[string][]byte) (*artifact.Artifact, error) {
+	if m.PackageFilesFunc != nil {
+		return m.PackageFilesFunc(ctx, files)
+	}
+	return &artifact.Artifact{
+		Revision: "test-revision-123",
+		Metadata: map[string]string{"files": fmt.Sprintf("%d", len(files))},
+	}, nil
+}
+
+func (m *MockArtifactManager) Store(ctx context.Context, art *artifact.Artifact, source string) (string, error) {
+	if m.StoreFunc != nil {
+		return m.StoreFunc(ctx, art, source)
+	}
+	return fmt.Sprintf("https://storage.example.com/%s/%s", source, art.Revision), nil
+}
+
+func (m *MockArtifactManager) Cleanup(ctx context.Context, source string, keepRevision string, historyLimit int) error {
+	if m.CleanupFunc != nil {
+		return m.CleanupFunc(ctx, source, keepRevision, historyLimit)
+	}
+	return nil
+}
+
+// Integration tests for reconciliation logic
+var _ = Describe("ExternalSource Controller Integration", func() {
+	Context("Complete reconciliation flow", func() {
+		var (
+			ctx                 context.Context
+			mockFactory         *MockGeneratorFactory
+			mockHookExecutor    *MockHookExecutor
+			mockArtifactManager *MockArtifactManager
+			reconciler          *ExternalSourceReconciler
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			mockFactory = NewMockGeneratorFactory()
+			mockHookExecutor = &MockHookExecutor{}
+			mockArtifactManager = &MockArtifactManager{}
+
+			reconciler = &ExternalSourceReconciler{
+				Client:           k8sClient,
+				Scheme:           k8sClient.Scheme(),
+				Config:           createTestConfig(),
+				GeneratorFactory: mockFactory,
+				HookExecutor:     mockHookExecutor,
+				ArtifactManager:  mockArtifactManager,
+			}
+
+			// Register mock HTTP generator
+			Expect(mockFactory.RegisterGenerator("http", func() generator.SourceGenerator {
+				return &MockSourceGenerator{}
+			})).To(Succeed())
+		})
+
+		It("should successfully complete full reconciliation flow", func() {
+			resourceName := "test-full-reconciliation"
 			typeNamespacedName := types.NamespacedName{
 				Name:      resourceName,
 				Namespace: "default",
 			}
 
-			By("setting up mock generator to fail")
-			mockFactory.CreateGeneratorFunc = func(generatorType string) (generator.SourceGenerator, error) {
-				return &MockSourceGenerator{
-					GenerateFunc: func(ctx context.Context, config generator.GeneratorConfig) (*generator.SourceData, error) {
-						return nil, fmt.Errorf("network error")
-					},
-				}, nil
-			}
-
 			By("creating an ExternalSource resource")
 			resource := &sourcev1alpha1.ExternalSource{
 				ObjectMeta: metav1.ObjectMeta{
@@ -767,14 +1295,297 @@ var _ = Describe("ExternalSource Controller Integration", func() {
 							URL: "https://api.example.com/config",
 						},
 					},
-				},
-			}
-			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
-
-			By("performing reconciliation")
-			// First reconcile adds finalizer
-			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
-			Expect(err).NotTo(HaveOccurred())
+					Hooks: &sourcev1alpha1.HooksSpec{
+						PostRequest: []sourcev1alpha1.HookSpec{
+							{
+								Name:        "extract-config",
+								Command:     "jq",
+								Args:        []string{".config"},
+								Timeout:     "30s",
+								RetryPolicy: "fail",
+							},
+						},
+					},
+					MaxRetries:      3,
+					DestinationPath: "config/app.json",
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			By("performing reconciliation")
+			// First reconcile adds finalizer
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			// Second reconcile performs actual work
+			result, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+
+			By("verifying the status was updated correctly")
+			var updatedResource sourcev1alpha1.ExternalSource
+			Expect(k8sClient.Get(ctx, typeNamespacedName, &updatedResource)).To(Succeed())
+
+			// Check conditions
+			readyCondition := findCondition(updatedResource.Status.Conditions, ReadyCondition)
+			Expect(readyCondition).NotTo(BeNil())
+			Expect(readyCondition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(readyCondition.Reason).To(Equal(SucceededReason))
+
+			// Check artifact metadata
+			Expect(updatedResource.Status.Artifact).NotTo(BeNil())
+			Expect(updatedResource.Status.Artifact.URL).To(ContainSubstring("storage.example.com"))
+			Expect(updatedResource.Status.Artifact.Revision).To(Equal("test-revision-123"))
+
+			// Check ETag was stored
+			Expect(updatedResource.Status.LastHandledETag).To(Equal("test-etag"))
+
+			By("verifying ExternalArtifact child resource was created")
+			var externalArtifact sourcev1.ExternalArtifact
+			artifactKey := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: "default",
+			}
+			Expect(k8sClient.Get(ctx, artifactKey, &externalArtifact)).To(Succeed())
+			// Verify sourceRef points to the ExternalSource
+			Expect(externalArtifact.Spec.SourceRef).NotTo(BeNil())
+			Expect(externalArtifact.Spec.SourceRef.Kind).To(Equal("ExternalSource"))
+			Expect(externalArtifact.Spec.SourceRef.Name).To(Equal(resourceName))
+			// Verify artifact data is in status
+			Expect(externalArtifact.Status.Artifact).NotTo(BeNil())
+			Expect(externalArtifact.Status.Artifact.URL).To(Equal(updatedResource.Status.Artifact.URL))
+			Expect(externalArtifact.Status.Artifact.Revision).To(Equal(updatedResource.Status.Artifact.Revision))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should handle conditional fetching with unchanged ETag", func() {
+			resourceName := "test-conditional-fetch"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: "default",
+			}
+
+			By("creating an ExternalSource resource with existing ETag")
+			resource := &sourcev1alpha1.ExternalSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: sourcev1alpha1.ExternalSourceSpec{
+					Interval: "5m",
+					Generator: sourcev1alpha1.GeneratorSpec{
+						Type: "http",
+						HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+							URL: "https://api.example.com/config",
+						},
+					},
+				},
+				Status: sourcev1alpha1.ExternalSourceStatus{
+					LastHandledETag: "test-etag", // Same as mock will return
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			By("performing reconciliation")
+			// First reconcile adds finalizer
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			// Second reconcile should skip fetch due to unchanged ETag
+			result, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+
+			By("verifying no new artifact was created")
+			var updatedResource sourcev1alpha1.ExternalSource
+			Expect(k8sClient.Get(ctx, typeNamespacedName, &updatedResource)).To(Succeed())
+
+			readyCondition := findCondition(updatedResource.Status.Conditions, ReadyCondition)
+			Expect(readyCondition).NotTo(BeNil())
+			Expect(readyCondition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(readyCondition.Message).To(Equal("ExternalSource is ready"))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should skip artifact updates when the generator reports not modified", func() {
+			resourceName := "test-not-modified"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: "default",
+			}
+
+			By("creating an ExternalSource resource")
+			resource := &sourcev1alpha1.ExternalSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: sourcev1alpha1.ExternalSourceSpec{
+					Interval: "5m",
+					Generator: sourcev1alpha1.GeneratorSpec{
+						Type: "http",
+						HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+							URL: "https://api.example.com/config",
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			By("performing the initial reconciliation")
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("switching to a generator that answers with not modified")
+			var receivedLastModified string
+			mockFactory.CreateGeneratorFunc = func(generatorType string) (generator.SourceGenerator, error) {
+				return &MockConditionalSourceGenerator{
+					MockSourceGenerator: MockSourceGenerator{
+						GenerateFunc: func(ctx context.Context, config generator.GeneratorConfig) (*generator.SourceData, error) {
+							receivedLastModified = config.LastModified
+							return &generator.SourceData{LastModified: config.LastModified, NotModified: true}, nil
+						},
+						GetLastModifiedFunc: func(ctx context.Context, config generator.GeneratorConfig) (string, error) {
+							return "", fmt.Errorf("GetLastModified should not be called")
+						},
+					},
+				}, nil
+			}
+			packageCalled := false
+			mockArtifactManager.PackageFunc = func(ctx context.Context, data []byte, path string) (*artifact.Artifact, error) {
+				packageCalled = true
+				return nil, fmt.Errorf("package should not be called")
+			}
+
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(receivedLastModified).To(Equal("test-etag"))
+			Expect(packageCalled).To(BeFalse())
+
+			var updatedResource sourcev1alpha1.ExternalSource
+			Expect(k8sClient.Get(ctx, typeNamespacedName, &updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.Artifact).NotTo(BeNil())
+			readyCondition := findCondition(updatedResource.Status.Conditions, ReadyCondition)
+			Expect(readyCondition).NotTo(BeNil())
+			Expect(readyCondition.Status).To(Equal(metav1.ConditionTrue))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should force a fetch when a reconcile is requested", func() {
+			resourceName := "test-reconcile-request"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: "default",
+			}
+
+			By("creating an ExternalSource resource")
+			resource := &sourcev1alpha1.ExternalSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: sourcev1alpha1.ExternalSourceSpec{
+					Interval: "5m",
+					Generator: sourcev1alpha1.GeneratorSpec{
+						Type: "http",
+						HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+							URL: "https://api.example.com/config",
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			By("performing the initial reconciliation")
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("requesting a reconcile with an unchanged ETag")
+			generateCalls := 0
+			mockFactory.CreateGeneratorFunc = func(generatorType string) (generator.SourceGenerator, error) {
+				return &MockSourceGenerator{
+					GenerateFunc: func(ctx context.Context, config generator.GeneratorConfig) (*generator.SourceData, error) {
+						generateCalls++
+						return &generator.SourceData{Data: []byte(`{"test": "data"}`), LastModified: "test-etag"}, nil
+					},
+				}, nil
+			}
+
+			var current sourcev1alpha1.ExternalSource
+			Expect(k8sClient.Get(ctx, typeNamespacedName, &current)).To(Succeed())
+			if current.Annotations == nil {
+				current.Annotations = map[string]string{}
+			}
+			current.Annotations[fluxmeta.ReconcileRequestAnnotation] = "requested-1"
+			Expect(k8sClient.Update(ctx, &current)).To(Succeed())
+
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(generateCalls).To(Equal(1))
+
+			var updatedResource sourcev1alpha1.ExternalSource
+			Expect(k8sClient.Get(ctx, typeNamespacedName, &updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.LastHandledReconcileAt).To(Equal("requested-1"))
+
+			By("reconciling again without a new request")
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(generateCalls).To(Equal(1))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should handle generator errors with retry logic", func() {
+			resourceName := "test-generator-error"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: "default",
+			}
+
+			By("setting up mock generator to fail")
+			mockFactory.CreateGeneratorFunc = func(generatorType string) (generator.SourceGenerator, error) {
+				return &MockSourceGenerator{
+					GenerateFunc: func(ctx context.Context, config generator.GeneratorConfig) (*generator.SourceData, error) {
+						return nil, fmt.Errorf("network error")
+					},
+				}, nil
+			}
+
+			By("creating an ExternalSource resource")
+			resource := &sourcev1alpha1.ExternalSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: sourcev1alpha1.ExternalSourceSpec{
+					Interval: "5m",
+					Generator: sourcev1alpha1.GeneratorSpec{
+						Type: "http",
+						HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+							URL: "https://api.example.com/config",
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			By("performing reconciliation")
+			// First reconcile adds finalizer
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
 			Expect(result.RequeueAfter).To(BeZero())
 
 			// Second reconcile should fail and schedule retry
@@ -1286,6 +2097,14 @@ type MockMetricsRecorder struct {
 	RecordArtifactOperationCalls  []RecordArtifactOperationCall
 	IncActiveReconciliationsCalls []ActiveReconciliationCall
 	DecActiveReconciliationsCalls []ActiveReconciliationCall
+	RecordArtifactSizeCalls       []RecordArtifactSizeCall
+	RecordContentAgeCalls         []RecordContentAgeCall
+	RecordDataFreshCalls          []bool
+	DeleteSourceMetricsCalls      []ActiveReconciliationCall
+	RecordFetchRateLimitedCalls   []string
+	RecordNotificationCalls       []bool
+	RecordCircuitBreakerCalls     []bool
+	RecordReconcileStuckCalls     []ActiveReconciliationCall
 }
 
 type RecordReconciliationCall struct {
@@ -1311,6 +2130,7 @@ type RecordHookExecutionCall struct {
 
 type RecordArtifactOperationCall struct {
 	Operation string
+	Backend   string
 	Success   bool
 	Duration  time.Duration
 }
@@ -1320,6 +2140,18 @@ type ActiveReconciliationCall struct {
 	Name      string
 }
 
+type RecordArtifactSizeCall struct {
+	Namespace string
+	Name      string
+	SizeBytes int64
+}
+
+type RecordContentAgeCall struct {
+	Namespace string
+	Name      string
+	Age       time.Duration
+}
+
 func (m *MockMetricsRecorder) RecordReconciliation(namespace, name, sourceType string, success bool, duration time.Duration) {
 	m.RecordReconciliationCalls = append(m.RecordReconciliationCalls, RecordReconciliationCall{
 		Namespace:  namespace,
@@ -1347,9 +2179,10 @@ func (m *MockMetricsRecorder) RecordHookExecution(hookName, retryPolicy string,
 	})
 }
 
-func (m *MockMetricsRecorder) RecordArtifactOperation(operation string, success bool, duration time.Duration) {
+func (m *MockMetricsRecorder) RecordArtifactOperation(operation, backend string, success bool, duration time.Duration) {
 	m.RecordArtifactOperationCalls = append(m.RecordArtifactOperationCalls, RecordArtifactOperationCall{
 		Operation: operation,
+		Backend:   backend,
 		Success:   success,
 		Duration:  duration,
 	})
@@ -1369,6 +2202,52 @@ func (m *MockMetricsRecorder) DecActiveReconciliations(namespace, name string) {
 	})
 }
 
+func (m *MockMetricsRecorder) RecordArtifactSize(namespace, name string, sizeBytes int64) {
+	m.RecordArtifactSizeCalls = append(m.RecordArtifactSizeCalls, RecordArtifactSizeCall{
+		Namespace: namespace,
+		Name:      name,
+		SizeBytes: sizeBytes,
+	})
+}
+
+func (m *MockMetricsRecorder) RecordContentAge(namespace, name string, age time.Duration) {
+	m.RecordContentAgeCalls = append(m.RecordContentAgeCalls, RecordContentAgeCall{
+		Namespace: namespace,
+		Name:      name,
+		Age:       age,
+	})
+}
+
+func (m *MockMetricsRecorder) RecordDataFresh(namespace, name string, fresh bool) {
+	m.RecordDataFreshCalls = append(m.RecordDataFreshCalls, fresh)
+}
+
+func (m *MockMetricsRecorder) DeleteSourceMetrics(namespace, name string) {
+	m.DeleteSourceMetricsCalls = append(m.DeleteSourceMetricsCalls, ActiveReconciliationCall{
+		Namespace: namespace,
+		Name:      name,
+	})
+}
+
+func (m *MockMetricsRecorder) RecordFetchRateLimited(sourceType string) {
+	m.RecordFetchRateLimitedCalls = append(m.RecordFetchRateLimitedCalls, sourceType)
+}
+
+func (m *MockMetricsRecorder) RecordNotification(namespace, name string, success bool) {
+	m.RecordNotificationCalls = append(m.RecordNotificationCalls, success)
+}
+
+func (m *MockMetricsRecorder) RecordCircuitBreakerState(host string, open bool) {
+	m.RecordCircuitBreakerCalls = append(m.RecordCircuitBreakerCalls, open)
+}
+
+func (m *MockMetricsRecorder) RecordReconcileStuck(namespace, name string) {
+	m.RecordReconcileStuckCalls = append(m.RecordReconcileStuckCalls, ActiveReconciliationCall{
+		Namespace: namespace,
+		Name:      name,
+	})
+}
+
 // Tests for error handling and resilience features
 var _ = Describe("ExternalSource Controller Error Handling and Resilience", func() {
 	Context("Exponential backoff retry logic", func() {
@@ -1402,6 +2281,9 @@ var _ = Describe("ExternalSource Controller Error Handling and Resilience", func
 			unsupportedErr := fmt.Errorf("unsupported generator type: invalid")
 			Expect(reconciler.classifyError(unsupportedErr)).To(Equal(ConfigurationError))
 
+			clientCertErr := fmt.Errorf("invalid client certificate in secret default/mtls: tls: private key does not match public key")
+			Expect(reconciler.classifyError(clientCertErr)).To(Equal(ConfigurationError))
+
 			By("classifying permanent errors")
 			notFoundErr := fmt.Errorf("404 not found")
 			Expect(reconciler.classifyError(notFoundErr)).To(Equal(PermanentError))
@@ -1411,6 +2293,12 @@ var _ = Describe("ExternalSource Controller Error Handling and Resilience", func
 
 			forbiddenErr := fmt.Errorf("403 forbidden")
 			Expect(reconciler.classifyError(forbiddenErr)).To(Equal(PermanentError))
+
+			limitErr := fmt.Errorf("hook failed: command jq exceeded memory resource limit")
+			Expect(reconciler.classifyError(limitErr)).To(Equal(PermanentError))
+
+			sizeErr := fmt.Errorf("response body exceeds maximum size of 1024 bytes")
+			Expect(reconciler.classifyError(sizeErr)).To(Equal(PermanentError))
 		})
 
 		It("should calculate retry delay with exponential backoff", func() {
@@ -1474,10 +2362,9 @@ var _ = Describe("ExternalSource Controller Error Handling and Resilience", func
 			reconciler.incrementRetryCount(externalSource, testErr)
 
 			Expect(reconciler.getRetryCount(externalSource)).To(Equal(1))
-			Expect(externalSource.Annotations).To(HaveKey(retryCountAnnotation))
-			Expect(externalSource.Annotations).To(HaveKey(lastFailureAnnotation))
-			Expect(externalSource.Annotations).To(HaveKey(backoffStartAnnotation))
-			Expect(externalSource.Annotations[lastFailureAnnotation]).To(Equal("test error message"))
+			Expect(externalSource.Status.Retry).NotTo(BeNil())
+			Expect(externalSource.Status.Retry.BackoffStartTime).NotTo(BeNil())
+			Expect(externalSource.Status.Retry.LastFailure).To(Equal("test error message"))
 
 			By("incrementing retry count multiple times")
 			reconciler.incrementRetryCount(externalSource, testErr)
@@ -1489,9 +2376,7 @@ var _ = Describe("ExternalSource Controller Error Handling and Resilience", func
 			reconciler.clearRetryCount(externalSource)
 
 			Expect(reconciler.getRetryCount(externalSource)).To(Equal(0))
-			Expect(externalSource.Annotations).NotTo(HaveKey(retryCountAnnotation))
-			Expect(externalSource.Annotations).NotTo(HaveKey(lastFailureAnnotation))
-			Expect(externalSource.Annotations).NotTo(HaveKey(backoffStartAnnotation))
+			Expect(externalSource.Status.Retry).To(BeNil())
 		})
 
 		It("should calculate backoff duration correctly", func() {
@@ -1846,13 +2731,22 @@ var _ = Describe("ExternalSource Controller Error Handling and Resilience", func
 				Expect(result.RequeueAfter).To(BeNumerically(">", 0))
 			}
 
-			// The retry logic is working - we've verified exponential backoff behavior
-			// In a real environment, after max retries it would return to regular interval
-			// but in test environment annotations don't persist, so we just verify retry behavior
+			By("verifying the retry state is persisted in status")
+			var updatedResource sourcev1alpha1.ExternalSource
+			Expect(k8sClient.Get(ctx, typeNamespacedName, &updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.Retry).NotTo(BeNil())
+			Expect(updatedResource.Status.Retry.Count).To(Equal(reconciler.Config.Retry.MaxAttempts))
+			Expect(updatedResource.Status.Retry.LastFailure).To(ContainSubstring("persistent network error"))
+
+			By("stalling once max retries are exhausted")
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(reconciler.Config.Retry.StalledInterval))
 
-			// Verify that the error handling is working correctly
-			By("verifying retry behavior is functioning")
-			Expect(previousDelay).To(BeNumerically(">", 0))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, &updatedResource)).To(Succeed())
+			stalledCondition := findCondition(updatedResource.Status.Conditions, StalledCondition)
+			Expect(stalledCondition).NotTo(BeNil())
+			Expect(stalledCondition.Status).To(Equal(metav1.ConditionTrue))
 
 			By("cleaning up the resource")
 			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
@@ -1911,577 +2805,4358 @@ var _ = Describe("ExternalSource Controller Error Handling and Resilience", func
 			// Verify no retry count is set
 			Expect(reconciler.getRetryCount(&updatedResource)).To(Equal(0))
 
-			By("cleaning up the resource")
-			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should handle permanent errors without retry", func() {
+			resourceName := "test-permanent-error"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: "default",
+			}
+
+			By("setting up generator to return permanent error")
+			Expect(mockFactory.RegisterGenerator("http", func() generator.SourceGenerator {
+				return &MockSourceGenerator{
+					GenerateFunc: func(ctx context.Context, config generator.GeneratorConfig) (*generator.SourceData, error) {
+						return nil, fmt.Errorf("404 not found")
+					},
+				}
+			})).To(Succeed())
+
+			By("creating an ExternalSource resource")
+			resource := &sourcev1alpha1.ExternalSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: sourcev1alpha1.ExternalSourceSpec{
+					Interval: "5m",
+					Generator: sourcev1alpha1.GeneratorSpec{
+						Type: "http",
+						HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+							URL: "https://api.example.com/config",
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			By("performing reconciliation")
+			// First reconcile adds finalizer
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			// Second reconcile should fail with permanent error
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(5 * time.Minute)) // Regular interval, no retry
+
+			By("verifying permanent error condition")
+			var updatedResource sourcev1alpha1.ExternalSource
+			Expect(k8sClient.Get(ctx, typeNamespacedName, &updatedResource)).To(Succeed())
+
+			readyCondition := findCondition(updatedResource.Status.Conditions, ReadyCondition)
+			Expect(readyCondition).NotTo(BeNil())
+			Expect(readyCondition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(readyCondition.Reason).To(Equal("PermanentError"))
+			Expect(readyCondition.Message).To(ContainSubstring("will not retry"))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should recover from failures when generator starts working", func() {
+			resourceName := "test-recovery-success"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: "default",
+			}
+
+			failureCount := 0
+			By("setting up generator to fail initially then succeed")
+			Expect(mockFactory.RegisterGenerator("http", func() generator.SourceGenerator {
+				return &MockSourceGenerator{
+					GenerateFunc: func(ctx context.Context, config generator.GeneratorConfig) (*generator.SourceData, error) {
+						failureCount++
+						if failureCount <= 3 {
+							return nil, fmt.Errorf("temporary network error")
+						}
+						return &generator.SourceData{
+							Data:         []byte(`{"recovered": true}`),
+							LastModified: "recovery-etag",
+							Metadata:     map[string]string{"status": "recovered"},
+						}, nil
+					},
+				}
+			})).To(Succeed())
+
+			By("creating an ExternalSource resource")
+			resource := &sourcev1alpha1.ExternalSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: sourcev1alpha1.ExternalSourceSpec{
+					Interval: "5m",
+					Generator: sourcev1alpha1.GeneratorSpec{
+						Type: "http",
+						HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+							URL: "https://api.example.com/config",
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			By("performing reconciliations through failures")
+			// First reconcile adds finalizer
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			// Fail a few times
+			for i := 0; i < 3; i++ {
+				result, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+			}
+
+			By("succeeding on recovery")
+			result, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+
+			By("verifying successful recovery")
+			var updatedResource sourcev1alpha1.ExternalSource
+			Expect(k8sClient.Get(ctx, typeNamespacedName, &updatedResource)).To(Succeed())
+
+			readyCondition := findCondition(updatedResource.Status.Conditions, ReadyCondition)
+			Expect(readyCondition).NotTo(BeNil())
+			Expect(readyCondition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(readyCondition.Reason).To(Equal(SucceededReason))
+
+			// Verify retry count is cleared
+			Expect(reconciler.getRetryCount(&updatedResource)).To(Equal(0))
+
+			// Verify artifact was created
+			Expect(updatedResource.Status.Artifact).NotTo(BeNil())
+			Expect(updatedResource.Status.LastHandledETag).To(Equal("recovery-etag"))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+	})
+})
+
+// Standard Go tests for utility functions
+
+func TestMapsEqual(t *testing.T) {
+	tests := []struct {
+		name     string
+		mapA     map[string]string
+		mapB     map[string]string
+		expected bool
+	}{
+		{
+			name:     "both maps nil",
+			mapA:     nil,
+			mapB:     nil,
+			expected: true,
+		},
+		{
+			name:     "both maps empty",
+			mapA:     map[string]string{},
+			mapB:     map[string]string{},
+			expected: true,
+		},
+		{
+			name:     "identical maps",
+			mapA:     map[string]string{"key1": "value1", "key2": "value2"},
+			mapB:     map[string]string{"key1": "value1", "key2": "value2"},
+			expected: true,
+		},
+		{
+			name:     "identical maps different order",
+			mapA:     map[string]string{"key1": "value1", "key2": "value2"},
+			mapB:     map[string]string{"key2": "value2", "key1": "value1"},
+			expected: true,
+		},
+		{
+			name:     "different lengths",
+			mapA:     map[string]string{"key1": "value1"},
+			mapB:     map[string]string{"key1": "value1", "key2": "value2"},
+			expected: false,
+		},
+		{
+			name:     "different values",
+			mapA:     map[string]string{"key1": "value1"},
+			mapB:     map[string]string{"key1": "value2"},
+			expected: false,
+		},
+		{
+			name:     "different keys",
+			mapA:     map[string]string{"key1": "value1"},
+			mapB:     map[string]string{"key2": "value1"},
+			expected: false,
+		},
+		{
+			name:     "one map nil, other empty",
+			mapA:     nil,
+			mapB:     map[string]string{},
+			expected: true,
+		},
+		{
+			name:     "one map nil, other has values",
+			mapA:     nil,
+			mapB:     map[string]string{"key1": "value1"},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := mapsEqual(tt.mapA, tt.mapB)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExternalSourceReconciler_needsRecovery(t *testing.T) {
+	reconciler := &ExternalSourceReconciler{}
+
+	tests := []struct {
+		name           string
+		externalSource *sourcev1alpha1.ExternalSource
+		expected       bool
+	}{
+		{
+			name: "no conditions - no recovery needed",
+			externalSource: &sourcev1alpha1.ExternalSource{
+				Status: sourcev1alpha1.ExternalSourceStatus{},
+			},
+			expected: false,
+		},
+		{
+			name: "ready condition true - no recovery needed",
+			externalSource: &sourcev1alpha1.ExternalSource{
+				Status: sourcev1alpha1.ExternalSourceStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   ReadyCondition,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "fetching condition true - recovery needed",
+			externalSource: &sourcev1alpha1.ExternalSource{
+				Status: sourcev1alpha1.ExternalSourceStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   FetchingCondition,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "executing hooks condition true - recovery needed",
+			externalSource: &sourcev1alpha1.ExternalSource{
+				Status: sourcev1alpha1.ExternalSourceStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   ExecutingHooksCondition,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "storing condition true - recovery needed",
+			externalSource: &sourcev1alpha1.ExternalSource{
+				Status: sourcev1alpha1.ExternalSourceStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   StoringCondition,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "multiple in-progress conditions - recovery needed",
+			externalSource: &sourcev1alpha1.ExternalSource{
+				Status: sourcev1alpha1.ExternalSourceStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   FetchingCondition,
+							Status: metav1.ConditionTrue,
+						},
+						{
+							Type:   ExecutingHooksCondition,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "in-progress condition false - no recovery needed",
+			externalSource: &sourcev1alpha1.ExternalSource{
+				Status: sourcev1alpha1.ExternalSourceStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   FetchingCondition,
+							Status: metav1.ConditionFalse,
+						},
+					},
+				},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := reconciler.needsRecovery(tt.externalSource)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExternalSourceReconciler_setCondition(t *testing.T) {
+	reconciler := &ExternalSourceReconciler{}
+
+	externalSource := &sourcev1alpha1.ExternalSource{
+		Status: sourcev1alpha1.ExternalSourceStatus{},
+	}
+
+	// Test setting a new condition
+	reconciler.setCondition(externalSource, ReadyCondition, metav1.ConditionTrue, "TestReason", "Test message")
+
+	assert.Len(t, externalSource.Status.Conditions, 1)
+	condition := externalSource.Status.Conditions[0]
+	assert.Equal(t, ReadyCondition, condition.Type)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "TestReason", condition.Reason)
+	assert.Equal(t, "Test message", condition.Message)
+	assert.NotEmpty(t, condition.LastTransitionTime)
+
+	// Test updating existing condition with same status
+	originalTime := condition.LastTransitionTime
+	reconciler.setCondition(externalSource, ReadyCondition, metav1.ConditionTrue, "TestReason", "Updated message")
+
+	assert.Len(t, externalSource.Status.Conditions, 1)
+	updatedCondition := externalSource.Status.Conditions[0]
+	assert.Equal(t, "Updated message", updatedCondition.Message)
+	assert.Equal(t, originalTime, updatedCondition.LastTransitionTime) // Should not change
+
+	// Test updating existing condition with different status
+	reconciler.setCondition(externalSource, ReadyCondition, metav1.ConditionFalse, "ErrorReason", "Error message")
+
+	assert.Len(t, externalSource.Status.Conditions, 1)
+	finalCondition := externalSource.Status.Conditions[0]
+	assert.Equal(t, metav1.ConditionFalse, finalCondition.Status)
+	assert.Equal(t, "ErrorReason", finalCondition.Reason)
+	assert.Equal(t, "Error message", finalCondition.Message)
+	assert.NotEqual(t, originalTime, finalCondition.LastTransitionTime) // Should change
+}
+
+func TestExternalSourceReconciler_hasCondition(t *testing.T) {
+	reconciler := &ExternalSourceReconciler{}
+
+	externalSource := &sourcev1alpha1.ExternalSource{
+		Status: sourcev1alpha1.ExternalSourceStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:   ReadyCondition,
+					Status: metav1.ConditionTrue,
+				},
+				{
+					Type:   FetchingCondition,
+					Status: metav1.ConditionFalse,
+				},
+			},
+		},
+	}
+
+	// Test existing condition with matching status
+	assert.True(t, reconciler.hasCondition(externalSource, ReadyCondition, metav1.ConditionTrue))
+	assert.True(t, reconciler.hasCondition(externalSource, FetchingCondition, metav1.ConditionFalse))
+
+	// Test existing condition with non-matching status
+	assert.False(t, reconciler.hasCondition(externalSource, ReadyCondition, metav1.ConditionFalse))
+	assert.False(t, reconciler.hasCondition(externalSource, FetchingCondition, metav1.ConditionTrue))
+
+	// Test non-existing condition
+	assert.False(t, reconciler.hasCondition(externalSource, ExecutingHooksCondition, metav1.ConditionTrue))
+	assert.False(t, reconciler.hasCondition(externalSource, ExecutingHooksCondition, metav1.ConditionFalse))
+}
+
+func TestExternalSourceReconciler_isReconcileRequested(t *testing.T) {
+	reconciler := &ExternalSourceReconciler{}
+
+	externalSource := &sourcev1alpha1.ExternalSource{}
+
+	// Test without annotation
+	assert.False(t, reconciler.isReconcileRequested(externalSource))
+
+	// Test with unhandled request
+	externalSource.Annotations = map[string]string{
+		fluxmeta.ReconcileRequestAnnotation: "2025-01-01T12:00:00Z",
+	}
+	assert.True(t, reconciler.isReconcileRequested(externalSource))
+
+	// Test with handled request
+	externalSource.Status.SetLastHandledReconcileRequest("2025-01-01T12:00:00Z")
+	assert.False(t, reconciler.isReconcileRequested(externalSource))
+
+	// Test with new request after a handled one
+	externalSource.Annotations[fluxmeta.ReconcileRequestAnnotation] = "2025-01-01T13:00:00Z"
+	assert.True(t, reconciler.isReconcileRequested(externalSource))
+}
+
+func TestExternalSourceReconciler_referencedArtifactKeys(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = sourcev1alpha1.AddToScheme(scheme)
+
+	withArtifact := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Status: sourcev1alpha1.ExternalSourceStatus{
+			Artifact: &sourcev1alpha1.ArtifactMetadata{Revision: "abc123"},
+		},
+	}
+	withoutArtifact := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "other"},
+	}
+	historyLimit := int32(5)
+	withPrefix := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-app", Namespace: "default"},
+		Spec:       sourcev1alpha1.ExternalSourceSpec{StoragePrefix: "team-a/", HistoryLimit: &historyLimit},
+		Status: sourcev1alpha1.ExternalSourceStatus{
+			Artifact: &sourcev1alpha1.ArtifactMetadata{Revision: "def456"},
+		},
+	}
+
+	cfg := createTestConfig()
+	cfg.Storage.KeyPrefix = "cluster-a"
+	reconciler := &ExternalSourceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(withArtifact, withoutArtifact, withPrefix).Build(),
+		Config: cfg,
+	}
+
+	references, err := reconciler.referencedArtifactKeys(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		"cluster-a/artifacts/default/app/abc123.tar.gz":             true,
+		"cluster-a/team-a/artifacts/default/team-app/def456.tar.gz": true,
+	}, references.Keys)
+
+	// Sources keeping more than the current revision report their history limit
+	assert.Equal(t, map[string]int{
+		"cluster-a/team-a/artifacts/default/team-app": 5,
+	}, references.HistoryLimits)
+}
+
+func TestExternalSourceReconciler_executeHooksEnvFrom(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "hook-credentials", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("s3cr3t-token")},
+	}
+
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+	}
+
+	envFromHook := func(secretName, key string) sourcev1alpha1.HookSpec {
+		return sourcev1alpha1.HookSpec{
+			Name:    "fetch",
+			Command: "curl",
+			Env:     []sourcev1alpha1.EnvVar{{Name: "MODE", Value: "fast"}},
+			EnvFrom: []sourcev1alpha1.EnvFromSource{{
+				Name:         "API_TOKEN",
+				SecretKeyRef: sourcev1alpha1.SecretKeyReference{Name: secretName, Key: key},
+			}},
+		}
+	}
+
+	t.Run("resolves secret values into env", func(t *testing.T) {
+		var received sourcev1alpha1.HookSpec
+		reconciler := &ExternalSourceReconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build(),
+			HookExecutor: &MockHookExecutor{
+				ExecuteFunc: func(ctx context.Context, input []byte, hook sourcev1alpha1.HookSpec) ([]byte, error) {
+					received = hook
+					return input, nil
+				},
+			},
+		}
+
+		hookSpecs := []sourcev1alpha1.HookSpec{envFromHook("hook-credentials", "token")}
+		_, err := reconciler.executeHooks(context.Background(), externalSource, []byte("{}"), hookSpecs)
+		assert.NoError(t, err)
+		assert.Equal(t, []sourcev1alpha1.EnvVar{
+			{Name: "MODE", Value: "fast"},
+			{Name: "API_TOKEN", Value: "s3cr3t-token"},
+		}, received.Env)
+		assert.Len(t, hookSpecs[0].Env, 1, "the spec must not be modified")
+	})
+
+	t.Run("redacts secret values from hook errors", func(t *testing.T) {
+		reconciler := &ExternalSourceReconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build(),
+			HookExecutor: &MockHookExecutor{
+				ExecuteFunc: func(ctx context.Context, input []byte, hook sourcev1alpha1.HookSpec) ([]byte, error) {
+					return nil, fmt.Errorf("command exited with code 1: bad token s3cr3t-token")
+				},
+			},
+		}
+
+		_, err := reconciler.executeHooks(context.Background(), externalSource, []byte("{}"),
+			[]sourcev1alpha1.HookSpec{envFromHook("hook-credentials", "token")})
+		if assert.Error(t, err) {
+			assert.NotContains(t, err.Error(), "s3cr3t-token")
+			assert.Contains(t, err.Error(), "[REDACTED]")
+		}
+	})
+
+	for _, tt := range []struct {
+		name       string
+		secretName string
+		key        string
+	}{
+		{name: "missing secret", secretName: "absent", key: "token"},
+		{name: "missing key", secretName: "hook-credentials", key: "password"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			executed := false
+			reconciler := &ExternalSourceReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build(),
+				HookExecutor: &MockHookExecutor{
+					ExecuteFunc: func(ctx context.Context, input []byte, hook sourcev1alpha1.HookSpec) ([]byte, error) {
+						executed = true
+						return input, nil
+					},
+				},
+			}
+
+			hookSpecs := []sourcev1alpha1.HookSpec{
+				{Name: "first", Command: "jq"},
+				envFromHook(tt.secretName, tt.key),
+			}
+			_, err := reconciler.executeHooks(context.Background(), externalSource, []byte("{}"), hookSpecs)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), "invalid hook secret reference")
+				assert.Equal(t, ConfigurationError, reconciler.classifyError(err))
+			}
+			assert.False(t, executed, "no hook should run when a secret reference is invalid")
+		})
+	}
+}
+
+func TestExternalSourceReconciler_DryRun(t *testing.T) {
+	stored := false
+	reconciler := &ExternalSourceReconciler{
+		GeneratorFactory: &MockGeneratorFactory{
+			CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+				return &MockSourceGenerator{
+					GenerateFunc: func(ctx context.Context, config generator.GeneratorConfig) (*generator.SourceData, error) {
+						return &generator.SourceData{Data: []byte(`{"name":"app"}`)}, nil
+					},
+				}, nil
+			},
+		},
+		HookExecutor: &MockHookExecutor{
+			ExecuteFunc: func(ctx context.Context, input []byte, hook sourcev1alpha1.HookSpec) ([]byte, error) {
+				return append(input, '\n'), nil
+			},
+		},
+		ArtifactManager: &MockArtifactManager{
+			PackageFunc: func(ctx context.Context, data []byte, path string) (*artifact.Artifact, error) {
+				return &artifact.Artifact{Data: data, Path: path, Revision: "abc123"}, nil
+			},
+			StoreFunc: func(ctx context.Context, artifact *artifact.Artifact, source string) (string, error) {
+				stored = true
+				return "", nil
+			},
+		},
+	}
+
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/config.json"},
+			},
+			Hooks: &sourcev1alpha1.HooksSpec{
+				PostRequest: []sourcev1alpha1.HookSpec{{Name: "newline", Command: "cat"}},
+			},
+		},
+	}
+
+	result, err := reconciler.DryRun(context.Background(), externalSource)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "{\"name\":\"app\"}\n", string(result.Data))
+		assert.Equal(t, "data", result.Artifact.Path)
+		assert.Equal(t, "abc123", result.Artifact.Revision)
+	}
+	assert.False(t, stored, "a dry run must not store the artifact")
+	assert.Empty(t, externalSource.Status.Conditions, "a dry run must not update status")
+
+	// Hooks cannot run without an executor
+	reconciler.HookExecutor = nil
+	_, err = reconciler.DryRun(context.Background(), externalSource)
+	assert.Error(t, err)
+}
+
+func TestExternalSourceReconciler_reconcileFetchStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = sourcev1alpha1.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
+
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: "app-uid"},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/config.json"},
+			},
+		},
+	}
+
+	notModified := false
+	reconciler := &ExternalSourceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(externalSource).
+			WithStatusSubresource(&sourcev1.ExternalArtifact{}).Build(),
+		Scheme: scheme,
+		GeneratorFactory: &MockGeneratorFactory{
+			CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+				return &MockSourceGenerator{
+					GenerateFunc: func(ctx context.Context, config generator.GeneratorConfig) (*generator.SourceData, error) {
+						if notModified {
+							return &generator.SourceData{NotModified: true}, nil
+						}
+						return &generator.SourceData{Data: []byte(`{"name":"app"}`), LastModified: "etag-1"}, nil
+					},
+				}, nil
+			},
+		},
+		ArtifactManager: &MockArtifactManager{},
+	}
+
+	_, err := reconciler.reconcile(context.Background(), externalSource)
+	assert.NoError(t, err)
+	if assert.NotNil(t, externalSource.Status.LastFetchTime) && assert.NotNil(t, externalSource.Status.LastFetchDuration) {
+		assert.Equal(t, int64(len(`{"name":"app"}`)), externalSource.Status.ContentSize)
+	}
+	fetchTime := externalSource.Status.LastFetchTime.DeepCopy()
+	fetchDuration := *externalSource.Status.LastFetchDuration
+	assert.Equal(t, fetchTime, externalSource.Status.LastConfirmedTime)
+
+	// An unchanged source keeps the fields from the last fetch that returned data, and
+	// records that the source confirmed the data is current
+	time.Sleep(10 * time.Millisecond)
+	notModified = true
+	_, err = reconciler.reconcile(context.Background(), externalSource)
+	assert.NoError(t, err)
+	assert.Equal(t, fetchTime, externalSource.Status.LastFetchTime)
+	if assert.NotNil(t, externalSource.Status.LastConfirmedTime) {
+		assert.True(t, externalSource.Status.LastConfirmedTime.After(fetchTime.Time))
+	}
+	assert.Equal(t, fetchDuration, *externalSource.Status.LastFetchDuration)
+	assert.Equal(t, int64(len(`{"name":"app"}`)), externalSource.Status.ContentSize)
+}
+
+// mockNotifier records the notifications it is asked to send
+type mockNotifier struct {
+	webhooks []notification.Webhook
+	events   []notification.Event
+	err      error
+}
+
+func (m *mockNotifier) Notify(_ context.Context, webhook notification.Webhook, event notification.Event) error {
+	m.webhooks = append(m.webhooks, webhook)
+	m.events = append(m.events, event)
+	return m.err
+}
+
+func TestExternalSourceReconciler_notifyOnRevisionChange(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = sourcev1alpha1.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
+
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: "app-uid"},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/config.json"},
+			},
+			Notify: &sourcev1alpha1.NotifySpec{
+				URL:       "https://hooks.example.com/default",
+				SecretRef: &sourcev1alpha1.SecretReference{Name: "notify"},
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "notify", Namespace: "default"},
+		Data: map[string][]byte{
+			"address": []byte("https://hooks.example.com/secret"),
+			"token":   []byte("token"),
+		},
+	}
+
+	revision := "rev-1"
+	notifier := &mockNotifier{}
+	mockMetrics := &MockMetricsRecorder{}
+	reconciler := &ExternalSourceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(externalSource, secret).
+			WithStatusSubresource(&sourcev1.ExternalArtifact{}).Build(),
+		Scheme: scheme,
+		GeneratorFactory: &MockGeneratorFactory{
+			CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+				// Always fetch so an unchanged revision reaches the notification check
+				return &MockSourceGenerator{
+					SupportsConditionalFetchFunc: func() bool { return false },
+				}, nil
+			},
+		},
+		ArtifactManager: &MockArtifactManager{
+			PackageFunc: func(ctx context.Context, data []byte, path string) (*artifact.Artifact, error) {
+				return &artifact.Artifact{Data: data, Path: path, Revision: revision}, nil
+			},
+		},
+		MetricsRecorder: mockMetrics,
+		Notifier:        notifier,
+	}
+
+	_, err := reconciler.reconcile(context.Background(), externalSource)
+	assert.NoError(t, err)
+	if assert.Len(t, notifier.events, 1) {
+		assert.Equal(t, notification.Webhook{URL: "https://hooks.example.com/secret", Token: "token"}, notifier.webhooks[0])
+		assert.Equal(t, "app", notifier.events[0].Name)
+		assert.Equal(t, "default", notifier.events[0].Namespace)
+		assert.Empty(t, notifier.events[0].OldRevision)
+		assert.Equal(t, "rev-1", notifier.events[0].Revision)
+	}
+
+	// An unchanged revision is not notified
+	_, err = reconciler.reconcile(context.Background(), externalSource)
+	assert.NoError(t, err)
+	assert.Len(t, notifier.events, 1)
+
+	// Notification failures don't fail reconciliation
+	revision = "rev-2"
+	notifier.err = fmt.Errorf("webhook unavailable")
+	_, err = reconciler.reconcile(context.Background(), externalSource)
+	assert.NoError(t, err)
+	if assert.Len(t, notifier.events, 2) {
+		assert.Equal(t, "rev-1", notifier.events[1].OldRevision)
+		assert.Equal(t, "rev-2", notifier.events[1].Revision)
+	}
+	assert.Equal(t, []bool{true, false}, mockMetrics.RecordNotificationCalls)
+}
+
+func TestExternalSourceReconciler_notifyRevisionChangeWithoutURL(t *testing.T) {
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec:       sourcev1alpha1.ExternalSourceSpec{Notify: &sourcev1alpha1.NotifySpec{}},
+	}
+	notifier := &mockNotifier{}
+	mockMetrics := &MockMetricsRecorder{}
+	reconciler := &ExternalSourceReconciler{Notifier: notifier, MetricsRecorder: mockMetrics}
+
+	// Neither a URL nor a secret is a failed notification, not a panic
+	reconciler.notifyRevisionChange(context.Background(), externalSource, "", "rev-1", "http://example.com/rev-1")
+	assert.Empty(t, notifier.events)
+	assert.Equal(t, []bool{false}, mockMetrics.RecordNotificationCalls)
+}
+
+func TestExternalSourceReconciler_resumeAfterBackoff(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = sourcev1alpha1.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
+
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "app",
+			Namespace:  "default",
+			Finalizers: []string{ExternalSourceFinalizer},
+		},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Interval: "10m",
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/config.json"},
+			},
+		},
+	}
+
+	cfg := createTestConfig()
+	cfg.Retry.JitterFactor = 0
+	fetches := 0
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(externalSource).
+		WithStatusSubresource(externalSource).Build()
+	reconciler := &ExternalSourceReconciler{
+		Client: k8sClient,
+		Scheme: scheme,
+		Config: cfg,
+		GeneratorFactory: &MockGeneratorFactory{
+			CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+				return &MockSourceGenerator{
+					SupportsConditionalFetchFunc: func() bool { return false },
+					GenerateFunc: func(ctx context.Context, config generator.GeneratorConfig) (*generator.SourceData, error) {
+						fetches++
+						return nil, fmt.Errorf("connection refused")
+					},
+				}, nil
+			},
+		},
+		ArtifactManager: &MockArtifactManager{},
+		MetricsRecorder: &MockMetricsRecorder{},
+	}
+
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "app", Namespace: "default"}}
+	current := func() *sourcev1alpha1.ExternalSource {
+		var source sourcev1alpha1.ExternalSource
+		assert.NoError(t, k8sClient.Get(ctx, req.NamespacedName, &source))
+		return &source
+	}
+	setSuspend := func(suspend bool) {
+		source := current()
+		source.Spec.Suspend = suspend
+		assert.NoError(t, k8sClient.Update(ctx, source))
+	}
+
+	// Build up a backoff with consecutive transient failures
+	var result reconcile.Result
+	var err error
+	for range 3 {
+		result, err = reconciler.Reconcile(ctx, req)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 3, current().Status.Retry.Count)
+	assert.Equal(t, 4*cfg.Retry.BaseDelay, result.RequeueAfter)
+
+	// Suspending mid-backoff drops the retry state
+	setSuspend(true)
+	result, err = reconciler.Reconcile(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+	assert.Nil(t, current().Status.Retry)
+	assert.Equal(t, 3, fetches)
+
+	// A resumed source fetches right away and backs off from the start again
+	setSuspend(false)
+	result, err = reconciler.Reconcile(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, fetches)
+	assert.Equal(t, cfg.Retry.BaseDelay, result.RequeueAfter)
+	assert.Equal(t, 1, current().Status.Retry.Count)
+}
+
+func TestExternalSourceReconciler_dataFreshCondition(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = sourcev1alpha1.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
+
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "app",
+			Namespace:  "default",
+			UID:        "app-uid",
+			Finalizers: []string{ExternalSourceFinalizer},
+		},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Interval: "10m",
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/config.json"},
+			},
+		},
+	}
+
+	var fetchErr error
+	metricsRecorder := &MockMetricsRecorder{}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(externalSource).
+		WithStatusSubresource(externalSource, &sourcev1.ExternalArtifact{}).Build()
+	reconciler := &ExternalSourceReconciler{
+		Client: k8sClient,
+		Scheme: scheme,
+		Config: createTestConfig(),
+		GeneratorFactory: &MockGeneratorFactory{
+			CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+				return &MockSourceGenerator{
+					SupportsConditionalFetchFunc: func() bool { return false },
+					GenerateFunc: func(ctx context.Context, config generator.GeneratorConfig) (*generator.SourceData, error) {
+						if fetchErr != nil {
+							return nil, fetchErr
+						}
+						return &generator.SourceData{Data: []byte(`{"v": 1}`)}, nil
+					},
+				}, nil
+			},
+		},
+		ArtifactManager: &MockArtifactManager{},
+		MetricsRecorder: metricsRecorder,
+	}
+
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "app", Namespace: "default"}}
+	conditions := func() []metav1.Condition {
+		var source sourcev1alpha1.ExternalSource
+		assert.NoError(t, k8sClient.Get(ctx, req.NamespacedName, &source))
+		return source.Status.Conditions
+	}
+
+	_, err := reconciler.Reconcile(ctx, req)
+	assert.NoError(t, err)
+	assert.True(t, apimeta.IsStatusConditionTrue(conditions(), ReadyCondition))
+	assert.True(t, apimeta.IsStatusConditionTrue(conditions(), DataFreshCondition))
+
+	// A transient failure keeps the source Ready on its last artifact but reports stale data
+	fetchErr = fmt.Errorf("connection refused")
+	_, err = reconciler.Reconcile(ctx, req)
+	assert.NoError(t, err)
+	ready := apimeta.FindStatusCondition(conditions(), ReadyCondition)
+	assert.Equal(t, metav1.ConditionTrue, ready.Status)
+	assert.Equal(t, DegradedReason, ready.Reason)
+	dataFresh := apimeta.FindStatusCondition(conditions(), DataFreshCondition)
+	assert.Equal(t, metav1.ConditionFalse, dataFresh.Status)
+	assert.Contains(t, dataFresh.Message, "connection refused")
+
+	// Recovering marks the data fresh again
+	fetchErr = nil
+	_, err = reconciler.Reconcile(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, SucceededReason, apimeta.FindStatusCondition(conditions(), ReadyCondition).Reason)
+	assert.True(t, apimeta.IsStatusConditionTrue(conditions(), DataFreshCondition))
+	assert.Equal(t, []bool{true, false, true}, metricsRecorder.RecordDataFreshCalls)
+}
+
+func TestExternalSourceReconciler_publishFailureKeepsPreviousArtifact(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = sourcev1alpha1.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
+
+	previous := &sourcev1alpha1.ArtifactMetadata{
+		URL:      "http://artifacts/default/app/rev-1.tar.gz",
+		Revision: "rev-1",
+	}
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: "app-uid"},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/config.json"},
+			},
+		},
+		Status: sourcev1alpha1.ExternalSourceStatus{
+			Artifact:        previous.DeepCopy(),
+			LastHandledETag: "etag-1",
+		},
+	}
+	child := &sourcev1.ExternalArtifact{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Status: sourcev1.ExternalArtifactStatus{
+			Artifact: &fluxmeta.Artifact{URL: previous.URL, Revision: previous.Revision},
+		},
+	}
+
+	publishErr := fmt.Errorf("apiserver unavailable")
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(externalSource, child).
+		WithStatusSubresource(&sourcev1.ExternalArtifact{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				if _, ok := obj.(*sourcev1.ExternalArtifact); ok && publishErr != nil {
+					return publishErr
+				}
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+		}).Build()
+
+	var cleanups []string
+	reconciler := &ExternalSourceReconciler{
+		Client: k8sClient,
+		Scheme: scheme,
+		Config: createTestConfig(),
+		GeneratorFactory: &MockGeneratorFactory{
+			CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+				return &MockSourceGenerator{
+					SupportsConditionalFetchFunc: func() bool { return false },
+					GenerateFunc: func(ctx context.Context, config generator.GeneratorConfig) (*generator.SourceData, error) {
+						return &generator.SourceData{Data: []byte(`{"v": 2}`), LastModified: "etag-2"}, nil
+					},
+				}, nil
+			},
+		},
+		ArtifactManager: &MockArtifactManager{
+			PackageFunc: func(ctx context.Context, data []byte, path string) (*artifact.Artifact, error) {
+				return &artifact.Artifact{Data: data, Path: path, Revision: "rev-2"}, nil
+			},
+			StoreFunc: func(ctx context.Context, a *artifact.Artifact, source string) (string, error) {
+				return "http://artifacts/default/app/rev-2.tar.gz", nil
+			},
+			CleanupFunc: func(ctx context.Context, source string, keepRevision string, historyLimit int) error {
+				cleanups = append(cleanups, keepRevision)
+				return nil
+			},
+		},
+		MetricsRecorder: &MockMetricsRecorder{},
+	}
+
+	ctx := context.Background()
+	currentChild := func() *fluxmeta.Artifact {
+		var artifact sourcev1.ExternalArtifact
+		assert.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(child), &artifact))
+		return artifact.Status.Artifact
+	}
+
+	// The new revision was stored but could not be published
+	_, err := reconciler.reconcile(ctx, externalSource)
+	assert.ErrorContains(t, err, "failed to reconcile ExternalArtifact")
+	assert.Equal(t, "rev-1", currentChild().Revision)
+	assert.Equal(t, previous.URL, externalSource.Status.Artifact.URL)
+	assert.Equal(t, "rev-1", externalSource.Status.Artifact.Revision)
+	assert.Equal(t, "etag-1", externalSource.Status.LastHandledETag, "the next reconcile must fetch again")
+	assert.Empty(t, cleanups, "the published revision must not be cleaned up")
+
+	// Once publishing succeeds the new revision replaces the previous one
+	publishErr = nil
+	_, err = reconciler.reconcile(ctx, externalSource)
+	assert.NoError(t, err)
+	assert.Equal(t, "rev-2", currentChild().Revision)
+	assert.Equal(t, "rev-2", externalSource.Status.Artifact.Revision)
+	assert.Equal(t, "etag-2", externalSource.Status.LastHandledETag)
+	assert.Equal(t, []string{"rev-2"}, cleanups)
+}
+
+func TestExternalSourceReconciler_hookStderrInCondition(t *testing.T) {
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/config.json"},
+			},
+			Hooks: &sourcev1alpha1.HooksSpec{
+				PostRequest: []sourcev1alpha1.HookSpec{{Name: "extract", Command: "jq"}},
+			},
+		},
+	}
+
+	stderr := strings.Repeat("x", 4096) + "\njq: error: Cannot index array with \"config\""
+	reconciler := &ExternalSourceReconciler{
+		GeneratorFactory: &MockGeneratorFactory{
+			CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+				return &MockSourceGenerator{}, nil
+			},
+		},
+		HookExecutor: &MockHookExecutor{
+			ExecuteFunc: func(ctx context.Context, input []byte, hook sourcev1alpha1.HookSpec) ([]byte, error) {
+				return nil, &hooks.ExitError{Command: hook.Command, ExitCode: 5, Stderr: []byte(stderr)}
+			},
+		},
+		ArtifactManager: &MockArtifactManager{},
+	}
+
+	_, err := reconciler.reconcile(context.Background(), externalSource)
+	assert.ErrorContains(t, err, `jq: error: Cannot index array with "config"`)
+
+	condition := apimeta.FindStatusCondition(externalSource.Status.Conditions, ExecutingHooksCondition)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, FailedReason, condition.Reason)
+		assert.Contains(t, condition.Message, "hook extract failed after 1 attempts: command exited with code 5: ...")
+		assert.True(t, strings.HasSuffix(condition.Message, `jq: error: Cannot index array with "config"`))
+		assert.Less(t, len(condition.Message), hooks.MaxStderrLength+200, "stderr must be capped")
+	}
+}
+
+func TestExternalSourceReconciler_decryptContent(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = sourcev1alpha1.AddToScheme(scheme)
+
+	keys := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "sops-keys", Namespace: "default"},
+		Data: map[string][]byte{
+			"identity.agekey": []byte("AGE-SECRET-KEY-1GFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPQ4EGAEX"),
+		},
+	}
+
+	reconciler := &ExternalSourceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(keys).Build(),
+	}
+
+	tests := []struct {
+		name        string
+		decryption  *sourcev1alpha1.DecryptionSpec
+		expectError string
+	}{
+		{
+			name: "no decryption",
+		},
+		{
+			name:        "missing secret",
+			decryption:  &sourcev1alpha1.DecryptionSpec{Provider: "sops", SecretRef: sourcev1alpha1.SecretReference{Name: "missing"}},
+			expectError: "failed to get secret default/missing",
+		},
+		{
+			name:        "not a SOPS document",
+			decryption:  &sourcev1alpha1.DecryptionSpec{Provider: "sops", SecretRef: sourcev1alpha1.SecretReference{Name: "sops-keys"}},
+			expectError: "failed to load SOPS document",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			externalSource := &sourcev1alpha1.ExternalSource{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+				Spec:       sourcev1alpha1.ExternalSourceSpec{Decryption: tt.decryption},
+			}
+
+			data := []byte("password: hunter2\n")
+			decrypted, err := reconciler.decryptContent(context.Background(), externalSource, data)
+			if tt.expectError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, data, decrypted)
+				return
+			}
+
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.expectError)
+				assert.NotContains(t, err.Error(), "hunter2")
+				assert.Equal(t, PermanentError, reconciler.classifyError(err))
+			}
+		})
+	}
+}
+
+func TestExternalSourceReconciler_reconcileContentValidation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = sourcev1alpha1.AddToScheme(scheme)
+
+	schema := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "schemas", Namespace: "default"},
+		Data:       map[string]string{"config.json": `{"type": "object", "required": ["name"]}`},
+	}
+
+	previousArtifact := &sourcev1alpha1.ArtifactMetadata{URL: "http://artifacts/app/good.tar.gz", Revision: "good"}
+
+	tests := []struct {
+		name        string
+		data        string
+		validation  *sourcev1alpha1.ContentValidationSpec
+		expectError string
+	}{
+		{
+			name:        "HTML error page",
+			data:        "<html><body>Bad Gateway</body></html>",
+			validation:  &sourcev1alpha1.ContentValidationSpec{Format: "json"},
+			expectError: "content is not valid JSON",
+		},
+		{
+			name: "schema mismatch",
+			data: `{"replicas": 2}`,
+			validation: &sourcev1alpha1.ContentValidationSpec{
+				SchemaRef: &sourcev1alpha1.ConfigMapKeyReference{Name: "schemas", Key: "config.json"},
+			},
+			expectError: "content does not match schema",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packaged := false
+			reconciler := &ExternalSourceReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(schema).Build(),
+				GeneratorFactory: &MockGeneratorFactory{
+					CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+						return &MockSourceGenerator{
+							GenerateFunc: func(ctx context.Context, config generator.GeneratorConfig) (*generator.SourceData, error) {
+								return &generator.SourceData{Data: []byte(tt.data)}, nil
+							},
+						}, nil
+					},
+				},
+				ArtifactManager: &MockArtifactManager{
+					PackageFunc: func(ctx context.Context, data []byte, path string) (*artifact.Artifact, error) {
+						packaged = true
+						return &artifact.Artifact{Data: data, Path: path, Revision: "bad"}, nil
+					},
+				},
+			}
+
+			externalSource := &sourcev1alpha1.ExternalSource{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+				Spec: sourcev1alpha1.ExternalSourceSpec{
+					Generator: sourcev1alpha1.GeneratorSpec{
+						Type: "http",
+						HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/config.json"},
+					},
+					Validation: tt.validation,
+				},
+				Status: sourcev1alpha1.ExternalSourceStatus{Artifact: previousArtifact.DeepCopy()},
+			}
+
+			_, err := reconciler.reconcile(context.Background(), externalSource)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.expectError)
+				assert.Equal(t, PermanentError, reconciler.classifyError(err))
+			}
+			assert.False(t, packaged, "invalid content must not be packaged")
+			assert.Equal(t, previousArtifact, externalSource.Status.Artifact)
+		})
+	}
+}
+
+func TestExternalSourceReconciler_retryStatusPersisted(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = sourcev1alpha1.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
+
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "app",
+			Namespace:  "default",
+			Generation: 1,
+			Finalizers: []string{ExternalSourceFinalizer},
+		},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Interval: "5m",
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/config.json"},
+			},
+		},
+	}
+
+	reconciler := &ExternalSourceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(externalSource).
+			WithStatusSubresource(&sourcev1alpha1.ExternalSource{}).Build(),
+		Scheme: scheme,
+		Config: createTestConfig(),
+		GeneratorFactory: &MockGeneratorFactory{
+			CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+				return &MockSourceGenerator{
+					GenerateFunc: func(ctx context.Context, config generator.GeneratorConfig) (*generator.SourceData, error) {
+						return nil, fmt.Errorf("connection refused")
+					},
+				}, nil
+			},
+		},
+		ArtifactManager: &MockArtifactManager{},
+	}
+
+	ctx := context.Background()
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: "app", Namespace: "default"}}
+
+	for i := 0; i < 2; i++ {
+		_, err := reconciler.Reconcile(ctx, request)
+		assert.NoError(t, err)
+	}
+
+	var stored sourcev1alpha1.ExternalSource
+	assert.NoError(t, reconciler.Get(ctx, request.NamespacedName, &stored))
+	if assert.NotNil(t, stored.Status.Retry) {
+		assert.Equal(t, 2, stored.Status.Retry.Count)
+		assert.Contains(t, stored.Status.Retry.LastFailure, "connection refused")
+		assert.NotNil(t, stored.Status.Retry.BackoffStartTime)
+	}
+	assert.Empty(t, stored.Annotations)
+
+	// A spec change starts the backoff over
+	stored.Status.ObservedGeneration = stored.Generation - 1
+	assert.NoError(t, reconciler.Status().Update(ctx, &stored))
+
+	_, err := reconciler.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.NoError(t, reconciler.Get(ctx, request.NamespacedName, &stored))
+	if assert.NotNil(t, stored.Status.Retry) {
+		assert.Equal(t, 1, stored.Status.Retry.Count)
+	}
+}
+
+func TestExternalSourceReconciler_stalledRequeue(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = sourcev1alpha1.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
+
+	tests := []struct {
+		name            string
+		stalledInterval time.Duration
+		expectStalled   time.Duration
+	}{
+		{name: "stalled interval", stalledInterval: time.Hour, expectStalled: time.Hour},
+		{name: "stalled interval shorter than interval", stalledInterval: time.Minute, expectStalled: 5 * time.Minute},
+		{name: "stalled interval disabled", stalledInterval: 0, expectStalled: 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig()
+			cfg.Retry.StalledInterval = tt.stalledInterval
+
+			externalSource := &sourcev1alpha1.ExternalSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "app",
+					Namespace:  "default",
+					Finalizers: []string{ExternalSourceFinalizer},
+				},
+				Spec: sourcev1alpha1.ExternalSourceSpec{
+					Interval: "5m",
+					Generator: sourcev1alpha1.GeneratorSpec{
+						Type: "http",
+						HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/config.json"},
+					},
+				},
+				Status: sourcev1alpha1.ExternalSourceStatus{
+					Retry: &sourcev1alpha1.RetryStatus{Count: cfg.Retry.MaxAttempts},
+				},
+			}
+
+			failing := true
+			reconciler := &ExternalSourceReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(externalSource).
+					WithStatusSubresource(&sourcev1alpha1.ExternalSource{}, &sourcev1.ExternalArtifact{}).Build(),
+				Scheme: scheme,
+				Config: cfg,
+				GeneratorFactory: &MockGeneratorFactory{
+					CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+						return &MockSourceGenerator{
+							GenerateFunc: func(ctx context.Context, config generator.GeneratorConfig) (*generator.SourceData, error) {
+								if failing {
+									return nil, fmt.Errorf("connection refused")
+								}
+								return &generator.SourceData{Data: []byte(`{"name":"app"}`)}, nil
+							},
+						}, nil
+					},
+				},
+				ArtifactManager: &MockArtifactManager{},
+			}
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: "app", Namespace: "default"}}
+
+			result, err := reconciler.Reconcile(context.Background(), request)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectStalled, result.RequeueAfter)
+
+			var stalled sourcev1alpha1.ExternalSource
+			assert.NoError(t, reconciler.Get(context.Background(), request.NamespacedName, &stalled))
+			assert.True(t, reconciler.hasCondition(&stalled, StalledCondition, metav1.ConditionTrue))
+
+			// A successful reconcile returns to the regular interval
+			failing = false
+			result, err = reconciler.Reconcile(context.Background(), request)
+			assert.NoError(t, err)
+			assert.Equal(t, 5*time.Minute, result.RequeueAfter)
+		})
+	}
+}
+
+func TestExternalSourceReconciler_recordContentMetrics(t *testing.T) {
+	mockMetrics := &MockMetricsRecorder{}
+	reconciler := &ExternalSourceReconciler{MetricsRecorder: mockMetrics}
+
+	fetchTime := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "metrics", Namespace: "default"},
+		Status: sourcev1alpha1.ExternalSourceStatus{
+			LastFetchTime: &fetchTime,
+			Artifact: &sourcev1alpha1.ArtifactMetadata{
+				Revision: "abc123",
+				Metadata: map[string]string{"size": "2048"},
+			},
+		},
+	}
+
+	reconciler.recordContentMetrics(externalSource)
+
+	if assert.Len(t, mockMetrics.RecordArtifactSizeCalls, 1) {
+		assert.Equal(t, RecordArtifactSizeCall{Namespace: "default", Name: "metrics", SizeBytes: 2048}, mockMetrics.RecordArtifactSizeCalls[0])
+	}
+	if assert.Len(t, mockMetrics.RecordContentAgeCalls, 1) {
+		assert.GreaterOrEqual(t, mockMetrics.RecordContentAgeCalls[0].Age, 10*time.Minute)
+	}
+
+	// A conditional fetch reporting no changes confirms the data is current
+	confirmedTime := metav1.NewTime(time.Now().Add(-time.Minute))
+	externalSource.Status.LastConfirmedTime = &confirmedTime
+	mockMetrics = &MockMetricsRecorder{}
+	reconciler.MetricsRecorder = mockMetrics
+	reconciler.recordContentMetrics(externalSource)
+	if assert.Len(t, mockMetrics.RecordContentAgeCalls, 1) {
+		assert.Less(t, mockMetrics.RecordContentAgeCalls[0].Age, 10*time.Minute)
+	}
+
+	// Sources that have not fetched or packaged anything yet report nothing
+	mockMetrics = &MockMetricsRecorder{}
+	reconciler.MetricsRecorder = mockMetrics
+	reconciler.recordContentMetrics(&sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "default"},
+	})
+	assert.Empty(t, mockMetrics.RecordArtifactSizeCalls)
+	assert.Empty(t, mockMetrics.RecordContentAgeCalls)
+}
+
+func TestExternalSourceReconciler_waitForFetch(t *testing.T) {
+	mockMetrics := &MockMetricsRecorder{}
+	reconciler := &ExternalSourceReconciler{
+		MetricsRecorder: mockMetrics,
+		fetchLimiter:    rate.NewLimiter(rate.Every(time.Hour), 1),
+	}
+
+	// The burst admits the first request without waiting
+	assert.NoError(t, reconciler.waitForFetch(context.Background(), "http"))
+	assert.Empty(t, mockMetrics.RecordFetchRateLimitedCalls)
+
+	// The next request has to wait and gives up when the context ends
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := reconciler.waitForFetch(ctx, "http")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "fetch rate limiter")
+	assert.Equal(t, []string{"http"}, mockMetrics.RecordFetchRateLimitedCalls)
+
+	// Without a limiter requests are never delayed
+	unlimited := &ExternalSourceReconciler{MetricsRecorder: mockMetrics}
+	assert.NoError(t, unlimited.waitForFetch(context.Background(), "git"))
+	assert.Len(t, mockMetrics.RecordFetchRateLimitedCalls, 1)
+}
+
+func TestRenderURLTemplate(t *testing.T) {
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-config",
+			Namespace: "team-a",
+			Labels: map[string]string{
+				"environment":            "production",
+				"app.kubernetes.io/name": "billing",
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		url     string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "literal URL",
+			url:  "https://api.example.com/config",
+			want: "https://api.example.com/config",
+		},
+		{
+			name: "name, namespace and label field",
+			url:  "https://api.example.com/{{ .Namespace }}/{{.Name}}?env={{ .Labels.environment }}",
+			want: "https://api.example.com/team-a/app-config?env=production",
+		},
+		{
+			name: "label index",
+			url:  `https://api.example.com/apps/{{ index .Labels "app.kubernetes.io/name" }}`,
+			want: "https://api.example.com/apps/billing",
+		},
+		{
+			name:    "missing label",
+			url:     "https://api.example.com/{{ .Labels.region }}",
+			wantErr: "label region is not set",
+		},
+		{
+			name:    "function call",
+			url:     `https://api.example.com/{{ printf "%s" .Name }}`,
+			wantErr: "only .Name, .Namespace and .Labels may be referenced",
+		},
+		{
+			name:    "spec access",
+			url:     "https://api.example.com/{{ .Spec.Generator.Type }}",
+			wantErr: "only .Name, .Namespace and .Labels may be referenced",
+		},
+		{
+			name:    "unbalanced braces",
+			url:     "https://api.example.com/{{ .Name",
+			wantErr: "unbalanced braces",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderURLTemplate(externalSource, tt.url)
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Contains(t, err.Error(), "invalid URL")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDestinationPath(t *testing.T) {
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "team-a"},
+	}
+	cfg := config.DefaultConfig()
+	reconciler := &ExternalSourceReconciler{Config: cfg}
+
+	// Without a spec path or controller default the data is placed at "data"
+	path, err := reconciler.destinationPath(externalSource)
+	assert.NoError(t, err)
+	assert.Equal(t, "data", path)
+
+	cfg.Storage.DefaultDestinationPath = "{{ .Namespace }}/{{.Name}}.json"
+	path, err = reconciler.destinationPath(externalSource)
+	assert.NoError(t, err)
+	assert.Equal(t, "team-a/app-config.json", path)
+
+	// The spec path takes precedence over the controller default
+	externalSource.Spec.DestinationPath = "config/{{ .Name }}.yaml"
+	path, err = reconciler.destinationPath(externalSource)
+	assert.NoError(t, err)
+	assert.Equal(t, "config/app-config.yaml", path)
+
+	externalSource.Spec.DestinationPath = "{{ .Labels.app }}.yaml"
+	_, err = reconciler.destinationPath(externalSource)
+	assert.ErrorContains(t, err, "only .Name and .Namespace may be referenced")
+	assert.Equal(t, ConfigurationError, reconciler.classifyError(err))
+
+	externalSource.Spec.DestinationPath = "{{ .Name.yaml"
+	_, err = reconciler.destinationPath(externalSource)
+	assert.ErrorContains(t, err, "unbalanced braces")
+}
+
+func TestHistoryLimit(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Storage.HistoryLimit = 3
+	reconciler := &ExternalSourceReconciler{Config: cfg}
+
+	externalSource := &sourcev1alpha1.ExternalSource{}
+	assert.Equal(t, 3, reconciler.historyLimit(externalSource))
+
+	limit := int32(5)
+	externalSource.Spec.HistoryLimit = &limit
+	assert.Equal(t, 5, reconciler.historyLimit(externalSource))
+}
+
+func TestCreateGeneratorConfig_VersionURL(t *testing.T) {
+	reconciler := &ExternalSourceReconciler{}
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "config",
+			Namespace: "team-a",
+		},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+					URL:        "https://api.example.com/{{ .Namespace }}/config",
+					VersionURL: "https://api.example.com/{{ .Namespace }}/version",
+				},
+			},
+		},
+	}
+
+	genConfig, err := reconciler.createGeneratorConfig(externalSource)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://api.example.com/team-a/config", genConfig.Config["url"])
+	assert.Equal(t, "https://api.example.com/team-a/version", genConfig.Config["versionURL"])
+
+	externalSource.Spec.Generator.HTTP.VersionURL = ""
+	genConfig, err = reconciler.createGeneratorConfig(externalSource)
+	assert.NoError(t, err)
+	assert.NotContains(t, genConfig.Config, "versionURL")
+}
+
+func TestRequestSigner(t *testing.T) {
+	newSource := func(hookSpecs ...sourcev1alpha1.HookSpec) *sourcev1alpha1.ExternalSource {
+		return &sourcev1alpha1.ExternalSource{
+			ObjectMeta: metav1.ObjectMeta{Name: "signed", Namespace: "default"},
+			Spec: sourcev1alpha1.ExternalSourceSpec{
+				Hooks: &sourcev1alpha1.HooksSpec{PreRequest: hookSpecs},
+			},
+		}
+	}
+	request := generator.RequestMetadata{
+		Method:  "GET",
+		URL:     "https://api.example.com/data",
+		Headers: map[string]string{"Accept": "application/json"},
+	}
+
+	t.Run("no pre-request hooks", func(t *testing.T) {
+		reconciler := &ExternalSourceReconciler{HookExecutor: &MockHookExecutor{}}
+		assert.Nil(t, reconciler.requestSigner(newSource()))
+		assert.Nil(t, reconciler.requestSigner(&sourcev1alpha1.ExternalSource{}))
+	})
+
+	t.Run("chains hooks and returns their headers", func(t *testing.T) {
+		var inputs []generator.RequestMetadata
+		reconciler := &ExternalSourceReconciler{
+			HookExecutor: &MockHookExecutor{
+				ExecuteFunc: func(ctx context.Context, input []byte, hook sourcev1alpha1.HookSpec) ([]byte, error) {
+					var received generator.RequestMetadata
+					assert.NoError(t, json.Unmarshal(input, &received))
+					inputs = append(inputs, received)
+					if hook.Name == "date" {
+						return []byte(`{"headers":{"X-Amz-Date":"20251015T000000Z"}}`), nil
+					}
+					return []byte(`{"headers":{"Authorization":"AWS4-HMAC-SHA256 Signature=abc"}}`), nil
+				},
+			},
+		}
+
+		signer := reconciler.requestSigner(newSource(
+			sourcev1alpha1.HookSpec{Name: "date", Command: "date"},
+			sourcev1alpha1.HookSpec{Name: "sign", Command: "sign"},
+		))
+		headers, err := signer(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"X-Amz-Date":    "20251015T000000Z",
+			"Authorization": "AWS4-HMAC-SHA256 Signature=abc",
+		}, headers)
+
+		assert.Len(t, inputs, 2)
+		assert.Equal(t, request, inputs[0])
+		assert.Equal(t, "20251015T000000Z", inputs[1].Headers["X-Amz-Date"], "later hooks see earlier headers")
+		assert.Len(t, request.Headers, 1, "the request must not be modified")
+	})
+
+	t.Run("skips hooks whose failure is ignored", func(t *testing.T) {
+		reconciler := &ExternalSourceReconciler{
+			HookExecutor: &MockHookExecutor{
+				ExecuteFunc: func(ctx context.Context, input []byte, hook sourcev1alpha1.HookSpec) ([]byte, error) {
+					return nil, fmt.Errorf("exit status 1")
+				},
+			},
+		}
+
+		signer := reconciler.requestSigner(newSource(sourcev1alpha1.HookSpec{Name: "sign", Command: "sign", RetryPolicy: "ignore"}))
+		headers, err := signer(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Empty(t, headers)
+	})
+
+	t.Run("rejects invalid output", func(t *testing.T) {
+		for _, output := range []string{
+			`not json`,
+			`{}`,
+			`{"headers":{"Authorization":1}}`,
+			`{"headers":{},"method":"POST"}`,
+			`{"headers":{"Bad Name":"value"}}`,
+			`{"headers":{"X-Signature":"a\nb"}}`,
+			`{"headers":{}} {"headers":{}}`,
+		} {
+			reconciler := &ExternalSourceReconciler{
+				HookExecutor: &MockHookExecutor{
+					ExecuteFunc: func(ctx context.Context, input []byte, hook sourcev1alpha1.HookSpec) ([]byte, error) {
+						return []byte(output), nil
+					},
+				},
+			}
+
+			signer := reconciler.requestSigner(newSource(sourcev1alpha1.HookSpec{Name: "sign", Command: "sign"}))
+			_, err := signer(context.Background(), request)
+			assert.ErrorContains(t, err, "pre-request hook sign returned invalid output", output)
+		}
+	})
+}
+
+func TestExternalSourceReconciler_startupDelay(t *testing.T) {
+	newSource := func(name string) *sourcev1alpha1.ExternalSource {
+		return &sourcev1alpha1.ExternalSource{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Generation: 1},
+			Status: sourcev1alpha1.ExternalSourceStatus{
+				ObservedGeneration: 1,
+				Artifact:           &sourcev1alpha1.ArtifactMetadata{Revision: "abc123"},
+			},
+		}
+	}
+	newReconciler := func(spread float64) *ExternalSourceReconciler {
+		cfg := createTestConfig()
+		cfg.Controller.StartupSpread = spread
+		return &ExternalSourceReconciler{Config: cfg}
+	}
+	interval := 10 * time.Minute
+
+	t.Run("spreads the first reconciliation deterministically", func(t *testing.T) {
+		delay := newReconciler(0.5).startupDelay(newSource("app"), interval)
+		assert.Greater(t, delay, time.Duration(0))
+		assert.Less(t, delay, interval/2)
+
+		// The same source gets the same delay after every restart
+		assert.Equal(t, delay, newReconciler(0.5).startupDelay(newSource("app"), interval))
+	})
+
+	t.Run("only delays the first reconciliation", func(t *testing.T) {
+		reconciler := newReconciler(1)
+		assert.Greater(t, reconciler.startupDelay(newSource("app"), interval), time.Duration(0))
+		assert.Zero(t, reconciler.startupDelay(newSource("app"), interval))
+	})
+
+	t.Run("fans out sources", func(t *testing.T) {
+		reconciler := newReconciler(1)
+		delays := make(map[time.Duration]bool)
+		for _, name := range []string{"app-1", "app-2", "app-3", "app-4"} {
+			delays[reconciler.startupDelay(newSource(name), interval)] = true
+		}
+		assert.Len(t, delays, 4)
+	})
+
+	t.Run("does not delay sources that need a fetch", func(t *testing.T) {
+		reconciler := newReconciler(1)
+
+		withoutArtifact := newSource("new")
+		withoutArtifact.Status.Artifact = nil
+		assert.Zero(t, reconciler.startupDelay(withoutArtifact, interval))
+
+		specChanged := newSource("changed")
+		specChanged.Generation = 2
+		assert.Zero(t, reconciler.startupDelay(specChanged, interval))
+
+		requested := newSource("requested")
+		requested.Annotations = map[string]string{fluxmeta.ReconcileRequestAnnotation: "2025-01-01T12:00:00Z"}
+		assert.Zero(t, reconciler.startupDelay(requested, interval))
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		assert.Zero(t, newReconciler(0).startupDelay(newSource("app"), interval))
+	})
+}
+
+func TestReferencedSecrets(t *testing.T) {
+	externalSource := &sourcev1alpha1.ExternalSource{
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+					URL:               "https://example.com/config.json",
+					HeadersSecretRef:  &sourcev1alpha1.HeadersSecretReference{Name: "headers"},
+					CABundleSecretRef: &sourcev1alpha1.SecretKeyReference{Name: "ca", Key: "ca.crt"},
+					OAuth2: &sourcev1alpha1.OAuth2ClientCredentials{
+						TokenURL:        "https://auth.example.com/token",
+						ClientSecretRef: sourcev1alpha1.SecretReference{Name: "oauth"},
+					},
+				},
+			},
+			Decryption: &sourcev1alpha1.DecryptionSpec{
+				Provider:  "sops",
+				SecretRef: sourcev1alpha1.SecretReference{Name: "sops-keys"},
+			},
+			Hooks: &sourcev1alpha1.HooksSpec{
+				PostRequest: []sourcev1alpha1.HookSpec{{
+					Name:    "transform",
+					Command: "jq",
+					EnvFrom: []sourcev1alpha1.EnvFromSource{{
+						Name:         "TOKEN",
+						SecretKeyRef: sourcev1alpha1.SecretKeyReference{Name: "headers", Key: "token"},
+					}},
+				}},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"ca", "headers", "oauth", "sops-keys"}, referencedSecrets(externalSource))
+	assert.Empty(t, referencedSecrets(&sourcev1alpha1.ExternalSource{}))
+
+	// The secret generator reads its source data from a referenced secret
+	assert.Equal(t, []string{"settings"}, referencedSecrets(&sourcev1alpha1.ExternalSource{
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type:   "secret",
+				Secret: &sourcev1alpha1.SecretKeyReference{Name: "settings", Key: "values.yaml"},
+			},
+		},
+	}))
+}
+
+func TestSecretDataChanged(t *testing.T) {
+	pred := secretDataChanged()
+	oldSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "default", ResourceVersion: "1"},
+		Data:       map[string][]byte{"token": []byte("old")},
+	}
+
+	relabeled := oldSecret.DeepCopy()
+	relabeled.ResourceVersion = "2"
+	relabeled.Labels = map[string]string{"team": "a"}
+	assert.False(t, pred.Update(event.UpdateEvent{ObjectOld: oldSecret, ObjectNew: relabeled}))
+
+	rotated := oldSecret.DeepCopy()
+	rotated.ResourceVersion = "3"
+	rotated.Data["token"] = []byte("new")
+	assert.True(t, pred.Update(event.UpdateEvent{ObjectOld: oldSecret, ObjectNew: rotated}))
+
+	exported := oldSecret.DeepCopy()
+	exported.ResourceVersion = "4"
+	exported.Annotations = map[string]string{generator.SecretExportAnnotation: "true"}
+	assert.True(t, pred.Update(event.UpdateEvent{ObjectOld: oldSecret, ObjectNew: exported}))
+
+	assert.True(t, pred.Create(event.CreateEvent{Object: oldSecret}))
+	assert.False(t, pred.Create(event.CreateEvent{Object: oldSecret, IsInInitialList: true}))
+	assert.False(t, pred.Delete(event.DeleteEvent{Object: oldSecret}))
+}
+
+func TestExternalSourceReconciler_sourcesForSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = sourcev1alpha1.AddToScheme(scheme)
+
+	newSource := func(name, namespace, secret string) *sourcev1alpha1.ExternalSource {
+		return &sourcev1alpha1.ExternalSource{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: sourcev1alpha1.ExternalSourceSpec{
+				Generator: sourcev1alpha1.GeneratorSpec{
+					Type: "http",
+					HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+						URL:                  "https://example.com/config.json",
+						BearerTokenSecretRef: &sourcev1alpha1.SecretReference{Name: secret},
+					},
+				},
+			},
+		}
+	}
+
+	reconciler := &ExternalSourceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithIndex(&sourcev1alpha1.ExternalSource{}, secretRefIndexKey, indexSecretRefs).
+			WithObjects(
+				newSource("app", "default", "creds"),
+				newSource("other", "default", "other-creds"),
+				newSource("app", "team-a", "creds"),
+			).Build(),
+		Scheme: scheme,
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "default", ResourceVersion: "7"}}
+	requests := reconciler.sourcesForSecret(context.Background(), secret)
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "app", Namespace: "default"}},
+	}, requests)
+
+	assert.True(t, reconciler.isReferenceChanged(newSource("app", "default", "creds")))
+	assert.False(t, reconciler.isReferenceChanged(newSource("other", "default", "other-creds")))
+	assert.False(t, reconciler.isReferenceChanged(newSource("app", "team-a", "creds")))
+
+	// A handled change is cleared, a change recorded in the meantime is kept
+	key := types.NamespacedName{Name: "app", Namespace: "default"}
+	change, _ := reconciler.changedRefSources.Load(key)
+	secret.ResourceVersion = "8"
+	reconciler.sourcesForSecret(context.Background(), secret)
+	reconciler.clearReferenceChange(key, change)
+	assert.True(t, reconciler.isReferenceChanged(newSource("app", "default", "creds")))
+
+	change, _ = reconciler.changedRefSources.Load(key)
+	reconciler.clearReferenceChange(key, change)
+	assert.False(t, reconciler.isReferenceChanged(newSource("app", "default", "creds")))
+}
+
+func TestExternalSourceReconciler_secretChangeForcesFetch(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = sourcev1alpha1.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
+
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: "app-uid"},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+					URL:               "https://example.com/config.json",
+					CABundleSecretRef: &sourcev1alpha1.SecretKeyReference{Name: "ca", Key: "ca.crt"},
+				},
+			},
+		},
+		Status: sourcev1alpha1.ExternalSourceStatus{
+			Artifact:        &sourcev1alpha1.ArtifactMetadata{Revision: "sha256:abc", URL: "http://example.com/artifact.tar.gz"},
+			LastHandledETag: "etag-1",
+		},
+	}
+
+	fetches := 0
+	reconciler := &ExternalSourceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(externalSource).
+			WithStatusSubresource(&sourcev1.ExternalArtifact{}).Build(),
+		Scheme: scheme,
+		GeneratorFactory: &MockGeneratorFactory{
+			CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+				return &MockSourceGenerator{
+					SupportsConditionalFetchFunc: func() bool { return true },
+					GetLastModifiedFunc: func(ctx context.Context, config generator.GeneratorConfig) (string, error) {
+						return "etag-1", nil
+					},
+					GenerateFunc: func(ctx context.Context, config generator.GeneratorConfig) (*generator.SourceData, error) {
+						fetches++
+						return &generator.SourceData{Data: []byte(`{"name":"app"}`), LastModified: "etag-1"}, nil
+					},
+				}, nil
+			},
+		},
+		ArtifactManager: &MockArtifactManager{},
+	}
+
+	_, err := reconciler.reconcile(context.Background(), externalSource)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, fetches)
+
+	reconciler.changedRefSources.Store(client.ObjectKeyFromObject(externalSource), "ca@2")
+	_, err = reconciler.reconcile(context.Background(), externalSource)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fetches)
+}
+
+func TestExternalSourceReconciler_freshContentSkipsFetch(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = sourcev1alpha1.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Cache-Control", "max-age=3600")
+		_, _ = w.Write([]byte(`{"name":"app"}`))
+	}))
+	defer server.Close()
+
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: "app-uid"},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Interval: "1m",
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: server.URL},
+			},
+		},
+	}
+
+	reconciler := &ExternalSourceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(externalSource).
+			WithStatusSubresource(&sourcev1.ExternalArtifact{}).Build(),
+		Scheme: scheme,
+		GeneratorFactory: &MockGeneratorFactory{
+			CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+				return generator.NewHTTPGenerator(nil), nil
+			},
+		},
+		ArtifactManager: &MockArtifactManager{},
+	}
+
+	_, err := reconciler.reconcile(context.Background(), externalSource)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+	if assert.NotNil(t, externalSource.Status.FreshUntil) {
+		assert.WithinDuration(t, time.Now().Add(time.Hour), externalSource.Status.FreshUntil.Time, time.Minute)
+	}
+
+	// Within the freshness window the current artifact is reused without a request
+	_, err = reconciler.reconcile(context.Background(), externalSource)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+	assert.True(t, apimeta.IsStatusConditionTrue(externalSource.Status.Conditions, ReadyCondition))
+
+	// Stale data is fetched again
+	externalSource.Status.FreshUntil = &metav1.Time{Time: time.Now().Add(-time.Second)}
+	_, err = reconciler.reconcile(context.Background(), externalSource)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+
+	// A requested reconcile bypasses the freshness window
+	externalSource.Annotations = map[string]string{fluxmeta.ReconcileRequestAnnotation: "2025-01-01T12:00:00Z"}
+	_, err = reconciler.reconcile(context.Background(), externalSource)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+func TestExternalSourceReconciler_failedReconcileRequestHandled(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = sourcev1alpha1.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
+
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "app",
+			Namespace:   "default",
+			Finalizers:  []string{ExternalSourceFinalizer},
+			Annotations: map[string]string{fluxmeta.ReconcileRequestAnnotation: "2025-01-01T12:00:00Z"},
+		},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Interval: "5m",
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/config.json"},
+			},
+		},
+	}
+
+	reconciler := &ExternalSourceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(externalSource).
+			WithStatusSubresource(&sourcev1alpha1.ExternalSource{}, &sourcev1.ExternalArtifact{}).Build(),
+		Scheme: scheme,
+		GeneratorFactory: &MockGeneratorFactory{
+			CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+				return &MockSourceGenerator{
+					GenerateFunc: func(ctx context.Context, _ generator.GeneratorConfig) (*generator.SourceData, error) {
+						return nil, fmt.Errorf("connection refused")
+					},
+				}, nil
+			},
+		},
+		ArtifactManager: &MockArtifactManager{},
+		Config:          createTestConfig(),
+	}
+
+	// A failed reconcile handles the request too, so retries don't force another fetch
+	key := types.NamespacedName{Name: "app", Namespace: "default"}
+	result, err := reconciler.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+	assert.NoError(t, err)
+	assert.Positive(t, result.RequeueAfter)
+
+	var updated sourcev1alpha1.ExternalSource
+	assert.NoError(t, reconciler.Get(context.Background(), key, &updated))
+	assert.Equal(t, "2025-01-01T12:00:00Z", updated.Status.GetLastHandledReconcileRequest())
+	assert.False(t, apimeta.IsStatusConditionTrue(updated.Status.Conditions, ReadyCondition))
+	assert.False(t, reconciler.isReconcileRequested(&updated))
+}
+
+func TestExternalSourceReconciler_contentHashStrategy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = sourcev1alpha1.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
+
+	// The ETag changes on every response as if rewritten by a proxy
+	var requests atomic.Int32
+	var body atomic.Value
+	body.Store(`{"name":"app"}`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		assert.Empty(t, r.Header.Get("If-None-Match"))
+		w.Header().Set("ETag", fmt.Sprintf(`"%d"`, n))
+		_, _ = w.Write([]byte(body.Load().(string)))
+	}))
+	defer server.Close()
+
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: "app-uid"},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Interval:            "1m",
+			ConditionalStrategy: "contentHash",
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: server.URL},
+			},
+		},
+	}
+
+	var packaged int
+	reconciler := &ExternalSourceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(externalSource).
+			WithStatusSubresource(&sourcev1.ExternalArtifact{}).Build(),
+		Scheme: scheme,
+		GeneratorFactory: &MockGeneratorFactory{
+			CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+				return generator.NewHTTPGenerator(nil), nil
+			},
+		},
+		ArtifactManager: &MockArtifactManager{
+			PackageFunc: func(ctx context.Context, data []byte, path string) (*artifact.Artifact, error) {
+				packaged++
+				revision, err := artifact.Digest(artifact.DigestSHA256, data)
+				if err != nil {
+					return nil, err
+				}
+				return &artifact.Artifact{
+					Data:     data,
+					Path:     path,
+					Revision: revision,
+					Metadata: map[string]string{"digestAlgorithm": artifact.DigestSHA256},
+				}, nil
+			},
+		},
+	}
+
+	_, err := reconciler.reconcile(context.Background(), externalSource)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, packaged)
+	revision := externalSource.Status.Artifact.Revision
+
+	// Byte-identical content is fetched in full but doesn't produce a new artifact
+	_, err = reconciler.reconcile(context.Background(), externalSource)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Equal(t, 1, packaged)
+	assert.Equal(t, revision, externalSource.Status.Artifact.Revision)
+	assert.True(t, apimeta.IsStatusConditionTrue(externalSource.Status.Conditions, ReadyCondition))
+
+	// Changed content is published
+	body.Store(`{"name":"app","replicas":2}`)
+	_, err = reconciler.reconcile(context.Background(), externalSource)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, packaged)
+	assert.NotEqual(t, revision, externalSource.Status.Artifact.Revision)
+}
+
+func TestContentUnchanged(t *testing.T) {
+	data := []byte(`{"name":"app"}`)
+	sha256Revision, _ := artifact.Digest(artifact.DigestSHA256, data)
+	sha512Revision, _ := artifact.Digest(artifact.DigestSHA512, data)
+	canonicalRevision, _ := artifact.Digest(artifact.DigestSHA256, artifact.Canonicalize([]byte(`{ "name": "app" }`)))
+
+	tests := []struct {
+		name         string
+		algorithm    string
+		canonicalize bool
+		current      *sourcev1alpha1.ArtifactMetadata
+		want         bool
+	}{
+		{name: "no artifact", want: false},
+		{
+			name:    "same content",
+			current: &sourcev1alpha1.ArtifactMetadata{Revision: sha256Revision},
+			want:    true,
+		},
+		{
+			name:    "different content",
+			current: &sourcev1alpha1.ArtifactMetadata{Revision: "0123abcd"},
+			want:    false,
+		},
+		{
+			name: "recorded algorithm",
+			current: &sourcev1alpha1.ArtifactMetadata{
+				Revision: sha512Revision,
+				Metadata: map[string]string{"digestAlgorithm": artifact.DigestSHA512},
+			},
+			want: true,
+		},
+		{
+			name:      "changed algorithm",
+			algorithm: artifact.DigestSHA512,
+			current:   &sourcev1alpha1.ArtifactMetadata{Revision: sha256Revision},
+			want:      false,
+		},
+		{
+			name:         "canonicalized content",
+			canonicalize: true,
+			current:      &sourcev1alpha1.ArtifactMetadata{Revision: canonicalRevision},
+			want:         true,
+		},
+		{
+			name:         "canonicalize enabled after packaging raw content",
+			canonicalize: true,
+			current:      &sourcev1alpha1.ArtifactMetadata{Revision: sha256Revision},
+			want:         false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			externalSource := &sourcev1alpha1.ExternalSource{
+				Spec:   sourcev1alpha1.ExternalSourceSpec{DigestAlgorithm: tt.algorithm, Canonicalize: tt.canonicalize},
+				Status: sourcev1alpha1.ExternalSourceStatus{Artifact: tt.current},
+			}
+			assert.Equal(t, tt.want, contentUnchanged(externalSource, data))
+		})
+	}
+}
+
+func TestExternalSourceReconciler_clampInterval(t *testing.T) {
+	cfg := createTestConfig()
+	reconciler := &ExternalSourceReconciler{Config: cfg}
+
+	assert.Equal(t, time.Minute, reconciler.clampInterval(10*time.Second))
+	assert.Equal(t, 24*time.Hour, reconciler.clampInterval(24*time.Hour))
+
+	cfg.Controller.MinInterval = 15 * time.Second
+	cfg.Controller.MaxInterval = time.Hour
+	assert.Equal(t, 15*time.Second, reconciler.clampInterval(10*time.Second))
+	assert.Equal(t, 30*time.Second, reconciler.clampInterval(30*time.Second))
+	assert.Equal(t, time.Hour, reconciler.clampInterval(24*time.Hour))
+}
+
+func TestExternalSourceReconciler_intervalClampedEvent(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = sourcev1alpha1.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
+
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "app",
+			Namespace:  "default",
+			Finalizers: []string{ExternalSourceFinalizer},
+		},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Interval: "30s",
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/config.json"},
+			},
+		},
+	}
+
+	cfg := createTestConfig()
+	cfg.Controller.MinInterval = 5 * time.Minute
+	recorder := record.NewFakeRecorder(10)
+	reconciler := &ExternalSourceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(externalSource).
+			WithStatusSubresource(&sourcev1alpha1.ExternalSource{}, &sourcev1.ExternalArtifact{}).Build(),
+		Scheme: scheme,
+		GeneratorFactory: &MockGeneratorFactory{
+			CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+				return &MockSourceGenerator{}, nil
+			},
+		},
+		ArtifactManager: &MockArtifactManager{},
+		Config:          cfg,
+		EventRecorder:   recorder,
+	}
+
+	result, err := reconciler.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: "app", Namespace: "default"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, result.RequeueAfter)
+	if assert.Len(t, recorder.Events, 1) {
+		assert.Equal(t, "Warning IntervalClamped Interval 30s is outside the range allowed by the controller, using 5m0s", <-recorder.Events)
+	}
+}
+
+func TestExternalSourceReconciler_insecureSkipVerifyPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		deny    bool
+		fetched bool
+	}{
+		{name: "denied", deny: true, fetched: false},
+		{name: "allowed", deny: false, fetched: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = sourcev1alpha1.AddToScheme(scheme)
+			_ = sourcev1.AddToScheme(scheme)
+
+			externalSource := &sourcev1alpha1.ExternalSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "app",
+					Namespace:  "default",
+					Finalizers: []string{ExternalSourceFinalizer},
+				},
+				Spec: sourcev1alpha1.ExternalSourceSpec{
+					Interval: "5m",
+					Generator: sourcev1alpha1.GeneratorSpec{
+						Type: "http",
+						HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+							URL:                "https://example.com/config.json",
+							InsecureSkipVerify: true,
+						},
+					},
+				},
+			}
+
+			cfg := createTestConfig()
+			cfg.HTTP.DenyInsecureSkipVerify = tt.deny
+			fetched := false
+			recorder := record.NewFakeRecorder(10)
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(externalSource).
+				WithStatusSubresource(&sourcev1alpha1.ExternalSource{}, &sourcev1.ExternalArtifact{}).Build()
+			reconciler := &ExternalSourceReconciler{
+				Client: k8sClient,
+				Scheme: scheme,
+				GeneratorFactory: &MockGeneratorFactory{
+					CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+						return &MockSourceGenerator{
+							GenerateFunc: func(ctx context.Context, config generator.GeneratorConfig) (*generator.SourceData, error) {
+								fetched = true
+								return &generator.SourceData{Data: []byte("data")}, nil
+							},
+						}, nil
+					},
+				},
+				ArtifactManager: &MockArtifactManager{},
+				Config:          cfg,
+				EventRecorder:   recorder,
+			}
+
+			key := types.NamespacedName{Name: "app", Namespace: "default"}
+			result, err := reconciler.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.fetched, fetched)
+
+			var updated sourcev1alpha1.ExternalSource
+			assert.NoError(t, k8sClient.Get(context.Background(), key, &updated))
+			ready := apimeta.FindStatusCondition(updated.Status.Conditions, ReadyCondition)
+			if !tt.deny {
+				assert.Equal(t, metav1.ConditionTrue, ready.Status)
+				return
+			}
+
+			assert.Equal(t, reconcile.Result{}, result)
+			assert.Equal(t, metav1.ConditionFalse, ready.Status)
+			assert.Equal(t, PolicyViolationReason, ready.Reason)
+			assert.Contains(t, ready.Message, "insecureSkipVerify is not allowed")
+			if assert.Len(t, recorder.Events, 1) {
+				assert.Contains(t, <-recorder.Events, "Warning PolicyViolation insecureSkipVerify is not allowed")
+			}
+		})
+	}
+}
+
+func TestWithCorrelationID(t *testing.T) {
+	key := types.NamespacedName{Name: "app", Namespace: "default"}
+
+	first := hooks.CorrelationIDFromContext(withCorrelationID(context.Background(), key))
+	second := hooks.CorrelationIDFromContext(withCorrelationID(context.Background(), key))
+	assert.NotEmpty(t, first)
+	assert.NotEqual(t, first, second)
+}
+
+func TestMaintenanceWindowEnd(t *testing.T) {
+	// Monday 2025-06-02 20:30 UTC
+	now := time.Date(2025, 6, 2, 20, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		windows     []sourcev1alpha1.MaintenanceWindow
+		expected    time.Time
+		expectError string
+	}{
+		{
+			name: "no windows",
+		},
+		{
+			name:     "inside a daily window",
+			windows:  []sourcev1alpha1.MaintenanceWindow{{Schedule: "0 20 * * *", Duration: "1h"}},
+			expected: time.Date(2025, 6, 2, 21, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "after a daily window",
+			windows: []sourcev1alpha1.MaintenanceWindow{{Schedule: "0 20 * * *", Duration: "30m"}},
+		},
+		{
+			name:    "before a daily window",
+			windows: []sourcev1alpha1.MaintenanceWindow{{Schedule: "0 21 * * *", Duration: "1h"}},
+		},
+		{
+			name:     "window crossing midnight in a time zone",
+			windows:  []sourcev1alpha1.MaintenanceWindow{{Schedule: "0 16 * * 1", Duration: "8h", TimeZone: "America/New_York"}},
+			expected: time.Date(2025, 6, 3, 4, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "overlapping windows end with the latest",
+			windows: []sourcev1alpha1.MaintenanceWindow{
+				{Schedule: "0 20 * * *", Duration: "1h"},
+				{Schedule: "15 20 * * *", Duration: "2h"},
+			},
+			expected: time.Date(2025, 6, 2, 22, 15, 0, 0, time.UTC),
+		},
+		{
+			name:     "overlapping occurrences of one window",
+			windows:  []sourcev1alpha1.MaintenanceWindow{{Schedule: "*/10 * * * *", Duration: "25m"}},
+			expected: time.Date(2025, 6, 2, 20, 55, 0, 0, time.UTC),
+		},
+		{
+			name:        "invalid schedule",
+			windows:     []sourcev1alpha1.MaintenanceWindow{{Schedule: "0 25 * * *", Duration: "1h"}},
+			expectError: "invalid maintenance window 0: invalid schedule",
+		},
+		{
+			name:        "invalid time zone",
+			windows:     []sourcev1alpha1.MaintenanceWindow{{Schedule: "@daily", Duration: "1h", TimeZone: "Mars/Olympus"}},
+			expectError: "invalid time zone",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			end, err := maintenanceWindowEnd(tt.windows, now)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, tt.expected.Equal(end), "expected %v, got %v", tt.expected, end)
+		})
+	}
+}
+
+func TestExternalSourceReconciler_maintenanceWindow(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = sourcev1alpha1.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
+
+	// A window that started a minute ago and lasts an hour
+	start := time.Now().UTC().Add(-time.Minute)
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "app",
+			Namespace:  "default",
+			Finalizers: []string{ExternalSourceFinalizer},
+		},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Interval: "5m",
+			MaintenanceWindows: []sourcev1alpha1.MaintenanceWindow{{
+				Schedule: fmt.Sprintf("%d %d * * *", start.Minute(), start.Hour()),
+				Duration: "1h",
+			}},
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/config.json"},
+			},
+		},
+		Status: sourcev1alpha1.ExternalSourceStatus{
+			Conditions: []metav1.Condition{{
+				Type:               ReadyCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             SucceededReason,
+				LastTransitionTime: metav1.Now(),
+			}},
+		},
+	}
+
+	fetched := false
+	reconciler := &ExternalSourceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(externalSource).
+			WithStatusSubresource(&sourcev1alpha1.ExternalSource{}, &sourcev1.ExternalArtifact{}).Build(),
+		Scheme: scheme,
+		GeneratorFactory: &MockGeneratorFactory{
+			CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+				fetched = true
+				return &MockSourceGenerator{}, nil
+			},
+		},
+		ArtifactManager: &MockArtifactManager{},
+		Config:          createTestConfig(),
+	}
+
+	key := types.NamespacedName{Name: "app", Namespace: "default"}
+	result, err := reconciler.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+	assert.NoError(t, err)
+	assert.False(t, fetched)
+	assert.InDelta(t, 59*time.Minute, result.RequeueAfter, float64(time.Minute))
+
+	var updated sourcev1alpha1.ExternalSource
+	assert.NoError(t, reconciler.Get(context.Background(), key, &updated))
+	ready := apimeta.FindStatusCondition(updated.Status.Conditions, ReadyCondition)
+	if assert.NotNil(t, ready) {
+		assert.Equal(t, metav1.ConditionTrue, ready.Status)
+		assert.Equal(t, MaintenanceReason, ready.Reason)
+	}
+}
+
+func TestNextRequeue(t *testing.T) {
+	// Monday 2025-06-02 20:30 UTC
+	now := time.Date(2025, 6, 2, 20, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		spec        sourcev1alpha1.ExternalSourceSpec
+		expected    time.Duration
+		expectError string
+	}{
+		{
+			name:     "interval",
+			spec:     sourcev1alpha1.ExternalSourceSpec{Interval: "5m"},
+			expected: 5 * time.Minute,
+		},
+		{
+			name:     "short interval is clamped by the reconciler",
+			spec:     sourcev1alpha1.ExternalSourceSpec{Interval: "10s"},
+			expected: 10 * time.Second,
+		},
+		{
+			name:     "schedule in UTC skips to next business day",
+			spec:     sourcev1alpha1.ExternalSourceSpec{Schedule: "0 9-17 * * 1-5"},
+			expected: 12*time.Hour + 30*time.Minute,
+		},
+		{
+			name: "schedule in time zone",
+			spec: sourcev1alpha1.ExternalSourceSpec{
+				Schedule: "0 9-17 * * 1-5",
+				TimeZone: "America/New_York",
+			},
+			// 16:30 in New York, next tick at 17:00 local
+			expected: 30 * time.Minute,
+		},
+		{
+			name:     "descriptor",
+			spec:     sourcev1alpha1.ExternalSourceSpec{Schedule: "@hourly"},
+			expected: 30 * time.Minute,
+		},
+		{
+			name:        "invalid interval",
+			spec:        sourcev1alpha1.ExternalSourceSpec{Interval: "x"},
+			expectError: "invalid interval",
+		},
+		{
+			name:        "invalid schedule",
+			spec:        sourcev1alpha1.ExternalSourceSpec{Schedule: "61 * * * *"},
+			expectError: "invalid schedule",
+		},
+		{
+			name:        "invalid time zone",
+			spec:        sourcev1alpha1.ExternalSourceSpec{Schedule: "@daily", TimeZone: "Mars/Olympus"},
+			expectError: "invalid time zone",
+		},
+	}
+
+	reconciler := &ExternalSourceReconciler{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := nextRequeue(tt.spec, now)
+			if tt.expectError != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.expectError)
+					assert.Equal(t, ConfigurationError, reconciler.classifyError(err))
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExternalSourceReconciler_executeHooksTransportError(t *testing.T) {
+	calls := 0
+	reconciler := &ExternalSourceReconciler{
+		HookExecutor: &MockHookExecutor{
+			ExecuteFunc: func(ctx context.Context, input []byte, hook sourcev1alpha1.HookSpec) ([]byte, error) {
+				calls++
+				return nil, &hooks.TransportError{Err: fmt.Errorf("dial tcp 127.0.0.1:8081: connect: connection refused")}
+			},
+		},
+	}
+
+	externalSource := &sourcev1alpha1.ExternalSource{
+		Spec: sourcev1alpha1.ExternalSourceSpec{MaxRetries: 3},
+	}
+
+	for _, policy := range []string{"retry", "ignore", "fail"} {
+		t.Run(policy, func(t *testing.T) {
+			calls = 0
+			hookSpecs := []sourcev1alpha1.HookSpec{{Name: "transform", Command: "jq", RetryPolicy: policy}}
+
+			_, err := reconciler.executeHooks(context.Background(), externalSource, []byte("{}"), hookSpecs)
+			if assert.Error(t, err) {
+				assert.True(t, hooks.IsTransportError(err))
+				assert.Equal(t, TransientError, reconciler.classifyError(err))
+			}
+			assert.Equal(t, 1, calls, "transport errors should not consume the hook retry budget")
+		})
+	}
+
+	// Transport errors stay transient even when the message matches a permanent pattern
+	notFoundErr := &hooks.TransportError{Err: fmt.Errorf("sidecar returned status 503: upstream not found")}
+	assert.Equal(t, TransientError, reconciler.classifyError(notFoundErr))
+}
+
+func TestClassifyError_GRPCStatus(t *testing.T) {
+	reconciler := &ExternalSourceReconciler{}
+
+	tests := []struct {
+		code codes.Code
+		want ErrorType
+	}{
+		{codes.Unavailable, TransientError},
+		{codes.DeadlineExceeded, TransientError},
+		{codes.NotFound, PermanentError},
+		{codes.PermissionDenied, PermanentError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			err := fmt.Errorf("failed to generate source data: gRPC call /example.Config/Get failed: %w",
+				status.Error(tt.code, "upstream not found"))
+			assert.Equal(t, tt.want, reconciler.classifyError(err))
+		})
+	}
+}
+
+func TestClassifyError_DigestMismatch(t *testing.T) {
+	reconciler := &ExternalSourceReconciler{}
+
+	// A digest mismatch usually means a partial transfer, so it is retried even when the
+	// digests contain a status code that is otherwise classified as permanent
+	err := fmt.Errorf("failed to generate source data: %w", &generator.DigestMismatchError{
+		Header:    "Digest",
+		Algorithm: "sha-256",
+		Expected:  "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		Actual:    "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+	})
+	assert.Equal(t, TransientError, reconciler.classifyError(err))
+
+	err = fmt.Errorf("failed to generate source data: %w", &generator.DigestMismatchError{
+		Header:    "Content-Digest",
+		Algorithm: "sha-256",
+		Expected:  "eebbf6457e46a7f63acdf9b97390f790ba443d60cfa44b607da7e5c40aa1cc1d",
+		Actual:    "b651d59190ff6614095294779699a03c259f2cb4e1d71b1404a71d588078c85b",
+	})
+	assert.Contains(t, err.Error(), "404")
+	assert.Equal(t, TransientError, reconciler.classifyError(err))
+}
+
+func TestClassifyError_OAuth2TokenError(t *testing.T) {
+	reconciler := &ExternalSourceReconciler{}
+
+	// A rejected token request is retried even though its status code looks permanent
+	err := fmt.Errorf("failed to parse HTTP config: %w", &generator.OAuth2TokenError{
+		TokenURL: "https://auth.example.com/token",
+		Err:      fmt.Errorf("oauth2: cannot fetch token: 401 Unauthorized"),
+	})
+	assert.Equal(t, TransientError, reconciler.classifyError(err))
+}
+
+func TestClassifyError_SecretNotExported(t *testing.T) {
+	reconciler := &ExternalSourceReconciler{}
+
+	err := fmt.Errorf("failed to generate source data: %w",
+		&generator.SecretNotExportedError{Namespace: "default", Name: "credentials"})
+	assert.Equal(t, ConfigurationError, reconciler.classifyError(err))
+}
+
+func TestClassifyError_CircuitOpen(t *testing.T) {
+	reconciler := &ExternalSourceReconciler{}
+
+	// Short-circuited requests are retried once the breaker lets a trial request through
+	breaker := generator.NewCircuitBreaker(1, time.Minute, nil)
+	breaker.Record("api.example.com", false)
+	err := fmt.Errorf("failed to generate source data: %w", &url.Error{
+		Op:  "Get",
+		URL: "https://api.example.com/config.json",
+		Err: breaker.Allow("api.example.com"),
+	})
+	assert.ErrorIs(t, err, generator.ErrCircuitOpen)
+	assert.Equal(t, TransientError, reconciler.classifyError(err))
+
+	// Hosts and failure counts that look like permanent status codes don't change that
+	err = fmt.Errorf("failed to generate source data: %w",
+		fmt.Errorf("%w for host %s after %d consecutive failures, retrying in %s",
+			generator.ErrCircuitOpen, "api404.example.com", 403, time.Minute))
+	assert.Equal(t, TransientError, reconciler.classifyError(err))
+}
+
+func TestClassifyError_DNS(t *testing.T) {
+	reconciler := &ExternalSourceReconciler{}
+
+	lookupError := func(dnsErr *net.DNSError) error {
+		err := error(&url.Error{
+			Op:  "Get",
+			URL: "https://api.exmaple.com/config.json",
+			Err: &net.OpError{Op: "dial", Net: "tcp", Err: dnsErr},
+		})
+		if dnsErr.IsNotFound && !dnsErr.IsTemporary {
+			err = &generator.SourceHostNotFoundError{Host: dnsErr.Name, Err: err}
+		}
+		return fmt.Errorf("failed to generate source data: %w", fmt.Errorf("HTTP request failed: %w", err))
+	}
+
+	tests := []struct {
+		name   string
+		dnsErr *net.DNSError
+		want   ErrorType
+	}{
+		{
+			name:   "NXDOMAIN",
+			dnsErr: &net.DNSError{Err: "no such host", Name: "api.exmaple.com", IsNotFound: true},
+			want:   ConfigurationError,
+		},
+		{
+			name:   "resolver timeout",
+			dnsErr: &net.DNSError{Err: "i/o timeout", Name: "api.example.com", IsTimeout: true, IsTemporary: true},
+			want:   TransientError,
+		},
+		{
+			name:   "SERVFAIL",
+			dnsErr: &net.DNSError{Err: "server misbehaving", Name: "api.example.com", IsTemporary: true},
+			want:   TransientError,
+		},
+		{
+			name:   "temporary not found",
+			dnsErr: &net.DNSError{Err: "no such host", Name: "api.example.com", IsNotFound: true, IsTemporary: true},
+			want:   TransientError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, reconciler.classifyError(lookupError(tt.dnsErr)))
+		})
+	}
+
+	// A host that doesn't exist outside the source fetch, e.g. the storage endpoint or a
+	// Service during a rollout, is retried
+	storageErr := fmt.Errorf("failed to store artifact: %w", &url.Error{
+		Op:  "Put",
+		URL: "https://minio.storage.svc:9000/artifacts",
+		Err: &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "minio.storage.svc", IsNotFound: true}},
+	})
+	assert.Equal(t, TransientError, reconciler.classifyError(storageErr))
+}
+
+func TestArtifactDigest(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		expected string
+	}{
+		{
+			name:     "no metadata defaults to sha256",
+			expected: "sha256:abc123",
+		},
+		{
+			name:     "recorded sha256",
+			metadata: map[string]string{"digestAlgorithm": "sha256"},
+			expected: "sha256:abc123",
+		},
+		{
+			name:     "recorded sha512",
+			metadata: map[string]string{"digestAlgorithm": "sha512"},
+			expected: "sha512:abc123",
+		},
+		{
+			name: "digest recorded by the storage backend",
+			metadata: map[string]string{
+				"digestAlgorithm":                "sha256",
+				artifact.StoredDigestMetadataKey: "sha256:def456",
+			},
+			expected: "sha256:def456",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, artifactDigest("abc123", tt.metadata))
+		})
+	}
+}
+
+func TestExternalSourceReconciler_getConditionMessage(t *testing.T) {
+	reconciler := &ExternalSourceReconciler{}
+
+	externalSource := &sourcev1alpha1.ExternalSource{
+		Status: sourcev1alpha1.ExternalSourceStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:    ReadyCondition,
+					Status:  metav1.ConditionTrue,
+					Message: "Ready message",
+				},
+				{
+					Type:    FetchingCondition,
+					Status:  metav1.ConditionFalse,
+					Message: "Fetching message",
+				},
+			},
+		},
+	}
+
+	// Test existing condition
+	assert.Equal(t, "Ready message", reconciler.getConditionMessage(externalSource, ReadyCondition))
+	assert.Equal(t, "Fetching message", reconciler.getConditionMessage(externalSource, FetchingCondition))
+
+	// Test non-existing condition
+	assert.Equal(t, "", reconciler.getConditionMessage(externalSource, ExecutingHooksCondition))
+}
+
+func TestExternalSourceReconciler_clearProgressConditions(t *testing.T) {
+	reconciler := &ExternalSourceReconciler{}
+
+	externalSource := &sourcev1alpha1.ExternalSource{
+		Status: sourcev1alpha1.ExternalSourceStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:   ReadyCondition,
+					Status: metav1.ConditionTrue,
+				},
+				{
+					Type:   FetchingCondition,
+					Status: metav1.ConditionTrue,
+				},
+				{
+					Type:   ExecutingHooksCondition,
+					Status: metav1.ConditionTrue,
+				},
+				{
+					Type:   StoringCondition,
+					Status: metav1.ConditionTrue,
+				},
+			},
+		},
+	}
+
+	reconciler.clearProgressConditions(externalSource)
+
+	// Should have only the Ready condition remaining (progress conditions removed)
+	assert.Len(t, externalSource.Status.Conditions, 1)
+
+	// Only Ready condition should remain
+	assert.Equal(t, ReadyCondition, externalSource.Status.Conditions[0].Type)
+	assert.Equal(t, metav1.ConditionTrue, externalSource.Status.Conditions[0].Status)
+}
+
+func TestContains(t *testing.T) {
+	tests := []struct {
+		name     string
+		str      string
+		substr   string
+		expected bool
+	}{
+		{
+			name:     "substring exists",
+			str:      "hello world",
+			substr:   "world",
+			expected: true,
+		},
+		{
+			name:     "substring does not exist",
+			str:      "hello world",
+			substr:   "foo",
+			expected: false,
+		},
+		{
+			name:     "empty substring",
+			str:      "hello",
+			substr:   "",
+			expected: true,
+		},
+		{
+			name:     "empty string",
+			str:      "",
+			substr:   "hello",
+			expected: false,
+		},
+		{
+			name:     "case sensitive match",
+			str:      "Hello World",
+			substr:   "World",
+			expected: true,
+		},
+		{
+			name:     "exact match",
+			str:      "hello",
+			substr:   "hello",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := contains(tt.str, tt.substr)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestFindSubstring(t *testing.T) {
+	tests := []struct {
+		name     string
+		str      string
+		substr   string
+		expected bool
+	}{
+		{
+			name:     "substring found",
+			str:      "hello world",
+			substr:   "world",
+			expected: true,
+		},
+		{
+			name:     "substring not found",
+			str:      "hello",
+			substr:   "world",
+			expected: false,
+		},
+		{
+			name:     "empty string",
+			str:      "",
+			substr:   "world",
+			expected: false,
+		},
+		{
+			name:     "empty substring",
+			str:      "hello",
+			substr:   "",
+			expected: true,
+		},
+		{
+			name:     "exact match",
+			str:      "world",
+			substr:   "world",
+			expected: true,
+		},
+		{
+			name:     "substring at beginning",
+			str:      "hello world",
+			substr:   "hello",
+			expected: true,
+		},
+		{
+			name:     "substring at end",
+			str:      "hello world",
+			substr:   "world",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := findSubstring(tt.str, tt.substr)
+			assert.Equal(t, tt.expected, result)
 		})
+	}
+}
 
-		It("should handle permanent errors without retry", func() {
-			resourceName := "test-permanent-error"
-			typeNamespacedName := types.NamespacedName{
-				Name:      resourceName,
-				Namespace: "default",
-			}
+func TestExternalSourceReconciler_incrementalRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		expected string
+	}{
+		{name: "append", mode: "Append", expected: "line 1\nline 2\nline 3\n"},
+		{name: "replace", mode: "Replace", expected: "line 3\n"},
+	}
 
-			By("setting up generator to return permanent error")
-			Expect(mockFactory.RegisterGenerator("http", func() generator.SourceGenerator {
-				return &MockSourceGenerator{
-					GenerateFunc: func(ctx context.Context, config generator.GeneratorConfig) (*generator.SourceData, error) {
-						return nil, fmt.Errorf("404 not found")
-					},
-				}
-			})).To(Succeed())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = sourcev1alpha1.AddToScheme(scheme)
+			_ = sourcev1.AddToScheme(scheme)
+
+			// An append-only log serving byte ranges
+			var ranges []string
+			var content atomic.Value
+			content.Store("line 1\nline 2\n")
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ranges = append(ranges, r.Header.Get("Range"))
+				http.ServeContent(w, r, "log", time.Time{}, strings.NewReader(content.Load().(string)))
+			}))
+			defer server.Close()
 
-			By("creating an ExternalSource resource")
-			resource := &sourcev1alpha1.ExternalSource{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      resourceName,
-					Namespace: "default",
-				},
+			externalSource := &sourcev1alpha1.ExternalSource{
+				ObjectMeta: metav1.ObjectMeta{Name: "log", Namespace: "default", UID: "log-uid"},
 				Spec: sourcev1alpha1.ExternalSourceSpec{
-					Interval: "5m",
+					Interval: "1m",
 					Generator: sourcev1alpha1.GeneratorSpec{
 						Type: "http",
 						HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
-							URL: "https://api.example.com/config",
+							URL:   server.URL,
+							Range: &sourcev1alpha1.HTTPRangeSpec{Incremental: true, Mode: tt.mode},
 						},
 					},
 				},
 			}
-			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
-
-			By("performing reconciliation")
-			// First reconcile adds finalizer
-			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
-			Expect(err).NotTo(HaveOccurred())
 
-			// Second reconcile should fail with permanent error
-			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
-			Expect(err).NotTo(HaveOccurred())
-			Expect(result.RequeueAfter).To(Equal(5 * time.Minute)) // Regular interval, no retry
+			artifactManager := artifact.NewManager(storage.NewMemoryBackend())
+			reconciler := &ExternalSourceReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(externalSource).
+					WithStatusSubresource(&sourcev1.ExternalArtifact{}).Build(),
+				Scheme: scheme,
+				GeneratorFactory: &MockGeneratorFactory{
+					CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+						return generator.NewHTTPGenerator(nil), nil
+					},
+				},
+				ArtifactManager: artifactManager,
+			}
 
-			By("verifying permanent error condition")
-			var updatedResource sourcev1alpha1.ExternalSource
-			Expect(k8sClient.Get(ctx, typeNamespacedName, &updatedResource)).To(Succeed())
+			// The first fetch starts at the beginning of the content
+			_, err := reconciler.reconcile(context.Background(), externalSource)
+			assert.NoError(t, err)
+			assert.Equal(t, &sourcev1alpha1.ContentRange{Offset: 0, Length: 14, Size: 14}, externalSource.Status.Range)
+
+			// Later fetches only transfer the appended content
+			content.Store("line 1\nline 2\nline 3\n")
+			_, err = reconciler.reconcile(context.Background(), externalSource)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"", "bytes=14-"}, ranges)
+
+			data, err := artifactManager.LoadContent(context.Background(), "default/log",
+				externalSource.Status.Artifact.Revision, artifact.CompressionGzip, "data")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(data))
+
+			expectedRange := &sourcev1alpha1.ContentRange{Offset: 14, Length: 7, Size: 21}
+			if tt.mode == "Append" {
+				expectedRange = &sourcev1alpha1.ContentRange{Offset: 0, Length: 21, Size: 21}
+			}
+			assert.Equal(t, expectedRange, externalSource.Status.Range)
+
+			// Nothing new to fetch keeps the current artifact
+			revision := externalSource.Status.Artifact.Revision
+			_, err = reconciler.reconcile(context.Background(), externalSource)
+			assert.NoError(t, err)
+			assert.Equal(t, "bytes=21-", ranges[2])
+			assert.Equal(t, revision, externalSource.Status.Artifact.Revision)
+			assert.Equal(t, expectedRange, externalSource.Status.Range)
+		})
+	}
+}
 
-			readyCondition := findCondition(updatedResource.Status.Conditions, ReadyCondition)
-			Expect(readyCondition).NotTo(BeNil())
-			Expect(readyCondition.Status).To(Equal(metav1.ConditionFalse))
-			Expect(readyCondition.Reason).To(Equal("PermanentError"))
-			Expect(readyCondition.Message).To(ContainSubstring("will not retry"))
+func TestExternalSourceReconciler_backoffStabilityWindow(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = sourcev1alpha1.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
 
-			By("cleaning up the resource")
-			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
-		})
+	cfg := createTestConfig()
+	cfg.Retry.SuccessThreshold = 3
 
-		It("should recover from failures when generator starts working", func() {
-			resourceName := "test-recovery-success"
-			typeNamespacedName := types.NamespacedName{
-				Name:      resourceName,
-				Namespace: "default",
-			}
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "flapping",
+			Namespace:  "default",
+			Finalizers: []string{ExternalSourceFinalizer},
+		},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Interval: "5m",
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/config.json"},
+			},
+		},
+	}
 
-			failureCount := 0
-			By("setting up generator to fail initially then succeed")
-			Expect(mockFactory.RegisterGenerator("http", func() generator.SourceGenerator {
+	failing := false
+	reconciler := &ExternalSourceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(externalSource).
+			WithStatusSubresource(&sourcev1alpha1.ExternalSource{}, &sourcev1.ExternalArtifact{}).Build(),
+		Scheme: scheme,
+		Config: cfg,
+		GeneratorFactory: &MockGeneratorFactory{
+			CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
 				return &MockSourceGenerator{
 					GenerateFunc: func(ctx context.Context, config generator.GeneratorConfig) (*generator.SourceData, error) {
-						failureCount++
-						if failureCount <= 3 {
-							return nil, fmt.Errorf("temporary network error")
+						if failing {
+							return nil, fmt.Errorf("connection refused")
 						}
-						return &generator.SourceData{
-							Data:         []byte(`{"recovered": true}`),
-							LastModified: "recovery-etag",
-							Metadata:     map[string]string{"status": "recovered"},
-						}, nil
+						return &generator.SourceData{Data: []byte(`{"name":"app"}`)}, nil
 					},
-				}
-			})).To(Succeed())
+				}, nil
+			},
+		},
+		ArtifactManager: &MockArtifactManager{},
+	}
 
-			By("creating an ExternalSource resource")
-			resource := &sourcev1alpha1.ExternalSource{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      resourceName,
-					Namespace: "default",
-				},
-				Spec: sourcev1alpha1.ExternalSourceSpec{
-					Interval: "5m",
-					Generator: sourcev1alpha1.GeneratorSpec{
-						Type: "http",
-						HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
-							URL: "https://api.example.com/config",
-						},
-					},
-				},
-			}
-			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: "flapping", Namespace: "default"}}
+	reconcileOnce := func(fail bool) *sourcev1alpha1.RetryStatus {
+		failing = fail
+		_, err := reconciler.Reconcile(context.Background(), request)
+		assert.NoError(t, err)
 
-			By("performing reconciliations through failures")
-			// First reconcile adds finalizer
-			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
-			Expect(err).NotTo(HaveOccurred())
+		var updated sourcev1alpha1.ExternalSource
+		assert.NoError(t, reconciler.Get(context.Background(), request.NamespacedName, &updated))
+		return updated.Status.Retry
+	}
 
-			// Fail a few times
-			for i := 0; i < 3; i++ {
-				result, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
-				Expect(err).NotTo(HaveOccurred())
-				Expect(result.RequeueAfter).To(BeNumerically(">", 0))
-			}
+	// Success, fail, success, fail: the successes in between don't reset the backoff
+	assert.Nil(t, reconcileOnce(false))
 
-			By("succeeding on recovery")
-			result, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
-			Expect(err).NotTo(HaveOccurred())
-			Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+	retry := reconcileOnce(true)
+	if assert.NotNil(t, retry) {
+		assert.Equal(t, 1, retry.Count)
+	}
 
-			By("verifying successful recovery")
-			var updatedResource sourcev1alpha1.ExternalSource
-			Expect(k8sClient.Get(ctx, typeNamespacedName, &updatedResource)).To(Succeed())
+	retry = reconcileOnce(false)
+	if assert.NotNil(t, retry) {
+		assert.Equal(t, 1, retry.Count)
+		assert.Equal(t, 1, retry.ConsecutiveSuccesses)
+	}
 
-			readyCondition := findCondition(updatedResource.Status.Conditions, ReadyCondition)
-			Expect(readyCondition).NotTo(BeNil())
-			Expect(readyCondition.Status).To(Equal(metav1.ConditionTrue))
-			Expect(readyCondition.Reason).To(Equal(SucceededReason))
+	retry = reconcileOnce(true)
+	if assert.NotNil(t, retry) {
+		assert.Equal(t, 2, retry.Count)
+		assert.Equal(t, 0, retry.ConsecutiveSuccesses)
+	}
 
-			// Verify retry count is cleared
-			Expect(reconciler.getRetryCount(&updatedResource)).To(Equal(0))
+	// The backoff is only cleared once the stability window is met
+	retry = reconcileOnce(false)
+	if assert.NotNil(t, retry) {
+		assert.Equal(t, 2, retry.Count)
+	}
+	retry = reconcileOnce(false)
+	if assert.NotNil(t, retry) {
+		assert.Equal(t, 2, retry.ConsecutiveSuccesses)
+	}
+	assert.Nil(t, reconcileOnce(false))
+}
 
-			// Verify artifact was created
-			Expect(updatedResource.Status.Artifact).NotTo(BeNil())
-			Expect(updatedResource.Status.LastHandledETag).To(Equal("recovery-etag"))
+func TestConfigMapDataChanged(t *testing.T) {
+	pred := configMapDataChanged()
+	oldConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default", ResourceVersion: "1"},
+		Data:       map[string]string{"config.json": `{"replicas":3}`},
+	}
 
-			By("cleaning up the resource")
-			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
-		})
-	})
-})
+	relabeled := oldConfigMap.DeepCopy()
+	relabeled.ResourceVersion = "2"
+	relabeled.Labels = map[string]string{"team": "a"}
+	assert.False(t, pred.Update(event.UpdateEvent{ObjectOld: oldConfigMap, ObjectNew: relabeled}))
 
-// Standard Go tests for utility functions
+	updated := oldConfigMap.DeepCopy()
+	updated.ResourceVersion = "3"
+	updated.Data["config.json"] = `{"replicas":5}`
+	assert.True(t, pred.Update(event.UpdateEvent{ObjectOld: oldConfigMap, ObjectNew: updated}))
 
-func TestMapsEqual(t *testing.T) {
-	tests := []struct {
-		name     string
-		mapA     map[string]string
-		mapB     map[string]string
-		expected bool
-	}{
-		{
-			name:     "both maps nil",
-			mapA:     nil,
-			mapB:     nil,
-			expected: true,
-		},
-		{
-			name:     "both maps empty",
-			mapA:     map[string]string{},
-			mapB:     map[string]string{},
-			expected: true,
-		},
-		{
-			name:     "identical maps",
-			mapA:     map[string]string{"key1": "value1", "key2": "value2"},
-			mapB:     map[string]string{"key1": "value1", "key2": "value2"},
-			expected: true,
-		},
-		{
-			name:     "identical maps different order",
-			mapA:     map[string]string{"key1": "value1", "key2": "value2"},
-			mapB:     map[string]string{"key2": "value2", "key1": "value1"},
-			expected: true,
-		},
-		{
-			name:     "different lengths",
-			mapA:     map[string]string{"key1": "value1"},
-			mapB:     map[string]string{"key1": "value1", "key2": "value2"},
-			expected: false,
-		},
-		{
-			name:     "different values",
-			mapA:     map[string]string{"key1": "value1"},
-			mapB:     map[string]string{"key1": "value2"},
-			expected: false,
-		},
-		{
-			name:     "different keys",
-			mapA:     map[string]string{"key1": "value1"},
-			mapB:     map[string]string{"key2": "value1"},
-			expected: false,
+	binary := oldConfigMap.DeepCopy()
+	binary.ResourceVersion = "4"
+	binary.BinaryData = map[string][]byte{"logo.png": {0x89, 0x50}}
+	assert.True(t, pred.Update(event.UpdateEvent{ObjectOld: oldConfigMap, ObjectNew: binary}))
+
+	assert.True(t, pred.Create(event.CreateEvent{Object: oldConfigMap}))
+	assert.False(t, pred.Create(event.CreateEvent{Object: oldConfigMap, IsInInitialList: true}))
+	assert.False(t, pred.Delete(event.DeleteEvent{Object: oldConfigMap}))
+}
+
+func TestExternalSourceReconciler_sourcesForConfigMap(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = sourcev1alpha1.AddToScheme(scheme)
+
+	newSource := func(name, namespace, configMap string) *sourcev1alpha1.ExternalSource {
+		return &sourcev1alpha1.ExternalSource{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: sourcev1alpha1.ExternalSourceSpec{
+				Generator: sourcev1alpha1.GeneratorSpec{
+					Type:      "configMap",
+					ConfigMap: &sourcev1alpha1.ConfigMapKeyReference{Name: configMap, Key: "config.json"},
+				},
+			},
+		}
+	}
+
+	reconciler := &ExternalSourceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithIndex(&sourcev1alpha1.ExternalSource{}, configMapRefIndexKey, indexConfigMapRefs).
+			WithObjects(
+				newSource("app", "default", "settings"),
+				newSource("other", "default", "other-settings"),
+				newSource("app", "team-a", "settings"),
+				&sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{Name: "http", Namespace: "default"},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "http",
+							HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/config.json"},
+						},
+					},
+				},
+				&sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "default"},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "http",
+							HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+								URL:                  "https://example.com/config.json",
+								CABundleConfigMapRef: &sourcev1alpha1.ConfigMapKeyReference{Name: "settings", Key: "ca.crt"},
+							},
+						},
+					},
+				},
+				&sourcev1alpha1.ExternalSource{
+					ObjectMeta: metav1.ObjectMeta{Name: "validated", Namespace: "default"},
+					Spec: sourcev1alpha1.ExternalSourceSpec{
+						Generator: sourcev1alpha1.GeneratorSpec{
+							Type: "http",
+							HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/config.json"},
+						},
+						Validation: &sourcev1alpha1.ContentValidationSpec{
+							SchemaRef: &sourcev1alpha1.ConfigMapKeyReference{Name: "settings", Key: "schema.json"},
+						},
+					},
+				},
+			).Build(),
+		Scheme: scheme,
+	}
+
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default", ResourceVersion: "4"}}
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "app", Namespace: "default"}},
+		{NamespacedName: types.NamespacedName{Name: "tls", Namespace: "default"}},
+		{NamespacedName: types.NamespacedName{Name: "validated", Namespace: "default"}},
+	}, reconciler.sourcesForConfigMap(context.Background(), configMap))
+
+	assert.True(t, reconciler.isReferenceChanged(newSource("app", "default", "settings")))
+	assert.False(t, reconciler.isReferenceChanged(newSource("other", "default", "other-settings")))
+	assert.False(t, reconciler.isReferenceChanged(newSource("app", "team-a", "settings")))
+}
+
+func TestReferencedConfigMaps(t *testing.T) {
+	externalSource := &sourcev1alpha1.ExternalSource{
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+					URL:                  "https://example.com/config.json",
+					CABundleConfigMapRef: &sourcev1alpha1.ConfigMapKeyReference{Name: "trust", Key: "ca.crt"},
+				},
+			},
+			Validation: &sourcev1alpha1.ContentValidationSpec{
+				SchemaRef: &sourcev1alpha1.ConfigMapKeyReference{Name: "schemas", Key: "app.json"},
+			},
 		},
-		{
-			name:     "one map nil, other empty",
-			mapA:     nil,
-			mapB:     map[string]string{},
-			expected: true,
+	}
+	assert.Equal(t, []string{"schemas", "trust"}, referencedConfigMaps(externalSource))
+
+	externalSource.Spec.Validation.SchemaRef.Name = "trust"
+	assert.Equal(t, []string{"trust"}, referencedConfigMaps(externalSource))
+
+	assert.Empty(t, referencedConfigMaps(&sourcev1alpha1.ExternalSource{}))
+}
+
+func TestExternalSourceReconciler_configMapChangeForcesFetch(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = sourcev1alpha1.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: "app-uid"},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/config.json"},
+			},
+			Validation: &sourcev1alpha1.ContentValidationSpec{
+				SchemaRef: &sourcev1alpha1.ConfigMapKeyReference{Name: "schemas", Key: "app.json"},
+			},
 		},
-		{
-			name:     "one map nil, other has values",
-			mapA:     nil,
-			mapB:     map[string]string{"key1": "value1"},
-			expected: false,
+		Status: sourcev1alpha1.ExternalSourceStatus{
+			Artifact:        &sourcev1alpha1.ArtifactMetadata{Revision: "sha256:abc", URL: "http://example.com/artifact.tar.gz"},
+			LastHandledETag: "etag-1",
 		},
 	}
+	schema := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "schemas", Namespace: "default", ResourceVersion: "1"},
+		Data:       map[string]string{"app.json": `{"type":"object","required":["name"]}`},
+	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := mapsEqual(tt.mapA, tt.mapB)
-			assert.Equal(t, tt.expected, result)
-		})
+	fetches := 0
+	reconciler := &ExternalSourceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(externalSource, schema).
+			WithIndex(&sourcev1alpha1.ExternalSource{}, configMapRefIndexKey, indexConfigMapRefs).
+			WithStatusSubresource(&sourcev1.ExternalArtifact{}).Build(),
+		Scheme: scheme,
+		GeneratorFactory: &MockGeneratorFactory{
+			CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+				return &MockSourceGenerator{
+					SupportsConditionalFetchFunc: func() bool { return true },
+					GetLastModifiedFunc: func(ctx context.Context, config generator.GeneratorConfig) (string, error) {
+						return "etag-1", nil
+					},
+					GenerateFunc: func(ctx context.Context, config generator.GeneratorConfig) (*generator.SourceData, error) {
+						fetches++
+						return &generator.SourceData{Data: []byte(`{"name":"app"}`), LastModified: "etag-1"}, nil
+					},
+				}, nil
+			},
+		},
+		ArtifactManager: &MockArtifactManager{},
 	}
+
+	_, err := reconciler.reconcile(context.Background(), externalSource)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, fetches)
+
+	// A changed schema enqueues the source and validates the unchanged upstream data against it
+	schema.ResourceVersion = "2"
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "app", Namespace: "default"}},
+	}, reconciler.sourcesForConfigMap(context.Background(), schema))
+	_, err = reconciler.reconcile(context.Background(), externalSource)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fetches)
 }
 
-func TestExternalSourceReconciler_needsRecovery(t *testing.T) {
+func TestExternalSourceReconciler_createGeneratorConfigKubernetes(t *testing.T) {
 	reconciler := &ExternalSourceReconciler{}
 
-	tests := []struct {
-		name           string
-		externalSource *sourcev1alpha1.ExternalSource
-		expected       bool
-	}{
-		{
-			name: "no conditions - no recovery needed",
-			externalSource: &sourcev1alpha1.ExternalSource{
-				Status: sourcev1alpha1.ExternalSourceStatus{},
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a"},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type:      "configMap",
+				ConfigMap: &sourcev1alpha1.ConfigMapKeyReference{Name: "settings", Key: "config.json"},
 			},
-			expected: false,
 		},
-		{
-			name: "ready condition true - no recovery needed",
-			externalSource: &sourcev1alpha1.ExternalSource{
-				Status: sourcev1alpha1.ExternalSourceStatus{
-					Conditions: []metav1.Condition{
-						{
-							Type:   ReadyCondition,
-							Status: metav1.ConditionTrue,
-						},
-					},
-				},
+	}
+
+	genConfig, err := reconciler.createGeneratorConfig(externalSource)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"namespace": "team-a",
+		"name":      "settings",
+		"key":       "config.json",
+	}, genConfig.Config)
+
+	externalSource.Spec.Generator = sourcev1alpha1.GeneratorSpec{Type: "secret"}
+	_, err = reconciler.createGeneratorConfig(externalSource)
+	assert.EqualError(t, err, "secret configuration is required for secret generator")
+}
+
+func TestExternalSourceReconciler_drainContext(t *testing.T) {
+	t.Run("in-flight reconcile outlives shutdown until the timeout", func(t *testing.T) {
+		reconciler := &ExternalSourceReconciler{ShutdownTimeout: 50 * time.Millisecond}
+
+		managerCtx, stopManager := context.WithCancel(context.Background())
+		ctx, cancel := reconciler.drainContext(managerCtx)
+		defer cancel()
+
+		stopManager()
+		select {
+		case <-ctx.Done():
+			t.Fatal("Expected the reconcile context to survive the manager shutdown")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+			t.Fatal("Expected the reconcile context to be cancelled after the shutdown timeout")
+		}
+	})
+
+	t.Run("cancelled immediately without a timeout", func(t *testing.T) {
+		reconciler := &ExternalSourceReconciler{}
+
+		managerCtx, stopManager := context.WithCancel(context.Background())
+		ctx, cancel := reconciler.drainContext(managerCtx)
+		defer cancel()
+
+		stopManager()
+		assert.Error(t, ctx.Err())
+	})
+
+	t.Run("no new reconciles after shutdown", func(t *testing.T) {
+		reconciler := &ExternalSourceReconciler{ShutdownTimeout: time.Minute}
+
+		ctx, stopManager := context.WithCancel(context.Background())
+		stopManager()
+
+		// The client is never used, the reconcile returns before fetching the source
+		result, err := reconciler.Reconcile(ctx, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: "app", Namespace: "default"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{}, result)
+	})
+}
+
+func TestPinnedRevision(t *testing.T) {
+	httpSource := &sourcev1alpha1.ExternalSource{
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/config.json", Revision: "v1.2.0"},
 			},
-			expected: false,
 		},
-		{
-			name: "fetching condition true - recovery needed",
-			externalSource: &sourcev1alpha1.ExternalSource{
-				Status: sourcev1alpha1.ExternalSourceStatus{
-					Conditions: []metav1.Condition{
-						{
-							Type:   FetchingCondition,
-							Status: metav1.ConditionTrue,
-						},
-					},
+	}
+	gitSource := &sourcev1alpha1.ExternalSource{
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "git",
+				Git: &sourcev1alpha1.GitGeneratorSpec{
+					URL:  "https://github.com/example/config.git",
+					Ref:  &sourcev1alpha1.GitRepositoryRef{Tag: "v1.2.0"},
+					Path: "config.json",
 				},
 			},
-			expected: true,
 		},
-		{
-			name: "executing hooks condition true - recovery needed",
-			externalSource: &sourcev1alpha1.ExternalSource{
-				Status: sourcev1alpha1.ExternalSourceStatus{
-					Conditions: []metav1.Condition{
-						{
-							Type:   ExecutingHooksCondition,
-							Status: metav1.ConditionTrue,
-						},
-					},
+		Status: sourcev1alpha1.ExternalSourceStatus{LastHandledETag: "abc123"},
+	}
+
+	assert.Equal(t, "v1.2.0", pinnedRevision(httpSource))
+	assert.Equal(t, "v1.2.0", pinnedRevision(gitSource))
+
+	// Nothing is resolved or stored until there is an artifact
+	assert.Empty(t, resolvedRevision(httpSource))
+	assert.False(t, isPinnedRevisionStored(httpSource))
+
+	artifact := &sourcev1alpha1.ArtifactMetadata{Revision: "sha256:abc"}
+	httpSource.Status.Artifact = artifact
+	gitSource.Status.Artifact = artifact
+	assert.Equal(t, "v1.2.0", resolvedRevision(httpSource))
+	assert.Equal(t, "v1.2.0@sha1:abc123", resolvedRevision(gitSource))
+
+	gitSource.Status.ResolvedRevision = resolvedRevision(gitSource)
+	assert.True(t, isPinnedRevisionStored(gitSource))
+	gitSource.Spec.Generator.Git.Ref.Tag = "v1.3.0"
+	assert.False(t, isPinnedRevisionStored(gitSource))
+
+	// Branches track the latest commit
+	gitSource.Spec.Generator.Git.Ref = &sourcev1alpha1.GitRepositoryRef{Branch: "main"}
+	assert.Empty(t, pinnedRevision(gitSource))
+	assert.False(t, isPinnedRevisionStored(gitSource))
+}
+
+func TestExternalSourceReconciler_pinnedRevision(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = sourcev1alpha1.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
+
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "app",
+			Namespace:  "default",
+			Generation: 1,
+			Finalizers: []string{ExternalSourceFinalizer},
+		},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Interval: "10m",
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+					URL:           "https://example.com/config.json",
+					QueryParams:   map[string]string{"env": "production"},
+					Revision:      "v1.2.0",
+					RevisionParam: "release",
 				},
 			},
-			expected: true,
 		},
-		{
-			name: "storing condition true - recovery needed",
-			externalSource: &sourcev1alpha1.ExternalSource{
-				Status: sourcev1alpha1.ExternalSourceStatus{
-					Conditions: []metav1.Condition{
-						{
-							Type:   StoringCondition,
-							Status: metav1.ConditionTrue,
-						},
+	}
+
+	var queryParams []map[string]string
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(externalSource).
+		WithStatusSubresource(externalSource, &sourcev1.ExternalArtifact{}).Build()
+	reconciler := &ExternalSourceReconciler{
+		Client: k8sClient,
+		Scheme: scheme,
+		Config: createTestConfig(),
+		GeneratorFactory: &MockGeneratorFactory{
+			CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+				return &MockSourceGenerator{
+					SupportsConditionalFetchFunc: func() bool { return false },
+					GenerateFunc: func(ctx context.Context, config generator.GeneratorConfig) (*generator.SourceData, error) {
+						queryParams = append(queryParams, config.Config["queryParams"].(map[string]string))
+						return &generator.SourceData{Data: []byte(`{"version": "1.2.0"}`)}, nil
 					},
-				},
+				}, nil
 			},
-			expected: true,
 		},
-		{
-			name: "multiple in-progress conditions - recovery needed",
-			externalSource: &sourcev1alpha1.ExternalSource{
-				Status: sourcev1alpha1.ExternalSourceStatus{
-					Conditions: []metav1.Condition{
-						{
-							Type:   FetchingCondition,
-							Status: metav1.ConditionTrue,
-						},
-						{
-							Type:   ExecutingHooksCondition,
-							Status: metav1.ConditionTrue,
-						},
-					},
-				},
+		ArtifactManager: &MockArtifactManager{},
+		MetricsRecorder: &MockMetricsRecorder{},
+	}
+
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "app", Namespace: "default"}}
+	current := func() *sourcev1alpha1.ExternalSource {
+		var source sourcev1alpha1.ExternalSource
+		assert.NoError(t, k8sClient.Get(ctx, req.NamespacedName, &source))
+		return &source
+	}
+
+	// The first reconcile fetches the pinned revision
+	_, err := reconciler.Reconcile(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]string{{"env": "production", "release": "v1.2.0"}}, queryParams)
+	assert.Equal(t, "v1.2.0", current().Status.ResolvedRevision)
+	assert.Equal(t, map[string]string{"env": "production"}, current().Spec.Generator.HTTP.QueryParams)
+
+	// Once stored, the pinned revision is not fetched again
+	_, err = reconciler.Reconcile(ctx, req)
+	assert.NoError(t, err)
+	assert.Len(t, queryParams, 1)
+	fetching := apimeta.FindStatusCondition(current().Status.Conditions, FetchingCondition)
+	assert.NotNil(t, fetching)
+	assert.Equal(t, "Pinned revision already stored", fetching.Message)
+
+	// Moving the pin fetches the new revision
+	source := current()
+	source.Spec.Generator.HTTP.Revision = "v1.3.0"
+	source.Generation = 2
+	assert.NoError(t, k8sClient.Update(ctx, source))
+	_, err = reconciler.Reconcile(ctx, req)
+	assert.NoError(t, err)
+	assert.Len(t, queryParams, 2)
+	assert.Equal(t, "v1.3.0", queryParams[1]["release"])
+	assert.Equal(t, "v1.3.0", current().Status.ResolvedRevision)
+}
+
+func TestExternalSourceReconciler_failureEventDeduplication(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = sourcev1alpha1.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
+
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "app",
+			Namespace:  "default",
+			Finalizers: []string{ExternalSourceFinalizer},
+		},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Interval: "10m",
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/config.json"},
 			},
-			expected: true,
 		},
-		{
-			name: "in-progress condition false - no recovery needed",
-			externalSource: &sourcev1alpha1.ExternalSource{
-				Status: sourcev1alpha1.ExternalSourceStatus{
-					Conditions: []metav1.Condition{
-						{
-							Type:   FetchingCondition,
-							Status: metav1.ConditionFalse,
-						},
+	}
+
+	fetchErr := fmt.Errorf("connection refused")
+	metricsRecorder := &MockMetricsRecorder{}
+	recorder := record.NewFakeRecorder(10)
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(externalSource).
+		WithStatusSubresource(externalSource).Build()
+	reconciler := &ExternalSourceReconciler{
+		Client: k8sClient,
+		Scheme: scheme,
+		Config: createTestConfig(),
+		GeneratorFactory: &MockGeneratorFactory{
+			CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+				return &MockSourceGenerator{
+					SupportsConditionalFetchFunc: func() bool { return false },
+					GenerateFunc: func(ctx context.Context, config generator.GeneratorConfig) (*generator.SourceData, error) {
+						return nil, fetchErr
 					},
-				},
+				}, nil
 			},
-			expected: false,
 		},
+		ArtifactManager: &MockArtifactManager{},
+		MetricsRecorder: metricsRecorder,
+		EventRecorder:   recorder,
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := reconciler.needsRecovery(tt.externalSource)
-			assert.Equal(t, tt.expected, result)
-		})
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "app", Namespace: "default"}}
+	readyMessage := func() string {
+		var source sourcev1alpha1.ExternalSource
+		assert.NoError(t, k8sClient.Get(ctx, req.NamespacedName, &source))
+		return apimeta.FindStatusCondition(source.Status.Conditions, ReadyCondition).Message
+	}
+
+	// Repeats of the same failure are reported once, with an unchanged Ready message
+	var messages []string
+	for range 3 {
+		_, err := reconciler.Reconcile(ctx, req)
+		assert.NoError(t, err)
+		messages = append(messages, readyMessage())
+	}
+	if assert.Len(t, recorder.Events, 1) {
+		assert.Contains(t, <-recorder.Events, "Warning Failed")
 	}
+	assert.Equal(t, messages[0], messages[2])
+	assert.Len(t, metricsRecorder.RecordReconciliationCalls, 3)
+
+	// A different failure is reported right away
+	fetchErr = fmt.Errorf("connection reset by peer")
+	_, err := reconciler.Reconcile(ctx, req)
+	assert.NoError(t, err)
+	if assert.Len(t, recorder.Events, 1) {
+		assert.Contains(t, <-recorder.Events, "connection reset by peer")
+	}
+
+	// Without an interval every failure is reported
+	reconciler.Config.Controller.FailureEventInterval = 0
+	_, err = reconciler.Reconcile(ctx, req)
+	assert.NoError(t, err)
+	assert.Len(t, recorder.Events, 1)
 }
 
-func TestExternalSourceReconciler_setCondition(t *testing.T) {
-	reconciler := &ExternalSourceReconciler{}
+func TestExternalSourceReconciler_reconcileExternalArtifactConflicts(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = sourcev1alpha1.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
 
 	externalSource := &sourcev1alpha1.ExternalSource{
-		Status: sourcev1alpha1.ExternalSourceStatus{},
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: "app-uid"},
+	}
+	existing := func() *sourcev1.ExternalArtifact {
+		return &sourcev1.ExternalArtifact{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Status: sourcev1.ExternalArtifactStatus{
+				Artifact: &fluxmeta.Artifact{URL: "http://artifacts/default/app/rev-1.tar.gz", Revision: "rev-1"},
+			},
+		}
 	}
+	artifactKey := client.ObjectKey{Name: "app", Namespace: "default"}
+	ctx := context.Background()
+
+	t.Run("conflicting status update is retried with a fresh copy", func(t *testing.T) {
+		updates := 0
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing()).
+			WithStatusSubresource(&sourcev1.ExternalArtifact{}).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+					updates++
+					if updates == 1 {
+						return apierrors.NewConflict(schema.GroupResource{Resource: "externalartifacts"}, obj.GetName(),
+							fmt.Errorf("the object has been modified"))
+					}
+					return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+				},
+			}).Build()
+		reconciler := &ExternalSourceReconciler{Client: k8sClient, Scheme: scheme}
 
-	// Test setting a new condition
-	reconciler.setCondition(externalSource, ReadyCondition, metav1.ConditionTrue, "TestReason", "Test message")
+		err := reconciler.reconcileExternalArtifact(ctx, externalSource, "http://artifacts/default/app/rev-2.tar.gz", "rev-2", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, updates)
 
-	assert.Len(t, externalSource.Status.Conditions, 1)
-	condition := externalSource.Status.Conditions[0]
-	assert.Equal(t, ReadyCondition, condition.Type)
-	assert.Equal(t, metav1.ConditionTrue, condition.Status)
-	assert.Equal(t, "TestReason", condition.Reason)
-	assert.Equal(t, "Test message", condition.Message)
-	assert.NotEmpty(t, condition.LastTransitionTime)
+		var artifact sourcev1.ExternalArtifact
+		assert.NoError(t, k8sClient.Get(ctx, artifactKey, &artifact))
+		assert.Equal(t, "rev-2", artifact.Status.Artifact.Revision)
+	})
 
-	// Test updating existing condition with same status
-	originalTime := condition.LastTransitionTime
-	reconciler.setCondition(externalSource, ReadyCondition, metav1.ConditionTrue, "TestReason", "Updated message")
+	t.Run("ExternalArtifact created concurrently is updated", func(t *testing.T) {
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).
+			WithStatusSubresource(&sourcev1.ExternalArtifact{}).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					// Another reconcile wins the race between the get and the create
+					if err := c.Create(ctx, existing()); err != nil {
+						return err
+					}
+					return apierrors.NewAlreadyExists(schema.GroupResource{Resource: "externalartifacts"}, obj.GetName())
+				},
+			}).Build()
+		reconciler := &ExternalSourceReconciler{Client: k8sClient, Scheme: scheme}
 
-	assert.Len(t, externalSource.Status.Conditions, 1)
-	updatedCondition := externalSource.Status.Conditions[0]
-	assert.Equal(t, "Updated message", updatedCondition.Message)
-	assert.Equal(t, originalTime, updatedCondition.LastTransitionTime) // Should not change
+		err := reconciler.reconcileExternalArtifact(ctx, externalSource, "http://artifacts/default/app/rev-2.tar.gz", "rev-2", nil)
+		assert.NoError(t, err)
 
-	// Test updating existing condition with different status
-	reconciler.setCondition(externalSource, ReadyCondition, metav1.ConditionFalse, "ErrorReason", "Error message")
+		var artifact sourcev1.ExternalArtifact
+		assert.NoError(t, k8sClient.Get(ctx, artifactKey, &artifact))
+		assert.Equal(t, "rev-2", artifact.Status.Artifact.Revision)
+	})
 
-	assert.Len(t, externalSource.Status.Conditions, 1)
-	finalCondition := externalSource.Status.Conditions[0]
-	assert.Equal(t, metav1.ConditionFalse, finalCondition.Status)
-	assert.Equal(t, "ErrorReason", finalCondition.Reason)
-	assert.Equal(t, "Error message", finalCondition.Message)
-	assert.NotEqual(t, originalTime, finalCondition.LastTransitionTime) // Should change
-}
+	t.Run("persistent conflicts are transient", func(t *testing.T) {
+		conflict := apierrors.NewConflict(schema.GroupResource{Resource: "externalartifacts"}, "app",
+			fmt.Errorf("the object has been modified"))
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing()).
+			WithStatusSubresource(&sourcev1.ExternalArtifact{}).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+					return conflict
+				},
+			}).Build()
+		reconciler := &ExternalSourceReconciler{Client: k8sClient, Scheme: scheme}
 
-func TestExternalSourceReconciler_hasCondition(t *testing.T) {
-	reconciler := &ExternalSourceReconciler{}
+		err := reconciler.reconcileExternalArtifact(ctx, externalSource, "http://artifacts/default/app/rev-2.tar.gz", "rev-2", nil)
+		assert.ErrorIs(t, err, conflict)
+		assert.Equal(t, TransientError, reconciler.classifyError(fmt.Errorf("failed to reconcile ExternalArtifact: %w", err)))
+	})
+}
 
-	externalSource := &sourcev1alpha1.ExternalSource{
+func TestExternalSourceReconciler_dependsOn(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = sourcev1alpha1.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
+
+	dependency := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "base", Namespace: "default", Generation: 2},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Interval: "5m",
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/base.json"},
+			},
+		},
 		Status: sourcev1alpha1.ExternalSourceStatus{
-			Conditions: []metav1.Condition{
-				{
-					Type:   ReadyCondition,
-					Status: metav1.ConditionTrue,
-				},
-				{
-					Type:   FetchingCondition,
-					Status: metav1.ConditionFalse,
-				},
+			Conditions: []metav1.Condition{{
+				Type:               ReadyCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             SucceededReason,
+				LastTransitionTime: metav1.Now(),
+				ObservedGeneration: 1,
+			}},
+		},
+	}
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "app",
+			Namespace:  "default",
+			Finalizers: []string{ExternalSourceFinalizer},
+		},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Interval:  "5m",
+			DependsOn: []fluxmeta.NamespacedObjectReference{{Name: "base"}},
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/config.json"},
+			},
+		},
+	}
+
+	fetched := false
+	reconciler := &ExternalSourceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(dependency, externalSource).
+			WithStatusSubresource(&sourcev1alpha1.ExternalSource{}, &sourcev1.ExternalArtifact{}).Build(),
+		Scheme: scheme,
+		GeneratorFactory: &MockGeneratorFactory{
+			CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+				fetched = true
+				return &MockSourceGenerator{}, nil
 			},
 		},
+		ArtifactManager: &MockArtifactManager{},
+		Config:          createTestConfig(),
 	}
 
-	// Test existing condition with matching status
-	assert.True(t, reconciler.hasCondition(externalSource, ReadyCondition, metav1.ConditionTrue))
-	assert.True(t, reconciler.hasCondition(externalSource, FetchingCondition, metav1.ConditionFalse))
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "app", Namespace: "default"}
+
+	// The dependency is Ready for an older generation and has no artifact yet
+	result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+	assert.NoError(t, err)
+	assert.False(t, fetched)
+	assert.Equal(t, 30*time.Second, result.RequeueAfter)
+
+	var updated sourcev1alpha1.ExternalSource
+	assert.NoError(t, reconciler.Get(ctx, key, &updated))
+	ready := apimeta.FindStatusCondition(updated.Status.Conditions, ReadyCondition)
+	if assert.NotNil(t, ready) {
+		assert.Equal(t, metav1.ConditionFalse, ready.Status)
+		assert.Equal(t, DependencyNotReadyReason, ready.Reason)
+		assert.Equal(t, "Dependency default/base is not ready", ready.Message)
+	}
 
-	// Test existing condition with non-matching status
-	assert.False(t, reconciler.hasCondition(externalSource, ReadyCondition, metav1.ConditionFalse))
-	assert.False(t, reconciler.hasCondition(externalSource, FetchingCondition, metav1.ConditionTrue))
+	// Once the dependency is ready with a current artifact the source is fetched
+	var base sourcev1alpha1.ExternalSource
+	assert.NoError(t, reconciler.Get(ctx, types.NamespacedName{Name: "base", Namespace: "default"}, &base))
+	base.Status.Artifact = &sourcev1alpha1.ArtifactMetadata{Revision: "sha256:abc", URL: "http://artifacts/default/base/abc.tar.gz"}
+	base.Status.Conditions[0].ObservedGeneration = base.Generation
+	assert.NoError(t, reconciler.Status().Update(ctx, &base))
 
-	// Test non-existing condition
-	assert.False(t, reconciler.hasCondition(externalSource, ExecutingHooksCondition, metav1.ConditionTrue))
-	assert.False(t, reconciler.hasCondition(externalSource, ExecutingHooksCondition, metav1.ConditionFalse))
+	_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+	assert.NoError(t, err)
+	assert.True(t, fetched)
 }
 
-func TestExternalSourceReconciler_getConditionMessage(t *testing.T) {
-	reconciler := &ExternalSourceReconciler{}
+func TestExternalSourceReconciler_checkDependencies(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = sourcev1alpha1.AddToScheme(scheme)
 
-	externalSource := &sourcev1alpha1.ExternalSource{
-		Status: sourcev1alpha1.ExternalSourceStatus{
-			Conditions: []metav1.Condition{
-				{
-					Type:    ReadyCondition,
-					Status:  metav1.ConditionTrue,
-					Message: "Ready message",
-				},
-				{
-					Type:    FetchingCondition,
-					Status:  metav1.ConditionFalse,
-					Message: "Fetching message",
+	reconciler := &ExternalSourceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&sourcev1alpha1.ExternalSource{
+				ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "platform", Generation: 1},
+				Status: sourcev1alpha1.ExternalSourceStatus{
+					Artifact: &sourcev1alpha1.ArtifactMetadata{Revision: "sha256:abc"},
+					Conditions: []metav1.Condition{{
+						Type:               ReadyCondition,
+						Status:             metav1.ConditionTrue,
+						Reason:             DegradedReason,
+						LastTransitionTime: metav1.Now(),
+						ObservedGeneration: 1,
+					}},
 				},
 			},
-		},
+		).Build(),
+		Scheme: scheme,
 	}
 
-	// Test existing condition
-	assert.Equal(t, "Ready message", reconciler.getConditionMessage(externalSource, ReadyCondition))
-	assert.Equal(t, "Fetching message", reconciler.getConditionMessage(externalSource, FetchingCondition))
+	newSource := func(refs ...fluxmeta.NamespacedObjectReference) *sourcev1alpha1.ExternalSource {
+		return &sourcev1alpha1.ExternalSource{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec:       sourcev1alpha1.ExternalSourceSpec{DependsOn: refs},
+		}
+	}
 
-	// Test non-existing condition
-	assert.Equal(t, "", reconciler.getConditionMessage(externalSource, ExecutingHooksCondition))
+	message, err := reconciler.checkDependencies(context.Background(),
+		newSource(fluxmeta.NamespacedObjectReference{Name: "shared", Namespace: "platform"}))
+	assert.NoError(t, err)
+	assert.Empty(t, message)
+
+	message, err = reconciler.checkDependencies(context.Background(), newSource(
+		fluxmeta.NamespacedObjectReference{Name: "shared", Namespace: "platform"},
+		fluxmeta.NamespacedObjectReference{Name: "shared"},
+	))
+	assert.NoError(t, err)
+	assert.Equal(t, "Dependency default/shared not found", message)
 }
 
-func TestExternalSourceReconciler_clearProgressConditions(t *testing.T) {
-	reconciler := &ExternalSourceReconciler{}
-
-	externalSource := &sourcev1alpha1.ExternalSource{
-		Status: sourcev1alpha1.ExternalSourceStatus{
-			Conditions: []metav1.Condition{
-				{
-					Type:   ReadyCondition,
-					Status: metav1.ConditionTrue,
-				},
-				{
-					Type:   FetchingCondition,
-					Status: metav1.ConditionTrue,
-				},
-				{
-					Type:   ExecutingHooksCondition,
-					Status: metav1.ConditionTrue,
-				},
-				{
-					Type:   StoringCondition,
-					Status: metav1.ConditionTrue,
-				},
-			},
-		},
+func TestDependencyReadinessChanged(t *testing.T) {
+	pred := dependencyReadinessChanged()
+	notReady := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "base", Namespace: "default", Generation: 1, ResourceVersion: "1"},
 	}
 
-	reconciler.clearProgressConditions(externalSource)
+	ready := notReady.DeepCopy()
+	ready.ResourceVersion = "2"
+	ready.Status.Artifact = &sourcev1alpha1.ArtifactMetadata{Revision: "sha256:abc"}
+	ready.Status.Conditions = []metav1.Condition{{
+		Type:               ReadyCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             SucceededReason,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: 1,
+	}}
+	assert.True(t, pred.Update(event.UpdateEvent{ObjectOld: notReady, ObjectNew: ready}))
+
+	refreshed := ready.DeepCopy()
+	refreshed.ResourceVersion = "3"
+	refreshed.Status.Artifact.Revision = "sha256:def"
+	assert.False(t, pred.Update(event.UpdateEvent{ObjectOld: ready, ObjectNew: refreshed}))
+
+	respecced := refreshed.DeepCopy()
+	respecced.ResourceVersion = "4"
+	respecced.Generation = 2
+	assert.True(t, pred.Update(event.UpdateEvent{ObjectOld: refreshed, ObjectNew: respecced}))
+
+	assert.False(t, pred.Create(event.CreateEvent{Object: ready}))
+	assert.False(t, pred.Delete(event.DeleteEvent{Object: ready}))
+}
 
-	// Should have only the Ready condition remaining (progress conditions removed)
-	assert.Len(t, externalSource.Status.Conditions, 1)
+func TestExternalSourceReconciler_sourcesForDependency(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = sourcev1alpha1.AddToScheme(scheme)
 
-	// Only Ready condition should remain
-	assert.Equal(t, ReadyCondition, externalSource.Status.Conditions[0].Type)
-	assert.Equal(t, metav1.ConditionTrue, externalSource.Status.Conditions[0].Status)
+	newSource := func(name, namespace string, refs ...fluxmeta.NamespacedObjectReference) *sourcev1alpha1.ExternalSource {
+		return &sourcev1alpha1.ExternalSource{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       sourcev1alpha1.ExternalSourceSpec{DependsOn: refs},
+		}
+	}
+
+	reconciler := &ExternalSourceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithIndex(&sourcev1alpha1.ExternalSource{}, dependsOnIndexKey, indexDependsOn).
+			WithObjects(
+				newSource("base", "default"),
+				newSource("app", "default", fluxmeta.NamespacedObjectReference{Name: "base"}),
+				newSource("app", "team-a", fluxmeta.NamespacedObjectReference{Name: "base", Namespace: "default"}),
+				newSource("other", "team-a", fluxmeta.NamespacedObjectReference{Name: "base"}),
+			).Build(),
+		Scheme: scheme,
+	}
+
+	dependency := &sourcev1alpha1.ExternalSource{ObjectMeta: metav1.ObjectMeta{Name: "base", Namespace: "default"}}
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "app", Namespace: "default"}},
+		{NamespacedName: types.NamespacedName{Name: "app", Namespace: "team-a"}},
+	}, reconciler.sourcesForDependency(context.Background(), dependency))
 }
 
-func TestContains(t *testing.T) {
+func TestExternalSourceReconciler_reconcileTimeout(t *testing.T) {
+	reconciler := &ExternalSourceReconciler{Config: createTestConfig()}
+
 	tests := []struct {
-		name     string
-		str      string
-		substr   string
-		expected bool
+		name        string
+		spec        sourcev1alpha1.ExternalSourceSpec
+		interval    time.Duration
+		expected    time.Duration
+		expectError string
 	}{
 		{
-			name:     "substring exists",
-			str:      "hello world",
-			substr:   "world",
-			expected: true,
+			name:     "defaults to the interval",
+			spec:     sourcev1alpha1.ExternalSourceSpec{Interval: "5m"},
+			interval: 5 * time.Minute,
+			expected: 5 * time.Minute,
 		},
 		{
-			name:     "substring does not exist",
-			str:      "hello world",
-			substr:   "foo",
-			expected: false,
+			name:     "long interval capped at the maximum",
+			spec:     sourcev1alpha1.ExternalSourceSpec{Interval: "1h"},
+			interval: time.Hour,
+			expected: 10 * time.Minute,
 		},
 		{
-			name:     "empty substring",
-			str:      "hello",
-			substr:   "",
-			expected: true,
+			name:     "schedule uses the maximum",
+			spec:     sourcev1alpha1.ExternalSourceSpec{Schedule: "*/5 * * * *"},
+			interval: 30 * time.Second,
+			expected: 10 * time.Minute,
 		},
 		{
-			name:     "empty string",
-			str:      "",
-			substr:   "hello",
-			expected: false,
+			name:     "explicit timeout",
+			spec:     sourcev1alpha1.ExternalSourceSpec{Interval: "5m", Timeout: "90s"},
+			interval: 5 * time.Minute,
+			expected: 90 * time.Second,
 		},
 		{
-			name:     "case sensitive match",
-			str:      "Hello World",
-			substr:   "World",
-			expected: true,
+			name:        "timeout above the maximum",
+			spec:        sourcev1alpha1.ExternalSourceSpec{Interval: "1h", Timeout: "30m"},
+			interval:    time.Hour,
+			expectError: "invalid timeout 30m: exceeds the controller maximum of 10m0s",
 		},
 		{
-			name:     "exact match",
-			str:      "hello",
-			substr:   "hello",
-			expected: true,
+			name:        "zero timeout",
+			spec:        sourcev1alpha1.ExternalSourceSpec{Interval: "5m", Timeout: "0s"},
+			interval:    5 * time.Minute,
+			expectError: "invalid timeout 0s: must be positive",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := contains(tt.str, tt.substr)
-			assert.Equal(t, tt.expected, result)
+			timeout, err := reconciler.reconcileTimeout(tt.spec, tt.interval)
+			if tt.expectError != "" {
+				assert.EqualError(t, err, tt.expectError)
+				assert.Equal(t, ConfigurationError, reconciler.classifyError(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, timeout)
 		})
 	}
 }
 
-func TestFindSubstring(t *testing.T) {
-	tests := []struct {
-		name     string
-		str      string
-		substr   string
-		expected bool
-	}{
-		{
-			name:     "substring found",
-			str:      "hello world",
-			substr:   "world",
-			expected: true,
+func TestExternalSourceReconciler_pipelineTimeout(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = sourcev1alpha1.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
+
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "slow",
+			Namespace:  "default",
+			Finalizers: []string{ExternalSourceFinalizer},
 		},
-		{
-			name:     "substring not found",
-			str:      "hello",
-			substr:   "world",
-			expected: false,
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Interval: "5m",
+			Timeout:  "200ms",
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/config.json"},
+			},
 		},
-		{
-			name:     "empty string",
-			str:      "",
-			substr:   "world",
-			expected: false,
+	}
+
+	// The generator only returns once the reconciliation context is done, or fails the test
+	// after a generous bound
+	reconciler := &ExternalSourceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(externalSource).
+			WithStatusSubresource(&sourcev1alpha1.ExternalSource{}, &sourcev1.ExternalArtifact{}).Build(),
+		Scheme: scheme,
+		GeneratorFactory: &MockGeneratorFactory{
+			CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+				return &MockSourceGenerator{
+					GenerateFunc: func(ctx context.Context, _ generator.GeneratorConfig) (*generator.SourceData, error) {
+						select {
+						case <-ctx.Done():
+							return nil, fmt.Errorf("failed to fetch: %w", ctx.Err())
+						case <-time.After(10 * time.Second):
+							return nil, fmt.Errorf("reconciliation was not cancelled")
+						}
+					},
+				}, nil
+			},
 		},
-		{
-			name:     "empty substring",
-			str:      "hello",
-			substr:   "",
-			expected: true,
+		ArtifactManager: &MockArtifactManager{},
+		Config:          createTestConfig(),
+	}
+
+	key := types.NamespacedName{Name: "slow", Namespace: "default"}
+	start := time.Now()
+	result, err := reconciler.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+	elapsed := time.Since(start)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, elapsed, 200*time.Millisecond)
+	assert.Less(t, elapsed, 5*time.Second)
+	assert.Positive(t, result.RequeueAfter)
+
+	var updated sourcev1alpha1.ExternalSource
+	assert.NoError(t, reconciler.Get(context.Background(), key, &updated))
+	ready := apimeta.FindStatusCondition(updated.Status.Conditions, ReadyCondition)
+	if assert.NotNil(t, ready) {
+		assert.Equal(t, metav1.ConditionFalse, ready.Status)
+		assert.Equal(t, FailedReason, ready.Reason)
+		assert.Contains(t, ready.Message, "reconciliation timed out after 200ms")
+	}
+	assert.NotNil(t, updated.Status.Retry)
+}
+
+func TestExternalSourceReconciler_forceRefetch(t *testing.T) {
+	for _, strategy := range []string{"etag", "contentHash"} {
+		t.Run(strategy, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = sourcev1alpha1.AddToScheme(scheme)
+			_ = sourcev1.AddToScheme(scheme)
+
+			// The content and its ETag never change
+			var fetches atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("ETag", `"v1"`)
+				if r.Header.Get("If-None-Match") == `"v1"` {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				if r.Method == http.MethodGet {
+					fetches.Add(1)
+				}
+				_, _ = w.Write([]byte(`{"name":"app"}`))
+			}))
+			defer server.Close()
+
+			externalSource := &sourcev1alpha1.ExternalSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "app",
+					Namespace:  "default",
+					Finalizers: []string{ExternalSourceFinalizer},
+				},
+				Spec: sourcev1alpha1.ExternalSourceSpec{
+					Interval:            "5m",
+					ConditionalStrategy: strategy,
+					Generator: sourcev1alpha1.GeneratorSpec{
+						Type: "http",
+						HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: server.URL},
+					},
+				},
+			}
+
+			var stored []string
+			reconciler := &ExternalSourceReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(externalSource).
+					WithStatusSubresource(&sourcev1alpha1.ExternalSource{}, &sourcev1.ExternalArtifact{}).Build(),
+				Scheme: scheme,
+				GeneratorFactory: &MockGeneratorFactory{
+					CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+						return generator.NewHTTPGenerator(nil), nil
+					},
+				},
+				ArtifactManager: &MockArtifactManager{
+					PackageFunc: func(ctx context.Context, data []byte, path string) (*artifact.Artifact, error) {
+						revision, err := artifact.Digest(artifact.DigestSHA256, data)
+						if err != nil {
+							return nil, err
+						}
+						return &artifact.Artifact{Data: data, Path: path, Revision: revision}, nil
+					},
+					StoreFunc: func(ctx context.Context, art *artifact.Artifact, source string) (string, error) {
+						stored = append(stored, art.Revision)
+						return fmt.Sprintf("https://storage.example.com/%s/%s", source, art.Revision), nil
+					},
+				},
+				Config: createTestConfig(),
+			}
+
+			ctx := context.Background()
+			key := types.NamespacedName{Name: "app", Namespace: "default"}
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			assert.NoError(t, err)
+			assert.Len(t, stored, 1)
+
+			// Unchanged content isn't stored again
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			assert.NoError(t, err)
+			assert.Len(t, stored, 1)
+
+			var current sourcev1alpha1.ExternalSource
+			assert.NoError(t, reconciler.Get(ctx, key, &current))
+			current.Annotations = map[string]string{ForceRefetchAnnotation: "rotated-credentials"}
+			assert.NoError(t, reconciler.Update(ctx, &current))
+
+			// The annotation stores the byte-identical content again and is removed
+			fetchesBefore := fetches.Load()
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			assert.NoError(t, err)
+			assert.Equal(t, fetchesBefore+1, fetches.Load())
+			if assert.Len(t, stored, 2) {
+				assert.Equal(t, stored[0], stored[1])
+			}
+
+			var updated sourcev1alpha1.ExternalSource
+			assert.NoError(t, reconciler.Get(ctx, key, &updated))
+			assert.NotContains(t, updated.Annotations, ForceRefetchAnnotation)
+			assert.Empty(t, updated.Status.GetLastHandledReconcileRequest())
+			assert.True(t, apimeta.IsStatusConditionTrue(updated.Status.Conditions, ReadyCondition))
+
+			// Later reconciles are conditional again
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			assert.NoError(t, err)
+			assert.Len(t, stored, 2)
+		})
+	}
+}
+
+func TestExternalSourceReconciler_artifactBackendType(t *testing.T) {
+	reconciler := &ExternalSourceReconciler{ArtifactManager: artifact.NewManager(storage.NewMemoryBackend())}
+	assert.Equal(t, "memory", reconciler.artifactBackendType())
+
+	reconciler.ArtifactManager = &MockArtifactManager{}
+	assert.Equal(t, storage.UnknownBackendType, reconciler.artifactBackendType())
+}
+
+func TestRequestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = sourcev1alpha1.AddToScheme(scheme)
+
+	newSource := func(name, namespace, tier string) *sourcev1alpha1.ExternalSource {
+		return &sourcev1alpha1.ExternalSource{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"tier": tier}},
+			Spec: sourcev1alpha1.ExternalSourceSpec{
+				Generator: sourcev1alpha1.GeneratorSpec{
+					Type: "http",
+					HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/config.json"},
+				},
+			},
+		}
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newSource("app", "default", "frontend"),
+		newSource("api", "default", "backend"),
+		newSource("app", "team-a", "frontend"),
+	).Build()
+	annotations := func(name, namespace string) map[string]string {
+		var externalSource sourcev1alpha1.ExternalSource
+		assert.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Name: name, Namespace: namespace}, &externalSource))
+		return externalSource.Annotations
+	}
+	frontend := labels.SelectorFromSet(labels.Set{"tier": "frontend"})
+
+	_, err := RequestReconcile(context.Background(), k8sClient, BulkReconcileOptions{Namespace: "default"})
+	assert.ErrorContains(t, err, "a label selector is required")
+
+	annotated, err := RequestReconcile(context.Background(), k8sClient, BulkReconcileOptions{
+		Namespace: "default",
+		Selector:  frontend,
+		DryRun:    true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []types.NamespacedName{{Name: "app", Namespace: "default"}}, annotated)
+	assert.Empty(t, annotations("app", "default"))
+
+	annotated, err = RequestReconcile(context.Background(), k8sClient, BulkReconcileOptions{
+		Namespace: "default",
+		Selector:  frontend,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []types.NamespacedName{{Name: "app", Namespace: "default"}}, annotated)
+	assert.Contains(t, annotations("app", "default"), fluxmeta.ReconcileRequestAnnotation)
+	assert.NotContains(t, annotations("app", "team-a"), fluxmeta.ReconcileRequestAnnotation)
+	assert.NotContains(t, annotations("api", "default"), fluxmeta.ReconcileRequestAnnotation)
+
+	annotated, err = RequestReconcile(context.Background(), k8sClient, BulkReconcileOptions{
+		Selector: frontend,
+		Force:    true,
+	})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []types.NamespacedName{
+		{Name: "app", Namespace: "default"},
+		{Name: "app", Namespace: "team-a"},
+	}, annotated)
+	assert.Contains(t, annotations("app", "team-a"), ForceRefetchAnnotation)
+	assert.NotContains(t, annotations("app", "team-a"), fluxmeta.ReconcileRequestAnnotation)
+}
+
+func TestExternalSourceReconciler_cursor(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = sourcev1alpha1.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
+
+	pages := map[string]string{
+		"":   `{"events":[1,2],"next":"p2"}`,
+		"p2": `{"events":[3],"next":"p3"}`,
+		"p3": `{"events":[]}`,
+	}
+	var cursors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("after")
+		cursors = append(cursors, cursor)
+		_, _ = w.Write([]byte(pages[cursor]))
+	}))
+	defer server.Close()
+
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "events", Namespace: "default", UID: "events-uid", Generation: 1},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Interval: "1m",
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{
+					URL:    server.URL,
+					Cursor: &sourcev1alpha1.HTTPCursorSpec{JSONPath: "{.next}", QueryParam: "after"},
+				},
+			},
 		},
-		{
-			name:     "exact match",
-			str:      "world",
-			substr:   "world",
-			expected: true,
+	}
+
+	storeErr := fmt.Errorf("storage unavailable")
+	var failStore bool
+	reconciler := &ExternalSourceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(externalSource).
+			WithStatusSubresource(&sourcev1.ExternalArtifact{}).Build(),
+		Scheme: scheme,
+		GeneratorFactory: &MockGeneratorFactory{
+			CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+				return generator.NewHTTPGenerator(nil), nil
+			},
 		},
-		{
-			name:     "substring at beginning",
-			str:      "hello world",
-			substr:   "hello",
-			expected: true,
+		ArtifactManager: &MockArtifactManager{
+			StoreFunc: func(ctx context.Context, artifact *artifact.Artifact, source string) (string, error) {
+				if failStore {
+					return "", storeErr
+				}
+				return "http://example.com/" + artifact.Revision, nil
+			},
 		},
-		{
-			name:     "substring at end",
-			str:      "hello world",
-			substr:   "world",
-			expected: true,
+	}
+
+	_, err := reconciler.reconcile(context.Background(), externalSource)
+	assert.NoError(t, err)
+	assert.Equal(t, "p2", externalSource.Status.Cursor)
+
+	// A response that isn't stored doesn't advance the cursor
+	failStore = true
+	_, err = reconciler.reconcile(context.Background(), externalSource)
+	assert.ErrorIs(t, err, storeErr)
+	assert.Equal(t, "p2", externalSource.Status.Cursor)
+
+	failStore = false
+	_, err = reconciler.reconcile(context.Background(), externalSource)
+	assert.NoError(t, err)
+	assert.Equal(t, "p3", externalSource.Status.Cursor)
+
+	// A response without a cursor keeps the current one
+	_, err = reconciler.reconcile(context.Background(), externalSource)
+	assert.NoError(t, err)
+	assert.Equal(t, "p3", externalSource.Status.Cursor)
+	assert.Equal(t, []string{"", "p2", "p2", "p3"}, cursors)
+}
+
+func TestExternalSourceReconciler_watchdog(t *testing.T) {
+	key := types.NamespacedName{Namespace: "default", Name: "hung"}
+
+	t.Run("reports a stuck reconcile once", func(t *testing.T) {
+		metricsRecorder := &MockMetricsRecorder{}
+		reconciler := &ExternalSourceReconciler{Config: config.DefaultConfig(), MetricsRecorder: metricsRecorder}
+
+		tracked, done := reconciler.trackReconcile(context.Background(), key)
+		defer done()
+		ctx, cancel := tracked.cancellable(context.Background())
+		defer cancel()
+
+		threshold := reconciler.stuckReconcileThreshold()
+		assert.Equal(t, 20*time.Minute, threshold)
+
+		reconciler.checkStuckReconciles(context.Background(), time.Now().Add(threshold/2), threshold)
+		assert.Empty(t, metricsRecorder.RecordReconcileStuckCalls)
+
+		reconciler.checkStuckReconciles(context.Background(), time.Now().Add(threshold), threshold)
+		reconciler.checkStuckReconciles(context.Background(), time.Now().Add(2*threshold), threshold)
+		assert.Equal(t, []ActiveReconciliationCall{{Namespace: "default", Name: "hung"}}, metricsRecorder.RecordReconcileStuckCalls)
+		assert.NoError(t, ctx.Err(), "Expected the reconcile to keep running without CancelStuckReconciles")
+	})
+
+	t.Run("cancels only the pipeline of a stuck reconcile when enabled", func(t *testing.T) {
+		cfg := config.DefaultConfig()
+		cfg.Controller.StuckReconcileThreshold = time.Hour
+		cfg.Controller.CancelStuckReconciles = true
+		reconciler := &ExternalSourceReconciler{Config: cfg, MetricsRecorder: &MockMetricsRecorder{}}
+
+		parent, cancelParent := context.WithCancel(context.Background())
+		defer cancelParent()
+		tracked, done := reconciler.trackReconcile(parent, key)
+		defer done()
+		ctx, cancel := tracked.cancellable(parent)
+		defer cancel()
+
+		threshold := reconciler.stuckReconcileThreshold()
+		assert.Equal(t, time.Hour, threshold)
+
+		reconciler.checkStuckReconciles(context.Background(), time.Now().Add(threshold), threshold)
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+			t.Fatal("Expected the pipeline context to be cancelled")
+		}
+		assert.ErrorIs(t, context.Cause(ctx), errReconcileStuck)
+		assert.NoError(t, parent.Err())
+	})
+
+	t.Run("finished reconciles are no longer tracked", func(t *testing.T) {
+		metricsRecorder := &MockMetricsRecorder{}
+		reconciler := &ExternalSourceReconciler{Config: config.DefaultConfig(), MetricsRecorder: metricsRecorder}
+
+		_, done := reconciler.trackReconcile(context.Background(), key)
+		done()
+
+		threshold := reconciler.stuckReconcileThreshold()
+		reconciler.checkStuckReconciles(context.Background(), time.Now().Add(threshold), threshold)
+		assert.Empty(t, metricsRecorder.RecordReconcileStuckCalls)
+	})
+}
+
+func TestExternalSourceReconciler_watchdogCancelRecordsStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = sourcev1alpha1.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
+
+	externalSource := &sourcev1alpha1.ExternalSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "hung",
+			Namespace:  "default",
+			Finalizers: []string{ExternalSourceFinalizer},
+		},
+		Spec: sourcev1alpha1.ExternalSourceSpec{
+			Interval: "5m",
+			Generator: sourcev1alpha1.GeneratorSpec{
+				Type: "http",
+				HTTP: &sourcev1alpha1.HTTPGeneratorSpec{URL: "https://example.com/config.json"},
+			},
 		},
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := findSubstring(tt.str, tt.substr)
-			assert.Equal(t, tt.expected, result)
-		})
+	cfg := createTestConfig()
+	cfg.Controller.CancelStuckReconciles = true
+	metricsRecorder := &MockMetricsRecorder{}
+
+	// The generator runs the watchdog as if the threshold had passed and waits for the
+	// cancellation, or fails the test after a generous bound
+	var reconciler *ExternalSourceReconciler
+	reconciler = &ExternalSourceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(externalSource).
+			WithStatusSubresource(&sourcev1alpha1.ExternalSource{}, &sourcev1.ExternalArtifact{}).Build(),
+		Scheme: scheme,
+		GeneratorFactory: &MockGeneratorFactory{
+			CreateGeneratorFunc: func(generatorType string) (generator.SourceGenerator, error) {
+				return &MockSourceGenerator{
+					GenerateFunc: func(ctx context.Context, _ generator.GeneratorConfig) (*generator.SourceData, error) {
+						threshold := reconciler.stuckReconcileThreshold()
+						reconciler.checkStuckReconciles(context.Background(), time.Now().Add(threshold), threshold)
+						select {
+						case <-ctx.Done():
+							return nil, fmt.Errorf("failed to fetch: %w", ctx.Err())
+						case <-time.After(10 * time.Second):
+							return nil, fmt.Errorf("reconciliation was not cancelled")
+						}
+					},
+				}, nil
+			},
+		},
+		ArtifactManager: &MockArtifactManager{},
+		MetricsRecorder: metricsRecorder,
+		Config:          cfg,
+	}
+
+	key := types.NamespacedName{Name: "hung", Namespace: "default"}
+	result, err := reconciler.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+	assert.NoError(t, err)
+	assert.Positive(t, result.RequeueAfter)
+	assert.Len(t, metricsRecorder.RecordReconcileStuckCalls, 1)
+
+	// The failure and retry count are written although the pipeline was cancelled
+	var updated sourcev1alpha1.ExternalSource
+	assert.NoError(t, reconciler.Get(context.Background(), key, &updated))
+	ready := apimeta.FindStatusCondition(updated.Status.Conditions, ReadyCondition)
+	if assert.NotNil(t, ready) {
+		assert.Equal(t, metav1.ConditionFalse, ready.Status)
+		assert.Equal(t, FailedReason, ready.Reason)
+		assert.Contains(t, ready.Message, errReconcileStuck.Error())
+	}
+	if assert.NotNil(t, updated.Status.Retry) {
+		assert.Equal(t, 1, updated.Status.Retry.Count)
 	}
+	assert.Equal(t, TransientError, reconciler.classifyError(fmt.Errorf("%w: %w", errReconcileStuck, context.Canceled)))
 }
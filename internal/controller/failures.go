@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	sourcev1alpha1 "github.com/oddkinco/flux-externalsource-controller/api/v1alpha1"
+)
+
+// reportedFailure is the last failure of a source reported through a Warning event
+type reportedFailure struct {
+	reason  string
+	message string
+	at      time.Time
+}
+
+// reportFailure logs the error of a failed reconciliation and emits a Warning event for it.
+// A failure identical to the last one reported for the source is only logged at debug level
+// until the failure event interval has passed, so a long outage doesn't flood the events and
+// logs with the same message on every retry.
+func (r *ExternalSourceReconciler) reportFailure(ctx context.Context, externalSource *sourcev1alpha1.ExternalSource, reason string, err error) {
+	log := logf.FromContext(ctx)
+	key := client.ObjectKeyFromObject(externalSource)
+	failure := reportedFailure{reason: reason, message: err.Error(), at: time.Now()}
+
+	if value, ok := r.reportedFailures.Load(key); ok {
+		last := value.(reportedFailure)
+		if last.reason == failure.reason && last.message == failure.message &&
+			failure.at.Sub(last.at) < r.Config.Controller.FailureEventInterval {
+			log.V(1).Info("Reconciliation still failing", "reason", reason, "error", failure.message)
+			return
+		}
+	}
+	r.reportedFailures.Store(key, failure)
+
+	log.Error(err, "Reconciliation failed", "reason", reason)
+	r.recordEvent(externalSource, corev1.EventTypeWarning, reason, failure.message)
+}
+
+// clearReportedFailure forgets the last failure reported for a source, so the next one is
+// reported right away
+func (r *ExternalSourceReconciler) clearReportedFailure(key client.ObjectKey) {
+	r.reportedFailures.Delete(key)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	sourcev1alpha1 "github.com/oddkinco/flux-externalsource-controller/api/v1alpha1"
+)
+
+// maxWindowStarts bounds the window starts checked for overlapping occurrences, so a
+// schedule starting every minute with a long duration can't stall the reconcile
+const maxWindowStarts = 1000
+
+// maintenanceWindowEnd returns when the maintenance window active at now ends, or the zero
+// time when no window is active. When windows overlap the latest end is returned.
+func maintenanceWindowEnd(windows []sourcev1alpha1.MaintenanceWindow, now time.Time) (time.Time, error) {
+	var end time.Time
+	for i, window := range windows {
+		windowEnd, err := windowEndAt(window, now)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid maintenance window %d: %w", i, err)
+		}
+		if windowEnd.After(end) {
+			end = windowEnd
+		}
+	}
+	return end, nil
+}
+
+// windowEndAt returns when the occurrence of the window active at now ends, or the zero time
+func windowEndAt(window sourcev1alpha1.MaintenanceWindow, now time.Time) (time.Time, error) {
+	duration, err := time.ParseDuration(window.Duration)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid duration: %w", err)
+	}
+
+	location := time.UTC
+	if window.TimeZone != "" {
+		location, err = time.LoadLocation(window.TimeZone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time zone %q: %w", window.TimeZone, err)
+		}
+	}
+
+	schedule, err := cron.ParseStandard(window.Schedule)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid schedule %q: %w", window.Schedule, err)
+	}
+
+	// Every occurrence that started within the last duration is still running, the
+	// latest one ends last
+	var end time.Time
+	start := schedule.Next(now.Add(-duration).In(location))
+	for i := 0; i < maxWindowStarts && !start.IsZero() && !start.After(now); i++ {
+		end = start.Add(duration)
+		start = schedule.Next(start)
+	}
+	return end, nil
+}
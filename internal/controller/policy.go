@@ -0,0 +1,47 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	sourcev1alpha1 "github.com/oddkinco/flux-externalsource-controller/api/v1alpha1"
+)
+
+// PolicyViolationReason indicates the ExternalSource uses a setting the controller policy forbids
+const PolicyViolationReason = "PolicyViolation"
+
+// checkPolicy returns an error if the ExternalSource uses a setting the controller
+// configuration forbids
+func (r *ExternalSourceReconciler) checkPolicy(externalSource *sourcev1alpha1.ExternalSource) error {
+	if r.Config == nil {
+		return nil
+	}
+
+	httpSpec := externalSource.Spec.Generator.HTTP
+	if r.Config.HTTP.DenyInsecureSkipVerify && httpSpec != nil && httpSpec.InsecureSkipVerify {
+		return fmt.Errorf("insecureSkipVerify is not allowed by the controller policy, configure caBundleSecretRef instead")
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/net/http/httpguts"
+
+	sourcev1alpha1 "github.com/oddkinco/flux-externalsource-controller/api/v1alpha1"
+	"github.com/oddkinco/flux-externalsource-controller/internal/generator"
+)
+
+// preRequestHookOutput is the JSON document a pre-request hook writes to stdout
+type preRequestHookOutput struct {
+	// Headers are set on the outgoing request, replacing any existing values
+	Headers map[string]string `json:"headers"`
+}
+
+// requestSigner returns a generator.RequestSigner that runs the pre-request hooks of the
+// ExternalSource, or nil when there are none.
+//
+// Each hook receives the outgoing request as a JSON object on stdin:
+//
+//	{"method": "GET", "url": "https://...", "headers": {"Name": "value"}}
+//
+// and must write a JSON object with the headers to set on the request to stdout:
+//
+//	{"headers": {"Authorization": "...", "X-Amz-Date": "..."}}
+//
+// Hooks run in order and each one sees the headers returned by the hooks before it. Output
+// that is not a JSON object with only a headers field of string values fails the request.
+func (r *ExternalSourceReconciler) requestSigner(externalSource *sourcev1alpha1.ExternalSource) generator.RequestSigner {
+	if externalSource.Spec.Hooks == nil || len(externalSource.Spec.Hooks.PreRequest) == 0 {
+		return nil
+	}
+
+	hookSpecs := externalSource.Spec.Hooks.PreRequest
+	return func(ctx context.Context, request generator.RequestMetadata) (map[string]string, error) {
+		if r.HookExecutor == nil {
+			return nil, fmt.Errorf("pre-request hooks are configured but no hook executor is available")
+		}
+
+		headers := make(map[string]string, len(request.Headers))
+		for name, value := range request.Headers {
+			headers[name] = value
+		}
+
+		signed := make(map[string]string)
+		for _, hookSpec := range hookSpecs {
+			input, err := json.Marshal(generator.RequestMetadata{
+				Method:  request.Method,
+				URL:     request.URL,
+				Headers: headers,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode request for pre-request hook %s: %w", hookSpec.Name, err)
+			}
+
+			output, err := r.executeHooks(ctx, externalSource, input, []sourcev1alpha1.HookSpec{hookSpec})
+			if err != nil {
+				return nil, err
+			}
+
+			// A failure ignored by the retry policy passes the input through unchanged
+			if bytes.Equal(output, input) {
+				continue
+			}
+
+			hookHeaders, err := parsePreRequestHookOutput(output)
+			if err != nil {
+				return nil, fmt.Errorf("pre-request hook %s returned invalid output: %w", hookSpec.Name, err)
+			}
+			for name, value := range hookHeaders {
+				headers[name] = value
+				signed[name] = value
+			}
+		}
+
+		return signed, nil
+	}
+}
+
+// parsePreRequestHookOutput decodes and validates the headers written by a pre-request hook
+func parsePreRequestHookOutput(output []byte) (map[string]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(output))
+	decoder.DisallowUnknownFields()
+
+	var result preRequestHookOutput
+	if err := decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("expected a JSON object with a headers field of string values: %w", err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after the JSON object")
+	}
+	if result.Headers == nil {
+		return nil, fmt.Errorf("headers field is required")
+	}
+
+	for name, value := range result.Headers {
+		if !httpguts.ValidHeaderFieldName(name) {
+			return nil, fmt.Errorf("invalid header name %q", name)
+		}
+		// Values are usually credentials, keep them out of the error
+		if !httpguts.ValidHeaderFieldValue(value) {
+			return nil, fmt.Errorf("invalid value for header %s", name)
+		}
+	}
+
+	return result.Headers, nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"strings"
+
+	sourcev1alpha1 "github.com/oddkinco/flux-externalsource-controller/api/v1alpha1"
+)
+
+// defaultRevisionParam is the query parameter carrying the pinned revision of an HTTP source
+const defaultRevisionParam = "version"
+
+// revisionParam returns the query parameter carrying the pinned revision of an HTTP source
+func revisionParam(httpSpec *sourcev1alpha1.HTTPGeneratorSpec) string {
+	if httpSpec.RevisionParam != "" {
+		return httpSpec.RevisionParam
+	}
+	return defaultRevisionParam
+}
+
+// pinnedRevision returns the revision the source is pinned to, the HTTP revision or the Git
+// tag, or an empty string when it tracks the latest content
+func pinnedRevision(externalSource *sourcev1alpha1.ExternalSource) string {
+	gen := externalSource.Spec.Generator
+	switch gen.Type {
+	case "http":
+		if gen.HTTP != nil {
+			return gen.HTTP.Revision
+		}
+	case "git":
+		if gen.Git != nil && gen.Git.Ref != nil {
+			return gen.Git.Ref.Tag
+		}
+	}
+	return ""
+}
+
+// resolvedRevision returns the revision recorded for the current artifact of a pinned
+// source. Git tags are recorded with the commit they resolved to.
+func resolvedRevision(externalSource *sourcev1alpha1.ExternalSource) string {
+	pinned := pinnedRevision(externalSource)
+	if pinned == "" || externalSource.Status.Artifact == nil {
+		return ""
+	}
+
+	if externalSource.Spec.Generator.Type == "git" && externalSource.Status.LastHandledETag != "" {
+		return pinned + "@sha1:" + externalSource.Status.LastHandledETag
+	}
+	return pinned
+}
+
+// isPinnedRevisionStored returns true if the current artifact was produced from the revision
+// the source is pinned to
+func isPinnedRevisionStored(externalSource *sourcev1alpha1.ExternalSource) bool {
+	pinned := pinnedRevision(externalSource)
+	if pinned == "" || externalSource.Status.Artifact == nil {
+		return false
+	}
+
+	resolved := externalSource.Status.ResolvedRevision
+	return resolved == pinned || strings.HasPrefix(resolved, pinned+"@sha1:")
+}
@@ -0,0 +1,187 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sourcev1alpha1 "github.com/oddkinco/flux-externalsource-controller/api/v1alpha1"
+	"github.com/oddkinco/flux-externalsource-controller/internal/generator"
+)
+
+// secretRefIndexKey indexes ExternalSources by the names of the secrets they reference
+const secretRefIndexKey = ".spec.secretRefs"
+
+// referencedSecrets returns the sorted names of the secrets in its namespace the ExternalSource reads
+func referencedSecrets(externalSource *sourcev1alpha1.ExternalSource) []string {
+	names := make(map[string]struct{})
+	add := func(name string) {
+		if name != "" {
+			names[name] = struct{}{}
+		}
+	}
+
+	spec := externalSource.Spec
+	if gen := spec.Generator.HTTP; gen != nil {
+		if gen.HeadersSecretRef != nil {
+			add(gen.HeadersSecretRef.Name)
+		}
+		if gen.BasicAuthSecretRef != nil {
+			add(gen.BasicAuthSecretRef.Name)
+		}
+		if gen.BearerTokenSecretRef != nil {
+			add(gen.BearerTokenSecretRef.Name)
+		}
+		if gen.OAuth2 != nil {
+			add(gen.OAuth2.ClientSecretRef.Name)
+		}
+		if gen.CABundleSecretRef != nil {
+			add(gen.CABundleSecretRef.Name)
+		}
+		if gen.ClientCertSecretRef != nil {
+			add(gen.ClientCertSecretRef.Name)
+		}
+	}
+	if gen := spec.Generator.Git; gen != nil && gen.SecretRef != nil {
+		add(gen.SecretRef.Name)
+	}
+	if gen := spec.Generator.S3; gen != nil && gen.SecretRef != nil {
+		add(gen.SecretRef.Name)
+	}
+	if gen := spec.Generator.Secret; gen != nil {
+		add(gen.Name)
+	}
+	if gen := spec.Generator.GRPC; gen != nil {
+		if gen.CABundleSecretRef != nil {
+			add(gen.CABundleSecretRef.Name)
+		}
+		if gen.ClientCertSecretRef != nil {
+			add(gen.ClientCertSecretRef.Name)
+		}
+		if gen.MetadataSecretRef != nil {
+			add(gen.MetadataSecretRef.Name)
+		}
+	}
+	if spec.Decryption != nil {
+		add(spec.Decryption.SecretRef.Name)
+	}
+	if spec.Notify != nil && spec.Notify.SecretRef != nil {
+		add(spec.Notify.SecretRef.Name)
+	}
+	if spec.Hooks != nil {
+		for _, hooks := range [][]sourcev1alpha1.HookSpec{spec.Hooks.PreRequest, spec.Hooks.PostRequest} {
+			for _, hook := range hooks {
+				for _, env := range hook.EnvFrom {
+					add(env.SecretKeyRef.Name)
+				}
+			}
+		}
+	}
+
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// indexSecretRefs is the field indexer for secretRefIndexKey
+func indexSecretRefs(obj client.Object) []string {
+	externalSource, ok := obj.(*sourcev1alpha1.ExternalSource)
+	if !ok {
+		return nil
+	}
+	return referencedSecrets(externalSource)
+}
+
+// secretDataChanged passes created secrets, so sources failing on a missing secret recover once
+// it exists, and secret updates that change the secret contents or whether the secret is
+// exported. Resyncs, other metadata updates and the secrets listed on startup don't force a fetch.
+func secretDataChanged() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return !e.IsInInitialList },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldSecret, ok := e.ObjectOld.(*corev1.Secret)
+			if !ok {
+				return false
+			}
+			newSecret, ok := e.ObjectNew.(*corev1.Secret)
+			if !ok {
+				return false
+			}
+			return !equality.Semantic.DeepEqual(oldSecret.Data, newSecret.Data) ||
+				generator.IsSecretExported(oldSecret) != generator.IsSecretExported(newSecret)
+		},
+	}
+}
+
+// sourcesForSecret enqueues the ExternalSources referencing a changed secret and marks them
+// so their next reconcile fetches the source even if it reports no changes, e.g. after a
+// credential rotation or a CA bundle update.
+func (r *ExternalSourceReconciler) sourcesForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	var sources sourcev1alpha1.ExternalSourceList
+	if err := r.List(ctx, &sources,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{secretRefIndexKey: obj.GetName()},
+	); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list ExternalSources referencing secret",
+			"secret", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+
+	change := fmt.Sprintf("Secret/%s@%s", obj.GetName(), obj.GetResourceVersion())
+	requests := make([]reconcile.Request, 0, len(sources.Items))
+	for i := range sources.Items {
+		key := client.ObjectKeyFromObject(&sources.Items[i])
+		r.changedRefSources.Store(key, change)
+		requests = append(requests, reconcile.Request{NamespacedName: key})
+	}
+	return requests
+}
+
+// isReferenceChanged returns true if a secret or ConfigMap referenced by the ExternalSource
+// changed since its last successful reconcile
+func (r *ExternalSourceReconciler) isReferenceChanged(externalSource *sourcev1alpha1.ExternalSource) bool {
+	_, changed := r.changedRefSources.Load(client.ObjectKeyFromObject(externalSource))
+	return changed
+}
+
+// clearReferenceChange forgets a handled secret or ConfigMap change, keeping any change
+// recorded while the reconcile was running
+func (r *ExternalSourceReconciler) clearReferenceChange(key types.NamespacedName, change any) {
+	r.changedRefSources.CompareAndDelete(key, change)
+}
@@ -0,0 +1,55 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+)
+
+// drainContext returns a context for a reconcile that outlives the cancellation of ctx on
+// shutdown by up to ShutdownTimeout, so an in-flight fetch or store can finish and record
+// its status instead of leaving progress conditions behind for performRecovery. The
+// returned cancel function must be called once the reconcile returns.
+func (r *ExternalSourceReconciler) drainContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.ShutdownTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	drainCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(ctx, func() {
+		timer := time.NewTimer(r.ShutdownTimeout)
+		defer timer.Stop()
+
+		select {
+		case <-drainCtx.Done():
+		case <-timer.C:
+			cancel()
+		}
+	})
+
+	return drainCtx, func() {
+		stop()
+		cancel()
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	sourcev1alpha1 "github.com/oddkinco/flux-externalsource-controller/api/v1alpha1"
+)
+
+var (
+	// urlPlaceholder matches a Go template action in a URL
+	urlPlaceholder = regexp.MustCompile(`\{\{\s*(.*?)\s*\}\}`)
+
+	// labelField and labelIndex match the supported forms of label access
+	labelField = regexp.MustCompile(`^\.Labels\.([A-Za-z_][A-Za-z0-9_]*)$`)
+	labelIndex = regexp.MustCompile(`^index \.Labels "([A-Za-z0-9./_-]+)"$`)
+)
+
+// renderURLTemplate resolves the placeholders in an HTTP generator URL against the
+// ExternalSource. Only field access to the name, namespace and labels is supported, which
+// keeps the expanded values within the characters Kubernetes allows for them.
+func renderURLTemplate(externalSource *sourcev1alpha1.ExternalSource, rawURL string) (string, error) {
+	if !strings.Contains(rawURL, "{{") {
+		return rawURL, nil
+	}
+
+	var renderErr error
+	rendered := urlPlaceholder.ReplaceAllStringFunc(rawURL, func(placeholder string) string {
+		value, err := resolveURLPlaceholder(externalSource, urlPlaceholder.FindStringSubmatch(placeholder)[1])
+		if err != nil && renderErr == nil {
+			renderErr = err
+		}
+		return value
+	})
+	if renderErr != nil {
+		return "", renderErr
+	}
+
+	if strings.Contains(rendered, "{{") || strings.Contains(rendered, "}}") {
+		return "", fmt.Errorf("invalid URL template %q: unbalanced braces", rawURL)
+	}
+	if _, err := url.Parse(rendered); err != nil {
+		return "", fmt.Errorf("invalid URL after template expansion: %w", err)
+	}
+
+	return rendered, nil
+}
+
+// resolveURLPlaceholder returns the value of a single placeholder expression
+func resolveURLPlaceholder(externalSource *sourcev1alpha1.ExternalSource, expression string) (string, error) {
+	switch expression {
+	case ".Name":
+		return externalSource.Name, nil
+	case ".Namespace":
+		return externalSource.Namespace, nil
+	}
+
+	var label string
+	if match := labelField.FindStringSubmatch(expression); match != nil {
+		label = match[1]
+	} else if match := labelIndex.FindStringSubmatch(expression); match != nil {
+		label = match[1]
+	} else {
+		return "", fmt.Errorf("invalid URL template placeholder {{ %s }}: only .Name, .Namespace and .Labels may be referenced", expression)
+	}
+
+	value, ok := externalSource.Labels[label]
+	if !ok {
+		return "", fmt.Errorf("invalid URL template: label %s is not set", label)
+	}
+	return value, nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// errReconcileStuck is the cancellation cause of reconciles cancelled by the watchdog
+var errReconcileStuck = errors.New("reconciliation exceeded the stuck reconcile threshold")
+
+// inFlightReconcile is a reconcile tracked by the watchdog
+type inFlightReconcile struct {
+	started time.Time
+
+	// cancelled is done once the watchdog cancels the reconcile, with errReconcileStuck as cause
+	cancelled context.Context
+	cancel    context.CancelCauseFunc
+
+	// stuck is set once the reconcile was reported as stuck, so it is only reported once
+	stuck atomic.Bool
+}
+
+// stuckReconcileThreshold returns how long a reconcile may run before the watchdog reports it,
+// twice the longest reconcile timeout unless configured
+func (r *ExternalSourceReconciler) stuckReconcileThreshold() time.Duration {
+	if r.Config == nil {
+		return 0
+	}
+	if r.Config.Controller.StuckReconcileThreshold > 0 {
+		return r.Config.Controller.StuckReconcileThreshold
+	}
+	return 2 * r.Config.Controller.MaxReconcileTimeout
+}
+
+// trackReconcile records the start of a reconcile for the watchdog. The returned function
+// must be called once the reconcile returns.
+func (r *ExternalSourceReconciler) trackReconcile(ctx context.Context, key types.NamespacedName) (*inFlightReconcile, func()) {
+	cancelled, cancel := context.WithCancelCause(context.Background())
+	reconcile := &inFlightReconcile{started: time.Now(), cancelled: cancelled, cancel: cancel}
+	r.inFlightReconciles.Store(key, reconcile)
+
+	return reconcile, func() {
+		r.inFlightReconciles.CompareAndDelete(key, reconcile)
+		if reconcile.stuck.Load() {
+			logf.FromContext(ctx).Info("Stuck reconciliation finished",
+				"duration", time.Since(reconcile.started).String(),
+				"cancelled", reconcile.cancelled.Err() != nil)
+		}
+		cancel(nil)
+	}
+}
+
+// cancellable returns a child of ctx that the watchdog cancels with errReconcileStuck. Only
+// the pipeline runs with it, so the failure is still written to the status with ctx.
+func (t *inFlightReconcile) cancellable(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	stop := context.AfterFunc(t.cancelled, func() {
+		cancel(context.Cause(t.cancelled))
+	})
+	return ctx, func() {
+		stop()
+		cancel(nil)
+	}
+}
+
+// runWatchdog checks the in-flight reconciles until ctx is done, reporting those running
+// longer than the stuck reconcile threshold
+func (r *ExternalSourceReconciler) runWatchdog(ctx context.Context) {
+	threshold := r.stuckReconcileThreshold()
+	if threshold <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(max(threshold/4, time.Second))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			r.checkStuckReconciles(ctx, now, threshold)
+		}
+	}
+}
+
+// checkStuckReconciles logs and counts each reconcile running longer than threshold once,
+// and cancels it when CancelStuckReconciles is set. A generator that ignores its context
+// keeps running after the cancellation, and the active reconciliations gauge only drops
+// once it returns.
+func (r *ExternalSourceReconciler) checkStuckReconciles(ctx context.Context, now time.Time, threshold time.Duration) {
+	log := logf.FromContext(ctx)
+	cancelStuck := r.Config != nil && r.Config.Controller.CancelStuckReconciles
+
+	r.inFlightReconciles.Range(func(k, v any) bool {
+		key := k.(types.NamespacedName)
+		reconcile := v.(*inFlightReconcile)
+
+		running := now.Sub(reconcile.started)
+		if running < threshold || reconcile.stuck.Swap(true) {
+			return true
+		}
+
+		log.Error(errReconcileStuck, "Reconciliation is stuck",
+			"namespace", key.Namespace,
+			"name", key.Name,
+			"running", running.Round(time.Second).String(),
+			"threshold", threshold.String(),
+			"cancelled", cancelStuck)
+		if r.MetricsRecorder != nil {
+			r.MetricsRecorder.RecordReconcileStuck(key.Namespace, key.Name)
+		}
+		if cancelStuck {
+			reconcile.cancel(errReconcileStuck)
+		}
+		return true
+	})
+}
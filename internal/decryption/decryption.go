@@ -0,0 +1,237 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package decryption decrypts fetched content before it is processed further.
+package decryption
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	agearmor "filippo.io/age/armor"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgparmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/aes"
+	"github.com/getsops/sops/v3/config"
+	"github.com/getsops/sops/v3/keyservice"
+	"github.com/getsops/sops/v3/stores/dotenv"
+	sopsjson "github.com/getsops/sops/v3/stores/json"
+	sopsyaml "github.com/getsops/sops/v3/stores/yaml"
+	"google.golang.org/grpc"
+)
+
+const (
+	// ProviderSOPS decrypts documents encrypted with SOPS
+	ProviderSOPS = "sops"
+
+	// FormatJSON is a SOPS encrypted JSON document
+	FormatJSON = "json"
+
+	// FormatYAML is a SOPS encrypted YAML document
+	FormatYAML = "yaml"
+
+	// FormatDotenv is a SOPS encrypted dotenv file
+	FormatDotenv = "dotenv"
+
+	// FormatBinary is an arbitrary file encrypted by SOPS as a whole
+	FormatBinary = "binary"
+
+	// AgeKeySuffix marks secret entries holding age identities
+	AgeKeySuffix = ".agekey"
+
+	// PGPKeySuffix marks secret entries holding ASCII armored PGP private keys
+	PGPKeySuffix = ".asc"
+)
+
+// DecryptSOPS decrypts a SOPS document with the age and PGP private keys found in
+// keys, which maps secret entry names to their values. When format is empty JSON
+// documents are detected from the content and anything else is treated as YAML.
+func DecryptSOPS(data []byte, format string, keys map[string][]byte) ([]byte, error) {
+	store, err := storeForFormat(data, format)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := newKeyService(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := store.LoadEncryptedFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load SOPS document: %w", err)
+	}
+
+	dataKey, err := tree.Metadata.GetDataKeyWithKeyServices([]keyservice.KeyServiceClient{svc}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt SOPS data key: %w", err)
+	}
+
+	cipher := aes.NewCipher()
+	mac, err := tree.Decrypt(dataKey, cipher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt SOPS document: %w", err)
+	}
+
+	// Verify the document was not tampered with
+	originalMac, err := cipher.Decrypt(tree.Metadata.MessageAuthenticationCode, dataKey,
+		tree.Metadata.LastModified.Format(time.RFC3339))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt SOPS message authentication code: %w", err)
+	}
+	if originalMac != mac {
+		return nil, fmt.Errorf("SOPS document failed integrity check")
+	}
+
+	plain, err := store.EmitPlainFile(tree.Branches)
+	if err != nil {
+		return nil, fmt.Errorf("failed to emit decrypted document: %w", err)
+	}
+
+	return plain, nil
+}
+
+// storeForFormat returns the SOPS store able to read documents in the given format
+func storeForFormat(data []byte, format string) (sops.Store, error) {
+	if format == "" {
+		format = FormatYAML
+		if json.Valid(data) {
+			format = FormatJSON
+		}
+	}
+
+	switch format {
+	case FormatJSON:
+		return sopsjson.NewStore(&config.JSONStoreConfig{}), nil
+	case FormatYAML:
+		return sopsyaml.NewStore(&config.YAMLStoreConfig{}), nil
+	case FormatDotenv:
+		return dotenv.NewStore(&config.DotenvStoreConfig{}), nil
+	case FormatBinary:
+		return sopsjson.NewBinaryStore(&config.JSONBinaryStoreConfig{}), nil
+	default:
+		return nil, fmt.Errorf("unsupported SOPS format %q", format)
+	}
+}
+
+// keyService decrypts SOPS data keys with in-memory age and PGP private keys, so
+// neither key files nor a GnuPG installation are needed
+type keyService struct {
+	ageIdentities []age.Identity
+	pgpKeyRing    openpgp.EntityList
+}
+
+// newKeyService parses the private keys from the secret entries
+func newKeyService(keys map[string][]byte) (*keyService, error) {
+	svc := &keyService{}
+
+	for name, value := range keys {
+		switch {
+		case strings.HasSuffix(name, AgeKeySuffix):
+			identities, err := age.ParseIdentities(bytes.NewReader(value))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse age identities from %s: %w", name, err)
+			}
+			svc.ageIdentities = append(svc.ageIdentities, identities...)
+		case strings.HasSuffix(name, PGPKeySuffix):
+			entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(value))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse PGP keys from %s: %w", name, err)
+			}
+			svc.pgpKeyRing = append(svc.pgpKeyRing, entities...)
+		}
+	}
+
+	if len(svc.ageIdentities) == 0 && len(svc.pgpKeyRing) == 0 {
+		return nil, fmt.Errorf("no age (%s) or PGP (%s) keys found", AgeKeySuffix, PGPKeySuffix)
+	}
+
+	return svc, nil
+}
+
+// Encrypt is not supported, the controller only decrypts
+func (s *keyService) Encrypt(_ context.Context, _ *keyservice.EncryptRequest, _ ...grpc.CallOption) (*keyservice.EncryptResponse, error) {
+	return nil, errors.New("encryption is not supported")
+}
+
+// Decrypt decrypts a data key encrypted for an age recipient or PGP key
+func (s *keyService) Decrypt(_ context.Context, req *keyservice.DecryptRequest, _ ...grpc.CallOption) (*keyservice.DecryptResponse, error) {
+	var (
+		plaintext []byte
+		err       error
+	)
+
+	switch {
+	case req.GetKey().GetAgeKey() != nil:
+		plaintext, err = s.decryptAge(req.GetCiphertext())
+	case req.GetKey().GetPgpKey() != nil:
+		plaintext, err = s.decryptPGP(req.GetCiphertext())
+	default:
+		err = errors.New("only age and PGP keys are supported")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &keyservice.DecryptResponse{Plaintext: plaintext}, nil
+}
+
+// decryptAge decrypts an ASCII armored age message
+func (s *keyService) decryptAge(ciphertext []byte) ([]byte, error) {
+	if len(s.ageIdentities) == 0 {
+		return nil, errors.New("no age identities configured")
+	}
+
+	r, err := age.Decrypt(agearmor.NewReader(bytes.NewReader(ciphertext)), s.ageIdentities...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt with age: %w", err)
+	}
+
+	return io.ReadAll(r)
+}
+
+// decryptPGP decrypts an ASCII armored PGP message
+func (s *keyService) decryptPGP(ciphertext []byte) ([]byte, error) {
+	if len(s.pgpKeyRing) == 0 {
+		return nil, errors.New("no PGP keys configured")
+	}
+
+	block, err := pgparmor.Decode(bytes.NewReader(ciphertext))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode PGP message: %w", err)
+	}
+
+	md, err := openpgp.ReadMessage(block.Body, s.pgpKeyRing, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt with PGP: %w", err)
+	}
+
+	return io.ReadAll(md.UnverifiedBody)
+}
@@ -0,0 +1,267 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package decryption
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgparmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/aes"
+	sopsage "github.com/getsops/sops/v3/age"
+	"github.com/getsops/sops/v3/keys"
+	"github.com/getsops/sops/v3/pgp"
+)
+
+// encryptSOPS encrypts plain with the given data key the way the sops CLI does,
+// the master keys must already hold the data key encrypted for them
+func encryptSOPS(t *testing.T, format string, plain string, dataKey []byte, masterKeys ...keys.MasterKey) []byte {
+	t.Helper()
+
+	store, err := storeForFormat([]byte(plain), format)
+	if err != nil {
+		t.Fatalf("Failed to get store: %v", err)
+	}
+	branches, err := store.LoadPlainFile([]byte(plain))
+	if err != nil {
+		t.Fatalf("Failed to load plain file: %v", err)
+	}
+
+	tree := sops.Tree{
+		Branches: branches,
+		Metadata: sops.Metadata{
+			KeyGroups:         []sops.KeyGroup{masterKeys},
+			UnencryptedSuffix: "_unencrypted",
+			Version:           "3.10.2",
+		},
+	}
+
+	cipher := aes.NewCipher()
+	mac, err := tree.Encrypt(dataKey, cipher)
+	if err != nil {
+		t.Fatalf("Failed to encrypt tree: %v", err)
+	}
+	tree.Metadata.LastModified = time.Now().UTC()
+	tree.Metadata.MessageAuthenticationCode, err = cipher.Encrypt(mac, dataKey, tree.Metadata.LastModified.Format(time.RFC3339))
+	if err != nil {
+		t.Fatalf("Failed to encrypt MAC: %v", err)
+	}
+
+	encrypted, err := store.EmitEncryptedFile(tree)
+	if err != nil {
+		t.Fatalf("Failed to emit encrypted file: %v", err)
+	}
+	return encrypted
+}
+
+func newDataKey(t *testing.T) []byte {
+	t.Helper()
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		t.Fatalf("Failed to generate data key: %v", err)
+	}
+	return dataKey
+}
+
+func newAgeKey(t *testing.T, dataKey []byte) (*sopsage.MasterKey, string) {
+	t.Helper()
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate age identity: %v", err)
+	}
+	masterKey, err := sopsage.MasterKeyFromRecipient(identity.Recipient().String())
+	if err != nil {
+		t.Fatalf("Failed to create age master key: %v", err)
+	}
+	if err := masterKey.Encrypt(dataKey); err != nil {
+		t.Fatalf("Failed to encrypt data key with age: %v", err)
+	}
+	return masterKey, identity.String()
+}
+
+func newPGPKey(t *testing.T, dataKey []byte) (*pgp.MasterKey, string) {
+	t.Helper()
+	entity, err := openpgp.NewEntity("test", "", "test@example.com", nil)
+	if err != nil {
+		t.Fatalf("Failed to generate PGP key: %v", err)
+	}
+
+	var message bytes.Buffer
+	armored, err := pgparmor.Encode(&message, "PGP MESSAGE", nil)
+	if err != nil {
+		t.Fatalf("Failed to armor PGP message: %v", err)
+	}
+	w, err := openpgp.Encrypt(armored, []*openpgp.Entity{entity}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to encrypt data key with PGP: %v", err)
+	}
+	if _, err := w.Write(dataKey); err != nil {
+		t.Fatalf("Failed to write data key: %v", err)
+	}
+	_ = w.Close()
+	_ = armored.Close()
+
+	var privateKey bytes.Buffer
+	armoredKey, err := pgparmor.Encode(&privateKey, openpgp.PrivateKeyType, nil)
+	if err != nil {
+		t.Fatalf("Failed to armor PGP key: %v", err)
+	}
+	if err := entity.SerializePrivate(armoredKey, nil); err != nil {
+		t.Fatalf("Failed to serialize PGP key: %v", err)
+	}
+	_ = armoredKey.Close()
+
+	masterKey := &pgp.MasterKey{
+		Fingerprint:  strings.ToUpper(hex.EncodeToString(entity.PrimaryKey.Fingerprint)),
+		EncryptedKey: message.String(),
+		CreationDate: time.Now().UTC(),
+	}
+	return masterKey, privateKey.String()
+}
+
+func TestDecryptSOPS(t *testing.T) {
+	dataKey := newDataKey(t)
+	ageKey, ageIdentity := newAgeKey(t, dataKey)
+	pgpKey, pgpPrivateKey := newPGPKey(t, dataKey)
+	_, otherIdentity := newAgeKey(t, newDataKey(t))
+
+	tests := []struct {
+		name        string
+		format      string
+		plain       string
+		masterKeys  []keys.MasterKey
+		keys        map[string][]byte
+		expect      string
+		expectError string
+	}{
+		{
+			name:       "YAML with age",
+			format:     FormatYAML,
+			plain:      "password: s3cr3t\n",
+			masterKeys: []keys.MasterKey{ageKey},
+			keys:       map[string][]byte{"identity.agekey": []byte(ageIdentity)},
+			expect:     "password: s3cr3t\n",
+		},
+		{
+			name:       "JSON detected with PGP",
+			plain:      `{"password": "s3cr3t"}`,
+			format:     FormatJSON,
+			masterKeys: []keys.MasterKey{pgpKey},
+			keys:       map[string][]byte{"private.asc": []byte(pgpPrivateKey)},
+			expect:     `"password": "s3cr3t"`,
+		},
+		{
+			name:       "dotenv with either key",
+			format:     FormatDotenv,
+			plain:      "PASSWORD=s3cr3t\n",
+			masterKeys: []keys.MasterKey{ageKey, pgpKey},
+			keys:       map[string][]byte{"private.asc": []byte(pgpPrivateKey)},
+			expect:     "PASSWORD=s3cr3t\n",
+		},
+		{
+			name:        "wrong key",
+			format:      FormatYAML,
+			plain:       "password: s3cr3t\n",
+			masterKeys:  []keys.MasterKey{ageKey},
+			keys:        map[string][]byte{"other.agekey": []byte(otherIdentity)},
+			expectError: "failed to decrypt SOPS data key",
+		},
+		{
+			name:        "no keys in secret",
+			format:      FormatYAML,
+			plain:       "password: s3cr3t\n",
+			masterKeys:  []keys.MasterKey{ageKey},
+			keys:        map[string][]byte{"readme.txt": []byte("nothing here")},
+			expectError: "no age (.agekey) or PGP (.asc) keys found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encrypted := encryptSOPS(t, tt.format, tt.plain, dataKey, tt.masterKeys...)
+			if strings.Contains(string(encrypted), "s3cr3t") {
+				t.Fatal("Expected the encrypted document not to contain the plain value")
+			}
+
+			// JSON must be detected when no format is given
+			format := tt.format
+			if format == FormatJSON {
+				format = ""
+			}
+
+			plain, err := DecryptSOPS(encrypted, format, tt.keys)
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("Expected error containing %q, got %v", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !strings.Contains(string(plain), tt.expect) {
+				t.Errorf("Expected decrypted content to contain %q, got %q", tt.expect, plain)
+			}
+		})
+	}
+}
+
+func TestDecryptSOPS_Tampered(t *testing.T) {
+	dataKey := newDataKey(t)
+	ageKey, ageIdentity := newAgeKey(t, dataKey)
+
+	encrypted := encryptSOPS(t, FormatYAML, "password: s3cr3t\nreplicas: 1\n", dataKey, ageKey)
+	other := encryptSOPS(t, FormatYAML, "password: s3cr3t\nreplicas: 2\n", dataKey, ageKey)
+
+	// Swap in an encrypted value from another document with the same data key
+	tampered := string(encrypted)
+	replicas := func(doc string) string {
+		for _, line := range strings.Split(doc, "\n") {
+			if strings.HasPrefix(line, "replicas:") {
+				return line
+			}
+		}
+		t.Fatal("Expected replicas in encrypted document")
+		return ""
+	}
+	tampered = strings.Replace(tampered, replicas(tampered), replicas(string(other)), 1)
+
+	_, err := DecryptSOPS([]byte(tampered), FormatYAML, map[string][]byte{"identity.agekey": []byte(ageIdentity)})
+	if err == nil {
+		t.Fatal("Expected tampered document to fail decryption")
+	}
+}
+
+func TestDecryptSOPS_UnsupportedFormat(t *testing.T) {
+	_, err := DecryptSOPS([]byte("a=b"), "ini", map[string][]byte{"identity.agekey": []byte("x")})
+	if err == nil || !strings.Contains(err.Error(), "unsupported SOPS format") {
+		t.Errorf("Expected unsupported format error, got %v", err)
+	}
+}
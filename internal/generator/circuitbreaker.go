@@ -0,0 +1,145 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without a network call for requests to a host whose circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// CircuitBreaker short-circuits requests to upstream hosts after consecutive failures
+type CircuitBreaker struct {
+	threshold     int
+	cooldown      time.Duration
+	onStateChange func(host string, open bool)
+	now           func() time.Time
+
+	mutex sync.Mutex
+	hosts map[string]*hostCircuit
+}
+
+// hostCircuit tracks the failures and breaker state of a single host
+type hostCircuit struct {
+	failures  int
+	open      bool
+	openUntil time.Time
+
+	// probing is set while the single trial request after the cooldown is in flight
+	probing bool
+}
+
+// NewCircuitBreaker creates a circuit breaker that opens after threshold consecutive failures
+// to a host and lets a trial request through once cooldown has elapsed. onStateChange, when
+// set, is called whenever the breaker for a host opens or closes.
+func NewCircuitBreaker(threshold int, cooldown time.Duration, onStateChange func(host string, open bool)) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold:     threshold,
+		cooldown:      cooldown,
+		onStateChange: onStateChange,
+		now:           time.Now,
+		hosts:         make(map[string]*hostCircuit),
+	}
+}
+
+// Allow returns an error wrapping ErrCircuitOpen when requests to host must be short-circuited
+func (b *CircuitBreaker) Allow(host string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	circuit, exists := b.hosts[host]
+	if !exists || !circuit.open {
+		return nil
+	}
+
+	now := b.now()
+	if now.Before(circuit.openUntil) {
+		return fmt.Errorf("%w for host %s after %d consecutive failures, retrying in %s",
+			ErrCircuitOpen, host, circuit.failures, circuit.openUntil.Sub(now).Round(time.Second))
+	}
+	if circuit.probing {
+		return fmt.Errorf("%w for host %s, waiting for the trial request to complete", ErrCircuitOpen, host)
+	}
+
+	circuit.probing = true
+	return nil
+}
+
+// Record records the outcome of a request to host, closing the breaker on success and
+// opening it once the failure threshold is reached
+func (b *CircuitBreaker) Record(host string, success bool) {
+	b.mutex.Lock()
+	circuit, exists := b.hosts[host]
+	changed := false
+	if success {
+		if exists {
+			changed = circuit.open
+			delete(b.hosts, host)
+		}
+	} else {
+		if !exists {
+			circuit = &hostCircuit{}
+			b.hosts[host] = circuit
+		}
+		circuit.failures++
+		circuit.probing = false
+		if circuit.open || circuit.failures >= b.threshold {
+			changed = !circuit.open
+			circuit.open = true
+			circuit.openUntil = b.now().Add(b.cooldown)
+		}
+	}
+	b.mutex.Unlock()
+
+	if changed && b.onStateChange != nil {
+		b.onStateChange(host, !success)
+	}
+}
+
+// circuitBreakerTransport checks the circuit breaker before every round trip and records its outcome
+type circuitBreakerTransport struct {
+	breaker *CircuitBreaker
+	next    http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *circuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	if err := t.breaker.Allow(host); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	t.breaker.Record(host, err == nil && !isUpstreamFailure(resp.StatusCode))
+	return resp, err
+}
+
+// isUpstreamFailure reports whether a response status counts as a failure of the host
+func isUpstreamFailure(statusCode int) bool {
+	return statusCode >= http.StatusInternalServerError || statusCode == http.StatusTooManyRequests
+}
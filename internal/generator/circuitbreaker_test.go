@@ -0,0 +1,152 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_OpensAfterThreshold(t *testing.T) {
+	now := time.Now()
+	var transitions []bool
+	breaker := NewCircuitBreaker(3, time.Minute, func(host string, open bool) {
+		if host != "api.example.com" {
+			t.Errorf("state change host = %q, want api.example.com", host)
+		}
+		transitions = append(transitions, open)
+	})
+	breaker.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if err := breaker.Allow("api.example.com"); err != nil {
+			t.Fatalf("Allow() before threshold error = %v", err)
+		}
+		breaker.Record("api.example.com", false)
+	}
+	if err := breaker.Allow("api.example.com"); err != nil {
+		t.Fatalf("Allow() below threshold error = %v", err)
+	}
+	breaker.Record("api.example.com", false)
+
+	err := breaker.Allow("api.example.com")
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Allow() after threshold error = %v, want ErrCircuitOpen", err)
+	}
+	if err := breaker.Allow("other.example.com"); err != nil {
+		t.Errorf("Allow() for another host error = %v", err)
+	}
+
+	// After the cooldown a single trial request is let through
+	now = now.Add(time.Minute)
+	if err := breaker.Allow("api.example.com"); err != nil {
+		t.Fatalf("Allow() trial request error = %v", err)
+	}
+	if err := breaker.Allow("api.example.com"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Allow() during trial request error = %v, want ErrCircuitOpen", err)
+	}
+
+	// A failed trial reopens the breaker for another cooldown
+	breaker.Record("api.example.com", false)
+	if err := breaker.Allow("api.example.com"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Allow() after failed trial error = %v, want ErrCircuitOpen", err)
+	}
+
+	now = now.Add(time.Minute)
+	if err := breaker.Allow("api.example.com"); err != nil {
+		t.Fatalf("Allow() second trial request error = %v", err)
+	}
+	breaker.Record("api.example.com", true)
+	if err := breaker.Allow("api.example.com"); err != nil {
+		t.Errorf("Allow() after successful trial error = %v", err)
+	}
+
+	if len(transitions) != 2 || !transitions[0] || transitions[1] {
+		t.Errorf("state transitions = %v, want [true false]", transitions)
+	}
+}
+
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	breaker := NewCircuitBreaker(2, time.Minute, nil)
+
+	breaker.Record("api.example.com", false)
+	breaker.Record("api.example.com", true)
+	breaker.Record("api.example.com", false)
+
+	if err := breaker.Allow("api.example.com"); err != nil {
+		t.Errorf("Allow() after non-consecutive failures error = %v", err)
+	}
+}
+
+func TestHTTPGenerator_CircuitBreaker(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("failed to parse server URL: %v", err)
+	}
+
+	var openedHost string
+	breaker := NewCircuitBreaker(2, time.Hour, func(host string, open bool) {
+		if open {
+			openedHost = host
+		}
+	})
+	generator := NewHTTPGeneratorWithConfig(nil, &HTTPClientConfig{
+		Timeout:        5 * time.Second,
+		CircuitBreaker: breaker,
+	})
+	// Every Generate call is one request to the host without in-request retries
+	config := GeneratorConfig{
+		Type:   "http",
+		Config: map[string]interface{}{"url": server.URL, "retries": 0},
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := generator.Generate(context.Background(), config); err == nil {
+			t.Fatal("expected error for HTTP 503 response")
+		}
+	}
+
+	_, err = generator.Generate(context.Background(), config)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Generate() with open breaker error = %v, want ErrCircuitOpen", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("server requests = %d, want 2", got)
+	}
+	if openedHost != serverURL.Host {
+		t.Errorf("opened host = %q, want %q", openedHost, serverURL.Host)
+	}
+}
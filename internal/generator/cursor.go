@@ -0,0 +1,121 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// parseCursor reads the cursor options and sends the cursor from the previous response, or the
+// initial value, in the configured header or query parameter
+func (c *HTTPConfig) parseCursor(config map[string]interface{}) error {
+	path, _ := config["cursorJSONPath"].(string)
+	if path == "" {
+		return nil
+	}
+
+	cursorPath := jsonpath.New("cursor").AllowMissingKeys(true)
+	if err := cursorPath.Parse(path); err != nil {
+		return fmt.Errorf("invalid cursor: JSONPath %q: %w", path, err)
+	}
+	c.CursorPath = cursorPath
+
+	header, _ := config["cursorHeader"].(string)
+	param, _ := config["cursorQueryParam"].(string)
+	if (header == "") == (param == "") {
+		return errors.New("invalid cursor: exactly one of header or queryParam must be set")
+	}
+
+	cursor, _ := config["cursor"].(string)
+	if cursor == "" {
+		return nil
+	}
+	if header != "" {
+		deleteHeader(c.Headers, header)
+		c.Headers[header] = cursor
+	} else {
+		// The query parameters may be shared with the spec, so they're copied before the change
+		params := maps.Clone(c.QueryParams)
+		if params == nil {
+			params = make(map[string]string, 1)
+		}
+		params[param] = cursor
+		c.QueryParams = params
+	}
+	return nil
+}
+
+// extractCursor returns the value CursorPath selects in a JSON response body, empty when no
+// cursor is configured or the path selects nothing or null
+func (c *HTTPConfig) extractCursor(data []byte) (string, error) {
+	if c.CursorPath == nil {
+		return "", nil
+	}
+
+	// Numbers are kept as written so large integers aren't rounded or reformatted
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var body interface{}
+	if err := decoder.Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to extract cursor: response is not JSON: %w", err)
+	}
+
+	results, err := c.CursorPath.FindResults(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to extract cursor: %w", err)
+	}
+	var values []reflect.Value
+	for _, result := range results {
+		values = append(values, result...)
+	}
+	switch len(values) {
+	case 0:
+		return "", nil
+	case 1:
+	default:
+		return "", fmt.Errorf("failed to extract cursor: JSONPath selected %d values, expected one", len(values))
+	}
+
+	if !values[0].IsValid() {
+		return "", nil
+	}
+	switch value := values[0].Interface().(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case json.Number:
+		return value.String(), nil
+	case bool:
+		return fmt.Sprint(value), nil
+	default:
+		return "", fmt.Errorf("failed to extract cursor: JSONPath selected a %T, expected a string, number or boolean", value)
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTPGenerator_Generate_Cursor(t *testing.T) {
+	var header, param, version string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Cursor")
+		param = r.URL.Query().Get("after")
+		version = r.URL.Query().Get("version")
+		_, _ = w.Write([]byte(`{"items":[{"id":1}],"meta":{"next":"page-3"}}`))
+	}))
+	defer server.Close()
+
+	generator := NewHTTPGenerator(nil)
+
+	// The cursor is sent in the header and the next one extracted
+	data, err := generator.Generate(context.Background(), GeneratorConfig{Type: "http", Config: map[string]interface{}{
+		"url":            server.URL,
+		"headers":        map[string]string{"x-cursor": "inline"},
+		"cursorJSONPath": "{.meta.next}",
+		"cursorHeader":   "X-Cursor",
+		"cursor":         "page-2",
+	}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if header != "page-2" {
+		t.Errorf("X-Cursor header = %q, want page-2", header)
+	}
+	if data.Cursor != "page-3" {
+		t.Errorf("Cursor = %q, want page-3", data.Cursor)
+	}
+
+	// The cursor is added to the query parameters without changing the configured ones
+	queryParams := map[string]string{"version": "2"}
+	if _, err := generator.Generate(context.Background(), GeneratorConfig{Type: "http", Config: map[string]interface{}{
+		"url":              server.URL,
+		"queryParams":      queryParams,
+		"cursorJSONPath":   "{.meta.next}",
+		"cursorQueryParam": "after",
+		"cursor":           "page-2",
+	}}); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if param != "page-2" || version != "2" {
+		t.Errorf("query parameters after = %q, version = %q, want page-2 and 2", param, version)
+	}
+	if len(queryParams) != 1 {
+		t.Errorf("configured query parameters were changed: %v", queryParams)
+	}
+
+	// Nothing is sent before the first cursor is extracted
+	if _, err := generator.Generate(context.Background(), GeneratorConfig{Type: "http", Config: map[string]interface{}{
+		"url":              server.URL,
+		"cursorJSONPath":   "{.meta.next}",
+		"cursorQueryParam": "after",
+	}}); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if param != "" {
+		t.Errorf("query parameter after = %q, want none", param)
+	}
+}
+
+func TestHTTPGenerator_ParseConfig_Cursor(t *testing.T) {
+	generator := NewHTTPGenerator(nil)
+
+	for _, invalid := range []map[string]interface{}{
+		{"url": "https://example.com", "cursorJSONPath": "{.meta.next", "cursorHeader": "X-Cursor"},
+		{"url": "https://example.com", "cursorJSONPath": "{.meta.next}"},
+		{"url": "https://example.com", "cursorJSONPath": "{.meta.next}", "cursorHeader": "X-Cursor", "cursorQueryParam": "after"},
+	} {
+		_, err := generator.parseConfig(context.Background(), invalid)
+		if err == nil || !strings.Contains(err.Error(), "invalid cursor") {
+			t.Errorf("parseConfig(%v) error = %v, want invalid cursor", invalid, err)
+		}
+	}
+}
+
+func TestHTTPConfig_extractCursor(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		body    string
+		want    string
+		wantErr string
+	}{
+		{name: "string", path: "{.next}", body: `{"next":"abc"}`, want: "abc"},
+		{name: "large number", path: "{.next}", body: `{"next":12345678901234567890}`, want: "12345678901234567890"},
+		{name: "boolean", path: "{.more}", body: `{"more":true}`, want: "true"},
+		{name: "null", path: "{.next}", body: `{"next":null}`},
+		{name: "missing", path: "{.meta.next}", body: `{"items":[]}`},
+		{name: "last element", path: "{.items[-1:].id}", body: `{"items":[{"id":"a"},{"id":"b"}]}`, want: "b"},
+		{name: "several values", path: "{.items[*].id}", body: `{"items":[{"id":"a"},{"id":"b"}]}`, wantErr: "selected 2 values"},
+		{name: "object", path: "{.meta}", body: `{"meta":{"next":"abc"}}`, wantErr: "expected a string, number or boolean"},
+		{name: "not JSON", path: "{.next}", body: "next: abc\n", wantErr: "response is not JSON"},
+	}
+
+	generator := NewHTTPGenerator(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := generator.parseConfig(context.Background(), map[string]interface{}{
+				"url":            "https://example.com",
+				"cursorJSONPath": tt.path,
+				"cursorHeader":   "X-Cursor",
+			})
+			if err != nil {
+				t.Fatalf("parseConfig() error = %v", err)
+			}
+
+			got, err := config.extractCursor([]byte(tt.body))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("extractCursor() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("extractCursor() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("extractCursor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FileGenerator implements SourceGenerator for files on a mounted volume
+type FileGenerator struct {
+	// root is the directory paths are resolved against, the generator is disabled when empty
+	root string
+}
+
+// FileConfig holds file-specific configuration
+type FileConfig struct {
+	Path string `json:"path"`
+}
+
+// NewFileGenerator creates a new file generator reading from below root
+func NewFileGenerator(root string) *FileGenerator {
+	return &FileGenerator{
+		root: root,
+	}
+}
+
+// Generate reads the file at the configured path
+func (f *FileGenerator) Generate(ctx context.Context, config GeneratorConfig) (*SourceData, error) {
+	fileConfig, err := f.parseConfig(config.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse file config: %w", err)
+	}
+
+	filePath, err := f.resolvePath(fileConfig.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat file %s: %w", filePath, err)
+	}
+
+	return &SourceData{
+		Data:         data,
+		LastModified: fileVersion(info),
+		Metadata: map[string]string{
+			"path":    fileConfig.Path,
+			"size":    strconv.FormatInt(info.Size(), 10),
+			"modTime": info.ModTime().UTC().Format(time.RFC3339),
+		},
+	}, nil
+}
+
+// SupportsConditionalFetch returns true as the modification time and size identify the content
+func (f *FileGenerator) SupportsConditionalFetch() bool {
+	return true
+}
+
+// GetLastModified returns the file modification time and size
+func (f *FileGenerator) GetLastModified(ctx context.Context, config GeneratorConfig) (string, error) {
+	fileConfig, err := f.parseConfig(config.Config)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse file config: %w", err)
+	}
+
+	filePath, err := f.resolvePath(fileConfig.Path)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to stat file %s: %w", filePath, err)
+	}
+
+	return fileVersion(info), nil
+}
+
+// parseConfig converts the generic config map to FileConfig, rejecting absolute paths
+// and path traversal
+func (f *FileGenerator) parseConfig(config map[string]interface{}) (*FileConfig, error) {
+	fileConfig := &FileConfig{}
+
+	if path, ok := config["path"].(string); ok && path != "" {
+		fileConfig.Path = path
+	} else {
+		return nil, fmt.Errorf("path is required and must be a string")
+	}
+
+	// Check for path traversal attempts
+	if strings.Contains(fileConfig.Path, "..") {
+		return nil, fmt.Errorf("path contains invalid path traversal: %s", fileConfig.Path)
+	}
+
+	// Ensure path doesn't start with /
+	if strings.HasPrefix(fileConfig.Path, "/") {
+		return nil, fmt.Errorf("path cannot start with /: %s", fileConfig.Path)
+	}
+
+	return fileConfig, nil
+}
+
+// resolvePath joins path to the root and resolves symbolic links, which must stay within
+// the root. Mounted ConfigMaps and Secrets link their files into a timestamped directory.
+func (f *FileGenerator) resolvePath(path string) (string, error) {
+	if f.root == "" {
+		return "", fmt.Errorf("file generator is disabled, root directory configuration is required")
+	}
+
+	root, err := filepath.EvalSymlinks(f.root)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve root directory %s: %w", f.root, err)
+	}
+
+	resolved, err := filepath.EvalSymlinks(filepath.Join(root, path))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path %s: %w", path, err)
+	}
+	if resolved != root && !strings.HasPrefix(resolved, root+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %s resolves outside the root directory", path)
+	}
+
+	return resolved, nil
+}
+
+// fileVersion identifies the file content by its modification time and size
+func fileVersion(info os.FileInfo) string {
+	return fmt.Sprintf("%d-%d", info.ModTime().UnixNano(), info.Size())
+}
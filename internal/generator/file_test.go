@@ -0,0 +1,205 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileGenerator_SupportsConditionalFetch(t *testing.T) {
+	generator := NewFileGenerator(t.TempDir())
+	if !generator.SupportsConditionalFetch() {
+		t.Error("File generator should support conditional fetch")
+	}
+}
+
+func TestFileGenerator_Generate_Success(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "config"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "config", "app.json"), []byte(`{"message": "test data"}`), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	generator := NewFileGenerator(root)
+	config := GeneratorConfig{
+		Type:   "file",
+		Config: map[string]interface{}{"path": "config/app.json"},
+	}
+
+	data, err := generator.Generate(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if string(data.Data) != `{"message": "test data"}` {
+		t.Errorf("Expected test data, got %s", string(data.Data))
+	}
+
+	if data.Metadata["path"] != "config/app.json" {
+		t.Errorf("Expected path metadata config/app.json, got %s", data.Metadata["path"])
+	}
+
+	lastModified, err := generator.GetLastModified(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if lastModified != data.LastModified {
+		t.Errorf("Expected last modified %s to match generated %s", lastModified, data.LastModified)
+	}
+}
+
+func TestFileGenerator_GetLastModified_Changes(t *testing.T) {
+	root := t.TempDir()
+	filePath := filepath.Join(root, "data.yaml")
+	if err := os.WriteFile(filePath, []byte("a: 1"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	generator := NewFileGenerator(root)
+	config := GeneratorConfig{Type: "file", Config: map[string]interface{}{"path": "data.yaml"}}
+
+	before, err := generator.GetLastModified(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Same modification time, different size
+	modTime := time.Now().Add(-time.Hour)
+	if err := os.WriteFile(filePath, []byte("a: 12"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := os.Chtimes(filePath, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set modification time: %v", err)
+	}
+
+	after, err := generator.GetLastModified(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if before == after {
+		t.Errorf("Expected last modified to change after rewriting the file, got %s", after)
+	}
+}
+
+func TestFileGenerator_Generate_FollowsSymlinksWithinRoot(t *testing.T) {
+	// Mirrors the layout of a mounted ConfigMap volume
+	root := t.TempDir()
+	dataDir := filepath.Join(root, "..2024_01_01_00_00_00.000000000")
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "config.json"), []byte("{}"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := os.Symlink(filepath.Base(dataDir), filepath.Join(root, "..data")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+	if err := os.Symlink(filepath.Join("..data", "config.json"), filepath.Join(root, "config.json")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	generator := NewFileGenerator(root)
+	data, err := generator.Generate(context.Background(), GeneratorConfig{
+		Type:   "file",
+		Config: map[string]interface{}{"path": "config.json"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if string(data.Data) != "{}" {
+		t.Errorf("Expected {}, got %s", string(data.Data))
+	}
+}
+
+func TestFileGenerator_Generate_RejectsPathsOutsideRoot(t *testing.T) {
+	outside := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outside, "secret"), []byte("secret"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	root := t.TempDir()
+	if err := os.Symlink(filepath.Join(outside, "secret"), filepath.Join(root, "link")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		root        string
+		config      map[string]interface{}
+		expectError string
+	}{
+		{
+			name:        "missing path",
+			root:        root,
+			config:      map[string]interface{}{},
+			expectError: "path is required",
+		},
+		{
+			name:        "path traversal",
+			root:        root,
+			config:      map[string]interface{}{"path": "../secret"},
+			expectError: "invalid path traversal",
+		},
+		{
+			name:        "absolute path",
+			root:        root,
+			config:      map[string]interface{}{"path": filepath.Join(outside, "secret")},
+			expectError: "cannot start with /",
+		},
+		{
+			name:        "symlink outside root",
+			root:        root,
+			config:      map[string]interface{}{"path": "link"},
+			expectError: "resolves outside the root directory",
+		},
+		{
+			name:        "missing file",
+			root:        root,
+			config:      map[string]interface{}{"path": "missing.json"},
+			expectError: "failed to resolve path missing.json",
+		},
+		{
+			name:        "disabled without root",
+			root:        "",
+			config:      map[string]interface{}{"path": "config.json"},
+			expectError: "file generator is disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := NewFileGenerator(tt.root)
+			_, err := generator.Generate(context.Background(), GeneratorConfig{Type: "file", Config: tt.config})
+			if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+				t.Fatalf("Expected error containing %q, got %v", tt.expectError, err)
+			}
+		})
+	}
+}
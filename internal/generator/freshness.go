@@ -0,0 +1,82 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generator
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// responseFreshUntil returns when a response stops being fresh according to its
+// Cache-Control max-age directive, or its Expires header when max-age is not set,
+// or the zero time when the response may not be reused without revalidation
+func responseFreshUntil(header http.Header, now time.Time) time.Time {
+	maxAge := -1
+	for _, value := range header.Values("Cache-Control") {
+		for _, directive := range strings.Split(value, ",") {
+			name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			switch strings.ToLower(name) {
+			case "no-store", "no-cache":
+				return time.Time{}
+			case "max-age":
+				seconds, err := strconv.Atoi(strings.Trim(arg, `"`))
+				if err != nil || seconds < 0 {
+					return time.Time{}
+				}
+				maxAge = seconds
+			}
+		}
+	}
+
+	if maxAge >= 0 {
+		// Time the response already spent in intermediate caches counts against its lifetime
+		age := 0
+		if value, err := strconv.Atoi(header.Get("Age")); err == nil && value > 0 {
+			age = value
+		}
+		if maxAge <= age {
+			return time.Time{}
+		}
+		return now.Add(time.Duration(maxAge-age) * time.Second)
+	}
+
+	expiresHeader := header.Get("Expires")
+	if expiresHeader == "" {
+		return time.Time{}
+	}
+	expires, err := http.ParseTime(expiresHeader)
+	if err != nil {
+		return time.Time{}
+	}
+	// Measure the lifetime against the server clock so clock skew doesn't extend it
+	date, err := http.ParseTime(header.Get("Date"))
+	if err != nil {
+		date = now
+	}
+	if !expires.After(date) {
+		return time.Time{}
+	}
+	return now.Add(expires.Sub(date))
+}
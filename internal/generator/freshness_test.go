@@ -0,0 +1,110 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestResponseFreshUntil(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	serverDate := now.Add(-time.Hour).Format(http.TimeFormat)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    time.Duration
+	}{
+		{name: "no caching headers", headers: map[string]string{}},
+		{name: "max-age", headers: map[string]string{"Cache-Control": "public, max-age=300"}, want: 5 * time.Minute},
+		{name: "max-age minus age", headers: map[string]string{"Cache-Control": "max-age=300", "Age": "60"}, want: 4 * time.Minute},
+		{name: "age beyond max-age", headers: map[string]string{"Cache-Control": "max-age=300", "Age": "600"}},
+		{name: "no-cache", headers: map[string]string{"Cache-Control": "no-cache, max-age=300"}},
+		{name: "no-store", headers: map[string]string{"Cache-Control": "no-store"}},
+		{name: "invalid max-age", headers: map[string]string{"Cache-Control": "max-age=soon"}},
+		{
+			name: "max-age takes precedence over expires",
+			headers: map[string]string{
+				"Cache-Control": "max-age=60",
+				"Date":          serverDate,
+				"Expires":       now.Format(http.TimeFormat),
+			},
+			want: time.Minute,
+		},
+		{
+			name: "expires relative to server date",
+			headers: map[string]string{
+				"Date":    serverDate,
+				"Expires": now.Add(-30 * time.Minute).Format(http.TimeFormat),
+			},
+			want: 30 * time.Minute,
+		},
+		{name: "expires in the past", headers: map[string]string{"Date": serverDate, "Expires": now.Add(-2 * time.Hour).Format(http.TimeFormat)}},
+		{name: "invalid expires", headers: map[string]string{"Expires": "0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for name, value := range tt.headers {
+				header.Set(name, value)
+			}
+
+			got := responseFreshUntil(header, now)
+			if tt.want == 0 {
+				if !got.IsZero() {
+					t.Errorf("responseFreshUntil() = %v, want zero", got)
+				}
+				return
+			}
+			if want := now.Add(tt.want); !got.Equal(want) {
+				t.Errorf("responseFreshUntil() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestHTTPGenerator_Generate_FreshUntil(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=600")
+		_, _ = w.Write([]byte(`{"message": "test data"}`))
+	}))
+	defer server.Close()
+
+	before := time.Now()
+	data, err := NewHTTPGenerator(nil).Generate(context.Background(), GeneratorConfig{
+		Type:   "http",
+		Config: map[string]interface{}{"url": server.URL},
+	})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if data.FreshUntil.Before(before.Add(10*time.Minute)) || data.FreshUntil.After(time.Now().Add(10*time.Minute)) {
+		t.Errorf("FreshUntil = %v, want about 10m after %v", data.FreshUntil, before)
+	}
+}
@@ -0,0 +1,340 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generator
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GitGenerator implements SourceGenerator for Git repositories
+type GitGenerator struct {
+	client client.Client
+}
+
+// GitConfig holds Git-specific configuration
+type GitConfig struct {
+	URL    string `json:"url"`
+	Branch string `json:"branch"`
+	Tag    string `json:"tag"`
+	Path   string `json:"path"`
+
+	auth transport.AuthMethod
+}
+
+// NewGitGenerator creates a new Git generator
+func NewGitGenerator(k8sClient client.Client) *GitGenerator {
+	return &GitGenerator{
+		client: k8sClient,
+	}
+}
+
+// Generate performs a shallow clone of the repository and returns the file at the configured path
+func (g *GitGenerator) Generate(ctx context.Context, config GeneratorConfig) (*SourceData, error) {
+	gitConfig, err := g.parseConfig(ctx, config.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Git config: %w", err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "externalsource-git-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	cloneOptions := &git.CloneOptions{
+		URL:          gitConfig.URL,
+		Auth:         gitConfig.auth,
+		Depth:        1,
+		SingleBranch: true,
+		NoCheckout:   true,
+		Tags:         git.NoTags,
+	}
+	if ref := gitConfig.referenceName(); ref != "" {
+		cloneOptions.ReferenceName = ref
+	}
+
+	repo, err := git.PlainCloneContext(ctx, tmpDir, false, cloneOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone repository %s: %w", gitConfig.URL, err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+
+	commit, err := resolveCommit(repo, head.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := commit.File(gitConfig.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find %s in commit %s: %w", gitConfig.Path, commit.Hash, err)
+	}
+
+	contents, err := file.Contents()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", gitConfig.Path, err)
+	}
+
+	sha := commit.Hash.String()
+
+	return &SourceData{
+		Data:         []byte(contents),
+		LastModified: sha,
+		Metadata: map[string]string{
+			"commit": sha,
+			"ref":    head.Name().String(),
+			"path":   gitConfig.Path,
+		},
+	}, nil
+}
+
+// SupportsConditionalFetch returns true as the resolved commit SHA identifies the content
+func (g *GitGenerator) SupportsConditionalFetch() bool {
+	return true
+}
+
+// GetLastModified lists the remote references and returns the commit SHA the configured ref points to
+func (g *GitGenerator) GetLastModified(ctx context.Context, config GeneratorConfig) (string, error) {
+	gitConfig, err := g.parseConfig(ctx, config.Config)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse Git config: %w", err)
+	}
+
+	remote := git.NewRemote(memory.NewStorage(), &gitconfig.RemoteConfig{
+		Name: git.DefaultRemoteName,
+		URLs: []string{gitConfig.URL},
+	})
+
+	refs, err := remote.ListContext(ctx, &git.ListOptions{
+		Auth:          gitConfig.auth,
+		PeelingOption: git.AppendPeeled,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list remote references: %w", err)
+	}
+
+	byName := make(map[plumbing.ReferenceName]*plumbing.Reference, len(refs))
+	for _, ref := range refs {
+		byName[ref.Name()] = ref
+	}
+
+	name := gitConfig.referenceName()
+	if name == "" {
+		name = plumbing.HEAD
+	}
+
+	// Annotated tags are advertised with a peeled entry pointing at the commit
+	if name.IsTag() {
+		if peeled, ok := byName[plumbing.ReferenceName(name.String()+"^{}")]; ok {
+			return peeled.Hash().String(), nil
+		}
+	}
+
+	ref, ok := byName[name]
+	if !ok {
+		return "", fmt.Errorf("reference %s not found in %s", name, gitConfig.URL)
+	}
+
+	if ref.Type() == plumbing.SymbolicReference {
+		target, ok := byName[ref.Target()]
+		if !ok {
+			return "", fmt.Errorf("reference %s not found in %s", ref.Target(), gitConfig.URL)
+		}
+		ref = target
+	}
+
+	return ref.Hash().String(), nil
+}
+
+// parseConfig converts the generic config map to GitConfig
+func (g *GitGenerator) parseConfig(ctx context.Context, config map[string]interface{}) (*GitConfig, error) {
+	gitConfig := &GitConfig{}
+
+	if repoURL, ok := config["url"].(string); ok && repoURL != "" {
+		gitConfig.URL = repoURL
+	} else {
+		return nil, fmt.Errorf("url is required and must be a string")
+	}
+
+	if path, ok := config["path"].(string); ok && path != "" {
+		gitConfig.Path = strings.TrimPrefix(filepath.ToSlash(filepath.Clean(path)), "/")
+	} else {
+		return nil, fmt.Errorf("path is required and must be a string")
+	}
+
+	gitConfig.Branch, _ = config["branch"].(string)
+	gitConfig.Tag, _ = config["tag"].(string)
+	if gitConfig.Branch != "" && gitConfig.Tag != "" {
+		return nil, fmt.Errorf("only one of branch or tag may be specified")
+	}
+
+	// Parse namespace for secret references
+	namespace, _ := config["namespace"].(string)
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	if secretName, ok := config["secretName"].(string); ok && secretName != "" {
+		auth, err := g.loadAuth(ctx, namespace, secretName, gitConfig.URL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Git credentials from secret: %w", err)
+		}
+		gitConfig.auth = auth
+	}
+
+	return gitConfig, nil
+}
+
+// referenceName returns the fully qualified reference for the configured branch or tag
+func (c *GitConfig) referenceName() plumbing.ReferenceName {
+	switch {
+	case c.Tag != "":
+		return plumbing.NewTagReferenceName(c.Tag)
+	case c.Branch != "":
+		return plumbing.NewBranchReferenceName(c.Branch)
+	default:
+		return ""
+	}
+}
+
+// loadAuth builds a transport auth method from a Kubernetes secret. Secrets with an
+// identity key are used for SSH and must also carry known_hosts; otherwise the
+// username and password keys are used for HTTP basic auth.
+func (g *GitGenerator) loadAuth(ctx context.Context, namespace, secretName, repoURL string) (transport.AuthMethod, error) {
+	secret := &corev1.Secret{}
+	secretKey := client.ObjectKey{
+		Namespace: namespace,
+		Name:      secretName,
+	}
+
+	if err := g.client.Get(ctx, secretKey, secret); err != nil {
+		return nil, fmt.Errorf("failed to get secret %s/%s: %w", namespace, secretName, err)
+	}
+
+	if identity, ok := secret.Data["identity"]; ok {
+		knownHosts, ok := secret.Data["known_hosts"]
+		if !ok || len(knownHosts) == 0 {
+			return nil, fmt.Errorf("key known_hosts not found in secret %s/%s", namespace, secretName)
+		}
+
+		user := string(secret.Data["username"])
+		if user == "" {
+			user = "git"
+			if u, err := url.Parse(repoURL); err == nil && u.User != nil && u.User.Username() != "" {
+				user = u.User.Username()
+			}
+		}
+
+		publicKeys, err := gitssh.NewPublicKeys(user, identity, string(secret.Data["password"]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse SSH identity: %w", err)
+		}
+
+		callback, err := knownHostsCallback(knownHosts)
+		if err != nil {
+			return nil, err
+		}
+		publicKeys.HostKeyCallback = callback
+
+		return publicKeys, nil
+	}
+
+	username, hasUsername := secret.Data["username"]
+	password, hasPassword := secret.Data["password"]
+	if !hasUsername && !hasPassword {
+		return nil, fmt.Errorf("secret %s/%s must contain either identity or username and password", namespace, secretName)
+	}
+
+	return &githttp.BasicAuth{
+		Username: string(username),
+		Password: string(password),
+	}, nil
+}
+
+// knownHostsCallback builds an SSH host key callback from known_hosts data
+func knownHostsCallback(knownHosts []byte) (ssh.HostKeyCallback, error) {
+	file, err := os.CreateTemp("", "externalsource-known-hosts-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create known_hosts file: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(file.Name())
+	}()
+
+	if _, err := file.Write(knownHosts); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to write known_hosts file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write known_hosts file: %w", err)
+	}
+
+	callback, err := gitssh.NewKnownHostsCallback(file.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse known_hosts: %w", err)
+	}
+
+	return callback, nil
+}
+
+// resolveCommit returns the commit for a hash, peeling annotated tags if necessary
+func resolveCommit(repo *git.Repository, hash plumbing.Hash) (*object.Commit, error) {
+	commit, err := repo.CommitObject(hash)
+	if err == nil {
+		return commit, nil
+	}
+
+	tag, tagErr := repo.TagObject(hash)
+	if tagErr != nil {
+		return nil, fmt.Errorf("failed to resolve commit %s: %w", hash, err)
+	}
+
+	commit, err = tag.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve commit for tag %s: %w", tag.Name, err)
+	}
+
+	return commit, nil
+}
@@ -0,0 +1,317 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// newTestRepository creates a local repository with a single committed file and returns its path
+func newTestRepository(t *testing.T, files map[string]string) (string, *git.Repository, plumbing.Hash) {
+	t.Helper()
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("Failed to init repository: %v", err)
+	}
+
+	hash := commitFiles(t, repo, dir, files)
+	return dir, repo, hash
+}
+
+// commitFiles writes the given files to the worktree and commits them
+func commitFiles(t *testing.T, repo *git.Repository, dir string, files map[string]string) plumbing.Hash {
+	t.Helper()
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		if _, err := worktree.Add(name); err != nil {
+			t.Fatalf("Failed to add file: %v", err)
+		}
+	}
+
+	hash, err := worktree.Commit("update", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+
+	return hash
+}
+
+func TestGitGenerator_SupportsConditionalFetch(t *testing.T) {
+	generator := NewGitGenerator(nil)
+	if !generator.SupportsConditionalFetch() {
+		t.Error("Git generator should support conditional fetch")
+	}
+}
+
+func TestGitGenerator_Generate_Success(t *testing.T) {
+	dir, _, hash := newTestRepository(t, map[string]string{
+		"config/app.json": `{"message": "test data"}`,
+	})
+
+	generator := NewGitGenerator(nil)
+	config := GeneratorConfig{
+		Type: "git",
+		Config: map[string]interface{}{
+			"url":  dir,
+			"path": "config/app.json",
+		},
+	}
+
+	data, err := generator.Generate(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if string(data.Data) != `{"message": "test data"}` {
+		t.Errorf("Expected test data, got %s", string(data.Data))
+	}
+
+	if data.LastModified != hash.String() {
+		t.Errorf("Expected commit %s, got %s", hash, data.LastModified)
+	}
+
+	if data.Metadata["commit"] != hash.String() {
+		t.Errorf("Expected commit metadata %s, got %s", hash, data.Metadata["commit"])
+	}
+}
+
+func TestGitGenerator_Generate_Tag(t *testing.T) {
+	dir, repo, tagged := newTestRepository(t, map[string]string{
+		"data.txt": "v1",
+	})
+
+	if _, err := repo.CreateTag("v1.0.0", tagged, &git.CreateTagOptions{
+		Tagger:  &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+		Message: "release",
+	}); err != nil {
+		t.Fatalf("Failed to create tag: %v", err)
+	}
+
+	commitFiles(t, repo, dir, map[string]string{"data.txt": "v2"})
+
+	generator := NewGitGenerator(nil)
+	config := GeneratorConfig{
+		Type: "git",
+		Config: map[string]interface{}{
+			"url":  dir,
+			"path": "data.txt",
+			"tag":  "v1.0.0",
+		},
+	}
+
+	ctx := context.Background()
+	data, err := generator.Generate(ctx, config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if string(data.Data) != "v1" {
+		t.Errorf("Expected tagged content v1, got %s", string(data.Data))
+	}
+
+	lastModified, err := generator.GetLastModified(ctx, config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if lastModified != tagged.String() || data.LastModified != tagged.String() {
+		t.Errorf("Expected commit %s, got %s and %s", tagged, lastModified, data.LastModified)
+	}
+}
+
+func TestGitGenerator_Generate_FileNotFound(t *testing.T) {
+	dir, _, _ := newTestRepository(t, map[string]string{
+		"data.txt": "content",
+	})
+
+	generator := NewGitGenerator(nil)
+	config := GeneratorConfig{
+		Type: "git",
+		Config: map[string]interface{}{
+			"url":  dir,
+			"path": "missing.txt",
+		},
+	}
+
+	_, err := generator.Generate(context.Background(), config)
+	if err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
+
+func TestGitGenerator_GetLastModified_Branch(t *testing.T) {
+	dir, repo, _ := newTestRepository(t, map[string]string{
+		"data.txt": "first",
+	})
+	latest := commitFiles(t, repo, dir, map[string]string{"data.txt": "second"})
+
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("Failed to get HEAD: %v", err)
+	}
+
+	generator := NewGitGenerator(nil)
+	config := GeneratorConfig{
+		Type: "git",
+		Config: map[string]interface{}{
+			"url":    dir,
+			"path":   "data.txt",
+			"branch": head.Name().Short(),
+		},
+	}
+
+	lastModified, err := generator.GetLastModified(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if lastModified != latest.String() {
+		t.Errorf("Expected commit %s, got %s", latest, lastModified)
+	}
+}
+
+func TestGitGenerator_GetLastModified_UnknownBranch(t *testing.T) {
+	dir, _, _ := newTestRepository(t, map[string]string{
+		"data.txt": "content",
+	})
+
+	generator := NewGitGenerator(nil)
+	config := GeneratorConfig{
+		Type: "git",
+		Config: map[string]interface{}{
+			"url":    dir,
+			"path":   "data.txt",
+			"branch": "does-not-exist",
+		},
+	}
+
+	_, err := generator.GetLastModified(context.Background(), config)
+	if err == nil {
+		t.Error("Expected error for unknown branch")
+	}
+}
+
+func TestGitGenerator_ParseConfig_MissingFields(t *testing.T) {
+	generator := NewGitGenerator(nil)
+	ctx := context.Background()
+
+	if _, err := generator.parseConfig(ctx, map[string]interface{}{"path": "data.txt"}); err == nil {
+		t.Error("Expected error for missing URL")
+	}
+
+	if _, err := generator.parseConfig(ctx, map[string]interface{}{"url": "https://example.com/repo.git"}); err == nil {
+		t.Error("Expected error for missing path")
+	}
+
+	if _, err := generator.parseConfig(ctx, map[string]interface{}{
+		"url":    "https://example.com/repo.git",
+		"path":   "data.txt",
+		"branch": "main",
+		"tag":    "v1.0.0",
+	}); err == nil {
+		t.Error("Expected error when both branch and tag are set")
+	}
+}
+
+func TestGitGenerator_LoadAuth(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	basicAuth := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "basic-auth", Namespace: "test-namespace"},
+		Data: map[string][]byte{
+			"username": []byte("user"),
+			"password": []byte("token"),
+		},
+	}
+	sshMissingKnownHosts := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ssh-auth", Namespace: "test-namespace"},
+		Data: map[string][]byte{
+			"identity": []byte("not-a-key"),
+		},
+	}
+	empty := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "empty", Namespace: "test-namespace"},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(basicAuth, sshMissingKnownHosts, empty).
+		Build()
+	generator := NewGitGenerator(fakeClient)
+	ctx := context.Background()
+
+	auth, err := generator.loadAuth(ctx, "test-namespace", "basic-auth", "https://example.com/repo.git")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	basic, ok := auth.(*githttp.BasicAuth)
+	if !ok {
+		t.Fatalf("Expected basic auth, got %T", auth)
+	}
+	if basic.Username != "user" || basic.Password != "token" {
+		t.Errorf("Unexpected credentials %s/%s", basic.Username, basic.Password)
+	}
+
+	if _, err := generator.loadAuth(ctx, "test-namespace", "ssh-auth", "ssh://git@example.com/repo.git"); err == nil {
+		t.Error("Expected error when known_hosts is missing")
+	}
+
+	if _, err := generator.loadAuth(ctx, "test-namespace", "empty", "https://example.com/repo.git"); err == nil {
+		t.Error("Expected error for secret without credentials")
+	}
+
+	if _, err := generator.loadAuth(ctx, "test-namespace", "missing", "https://example.com/repo.git"); err == nil {
+		t.Error("Expected error for missing secret")
+	}
+}
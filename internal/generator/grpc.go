@@ -0,0 +1,375 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generator
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultGRPCTimeout bounds a gRPC call, including method resolution, when the source sets no timeout
+const defaultGRPCTimeout = 30 * time.Second
+
+// GRPCGenerator implements SourceGenerator for unary gRPC methods. Request and response
+// types are resolved with server reflection, so no generated code is needed.
+type GRPCGenerator struct {
+	client  client.Client
+	timeout time.Duration
+}
+
+// GRPCConfig holds gRPC-specific configuration
+type GRPCConfig struct {
+	Target    string        `json:"target"`
+	Service   string        `json:"service"`
+	Method    string        `json:"method"`
+	Request   []byte        `json:"request"`
+	Plaintext bool          `json:"plaintext"`
+	CABundle  []byte        `json:"caBundle"`
+	Timeout   time.Duration `json:"timeout"`
+
+	// ClientCertificate is presented for mutual TLS when set
+	ClientCertificate *tls.Certificate `json:"-"`
+
+	// Metadata is sent with the call, keys are lowercase
+	Metadata map[string]string `json:"-"`
+}
+
+// NewGRPCGenerator creates a new gRPC generator
+func NewGRPCGenerator(k8sClient client.Client) *GRPCGenerator {
+	return &GRPCGenerator{
+		client:  k8sClient,
+		timeout: defaultGRPCTimeout,
+	}
+}
+
+// Generate invokes the configured method and returns the response encoded as JSON
+func (g *GRPCGenerator) Generate(ctx context.Context, config GeneratorConfig) (*SourceData, error) {
+	grpcConfig, err := g.parseConfig(ctx, config.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse gRPC config: %w", err)
+	}
+
+	timeout := g.timeout
+	if grpcConfig.Timeout > 0 {
+		timeout = grpcConfig.Timeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	transportCredentials, err := grpcConfig.transportCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.NewClient(grpcConfig.Target, grpc.WithTransportCredentials(transportCredentials))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for %s: %w", grpcConfig.Target, err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	if len(grpcConfig.Metadata) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(grpcConfig.Metadata))
+	}
+
+	methodDesc, types, err := resolveMethod(ctx, conn, grpcConfig.Service, grpcConfig.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	request := dynamicpb.NewMessage(methodDesc.Input())
+	if len(grpcConfig.Request) > 0 {
+		if err := (protojson.UnmarshalOptions{Resolver: types}).Unmarshal(grpcConfig.Request, request); err != nil {
+			return nil, fmt.Errorf("invalid gRPC request for %s: %w", methodDesc.Input().FullName(), err)
+		}
+	}
+
+	fullMethod := "/" + grpcConfig.Service + "/" + grpcConfig.Method
+	response := dynamicpb.NewMessage(methodDesc.Output())
+	if err := conn.Invoke(ctx, fullMethod, request, response); err != nil {
+		return nil, fmt.Errorf("gRPC call %s failed: %w", fullMethod, err)
+	}
+
+	encoded, err := (protojson.MarshalOptions{Resolver: types}).Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode gRPC response as JSON: %w", err)
+	}
+
+	// protojson deliberately varies its whitespace, compact it so revisions are stable
+	var data bytes.Buffer
+	if err := json.Compact(&data, encoded); err != nil {
+		return nil, fmt.Errorf("failed to encode gRPC response as JSON: %w", err)
+	}
+
+	return &SourceData{
+		Data: data.Bytes(),
+		Metadata: map[string]string{
+			"target":       grpcConfig.Target,
+			"method":       fullMethod,
+			"content-type": "application/json",
+		},
+	}, nil
+}
+
+// SupportsConditionalFetch returns false as unary gRPC methods have no version to compare
+func (g *GRPCGenerator) SupportsConditionalFetch() bool {
+	return false
+}
+
+// GetLastModified is not supported for gRPC sources
+func (g *GRPCGenerator) GetLastModified(_ context.Context, _ GeneratorConfig) (string, error) {
+	return "", nil
+}
+
+// transportCredentials returns plaintext credentials or TLS credentials with the
+// configured CA bundle and client certificate
+func (c *GRPCConfig) transportCredentials() (credentials.TransportCredentials, error) {
+	if c.Plaintext {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if len(c.CABundle) > 0 {
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(c.CABundle) {
+			return nil, fmt.Errorf("failed to parse CA bundle")
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	if c.ClientCertificate != nil {
+		tlsConfig.Certificates = []tls.Certificate{*c.ClientCertificate}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// resolveMethod looks up the method with server reflection and returns its descriptor
+// together with the types of the files it was resolved from
+func resolveMethod(ctx context.Context, conn *grpc.ClientConn, service, method string) (protoreflect.MethodDescriptor, *dynamicpb.Types, error) {
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open gRPC reflection stream: %w", err)
+	}
+	defer func() {
+		_ = stream.CloseSend()
+	}()
+
+	fileProtos := make(map[string]*descriptorpb.FileDescriptorProto)
+	request := &reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	}
+
+	// Request files until every dependency of the files received so far is known
+	for request != nil {
+		if err := stream.Send(request); err != nil {
+			return nil, nil, fmt.Errorf("gRPC reflection request failed: %w", err)
+		}
+		response, err := stream.Recv()
+		if err != nil {
+			return nil, nil, fmt.Errorf("gRPC reflection request failed: %w", err)
+		}
+		if errorResponse := response.GetErrorResponse(); errorResponse != nil {
+			return nil, nil, fmt.Errorf("gRPC reflection for %s failed: %s", service, errorResponse.GetErrorMessage())
+		}
+
+		for _, encoded := range response.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fileProto := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(encoded, fileProto); err != nil {
+				return nil, nil, fmt.Errorf("invalid file descriptor from gRPC reflection: %w", err)
+			}
+			fileProtos[fileProto.GetName()] = fileProto
+		}
+
+		request = nil
+		for _, fileProto := range fileProtos {
+			for _, dependency := range fileProto.GetDependency() {
+				if _, ok := fileProtos[dependency]; !ok {
+					request = &reflectionpb.ServerReflectionRequest{
+						MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: dependency},
+					}
+					break
+				}
+			}
+			if request != nil {
+				break
+			}
+		}
+	}
+
+	fileSet := &descriptorpb.FileDescriptorSet{}
+	for _, fileProto := range fileProtos {
+		fileSet.File = append(fileSet.File, fileProto)
+	}
+	files, err := protodesc.NewFiles(fileSet)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid file descriptors from gRPC reflection: %w", err)
+	}
+
+	descriptor, err := files.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, nil, fmt.Errorf("gRPC service %s not found: %w", service, err)
+	}
+	serviceDesc, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, nil, fmt.Errorf("%s is not a gRPC service", service)
+	}
+	methodDesc := serviceDesc.Methods().ByName(protoreflect.Name(method))
+	if methodDesc == nil {
+		return nil, nil, fmt.Errorf("gRPC method %s/%s not found", service, method)
+	}
+	if methodDesc.IsStreamingClient() || methodDesc.IsStreamingServer() {
+		return nil, nil, fmt.Errorf("gRPC method %s/%s is not unary", service, method)
+	}
+
+	return methodDesc, dynamicpb.NewTypes(files), nil
+}
+
+// parseConfig converts the generic config map to GRPCConfig
+func (g *GRPCGenerator) parseConfig(ctx context.Context, config map[string]interface{}) (*GRPCConfig, error) {
+	grpcConfig := &GRPCConfig{}
+
+	if target, ok := config["target"].(string); ok && target != "" {
+		grpcConfig.Target = target
+	} else {
+		return nil, fmt.Errorf("target is required and must be a string")
+	}
+
+	method, _ := config["method"].(string)
+	service, methodName, ok := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	if !ok || service == "" || methodName == "" || strings.Contains(methodName, "/") {
+		return nil, fmt.Errorf("method must be a fully-qualified method name such as package.Service/Method")
+	}
+	grpcConfig.Service = service
+	grpcConfig.Method = methodName
+
+	if request, ok := config["request"].(string); ok && request != "" {
+		grpcConfig.Request = []byte(request)
+	}
+
+	if plaintext, ok := config["plaintext"].(bool); ok {
+		grpcConfig.Plaintext = plaintext
+	}
+
+	// Parse per-source timeout
+	if timeout, ok := config["timeout"].(string); ok && timeout != "" {
+		duration, err := time.ParseDuration(timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout %q: %w", timeout, err)
+		}
+		if duration <= 0 {
+			return nil, fmt.Errorf("invalid timeout %q: must be positive", timeout)
+		}
+		grpcConfig.Timeout = duration
+	}
+
+	// Parse namespace for secret references
+	namespace, _ := config["namespace"].(string)
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	if caBundleSecretName, ok := config["caBundleSecretName"].(string); ok && caBundleSecretName != "" {
+		caBundleKey, _ := config["caBundleSecretKey"].(string)
+		if caBundleKey == "" {
+			caBundleKey = "ca.crt"
+		}
+		secret, err := g.loadSecret(ctx, namespace, caBundleSecretName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load CA bundle from secret: %w", err)
+		}
+		caBundle, exists := secret.Data[caBundleKey]
+		if !exists {
+			return nil, fmt.Errorf("key %s not found in secret %s/%s", caBundleKey, namespace, caBundleSecretName)
+		}
+		grpcConfig.CABundle = caBundle
+	}
+
+	if clientCertSecretName, ok := config["clientCertSecretName"].(string); ok && clientCertSecretName != "" {
+		secret, err := g.loadSecret(ctx, namespace, clientCertSecretName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate from secret: %w", err)
+		}
+		certificate, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate in secret %s/%s: %w", namespace, clientCertSecretName, err)
+		}
+		grpcConfig.ClientCertificate = &certificate
+	}
+
+	if metadataSecretName, ok := config["metadataSecretName"].(string); ok && metadataSecretName != "" {
+		secret, err := g.loadSecret(ctx, namespace, metadataSecretName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load metadata from secret: %w", err)
+		}
+		grpcConfig.Metadata = make(map[string]string, len(secret.Data))
+		for key, value := range secret.Data {
+			grpcConfig.Metadata[strings.ToLower(key)] = string(value)
+		}
+	}
+
+	if grpcConfig.Plaintext && (grpcConfig.CABundle != nil || grpcConfig.ClientCertificate != nil) {
+		return nil, fmt.Errorf("caBundleSecretRef and clientCertSecretRef cannot be used with plaintext")
+	}
+
+	return grpcConfig, nil
+}
+
+// loadSecret fetches a secret referenced by the source
+func (g *GRPCGenerator) loadSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	secretKey := client.ObjectKey{
+		Namespace: namespace,
+		Name:      name,
+	}
+
+	if err := g.client.Get(ctx, secretKey, secret); err != nil {
+		return nil, fmt.Errorf("failed to get secret %s/%s: %w", namespace, name, err)
+	}
+
+	return secret, nil
+}
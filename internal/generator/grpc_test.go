@@ -0,0 +1,282 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generator
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// startGRPCTestServer serves the health service with reflection and returns its address
+// and the metadata of the last call
+func startGRPCTestServer(t *testing.T, opts ...grpc.ServerOption) (string, *metadata.MD) {
+	t.Helper()
+
+	var received metadata.MD
+	opts = append(opts, grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		received, _ = metadata.FromIncomingContext(ctx)
+		return handler(ctx, req)
+	}))
+	server := grpc.NewServer(opts...)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String(), &received
+}
+
+// newFakeClient returns a fake client holding the given objects
+func newFakeClient(t *testing.T, objects ...client.Object) client.Client {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func TestGRPCGenerator_Generate(t *testing.T) {
+	target, received := startGRPCTestServer(t)
+
+	generator := NewGRPCGenerator(newFakeClient(t, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "grpc-metadata", Namespace: "default"},
+		Data:       map[string][]byte{"Authorization": []byte("Bearer token")},
+	}))
+	data, err := generator.Generate(context.Background(), GeneratorConfig{
+		Type: "grpc",
+		Config: map[string]interface{}{
+			"target":             target,
+			"method":             "grpc.health.v1.Health/Check",
+			"request":            `{"service": ""}`,
+			"plaintext":          true,
+			"metadataSecretName": "grpc-metadata",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if string(data.Data) != `{"status":"SERVING"}` {
+		t.Errorf("Expected JSON response, got %s", string(data.Data))
+	}
+	if data.Metadata["method"] != "/grpc.health.v1.Health/Check" {
+		t.Errorf("Expected method metadata, got %q", data.Metadata["method"])
+	}
+	if got := received.Get("authorization"); len(got) != 1 || got[0] != "Bearer token" {
+		t.Errorf("Expected authorization metadata from the secret, got %v", got)
+	}
+}
+
+func TestGRPCGenerator_Generate_Errors(t *testing.T) {
+	target, _ := startGRPCTestServer(t)
+
+	tests := []struct {
+		name        string
+		method      string
+		request     string
+		expectCode  codes.Code
+		expectError string
+	}{
+		{
+			name:       "status code is preserved",
+			method:     "grpc.health.v1.Health/Check",
+			request:    `{"service": "unknown"}`,
+			expectCode: codes.NotFound,
+		},
+		{
+			name:        "unknown method",
+			method:      "grpc.health.v1.Health/Missing",
+			expectError: "gRPC method grpc.health.v1.Health/Missing not found",
+		},
+		{
+			name:        "unknown service",
+			method:      "example.Missing/Get",
+			expectError: "gRPC reflection for example.Missing failed",
+		},
+		{
+			name:        "streaming method",
+			method:      "grpc.health.v1.Health/Watch",
+			expectError: "is not unary",
+		},
+		{
+			name:        "invalid request",
+			method:      "grpc.health.v1.Health/Check",
+			request:     `{"unknown": true}`,
+			expectError: "invalid gRPC request for grpc.health.v1.HealthCheckRequest",
+		},
+	}
+
+	generator := NewGRPCGenerator(newFakeClient(t))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := generator.Generate(context.Background(), GeneratorConfig{
+				Type: "grpc",
+				Config: map[string]interface{}{
+					"target":    target,
+					"method":    tt.method,
+					"request":   tt.request,
+					"plaintext": true,
+				},
+			})
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			if tt.expectError != "" && !strings.Contains(err.Error(), tt.expectError) {
+				t.Errorf("Expected error containing %q, got %v", tt.expectError, err)
+			}
+			if tt.expectCode != codes.OK {
+				if code := status.Code(err); code != tt.expectCode {
+					t.Errorf("Expected code %v, got %v", tt.expectCode, code)
+				}
+			}
+		})
+	}
+}
+
+func TestGRPCGenerator_Generate_ClientCertificate(t *testing.T) {
+	clientCert, clientKey := newClientCertificate(t, "externalsource-client")
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(clientCert)
+
+	// Reuse the httptest certificate, it is valid for 127.0.0.1
+	tlsServer := httptest.NewUnstartedServer(nil)
+	tlsServer.StartTLS()
+	serverCertificate := tlsServer.TLS.Certificates[0]
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tlsServer.Certificate().Raw})
+	tlsServer.Close()
+
+	target, _ := startGRPCTestServer(t, grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{serverCertificate},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	})))
+
+	generator := NewGRPCGenerator(newFakeClient(t,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "server-ca", Namespace: "default"},
+			Data:       map[string][]byte{"ca.crt": serverCA},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "client-cert", Namespace: "default"},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{"tls.crt": clientCert, "tls.key": clientKey},
+		},
+	))
+
+	config := map[string]interface{}{
+		"target":               target,
+		"method":               "grpc.health.v1.Health/Check",
+		"caBundleSecretName":   "server-ca",
+		"clientCertSecretName": "client-cert",
+	}
+	data, err := generator.Generate(context.Background(), GeneratorConfig{Type: "grpc", Config: config})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if string(data.Data) != `{"status":"SERVING"}` {
+		t.Errorf("Expected JSON response, got %s", string(data.Data))
+	}
+
+	// Without the client certificate the handshake is rejected
+	delete(config, "clientCertSecretName")
+	if _, err := generator.Generate(context.Background(), GeneratorConfig{Type: "grpc", Config: config}); err == nil {
+		t.Error("Expected error without a client certificate, got nil")
+	}
+}
+
+func TestGRPCGenerator_ParseConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      map[string]interface{}
+		expectError string
+	}{
+		{
+			name:        "missing target",
+			config:      map[string]interface{}{"method": "example.Service/Get"},
+			expectError: "target is required",
+		},
+		{
+			name:        "method without service",
+			config:      map[string]interface{}{"target": "localhost:50051", "method": "Get"},
+			expectError: "method must be a fully-qualified method name",
+		},
+		{
+			name:        "invalid timeout",
+			config:      map[string]interface{}{"target": "localhost:50051", "method": "example.Service/Get", "timeout": "soon"},
+			expectError: "invalid timeout",
+		},
+		{
+			name:   "leading slash",
+			config: map[string]interface{}{"target": "localhost:50051", "method": "/example.Service/Get"},
+		},
+	}
+
+	generator := NewGRPCGenerator(newFakeClient(t))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grpcConfig, err := generator.parseConfig(context.Background(), tt.config)
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Errorf("Expected error containing %q, got %v", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if grpcConfig.Service != "example.Service" || grpcConfig.Method != "Get" {
+				t.Errorf("Expected example.Service/Get, got %s/%s", grpcConfig.Service, grpcConfig.Method)
+			}
+		})
+	}
+}
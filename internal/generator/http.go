@@ -23,16 +23,27 @@ SOFTWARE.
 package generator
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"crypto/sha512"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/jsonpath"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 // HTTPGenerator implements SourceGenerator for HTTP sources
@@ -40,17 +51,81 @@ type HTTPGenerator struct {
 	client     client.Client
 	httpClient *http.Client
 	userAgent  string
+
+	// defaultHeaders are sent with every request, headers from the source configuration take precedence
+	defaultHeaders map[string]string
+
+	// maxResponseSize is the default response body limit in bytes, 0 means unlimited
+	maxResponseSize int64
+
+	// circuitBreaker short-circuits requests to failing hosts, nil disables it
+	circuitBreaker *CircuitBreaker
+
+	// tokenCache holds OAuth2 tokens shared by the sources using the same client
+	tokenCache *OAuth2TokenCache
+
+	// connectTimeout, tlsHandshakeTimeout and responseHeaderTimeout bound the phases of a
+	// request within the overall timeout, 0 leaves a phase bounded only by it
+	connectTimeout        time.Duration
+	tlsHandshakeTimeout   time.Duration
+	responseHeaderTimeout time.Duration
 }
 
 // HTTPConfig holds HTTP-specific configuration
 type HTTPConfig struct {
 	URL                string            `json:"url"`
+	VersionURL         string            `json:"versionURL"`
 	Method             string            `json:"method"`
 	Headers            map[string]string `json:"headers"`
 	CABundle           []byte            `json:"caBundle"`
 	InsecureSkipVerify bool              `json:"insecureSkipVerify"`
+	ClientCertificate  *tls.Certificate  `json:"-"`
+	Body               []byte            `json:"body"`
+	QueryParams        map[string]string `json:"queryParams"`
+	Timeout            time.Duration     `json:"timeout"`
+
+	// MaxRedirects is the maximum number of redirects to follow, 0 disables redirects
+	MaxRedirects int `json:"maxRedirects"`
+
+	// KeepAuthorizationOnRedirect forwards the Authorization header to other hosts on redirect
+	KeepAuthorizationOnRedirect bool `json:"keepAuthorizationOnRedirect"`
+
+	// MaxResponseSize overrides the generator's response body limit in bytes when set
+	MaxResponseSize int64 `json:"maxResponseSize"`
+
+	// DigestHeader is the response header holding a digest of the body, the default
+	// digest headers are checked when empty
+	DigestHeader string `json:"digestHeader"`
+
+	// Retries is how many times a GET or HEAD request failing with a connection error or
+	// a 5xx response is retried within a fetch, 0 disables retries
+	Retries int `json:"retries"`
+
+	// RetryBackoff is the delay before the first retry, doubled on each further retry
+	RetryBackoff time.Duration `json:"retryBackoff"`
+
+	// RangeOffset and RangeLength request part of the resource with a Range header, a
+	// length of 0 fetches the rest of the resource after the offset
+	RangeOffset int64 `json:"rangeOffset"`
+	RangeLength int64 `json:"rangeLength"`
+
+	// RangeSuffix requests the last bytes of the resource, taking precedence over the offset
+	RangeSuffix int64 `json:"rangeSuffix"`
+
+	// CursorPath selects the cursor sent with the next request in the response body, nil
+	// when no cursor is configured
+	CursorPath *jsonpath.JSONPath `json:"-"`
+
+	// secretHeaders holds the lowercased names of headers whose values come from secrets
+	secretHeaders map[string]bool
+
+	// signer adds headers, such as request signatures, to every outgoing request
+	signer RequestSigner
 }
 
+// defaultMaxRedirects matches the net/http client default
+const defaultMaxRedirects = 10
+
 // HTTPClientConfig holds HTTP client configuration
 type HTTPClientConfig struct {
 	Timeout             time.Duration
@@ -59,6 +134,21 @@ type HTTPClientConfig struct {
 	MaxConnsPerHost     int
 	IdleConnTimeout     time.Duration
 	UserAgent           string
+	MaxResponseSize     int64
+	DefaultHeaders      map[string]string
+
+	// ConnectTimeout bounds establishing the connection, TLSHandshakeTimeout the TLS handshake
+	// and ResponseHeaderTimeout the wait for the response headers once the request is sent.
+	// Timeout still caps the whole request including the body, 0 disables a phase timeout.
+	ConnectTimeout        time.Duration
+	TLSHandshakeTimeout   time.Duration
+	ResponseHeaderTimeout time.Duration
+
+	// CircuitBreaker is shared by all requests of the generator, nil disables it
+	CircuitBreaker *CircuitBreaker
+
+	// OAuth2TokenCache caches OAuth2 tokens, a new cache is created when nil
+	OAuth2TokenCache *OAuth2TokenCache
 }
 
 // NewHTTPGenerator creates a new HTTP generator with default configuration
@@ -68,17 +158,21 @@ func NewHTTPGenerator(k8sClient client.Client) *HTTPGenerator {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		userAgent: "externalsource-controller/1.0",
+		userAgent:  "externalsource-controller/1.0",
+		tokenCache: NewOAuth2TokenCache(30 * time.Second),
 	}
 }
 
 // NewHTTPGeneratorWithConfig creates a new HTTP generator with custom configuration
 func NewHTTPGeneratorWithConfig(k8sClient client.Client, config *HTTPClientConfig) *HTTPGenerator {
 	transport := &http.Transport{
-		MaxIdleConns:        config.MaxIdleConns,
-		MaxIdleConnsPerHost: config.MaxIdleConnsPerHost,
-		MaxConnsPerHost:     config.MaxConnsPerHost,
-		IdleConnTimeout:     config.IdleConnTimeout,
+		MaxIdleConns:          config.MaxIdleConns,
+		MaxIdleConnsPerHost:   config.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       config.MaxConnsPerHost,
+		IdleConnTimeout:       config.IdleConnTimeout,
+		DialContext:           (&net.Dialer{Timeout: config.ConnectTimeout}).DialContext,
+		TLSHandshakeTimeout:   config.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: config.ResponseHeaderTimeout,
 	}
 
 	httpClient := &http.Client{
@@ -86,19 +180,35 @@ func NewHTTPGeneratorWithConfig(k8sClient client.Client, config *HTTPClientConfi
 		Transport: transport,
 	}
 
+	tokenCache := config.OAuth2TokenCache
+	if tokenCache == nil {
+		tokenCache = NewOAuth2TokenCache(config.Timeout)
+	}
+
 	return &HTTPGenerator{
-		client:     k8sClient,
-		httpClient: httpClient,
-		userAgent:  config.UserAgent,
+		client:          k8sClient,
+		httpClient:      httpClient,
+		userAgent:       config.UserAgent,
+		defaultHeaders:  config.DefaultHeaders,
+		maxResponseSize: config.MaxResponseSize,
+		circuitBreaker:  config.CircuitBreaker,
+		tokenCache:      tokenCache,
+
+		connectTimeout:        config.ConnectTimeout,
+		tlsHandshakeTimeout:   config.TLSHandshakeTimeout,
+		responseHeaderTimeout: config.ResponseHeaderTimeout,
 	}
 }
 
 // Generate fetches data from the HTTP endpoint
 func (h *HTTPGenerator) Generate(ctx context.Context, config GeneratorConfig) (*SourceData, error) {
+	log := logf.FromContext(ctx).V(1)
+
 	httpConfig, err := h.parseConfig(ctx, config.Config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse HTTP config: %w", err)
 	}
+	httpConfig.signer = config.RequestSigner
 
 	// Configure HTTP client with TLS settings
 	httpClient, err := h.configureHTTPClient(ctx, httpConfig)
@@ -106,26 +216,97 @@ func (h *HTTPGenerator) Generate(ctx context.Context, config GeneratorConfig) (*
 		return nil, fmt.Errorf("failed to configure HTTP client: %w", err)
 	}
 
+	requestURL, err := httpConfig.requestURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// With a version endpoint the recorded version is compared against it instead of
+	// sending a conditional request for the content
+	lastModified := config.LastModified
+	version := ""
+	if httpConfig.VersionURL != "" {
+		version, err = h.fetchVersion(ctx, httpClient, httpConfig)
+		if err != nil {
+			return nil, err
+		}
+		if version == config.LastModified {
+			log.Info("Version unchanged, skipping fetch", "version", version)
+			return &SourceData{
+				LastModified: config.LastModified,
+				NotModified:  true,
+				Metadata: map[string]string{
+					"version": version,
+				},
+			}, nil
+		}
+		lastModified = ""
+	}
+
+	var body io.Reader
+	if len(httpConfig.Body) > 0 {
+		body = bytes.NewReader(httpConfig.Body)
+	}
+
 	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, httpConfig.Method, httpConfig.URL, nil)
+	req, err := http.NewRequestWithContext(ctx, httpConfig.Method, requestURL, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 
+	// Add controller-wide default headers first so that everything else overrides them
+	for key, value := range h.defaultHeaders {
+		req.Header.Set(key, value)
+	}
+
 	// Add User-Agent header
 	if h.userAgent != "" {
 		req.Header.Set("User-Agent", h.userAgent)
 	}
 
+	// Default the content type for request bodies, headers from the secret take precedence
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
 	// Add headers
 	for key, value := range httpConfig.Headers {
 		req.Header.Set(key, value)
 	}
 
+	// Make the request conditional on the previously fetched version
+	if lastModified != "" {
+		if _, err := http.ParseTime(lastModified); err == nil {
+			req.Header.Set("If-Modified-Since", lastModified)
+			log.Info("Sending conditional request", "header", "If-Modified-Since", "value", lastModified)
+		} else {
+			req.Header.Set("If-None-Match", lastModified)
+			log.Info("Sending conditional request", "header", "If-None-Match", "value", lastModified)
+		}
+	} else if version == "" {
+		log.Info("Sending unconditional request, no previous version recorded")
+	}
+
+	// Request only part of the resource when a range is configured
+	rangeHeader := httpConfig.rangeHeader()
+	if rangeHeader != "" {
+		req.Header.Set("Range", rangeHeader)
+	}
+
+	// Sign the request last so the signature covers every header that is sent
+	if err := httpConfig.signRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
 	// Execute request
+	log.Info("Sending HTTP request", "method", req.Method, "url", req.URL.Redacted(),
+		"headers", httpConfig.redactHeaders(req.Header))
+	startTime := time.Now()
 	resp, err := httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("HTTP request failed: %w", err)
+		log.Info("HTTP request failed", "method", req.Method, "url", req.URL.Redacted(),
+			"duration", time.Since(startTime), "error", err.Error())
+		return nil, fmt.Errorf("HTTP request failed: %w", sourceRequestError(err))
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil { //nolint:staticcheck // SA9003: Intentionally empty - we don't want to fail HTTP operations due to close errors
@@ -133,28 +314,298 @@ func (h *HTTPGenerator) Generate(ctx context.Context, config GeneratorConfig) (*
 		}
 	}()
 
+	if resp.StatusCode == http.StatusNotModified && lastModified != "" {
+		log.Info("Received HTTP response, source not modified", "method", req.Method, "url", req.URL.Redacted(),
+			"status", resp.StatusCode, "duration", time.Since(startTime))
+		return &SourceData{
+			LastModified: config.LastModified,
+			NotModified:  true,
+			FreshUntil:   responseFreshUntil(resp.Header, time.Now()),
+			Metadata: map[string]string{
+				"etag":          resp.Header.Get("ETag"),
+				"last-modified": resp.Header.Get("Last-Modified"),
+			},
+		}, nil
+	}
+
+	// A range starting at the end of the resource has no new data. Any other unsatisfiable
+	// range, e.g. after the resource was truncated, falls back to fetching it in full.
+	if resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && rangeHeader != "" {
+		size, ok := unsatisfiedRangeSize(resp.Header.Get("Content-Range"))
+		if ok && httpConfig.RangeOffset > 0 && size == httpConfig.RangeOffset {
+			log.Info("Received HTTP response, no data after range offset", "method", req.Method, "url", req.URL.Redacted(),
+				"status", resp.StatusCode, "offset", httpConfig.RangeOffset, "duration", time.Since(startTime))
+			return &SourceData{
+				LastModified: config.LastModified,
+				NotModified:  true,
+				Metadata: map[string]string{
+					"content-range": resp.Header.Get("Content-Range"),
+				},
+			}, nil
+		}
+
+		log.Info("Requested range not satisfiable, fetching full content", "method", req.Method, "url", req.URL.Redacted(),
+			"range", rangeHeader, "contentRange", resp.Header.Get("Content-Range"))
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxRetryDrain))
+		_ = resp.Body.Close()
+
+		// The deferred close releases the body of the full response instead. The full content
+		// replaces the appended data, so the request is not conditional on the last version.
+		fullReq := req.Clone(ctx)
+		fullReq.Header.Del("Range")
+		fullReq.Header.Del("If-None-Match")
+		fullReq.Header.Del("If-Modified-Since")
+		if err := httpConfig.signRequest(ctx, fullReq); err != nil {
+			return nil, err
+		}
+		rangeHeader = ""
+		startTime = time.Now()
+		resp, err = httpClient.Do(fullReq)
+		if err != nil {
+			log.Info("HTTP request failed", "method", req.Method, "url", req.URL.Redacted(),
+				"duration", time.Since(startTime), "error", err.Error())
+			return nil, fmt.Errorf("HTTP request failed: %w", sourceRequestError(err))
+		}
+	}
+
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		log.Info("Received HTTP error response", "method", req.Method, "url", req.URL.Redacted(),
+			"status", resp.StatusCode, "duration", time.Since(startTime))
 		return nil, fmt.Errorf("HTTP request failed with status %d: %s", resp.StatusCode, resp.Status)
 	}
 
-	// Read response body
-	data, err := io.ReadAll(resp.Body)
+	// Servers that don't support ranges answer with the full content
+	var contentRange *ContentRange
+	if rangeHeader != "" {
+		if resp.StatusCode == http.StatusPartialContent {
+			if contentRange, err = parseContentRange(resp.Header.Get("Content-Range")); err != nil {
+				return nil, err
+			}
+		} else {
+			log.Info("Server ignored range request, fetched full content", "range", rangeHeader, "status", resp.StatusCode)
+		}
+	}
+
+	// Read response body, bounded so an oversized response can't exhaust memory
+	maxResponseSize := h.maxResponseSize
+	if httpConfig.MaxResponseSize > 0 {
+		maxResponseSize = httpConfig.MaxResponseSize
+	}
+	data, err := readLimited(resp, maxResponseSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// Catch truncated or corrupted transfers before the content is packaged
+	if err := httpConfig.verifyDigest(resp, data); err != nil {
+		log.Info("Received HTTP response with mismatched digest", "method", req.Method, "url", req.URL.Redacted(),
+			"status", resp.StatusCode, "size", len(data), "duration", time.Since(startTime))
+		return nil, err
+	}
+
+	cursor, err := httpConfig.extractCursor(data)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
 
-	// Extract ETag for conditional fetching
-	etag := resp.Header.Get("ETag")
+	log.Info("Received HTTP response", "method", req.Method, "url", req.URL.Redacted(),
+		"status", resp.StatusCode, "size", len(data), "duration", time.Since(startTime),
+		"version", responseVersion(resp.Header), "contentType", resp.Header.Get("Content-Type"))
 
-	return &SourceData{
+	sourceData := &SourceData{
 		Data:         data,
-		LastModified: etag,
+		LastModified: responseVersion(resp.Header),
+		FreshUntil:   responseFreshUntil(resp.Header, time.Now()),
+		Range:        contentRange,
+		Cursor:       cursor,
 		Metadata: map[string]string{
 			"content-type":   resp.Header.Get("Content-Type"),
 			"content-length": resp.Header.Get("Content-Length"),
-			"etag":           etag,
+			"etag":           resp.Header.Get("ETag"),
+			"last-modified":  resp.Header.Get("Last-Modified"),
 		},
-	}, nil
+	}
+
+	// Record the version endpoint's value so the next poll compares against it
+	if version != "" {
+		sourceData.LastModified = version
+		sourceData.Metadata["version"] = version
+	}
+
+	return sourceData, nil
+}
+
+// readLimited reads the response body, failing once it exceeds maxSize bytes.
+// A maxSize of 0 means unlimited.
+func readLimited(resp *http.Response, maxSize int64) ([]byte, error) {
+	if maxSize <= 0 {
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return data, nil
+	}
+
+	if resp.ContentLength > maxSize {
+		return nil, fmt.Errorf("response body of %d bytes exceeds maximum size of %d bytes", resp.ContentLength, maxSize)
+	}
+
+	// Read one byte past the limit to tell an exact fit from an oversized body
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("response body exceeds maximum size of %d bytes", maxSize)
+	}
+
+	return data, nil
+}
+
+// defaultDigestHeaders are checked for a body digest when no digest header is configured
+var defaultDigestHeaders = []string{"Content-Digest", "Digest"}
+
+// DigestMismatchError is returned when a response body doesn't match the digest in its
+// digest header, usually because the response was truncated
+type DigestMismatchError struct {
+	Header    string
+	Algorithm string
+	Expected  string
+	Actual    string
+}
+
+// Error implements the error interface
+func (e *DigestMismatchError) Error() string {
+	return fmt.Sprintf("content digest mismatch in %s header: expected %s %s, got %s, the response may have been truncated",
+		e.Header, e.Algorithm, e.Expected, e.Actual)
+}
+
+// IsDigestMismatchError reports whether err was caused by a body that didn't match its digest.
+// A partial transfer is expected to succeed when retried, whatever the digests contain.
+func IsDigestMismatchError(err error) bool {
+	var digestErr *DigestMismatchError
+	return errors.As(err, &digestErr)
+}
+
+// SourceHostNotFoundError is returned when the host of a source URL doesn't exist, which
+// usually means the URL is mistyped. Lookups failing for other reasons are not wrapped.
+type SourceHostNotFoundError struct {
+	Host string
+	Err  error
+}
+
+// Error implements the error interface
+func (e *SourceHostNotFoundError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *SourceHostNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// IsSourceHostNotFoundError reports whether err was caused by a source host that doesn't
+// exist. DNS failures of other endpoints, such as storage or webhooks, are not reported.
+func IsSourceHostNotFoundError(err error) bool {
+	var hostErr *SourceHostNotFoundError
+	return errors.As(err, &hostErr)
+}
+
+// sourceRequestError wraps the error of a request to the source in SourceHostNotFoundError
+// when the resolver reported that its host doesn't exist
+func sourceRequestError(err error) error {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound && !dnsErr.IsTemporary {
+		return &SourceHostNotFoundError{Host: dnsErr.Name, Err: err}
+	}
+	return err
+}
+
+// verifyDigest compares the body against the digest in the response's digest header.
+// Responses without a digest in a supported algorithm are accepted, as are bodies that
+// were transparently decompressed since the digest covers the encoded content.
+func (c *HTTPConfig) verifyDigest(resp *http.Response, data []byte) error {
+	if resp.Uncompressed {
+		return nil
+	}
+
+	headers := defaultDigestHeaders
+	if c.DigestHeader != "" {
+		headers = []string{c.DigestHeader}
+	} else if resp.StatusCode == http.StatusPartialContent {
+		// Digest describes the whole representation, Content-Digest the returned part
+		headers = []string{"Content-Digest"}
+	}
+
+	for _, header := range headers {
+		value := resp.Header.Get(header)
+		if value == "" {
+			continue
+		}
+
+		algorithm, expected, ok := parseDigest(value)
+		if !ok {
+			continue
+		}
+
+		var actual []byte
+		switch algorithm {
+		case "sha-256":
+			sum := sha256.Sum256(data)
+			actual = sum[:]
+		case "sha-512":
+			sum := sha512.Sum512(data)
+			actual = sum[:]
+		}
+		if !bytes.Equal(actual, expected) {
+			return &DigestMismatchError{
+				Header:    header,
+				Algorithm: algorithm,
+				Expected:  hex.EncodeToString(expected),
+				Actual:    hex.EncodeToString(actual),
+			}
+		}
+		return nil
+	}
+
+	return nil
+}
+
+// parseDigest returns the first sha-256 or sha-512 digest in a header value. Values are
+// comma separated algorithm=<base64> pairs, with the base64 optionally wrapped in colons,
+// or a plain hex digest whose algorithm is inferred from its length.
+func parseDigest(value string) (string, []byte, bool) {
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+
+		algorithm, encoded, found := strings.Cut(entry, "=")
+		if !found {
+			digest, err := hex.DecodeString(entry)
+			if err != nil {
+				continue
+			}
+			switch len(digest) {
+			case sha256.Size:
+				return "sha-256", digest, true
+			case sha512.Size:
+				return "sha-512", digest, true
+			}
+			continue
+		}
+
+		algorithm = strings.ToLower(strings.TrimSpace(algorithm))
+		if algorithm != "sha-256" && algorithm != "sha-512" {
+			continue
+		}
+		digest, err := base64.StdEncoding.DecodeString(strings.Trim(strings.TrimSpace(encoded), ":"))
+		if err != nil || (algorithm == "sha-256" && len(digest) != sha256.Size) ||
+			(algorithm == "sha-512" && len(digest) != sha512.Size) {
+			continue
+		}
+		return algorithm, digest, true
+	}
+
+	return "", nil, false
 }
 
 // SupportsConditionalFetch returns true as HTTP supports ETag-based conditional fetching
@@ -162,12 +613,21 @@ func (h *HTTPGenerator) SupportsConditionalFetch() bool {
 	return true
 }
 
-// GetLastModified performs a HEAD request to get the current ETag
+// SupportsConditionalRequest returns true as Generate sends If-None-Match or If-Modified-Since
+// and reports 304 Not Modified responses
+func (h *HTTPGenerator) SupportsConditionalRequest() bool {
+	return true
+}
+
+// GetLastModified performs a HEAD request to get the current ETag or Last-Modified value
 func (h *HTTPGenerator) GetLastModified(ctx context.Context, config GeneratorConfig) (string, error) {
+	log := logf.FromContext(ctx).V(1)
+
 	httpConfig, err := h.parseConfig(ctx, config.Config)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse HTTP config: %w", err)
 	}
+	httpConfig.signer = config.RequestSigner
 
 	// Configure HTTP client with TLS settings
 	httpClient, err := h.configureHTTPClient(ctx, httpConfig)
@@ -175,12 +635,27 @@ func (h *HTTPGenerator) GetLastModified(ctx context.Context, config GeneratorCon
 		return "", fmt.Errorf("failed to configure HTTP client: %w", err)
 	}
 
+	// Poll the version endpoint instead of sending a HEAD request for the content
+	if httpConfig.VersionURL != "" {
+		return h.fetchVersion(ctx, httpClient, httpConfig)
+	}
+
+	requestURL, err := httpConfig.requestURL()
+	if err != nil {
+		return "", err
+	}
+
 	// Create HEAD request
-	req, err := http.NewRequestWithContext(ctx, "HEAD", httpConfig.URL, nil)
+	req, err := http.NewRequestWithContext(ctx, "HEAD", requestURL, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create HEAD request: %w", err)
 	}
 
+	// Add controller-wide default headers first so that everything else overrides them
+	for key, value := range h.defaultHeaders {
+		req.Header.Set(key, value)
+	}
+
 	// Add User-Agent header
 	if h.userAgent != "" {
 		req.Header.Set("User-Agent", h.userAgent)
@@ -191,10 +666,20 @@ func (h *HTTPGenerator) GetLastModified(ctx context.Context, config GeneratorCon
 		req.Header.Set(key, value)
 	}
 
+	// Sign the request last so the signature covers every header that is sent
+	if err := httpConfig.signRequest(ctx, req); err != nil {
+		return "", err
+	}
+
 	// Execute request
+	log.Info("Sending HTTP request", "method", req.Method, "url", req.URL.Redacted(),
+		"headers", httpConfig.redactHeaders(req.Header))
+	startTime := time.Now()
 	resp, err := httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("HEAD request failed: %w", err)
+		log.Info("HTTP request failed", "method", req.Method, "url", req.URL.Redacted(),
+			"duration", time.Since(startTime), "error", err.Error())
+		return "", fmt.Errorf("HEAD request failed: %w", sourceRequestError(err))
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil { //nolint:staticcheck // SA9003: Intentionally empty - we don't want to fail HTTP operations due to close errors
@@ -202,18 +687,111 @@ func (h *HTTPGenerator) GetLastModified(ctx context.Context, config GeneratorCon
 		}
 	}()
 
+	log.Info("Received HTTP response", "method", req.Method, "url", req.URL.Redacted(),
+		"status", resp.StatusCode, "duration", time.Since(startTime), "version", responseVersion(resp.Header))
+
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		return "", fmt.Errorf("HEAD request failed with status %d: %s", resp.StatusCode, resp.Status)
 	}
 
-	return resp.Header.Get("ETag"), nil
+	return responseVersion(resp.Header), nil
+}
+
+// fetchVersion sends a GET request to the version URL and returns the version it reports.
+// The response body identifies the version, the ETag or Last-Modified header is used when
+// the body is empty.
+func (h *HTTPGenerator) fetchVersion(ctx context.Context, httpClient *http.Client, httpConfig *HTTPConfig) (string, error) {
+	log := logf.FromContext(ctx).V(1)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", httpConfig.VersionURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create version request: %w", err)
+	}
+
+	// Add controller-wide default headers first so that everything else overrides them
+	for key, value := range h.defaultHeaders {
+		req.Header.Set(key, value)
+	}
+
+	// Add User-Agent header
+	if h.userAgent != "" {
+		req.Header.Set("User-Agent", h.userAgent)
+	}
+
+	// Add headers
+	for key, value := range httpConfig.Headers {
+		req.Header.Set(key, value)
+	}
+
+	// Sign the request last so the signature covers every header that is sent
+	if err := httpConfig.signRequest(ctx, req); err != nil {
+		return "", err
+	}
+
+	// Execute request
+	log.Info("Sending HTTP request", "method", req.Method, "url", req.URL.Redacted(),
+		"headers", httpConfig.redactHeaders(req.Header))
+	startTime := time.Now()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		log.Info("HTTP request failed", "method", req.Method, "url", req.URL.Redacted(),
+			"duration", time.Since(startTime), "error", err.Error())
+		return "", fmt.Errorf("version request failed: %w", sourceRequestError(err))
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil { //nolint:staticcheck // SA9003: Intentionally empty - we don't want to fail HTTP operations due to close errors
+			// Log error but don't fail the operation
+		}
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		log.Info("Received HTTP error response", "method", req.Method, "url", req.URL.Redacted(),
+			"status", resp.StatusCode, "duration", time.Since(startTime))
+		return "", fmt.Errorf("version request failed with status %d: %s", resp.StatusCode, resp.Status)
+	}
+
+	maxResponseSize := h.maxResponseSize
+	if httpConfig.MaxResponseSize > 0 {
+		maxResponseSize = httpConfig.MaxResponseSize
+	}
+	data, err := readLimited(resp, maxResponseSize)
+	if err != nil {
+		return "", err
+	}
+
+	version := responseVersion(resp.Header)
+	if content := bytes.TrimSpace(data); len(content) > 0 {
+		sum := sha256.Sum256(content)
+		version = "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	log.Info("Received HTTP response", "method", req.Method, "url", req.URL.Redacted(),
+		"status", resp.StatusCode, "duration", time.Since(startTime), "version", version)
+
+	if version == "" {
+		return "", fmt.Errorf("version endpoint returned an empty body and no ETag or Last-Modified header")
+	}
+
+	return version, nil
+}
+
+// responseVersion returns the identifier used for conditional fetching. The ETag is
+// preferred, falling back to Last-Modified for servers that do not send one.
+func responseVersion(header http.Header) string {
+	if etag := header.Get("ETag"); etag != "" {
+		return etag
+	}
+	return header.Get("Last-Modified")
 }
 
 // parseConfig converts the generic config map to HTTPConfig
 func (h *HTTPGenerator) parseConfig(ctx context.Context, config map[string]interface{}) (*HTTPConfig, error) {
 	httpConfig := &HTTPConfig{
-		Method:  "GET",
-		Headers: make(map[string]string),
+		Method:       "GET",
+		Headers:      make(map[string]string),
+		MaxRedirects: defaultMaxRedirects,
+		Retries:      defaultRetries,
+		RetryBackoff: defaultRetryBackoff,
 	}
 
 	// Parse URL
@@ -223,6 +801,11 @@ func (h *HTTPGenerator) parseConfig(ctx context.Context, config map[string]inter
 		return nil, fmt.Errorf("url is required and must be a string")
 	}
 
+	// Parse version URL
+	if versionURL, ok := config["versionURL"].(string); ok {
+		httpConfig.VersionURL = versionURL
+	}
+
 	// Parse method
 	if method, ok := config["method"].(string); ok && method != "" {
 		httpConfig.Method = method
@@ -233,25 +816,202 @@ func (h *HTTPGenerator) parseConfig(ctx context.Context, config map[string]inter
 		httpConfig.InsecureSkipVerify = insecure
 	}
 
+	// Parse per-source timeout
+	if timeout, ok := config["timeout"].(string); ok && timeout != "" {
+		duration, err := time.ParseDuration(timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout %q: %w", timeout, err)
+		}
+		if duration <= 0 {
+			return nil, fmt.Errorf("timeout must be positive, got %s", timeout)
+		}
+		httpConfig.Timeout = duration
+	}
+
+	// Parse redirect policy
+	switch maxRedirects := config["maxRedirects"].(type) {
+	case nil:
+	case int:
+		httpConfig.MaxRedirects = maxRedirects
+	case float64:
+		httpConfig.MaxRedirects = int(maxRedirects)
+	default:
+		return nil, fmt.Errorf("maxRedirects must be a number")
+	}
+	if httpConfig.MaxRedirects < 0 {
+		return nil, fmt.Errorf("maxRedirects must be non-negative, got %d", httpConfig.MaxRedirects)
+	}
+	if keep, ok := config["keepAuthorizationOnRedirect"].(bool); ok {
+		httpConfig.KeepAuthorizationOnRedirect = keep
+	}
+
+	// Parse per-source response size limit
+	switch maxResponseSize := config["maxResponseSize"].(type) {
+	case nil:
+	case int64:
+		httpConfig.MaxResponseSize = maxResponseSize
+	case int:
+		httpConfig.MaxResponseSize = int64(maxResponseSize)
+	case float64:
+		httpConfig.MaxResponseSize = int64(maxResponseSize)
+	default:
+		return nil, fmt.Errorf("maxResponseSize must be a number")
+	}
+	if httpConfig.MaxResponseSize < 0 {
+		return nil, fmt.Errorf("maxResponseSize must be non-negative, got %d", httpConfig.MaxResponseSize)
+	}
+
+	// Parse in-request retries
+	switch retries := config["retries"].(type) {
+	case nil:
+	case int:
+		httpConfig.Retries = retries
+	case float64:
+		httpConfig.Retries = int(retries)
+	default:
+		return nil, fmt.Errorf("retries must be a number")
+	}
+	if httpConfig.Retries < 0 {
+		return nil, fmt.Errorf("retries must be non-negative, got %d", httpConfig.Retries)
+	}
+	if retryBackoff, ok := config["retryBackoff"].(string); ok && retryBackoff != "" {
+		duration, err := time.ParseDuration(retryBackoff)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retryBackoff %q: %w", retryBackoff, err)
+		}
+		if duration <= 0 {
+			return nil, fmt.Errorf("retryBackoff must be positive, got %s", retryBackoff)
+		}
+		httpConfig.RetryBackoff = duration
+	}
+
+	// Parse the requested byte range
+	var err error
+	if httpConfig.RangeOffset, err = int64Option(config, "rangeOffset"); err != nil {
+		return nil, err
+	}
+	if httpConfig.RangeLength, err = int64Option(config, "rangeLength"); err != nil {
+		return nil, err
+	}
+	if httpConfig.RangeSuffix, err = int64Option(config, "rangeSuffix"); err != nil {
+		return nil, err
+	}
+	if httpConfig.rangeHeader() != "" && httpConfig.Method != http.MethodGet {
+		return nil, fmt.Errorf("range requests are only supported with the GET method")
+	}
+
+	// Parse digest header
+	if digestHeader, ok := config["digestHeader"].(string); ok {
+		httpConfig.DigestHeader = digestHeader
+	}
+
+	// Parse request body
+	if body, ok := config["body"].(string); ok && body != "" {
+		if !methodAllowsBody(httpConfig.Method) {
+			return nil, fmt.Errorf("request body is not allowed for method %s", httpConfig.Method)
+		}
+
+		encoding, _ := config["bodyEncoding"].(string)
+		switch encoding {
+		case "", "text":
+			httpConfig.Body = []byte(body)
+		case "base64":
+			decoded, err := base64.StdEncoding.DecodeString(body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode base64 request body: %w", err)
+			}
+			httpConfig.Body = decoded
+		default:
+			return nil, fmt.Errorf("unsupported body encoding: %s", encoding)
+		}
+	}
+
+	// Parse query parameters
+	switch params := config["queryParams"].(type) {
+	case map[string]string:
+		httpConfig.QueryParams = params
+	case map[string]interface{}:
+		httpConfig.QueryParams = make(map[string]string, len(params))
+		for k, v := range params {
+			value, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("query parameter %s must be a string", k)
+			}
+			httpConfig.QueryParams[k] = value
+		}
+	}
+
 	// Parse namespace for secret references
 	namespace, _ := config["namespace"].(string)
 	if namespace == "" {
 		namespace = "default"
 	}
 
-	// Load headers from secret if specified
+	// Parse inline headers
+	switch headers := config["headers"].(type) {
+	case map[string]string:
+		for k, v := range headers {
+			httpConfig.Headers[k] = v
+		}
+	case map[string]interface{}:
+		for k, v := range headers {
+			value, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("header %s must be a string", k)
+			}
+			httpConfig.Headers[k] = value
+		}
+	}
+
+	// Load headers from secret if specified, these take precedence over inline headers
 	if headersSecretName, ok := config["headersSecretName"].(string); ok && headersSecretName != "" {
-		headers, err := h.loadHeaders(ctx, namespace, headersSecretName)
+		keys, err := stringList(config["headersSecretKeys"])
+		if err != nil {
+			return nil, fmt.Errorf("invalid headers secret keys: %w", err)
+		}
+		keyPrefix, _ := config["headersSecretKeyPrefix"].(string)
+		if len(keys) > 0 && keyPrefix != "" {
+			return nil, fmt.Errorf("only one of keys or keyPrefix may be set for headersSecretRef")
+		}
+
+		headers, err := h.loadHeaders(ctx, namespace, headersSecretName, keys, keyPrefix)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load headers from secret: %w", err)
 		}
 		for k, v := range headers {
+			deleteHeader(httpConfig.Headers, k)
 			httpConfig.Headers[k] = v
+			httpConfig.markSecretHeader(k)
 		}
 	}
 
-	// Load CA bundle from secret if specified
-	if caBundleSecretName, ok := config["caBundleSecretName"].(string); ok && caBundleSecretName != "" {
+	// Request a representation, an explicit Accept header takes precedence
+	if accept, ok := config["accept"].(string); ok && accept != "" && !hasHeader(httpConfig.Headers, "Accept") {
+		httpConfig.Headers["Accept"] = accept
+	}
+
+	// The cursor replaces a header or query parameter of the same name
+	if err := httpConfig.parseCursor(config); err != nil {
+		return nil, err
+	}
+
+	// Resolve convenience authentication, an explicit Authorization header takes precedence
+	authorization, err := h.loadAuthorization(ctx, namespace, config)
+	if err != nil {
+		return nil, err
+	}
+	if authorization != "" && !hasHeader(httpConfig.Headers, "Authorization") {
+		httpConfig.Headers["Authorization"] = authorization
+		httpConfig.markSecretHeader("Authorization")
+	}
+
+	// Load CA bundle from a secret or ConfigMap if specified
+	caBundleSecretName, _ := config["caBundleSecretName"].(string)
+	caBundleConfigMapName, _ := config["caBundleConfigMapName"].(string)
+	if caBundleSecretName != "" && caBundleConfigMapName != "" {
+		return nil, fmt.Errorf("invalid CA bundle reference: only one of caBundleSecretRef or caBundleConfigMapRef may be set")
+	}
+	if caBundleSecretName != "" {
 		caBundleKey, _ := config["caBundleSecretKey"].(string)
 		if caBundleKey == "" {
 			caBundleKey = "ca.crt"
@@ -263,18 +1023,172 @@ func (h *HTTPGenerator) parseConfig(ctx context.Context, config map[string]inter
 		}
 		httpConfig.CABundle = caBundle
 	}
+	if caBundleConfigMapName != "" {
+		caBundleKey, _ := config["caBundleConfigMapKey"].(string)
+		if caBundleKey == "" {
+			caBundleKey = "ca.crt"
+		}
+
+		caBundle, err := h.loadConfigMapData(ctx, namespace, caBundleConfigMapName, caBundleKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load CA bundle from ConfigMap: %w", err)
+		}
+		httpConfig.CABundle = caBundle
+	}
+
+	// Load the client certificate for mutual TLS if specified
+	if clientCertSecretName, ok := config["clientCertSecretName"].(string); ok && clientCertSecretName != "" {
+		certificate, err := h.loadClientCertificate(ctx, namespace, clientCertSecretName)
+		if err != nil {
+			return nil, err
+		}
+		httpConfig.ClientCertificate = certificate
+	}
+
+	// Obtain an OAuth2 access token unless an Authorization header is already set. The token
+	// endpoint is requested with the TLS settings of the source, so it may use the same private CA.
+	if tokenURL, ok := config["oauth2TokenURL"].(string); ok && tokenURL != "" &&
+		!hasHeader(httpConfig.Headers, "Authorization") {
+		transport, err := h.newTransport(httpConfig)
+		if err != nil {
+			return nil, err
+		}
+		authorization, err := h.loadOAuth2Authorization(ctx, namespace, tokenURL, config, transport)
+		if err != nil {
+			return nil, err
+		}
+		httpConfig.Headers["Authorization"] = authorization
+		httpConfig.markSecretHeader("Authorization")
+	}
 
 	return httpConfig, nil
 }
 
+// requestURL returns the configured URL with query parameters applied
+func (c *HTTPConfig) requestURL() (string, error) {
+	if len(c.QueryParams) == 0 {
+		return c.URL, nil
+	}
+
+	parsed, err := url.Parse(c.URL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	query := parsed.Query()
+	for k, v := range c.QueryParams {
+		query.Set(k, v)
+	}
+	parsed.RawQuery = query.Encode()
+
+	return parsed.String(), nil
+}
+
+// signRequest passes the request to the signer and sets the headers it returns. Signed
+// headers are redacted from logs like headers loaded from secrets.
+func (c *HTTPConfig) signRequest(ctx context.Context, req *http.Request) error {
+	if c.signer == nil {
+		return nil
+	}
+
+	headers := make(map[string]string, len(req.Header))
+	for name, values := range req.Header {
+		headers[name] = strings.Join(values, ", ")
+	}
+
+	signed, err := c.signer(ctx, RequestMetadata{
+		Method:  req.Method,
+		URL:     req.URL.String(),
+		Headers: headers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	for name, value := range signed {
+		req.Header.Set(name, value)
+		c.markSecretHeader(name)
+	}
+	return nil
+}
+
+// redactedValue replaces header values that must not appear in logs
+const redactedValue = "[REDACTED]"
+
+// markSecretHeader records that the value of the named header comes from a secret
+func (c *HTTPConfig) markSecretHeader(name string) {
+	if c.secretHeaders == nil {
+		c.secretHeaders = make(map[string]bool)
+	}
+	c.secretHeaders[strings.ToLower(name)] = true
+}
+
+// redactHeaders returns the request headers for logging, with credentials and values
+// loaded from secrets replaced
+func (c *HTTPConfig) redactHeaders(header http.Header) map[string]string {
+	redacted := make(map[string]string, len(header))
+	for name, values := range header {
+		lower := strings.ToLower(name)
+		if c.secretHeaders[lower] || lower == "authorization" || lower == "proxy-authorization" || lower == "cookie" {
+			redacted[name] = redactedValue
+			continue
+		}
+		redacted[name] = strings.Join(values, ", ")
+	}
+	return redacted
+}
+
+// methodAllowsBody reports whether a request body may be sent with the given method
+func methodAllowsBody(method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return true
+	default:
+		return false
+	}
+}
+
 // configureHTTPClient creates an HTTP client with appropriate TLS configuration
 //
 //nolint:unparam // ctx parameter reserved for future use (e.g., timeout handling, tracing)
 func (h *HTTPGenerator) configureHTTPClient(_ context.Context, config *HTTPConfig) (*http.Client, error) {
+	transport, err := h.newTransport(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use the per-source timeout when set, otherwise the configured timeout from the generator
+	timeout := h.httpClient.Timeout
+	if config.Timeout > 0 {
+		timeout = config.Timeout
+	}
+
+	var roundTripper http.RoundTripper = transport
+	if h.circuitBreaker != nil {
+		roundTripper = &circuitBreakerTransport{breaker: h.circuitBreaker, next: transport}
+	}
+
+	// Retry outside the circuit breaker so every attempt counts towards it
+	if config.Retries > 0 {
+		roundTripper = &retryTransport{retries: config.Retries, backoff: config.RetryBackoff, next: roundTripper}
+	}
+
+	return &http.Client{
+		Transport:     roundTripper,
+		Timeout:       timeout,
+		CheckRedirect: config.checkRedirect,
+	}, nil
+}
+
+// newTransport creates a transport with the TLS configuration and connection timeouts of the source
+func (h *HTTPGenerator) newTransport(config *HTTPConfig) (*http.Transport, error) {
 	transport := &http.Transport{
 		TLSClientConfig: &tls.Config{
 			InsecureSkipVerify: config.InsecureSkipVerify,
 		},
+		DialContext:           (&net.Dialer{Timeout: h.connectTimeout}).DialContext,
+		TLSHandshakeTimeout:   h.tlsHandshakeTimeout,
+		ResponseHeaderTimeout: h.responseHeaderTimeout,
 	}
 
 	// Configure custom CA bundle if provided
@@ -286,10 +1200,36 @@ func (h *HTTPGenerator) configureHTTPClient(_ context.Context, config *HTTPConfi
 		transport.TLSClientConfig.RootCAs = caCertPool
 	}
 
-	return &http.Client{
-		Transport: transport,
-		Timeout:   h.httpClient.Timeout, // Use the configured timeout from the generator
-	}, nil
+	// Present the client certificate for mutual TLS
+	if config.ClientCertificate != nil {
+		transport.TLSClientConfig.Certificates = []tls.Certificate{*config.ClientCertificate}
+	}
+
+	return transport, nil
+}
+
+// checkRedirect enforces the redirect limit and controls whether the Authorization
+// header follows a redirect to a different host
+func (c *HTTPConfig) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) > c.MaxRedirects {
+		return fmt.Errorf("stopped after %d redirects", c.MaxRedirects)
+	}
+
+	original := via[0]
+	if req.URL.Host == original.URL.Host {
+		return nil
+	}
+
+	// net/http only drops the header for hosts outside the original domain, strip it for any
+	// host change, or restore it when explicitly allowed
+	if c.KeepAuthorizationOnRedirect {
+		if authorization := original.Header.Get("Authorization"); authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		return nil
+	}
+	req.Header.Del("Authorization")
+	return nil
 }
 
 // loadSecretData loads data from a Kubernetes secret
@@ -312,8 +1252,130 @@ func (h *HTTPGenerator) loadSecretData(ctx context.Context, namespace, name, key
 	return data, nil
 }
 
-// loadHeaders loads headers from a Kubernetes secret
-func (h *HTTPGenerator) loadHeaders(ctx context.Context, namespace, secretName string) (map[string]string, error) {
+// loadConfigMapData loads a key from a ConfigMap, from either its data or its binary data
+func (h *HTTPGenerator) loadConfigMapData(ctx context.Context, namespace, name, key string) ([]byte, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := h.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, configMap); err != nil {
+		return nil, fmt.Errorf("failed to get ConfigMap %s/%s: %w", namespace, name, err)
+	}
+
+	if data, exists := configMap.Data[key]; exists {
+		return []byte(data), nil
+	}
+	if data, exists := configMap.BinaryData[key]; exists {
+		return data, nil
+	}
+	return nil, fmt.Errorf("key %s not found in ConfigMap %s/%s", key, namespace, name)
+}
+
+// loadClientCertificate loads and parses the tls.crt and tls.key pair from a secret
+func (h *HTTPGenerator) loadClientCertificate(ctx context.Context, namespace, name string) (*tls.Certificate, error) {
+	certPEM, err := h.loadSecretData(ctx, namespace, name, corev1.TLSCertKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client certificate from secret: %w", err)
+	}
+	keyPEM, err := h.loadSecretData(ctx, namespace, name, corev1.TLSPrivateKeyKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client certificate from secret: %w", err)
+	}
+
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client certificate in secret %s/%s: %w", namespace, name, err)
+	}
+
+	return &certificate, nil
+}
+
+// loadAuthorization builds an Authorization header value from basic auth or bearer token secrets
+func (h *HTTPGenerator) loadAuthorization(ctx context.Context, namespace string, config map[string]interface{}) (string, error) {
+	basicAuthSecretName, _ := config["basicAuthSecretName"].(string)
+	bearerTokenSecretName, _ := config["bearerTokenSecretName"].(string)
+
+	if basicAuthSecretName != "" && bearerTokenSecretName != "" {
+		return "", fmt.Errorf("only one of basicAuthSecretRef or bearerTokenSecretRef may be set; " +
+			"an Authorization header in headersSecretRef takes precedence over both")
+	}
+	if tokenURL, _ := config["oauth2TokenURL"].(string); tokenURL != "" &&
+		(basicAuthSecretName != "" || bearerTokenSecretName != "") {
+		return "", fmt.Errorf("oauth2 cannot be combined with basicAuthSecretRef or bearerTokenSecretRef")
+	}
+
+	if basicAuthSecretName != "" {
+		username, err := h.loadSecretData(ctx, namespace, basicAuthSecretName, "username")
+		if err != nil {
+			return "", fmt.Errorf("failed to load basic auth from secret: %w", err)
+		}
+		password, err := h.loadSecretData(ctx, namespace, basicAuthSecretName, "password")
+		if err != nil {
+			return "", fmt.Errorf("failed to load basic auth from secret: %w", err)
+		}
+		credentials := base64.StdEncoding.EncodeToString([]byte(string(username) + ":" + string(password)))
+		return "Basic " + credentials, nil
+	}
+
+	if bearerTokenSecretName != "" {
+		token, err := h.loadSecretData(ctx, namespace, bearerTokenSecretName, "token")
+		if err != nil {
+			return "", fmt.Errorf("failed to load bearer token from secret: %w", err)
+		}
+		return "Bearer " + strings.TrimSpace(string(token)), nil
+	}
+
+	return "", nil
+}
+
+// loadOAuth2Authorization builds a bearer Authorization header value from a client credentials token
+func (h *HTTPGenerator) loadOAuth2Authorization(ctx context.Context, namespace, tokenURL string, config map[string]interface{}, transport http.RoundTripper) (string, error) {
+	secretName, _ := config["oauth2SecretName"].(string)
+	if secretName == "" {
+		return "", fmt.Errorf("oauth2 client secret reference is required")
+	}
+	scopes, err := stringList(config["oauth2Scopes"])
+	if err != nil {
+		return "", fmt.Errorf("invalid oauth2 scopes: %w", err)
+	}
+
+	clientID, err := h.loadSecretData(ctx, namespace, secretName, "clientID")
+	if err != nil {
+		return "", fmt.Errorf("failed to load OAuth2 client credentials from secret: %w", err)
+	}
+	clientSecret, err := h.loadSecretData(ctx, namespace, secretName, "clientSecret")
+	if err != nil {
+		return "", fmt.Errorf("failed to load OAuth2 client credentials from secret: %w", err)
+	}
+
+	token, err := h.tokenCache.Token(transport, tokenURL, strings.TrimSpace(string(clientID)),
+		strings.TrimSpace(string(clientSecret)), scopes)
+	if err != nil {
+		return "", err
+	}
+	return token.Type() + " " + token.AccessToken, nil
+}
+
+// hasHeader reports whether headers contains the named header, ignoring case
+func hasHeader(headers map[string]string, name string) bool {
+	for key := range headers {
+		if http.CanonicalHeaderKey(key) == http.CanonicalHeaderKey(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteHeader removes a header from headers regardless of the case of its key
+func deleteHeader(headers map[string]string, name string) {
+	for key := range headers {
+		if http.CanonicalHeaderKey(key) == http.CanonicalHeaderKey(name) {
+			delete(headers, key)
+		}
+	}
+}
+
+// loadHeaders loads headers from a Kubernetes secret. When keys are given only those keys are
+// used, when keyPrefix is given only keys with the prefix are used with the prefix removed,
+// otherwise every key becomes a header.
+func (h *HTTPGenerator) loadHeaders(ctx context.Context, namespace, secretName string, keys []string, keyPrefix string) (map[string]string, error) {
 	secret := &corev1.Secret{}
 	secretKey := client.ObjectKey{
 		Namespace: namespace,
@@ -325,9 +1387,50 @@ func (h *HTTPGenerator) loadHeaders(ctx context.Context, namespace, secretName s
 	}
 
 	headers := make(map[string]string)
-	for key, value := range secret.Data {
-		headers[key] = string(value)
+	switch {
+	case len(keys) > 0:
+		for _, key := range keys {
+			value, ok := secret.Data[key]
+			if !ok {
+				return nil, fmt.Errorf("key %s not found in headers secret %s/%s", key, namespace, secretName)
+			}
+			headers[key] = string(value)
+		}
+	case keyPrefix != "":
+		for key, value := range secret.Data {
+			name, found := strings.CutPrefix(key, keyPrefix)
+			if !found || name == "" {
+				continue
+			}
+			headers[name] = string(value)
+		}
+	default:
+		for key, value := range secret.Data {
+			headers[key] = string(value)
+		}
 	}
 
 	return headers, nil
 }
+
+// stringList converts a config value holding a list of strings
+func stringList(value interface{}) ([]string, error) {
+	switch list := value.(type) {
+	case nil:
+		return nil, nil
+	case []string:
+		return list, nil
+	case []interface{}:
+		result := make([]string, 0, len(list))
+		for _, item := range list {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a list of strings, got %T", item)
+			}
+			result = append(result, s)
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("expected a list of strings, got %T", value)
+	}
+}
@@ -24,10 +24,30 @@ package generator
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -148,6 +168,395 @@ func TestHTTPGenerator_GetLastModified_Success(t *testing.T) {
 	}
 }
 
+func TestHTTPGenerator_LastModifiedFallback(t *testing.T) {
+	lastModified := "Wed, 21 Oct 2015 07:28:00 GMT"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Last-Modified", lastModified)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	generator := NewHTTPGenerator(nil)
+	config := GeneratorConfig{
+		Type: "http",
+		Config: map[string]interface{}{
+			"url": server.URL,
+		},
+	}
+
+	ctx := context.Background()
+	data, err := generator.Generate(ctx, config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if data.LastModified != lastModified {
+		t.Errorf("Expected %s, got %s", lastModified, data.LastModified)
+	}
+
+	version, err := generator.GetLastModified(ctx, config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if version != data.LastModified {
+		t.Errorf("Expected GetLastModified to match Generate, got %s and %s", version, data.LastModified)
+	}
+}
+
+func TestHTTPGenerator_PrefersETagOverLastModified(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("ETag", "test-etag")
+		w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	generator := NewHTTPGenerator(nil)
+	config := GeneratorConfig{
+		Type: "http",
+		Config: map[string]interface{}{
+			"url": server.URL,
+		},
+	}
+
+	version, err := generator.GetLastModified(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if version != "test-etag" {
+		t.Errorf("Expected test-etag, got %s", version)
+	}
+}
+
+func TestHTTPGenerator_Generate_NotModified(t *testing.T) {
+	lastModified := "Wed, 21 Oct 2015 07:28:00 GMT"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` || r.Header.Get("If-Modified-Since") == lastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v2"`)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	generator := NewHTTPGenerator(nil)
+	ctx := context.Background()
+
+	for _, previous := range []string{`"v1"`, lastModified} {
+		config := GeneratorConfig{
+			Type: "http",
+			Config: map[string]interface{}{
+				"url": server.URL,
+			},
+			LastModified: previous,
+		}
+
+		data, err := generator.Generate(ctx, config)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if !data.NotModified {
+			t.Errorf("Expected NotModified for %s", previous)
+		}
+
+		if data.LastModified != previous {
+			t.Errorf("Expected %s to be preserved, got %s", previous, data.LastModified)
+		}
+	}
+
+	config := GeneratorConfig{
+		Type: "http",
+		Config: map[string]interface{}{
+			"url": server.URL,
+		},
+		LastModified: `"v0"`,
+	}
+
+	data, err := generator.Generate(ctx, config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if data.NotModified || string(data.Data) != "data" || data.LastModified != `"v2"` {
+		t.Errorf("Expected updated data, got %+v", data)
+	}
+}
+
+func TestHTTPGenerator_VersionURL(t *testing.T) {
+	version := "abc123"
+	contentRequests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/version", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected GET request for version, got %s", r.Method)
+		}
+		if got := r.Header.Get("X-Api-Key"); got != "key" {
+			t.Errorf("Expected headers on version request, got X-Api-Key %q", got)
+		}
+		_, _ = w.Write([]byte(version + "\n"))
+	})
+	mux.HandleFunc("/content", func(w http.ResponseWriter, r *http.Request) {
+		contentRequests++
+		if r.Header.Get("If-None-Match") != "" {
+			t.Error("Expected no conditional header when a version URL is set")
+		}
+		w.Header().Set("ETag", "content-etag")
+		_, _ = w.Write([]byte("large payload"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	generator := NewHTTPGenerator(nil)
+	config := GeneratorConfig{
+		Type: "http",
+		Config: map[string]interface{}{
+			"url":        server.URL + "/content",
+			"versionURL": server.URL + "/version",
+			"headers":    map[string]string{"X-Api-Key": "key"},
+		},
+	}
+
+	ctx := context.Background()
+	current, err := generator.GetLastModified(ctx, config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.HasPrefix(current, "sha256:") {
+		t.Errorf("Expected a sha256 version, got %q", current)
+	}
+	if contentRequests != 0 {
+		t.Errorf("Expected GetLastModified not to fetch content, got %d requests", contentRequests)
+	}
+
+	// The first fetch records the version endpoint's value rather than the content ETag
+	data, err := generator.Generate(ctx, config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if string(data.Data) != "large payload" {
+		t.Errorf("Expected content to be fetched, got %q", string(data.Data))
+	}
+	if data.LastModified != current {
+		t.Errorf("Expected LastModified %q, got %q", current, data.LastModified)
+	}
+
+	// An unchanged version skips the content fetch
+	config.LastModified = data.LastModified
+	data, err = generator.Generate(ctx, config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !data.NotModified {
+		t.Error("Expected NotModified when the version is unchanged")
+	}
+	if contentRequests != 1 {
+		t.Errorf("Expected 1 content request, got %d", contentRequests)
+	}
+
+	// A new version fetches the content again
+	version = "def456"
+	data, err = generator.Generate(ctx, config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if data.NotModified || data.LastModified == config.LastModified {
+		t.Error("Expected content to be fetched when the version changes")
+	}
+	if contentRequests != 2 {
+		t.Errorf("Expected 2 content requests, got %d", contentRequests)
+	}
+}
+
+func TestHTTPGenerator_VersionURL_HTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	generator := NewHTTPGenerator(nil)
+	config := GeneratorConfig{
+		Type: "http",
+		Config: map[string]interface{}{
+			"url":        server.URL + "/content",
+			"versionURL": server.URL + "/version",
+		},
+	}
+
+	_, err := generator.GetLastModified(context.Background(), config)
+	if err == nil || !strings.Contains(err.Error(), "version request failed with status 503") {
+		t.Errorf("Expected version request error, got %v", err)
+	}
+}
+
+func TestHTTPGenerator_RequestSigner(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "AWS4-HMAC-SHA256 Signature=abc" {
+			t.Errorf("Expected signed Authorization header, got %q", got)
+		}
+		if got := r.Header.Get("X-Amz-Date"); got != "20251015T000000Z" {
+			t.Errorf("Expected X-Amz-Date header, got %q", got)
+		}
+		_, _ = w.Write([]byte("signed"))
+	}))
+	defer server.Close()
+
+	var received RequestMetadata
+	generator := NewHTTPGenerator(nil)
+	config := GeneratorConfig{
+		Type: "http",
+		Config: map[string]interface{}{
+			"url":         server.URL + "/data",
+			"headers":     map[string]string{"X-Api-Key": "key"},
+			"queryParams": map[string]string{"page": "1"},
+		},
+		RequestSigner: func(_ context.Context, request RequestMetadata) (map[string]string, error) {
+			received = request
+			return map[string]string{
+				"Authorization": "AWS4-HMAC-SHA256 Signature=abc",
+				"X-Amz-Date":    "20251015T000000Z",
+			}, nil
+		},
+	}
+
+	data, err := generator.Generate(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if string(data.Data) != "signed" {
+		t.Errorf("Expected data 'signed', got %q", string(data.Data))
+	}
+
+	// The signer sees the final request, including query parameters and headers
+	if received.Method != "GET" {
+		t.Errorf("Expected method GET, got %q", received.Method)
+	}
+	if received.URL != server.URL+"/data?page=1" {
+		t.Errorf("Expected URL with query parameters, got %q", received.URL)
+	}
+	if received.Headers["X-Api-Key"] != "key" {
+		t.Errorf("Expected configured headers to be passed to the signer, got %v", received.Headers)
+	}
+}
+
+func TestHTTPGenerator_RequestSignerError(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	generator := NewHTTPGenerator(nil)
+	config := GeneratorConfig{
+		Type: "http",
+		Config: map[string]interface{}{
+			"url": server.URL,
+		},
+		RequestSigner: func(context.Context, RequestMetadata) (map[string]string, error) {
+			return nil, errors.New("hook failed")
+		},
+	}
+
+	_, err := generator.Generate(context.Background(), config)
+	if err == nil || !strings.Contains(err.Error(), "failed to sign request") {
+		t.Errorf("Expected signing error, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("Expected no request to be sent, got %d", requests)
+	}
+}
+
+func TestHTTPGenerator_DigestVerification(t *testing.T) {
+	body := []byte(`{"key": "value"}`)
+	sha256Sum := sha256.Sum256(body)
+	sha512Sum := sha512.Sum512(body)
+	wrongSum := sha256.Sum256([]byte(`{"key": "val`))
+
+	tests := []struct {
+		name         string
+		digestHeader string
+		headers      map[string]string
+		expectError  bool
+	}{
+		{
+			name:    "no digest",
+			headers: map[string]string{},
+		},
+		{
+			name:    "matching Digest header",
+			headers: map[string]string{"Digest": "sha-256=" + base64.StdEncoding.EncodeToString(sha256Sum[:])},
+		},
+		{
+			name:    "matching Content-Digest header",
+			headers: map[string]string{"Content-Digest": "sha-512=:" + base64.StdEncoding.EncodeToString(sha512Sum[:]) + ":"},
+		},
+		{
+			name:    "unsupported algorithm is ignored",
+			headers: map[string]string{"Digest": "md5=HUXZLQLMuI/KZ5KDcJPcOA=="},
+		},
+		{
+			name:         "matching hex digest in custom header",
+			digestHeader: "X-Checksum-Sha256",
+			headers:      map[string]string{"X-Checksum-Sha256": hex.EncodeToString(sha256Sum[:])},
+		},
+		{
+			name:         "custom header replaces the default headers",
+			digestHeader: "X-Checksum-Sha256",
+			headers:      map[string]string{"Digest": "sha-256=" + base64.StdEncoding.EncodeToString(wrongSum[:])},
+		},
+		{
+			name:        "mismatched Digest header",
+			headers:     map[string]string{"Digest": "sha-256=" + base64.StdEncoding.EncodeToString(wrongSum[:])},
+			expectError: true,
+		},
+		{
+			name:         "mismatched hex digest in custom header",
+			digestHeader: "X-Checksum-Sha256",
+			headers:      map[string]string{"X-Checksum-Sha256": hex.EncodeToString(wrongSum[:])},
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for name, value := range tt.headers {
+					w.Header().Set(name, value)
+				}
+				_, _ = w.Write(body)
+			}))
+			defer server.Close()
+
+			config := GeneratorConfig{
+				Type:   "http",
+				Config: map[string]interface{}{"url": server.URL},
+			}
+			if tt.digestHeader != "" {
+				config.Config["digestHeader"] = tt.digestHeader
+			}
+
+			data, err := NewHTTPGenerator(nil).Generate(context.Background(), config)
+			if tt.expectError {
+				if !IsDigestMismatchError(err) || !strings.Contains(err.Error(), "content digest mismatch") {
+					t.Errorf("Expected digest mismatch error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if string(data.Data) != string(body) {
+				t.Errorf("Expected body %q, got %q", body, data.Data)
+			}
+		})
+	}
+}
+
 func TestHTTPGenerator_GetLastModified_HTTPError(t *testing.T) {
 	// Create test HTTP server that returns error
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
@@ -236,66 +645,334 @@ func TestHTTPGenerator_ParseConfig_InsecureSkipVerify(t *testing.T) {
 	}
 }
 
-func TestHTTPGenerator_LoadHeaders_Success(t *testing.T) {
-	// Create fake Kubernetes client with secret
-	scheme := runtime.NewScheme()
-	_ = corev1.AddToScheme(scheme)
+func TestHTTPGenerator_Generate_WithBodyAndQueryParams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST method, got %s", r.Method)
+		}
+		if r.URL.Query().Get("env") != "prod" || r.URL.Query().Get("existing") != "1" {
+			t.Errorf("Expected query parameters to be set, got %s", r.URL.RawQuery)
+		}
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected application/json content-type, got %s", r.Header.Get("Content-Type"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"query": "config"}` {
+			t.Errorf("Expected request body, got %s", string(body))
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
 
-	secret := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-headers",
-			Namespace: "default",
-		},
-		Data: map[string][]byte{
-			"Authorization": []byte("Bearer token123"),
-			"X-Custom":      []byte("custom-value"),
+	generator := NewHTTPGenerator(nil)
+	config := GeneratorConfig{
+		Type: "http",
+		Config: map[string]interface{}{
+			"url":         server.URL + "?existing=1",
+			"method":      "POST",
+			"body":        `{"query": "config"}`,
+			"queryParams": map[string]string{"env": "prod"},
 		},
 	}
 
-	fakeClient := fake.NewClientBuilder().
-		WithScheme(scheme).
-		WithObjects(secret).
-		Build()
-
-	generator := NewHTTPGenerator(fakeClient)
-
-	ctx := context.Background()
-	headers, err := generator.loadHeaders(ctx, "default", "test-headers")
+	data, err := generator.Generate(context.Background(), config)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	if headers["Authorization"] != "Bearer token123" {
-		t.Errorf("Expected Authorization header, got %s", headers["Authorization"])
+	if string(data.Data) != "ok" {
+		t.Errorf("Expected ok, got %s", string(data.Data))
 	}
+}
 
-	if headers["X-Custom"] != "custom-value" {
-		t.Errorf("Expected X-Custom header, got %s", headers["X-Custom"])
+func TestHTTPGenerator_ParseConfig_Base64Body(t *testing.T) {
+	generator := NewHTTPGenerator(nil)
+	config := map[string]interface{}{
+		"url":          "https://example.com",
+		"method":       "PUT",
+		"body":         "aGVsbG8=",
+		"bodyEncoding": "base64",
 	}
-}
 
-func TestHTTPGenerator_LoadHeaders_SecretNotFound(t *testing.T) {
-	// Create fake Kubernetes client without secret
-	scheme := runtime.NewScheme()
-	_ = corev1.AddToScheme(scheme)
+	httpConfig, err := generator.parseConfig(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
 
-	fakeClient := fake.NewClientBuilder().
-		WithScheme(scheme).
-		Build()
+	if string(httpConfig.Body) != "hello" {
+		t.Errorf("Expected decoded body hello, got %s", string(httpConfig.Body))
+	}
+}
 
-	generator := NewHTTPGenerator(fakeClient)
+func TestHTTPGenerator_ParseConfig_BodyNotAllowed(t *testing.T) {
+	generator := NewHTTPGenerator(nil)
+	config := map[string]interface{}{
+		"url":  "https://example.com",
+		"body": `{"query": "config"}`,
+	}
 
-	ctx := context.Background()
-	_, err := generator.loadHeaders(ctx, "default", "nonexistent-secret")
+	_, err := generator.parseConfig(context.Background(), config)
 	if err == nil {
-		t.Error("Expected error for nonexistent secret")
+		t.Error("Expected error for body with GET method")
 	}
 }
 
-func TestHTTPGenerator_LoadSecretData_Success(t *testing.T) {
-	// Create fake Kubernetes client with secret
-	scheme := runtime.NewScheme()
-	_ = corev1.AddToScheme(scheme)
+func TestHTTPGenerator_ParseConfig_Timeout(t *testing.T) {
+	generator := NewHTTPGenerator(nil)
+	ctx := context.Background()
+
+	httpConfig, err := generator.parseConfig(ctx, map[string]interface{}{
+		"url":     "https://example.com",
+		"timeout": "5m",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	client, err := generator.configureHTTPClient(ctx, httpConfig)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if client.Timeout != 5*time.Minute {
+		t.Errorf("Expected 5m timeout, got %s", client.Timeout)
+	}
+
+	if _, err := generator.parseConfig(ctx, map[string]interface{}{
+		"url":     "https://example.com",
+		"timeout": "soon",
+	}); err == nil {
+		t.Error("Expected error for invalid timeout")
+	}
+}
+
+func TestHTTPGenerator_ConfigureHTTPClient_DefaultTimeout(t *testing.T) {
+	generator := NewHTTPGeneratorWithConfig(nil, &HTTPClientConfig{Timeout: 10 * time.Second})
+
+	client, err := generator.configureHTTPClient(context.Background(), &HTTPConfig{URL: "https://example.com"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if client.Timeout != 10*time.Second {
+		t.Errorf("Expected generator default timeout, got %s", client.Timeout)
+	}
+}
+
+func TestHTTPGenerator_Generate_PhaseTimeouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/stalled" {
+			// A live host that never answers
+			time.Sleep(500 * time.Millisecond)
+			return
+		}
+
+		// A slow source that answers promptly and streams its body
+		w.WriteHeader(http.StatusOK)
+		for _, chunk := range []string{`{"slow": `, `true}`} {
+			_, _ = w.Write([]byte(chunk))
+			w.(http.Flusher).Flush()
+			time.Sleep(150 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+
+	generator := NewHTTPGeneratorWithConfig(nil, &HTTPClientConfig{
+		Timeout:               5 * time.Second,
+		ConnectTimeout:        time.Second,
+		TLSHandshakeTimeout:   time.Second,
+		ResponseHeaderTimeout: 100 * time.Millisecond,
+	})
+	ctx := context.Background()
+
+	data, err := generator.Generate(ctx, GeneratorConfig{Config: map[string]interface{}{"url": server.URL + "/slow"}})
+	if err != nil {
+		t.Fatalf("Expected slow body within the overall timeout to succeed, got %v", err)
+	}
+	if string(data.Data) != `{"slow": true}` {
+		t.Errorf("Expected streamed body, got %s", string(data.Data))
+	}
+
+	start := time.Now()
+	if _, err := generator.Generate(ctx, GeneratorConfig{Config: map[string]interface{}{"url": server.URL + "/stalled"}}); err == nil {
+		t.Fatal("Expected error when the response headers time out")
+	}
+	if elapsed := time.Since(start); elapsed > 400*time.Millisecond {
+		t.Errorf("Expected the response header timeout to fail fast, took %s", elapsed)
+	}
+
+	client, err := generator.configureHTTPClient(ctx, &HTTPConfig{URL: server.URL})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	transport := client.Transport.(*http.Transport)
+	if transport.TLSHandshakeTimeout != time.Second || transport.ResponseHeaderTimeout != 100*time.Millisecond {
+		t.Errorf("Expected phase timeouts on the transport, got handshake %s and response header %s",
+			transport.TLSHandshakeTimeout, transport.ResponseHeaderTimeout)
+	}
+	if client.Timeout != 5*time.Second {
+		t.Errorf("Expected the overall timeout to cap the request, got %s", client.Timeout)
+	}
+}
+
+func TestHTTPGenerator_LoadHeaders_Success(t *testing.T) {
+	// Create fake Kubernetes client with secret
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-headers",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"Authorization": []byte("Bearer token123"),
+			"X-Custom":      []byte("custom-value"),
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(secret).
+		Build()
+
+	generator := NewHTTPGenerator(fakeClient)
+
+	ctx := context.Background()
+	headers, err := generator.loadHeaders(ctx, "default", "test-headers", nil, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if headers["Authorization"] != "Bearer token123" {
+		t.Errorf("Expected Authorization header, got %s", headers["Authorization"])
+	}
+
+	if headers["X-Custom"] != "custom-value" {
+		t.Errorf("Expected X-Custom header, got %s", headers["X-Custom"])
+	}
+}
+
+func TestHTTPGenerator_LoadHeaders_SecretNotFound(t *testing.T) {
+	// Create fake Kubernetes client without secret
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		Build()
+
+	generator := NewHTTPGenerator(fakeClient)
+
+	ctx := context.Background()
+	_, err := generator.loadHeaders(ctx, "default", "nonexistent-secret", nil, "")
+	if err == nil {
+		t.Error("Expected error for nonexistent secret")
+	}
+}
+
+func TestHTTPGenerator_ParseConfig_HeadersSecretKeyFilters(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "shared-secret",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"X-Org-Id":         []byte("platform"),
+			"header-X-Api-Key": []byte("key123"),
+			"notes":            []byte("rotated quarterly"),
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(secret).
+		Build()
+	generator := NewHTTPGenerator(fakeClient)
+
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name: "all keys by default",
+			config: map[string]interface{}{
+				"headersSecretName": "shared-secret",
+			},
+			want: map[string]string{
+				"X-Org-Id":         "platform",
+				"header-X-Api-Key": "key123",
+				"notes":            "rotated quarterly",
+			},
+		},
+		{
+			name: "listed keys",
+			config: map[string]interface{}{
+				"headersSecretName": "shared-secret",
+				"headersSecretKeys": []interface{}{"X-Org-Id"},
+			},
+			want: map[string]string{"X-Org-Id": "platform"},
+		},
+		{
+			name: "key prefix",
+			config: map[string]interface{}{
+				"headersSecretName":      "shared-secret",
+				"headersSecretKeyPrefix": "header-",
+			},
+			want: map[string]string{"X-Api-Key": "key123"},
+		},
+		{
+			name: "missing listed key",
+			config: map[string]interface{}{
+				"headersSecretName": "shared-secret",
+				"headersSecretKeys": []string{"X-Missing"},
+			},
+			wantErr: "key X-Missing not found",
+		},
+		{
+			name: "keys and prefix",
+			config: map[string]interface{}{
+				"headersSecretName":      "shared-secret",
+				"headersSecretKeys":      []string{"X-Org-Id"},
+				"headersSecretKeyPrefix": "header-",
+			},
+			wantErr: "only one of keys or keyPrefix",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config["url"] = "https://example.com"
+			tt.config["namespace"] = "default"
+
+			httpConfig, err := generator.parseConfig(context.Background(), tt.config)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if !reflect.DeepEqual(httpConfig.Headers, tt.want) {
+				t.Errorf("Expected headers %v, got %v", tt.want, httpConfig.Headers)
+			}
+		})
+	}
+}
+
+func TestHTTPGenerator_LoadSecretData_Success(t *testing.T) {
+	// Create fake Kubernetes client with secret
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
 
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -354,3 +1031,828 @@ func TestHTTPGenerator_LoadSecretData_KeyNotFound(t *testing.T) {
 		t.Error("Expected error for nonexistent key")
 	}
 }
+
+func TestHTTPGenerator_Generate_AuthSecrets(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "basic", Namespace: "default"},
+				Data: map[string][]byte{
+					"username": []byte("user"),
+					"password": []byte("pass"),
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "bearer", Namespace: "default"},
+				Data:       map[string][]byte{"token": []byte("token123\n")},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "headers", Namespace: "default"},
+				Data:       map[string][]byte{"authorization": []byte("Custom explicit")},
+			},
+		).
+		Build()
+
+	tests := []struct {
+		name     string
+		config   map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "basic auth",
+			config:   map[string]interface{}{"basicAuthSecretName": "basic"},
+			expected: "Basic dXNlcjpwYXNz",
+		},
+		{
+			name:     "bearer token",
+			config:   map[string]interface{}{"bearerTokenSecretName": "bearer"},
+			expected: "Bearer token123",
+		},
+		{
+			name: "explicit header wins",
+			config: map[string]interface{}{
+				"bearerTokenSecretName": "bearer",
+				"headersSecretName":     "headers",
+			},
+			expected: "Custom explicit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r.Header.Get("Authorization")
+				_, _ = w.Write([]byte("ok"))
+			}))
+			defer server.Close()
+
+			tt.config["url"] = server.URL
+			generator := NewHTTPGenerator(fakeClient)
+			_, err := generator.Generate(context.Background(), GeneratorConfig{Type: "http", Config: tt.config})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if received != tt.expected {
+				t.Errorf("Expected Authorization %q, got %q", tt.expected, received)
+			}
+		})
+	}
+}
+
+func TestHTTPGenerator_ParseConfig_ConflictingAuth(t *testing.T) {
+	generator := NewHTTPGenerator(nil)
+
+	_, err := generator.parseConfig(context.Background(), map[string]interface{}{
+		"url":                   "https://example.com",
+		"basicAuthSecretName":   "basic",
+		"bearerTokenSecretName": "bearer",
+	})
+	if err == nil {
+		t.Fatal("Expected error when both basic auth and bearer token are set")
+	}
+	if !strings.Contains(err.Error(), "takes precedence") {
+		t.Errorf("Expected error to document header precedence, got %v", err)
+	}
+}
+
+func TestHTTPGenerator_Generate_Redirects(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "bearer", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte("token123")},
+		}).
+		Build()
+
+	var receivedAuth string
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte("signed content"))
+	}))
+	defer target.Close()
+
+	// Redirects within the same host first, then to the target on another host
+	redirector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/start" {
+			http.Redirect(w, r, "/cdn", http.StatusFound)
+			return
+		}
+		receivedAuth = r.Header.Get("Authorization")
+		http.Redirect(w, r, target.URL+"/signed", http.StatusMovedPermanently)
+	}))
+	defer redirector.Close()
+
+	tests := []struct {
+		name         string
+		config       map[string]interface{}
+		expectAuth   string
+		expectError  string
+		expectedData string
+	}{
+		{
+			name:         "strips authorization on cross-host redirect by default",
+			config:       map[string]interface{}{},
+			expectAuth:   "",
+			expectedData: "signed content",
+		},
+		{
+			name:         "keeps authorization when allowed",
+			config:       map[string]interface{}{"keepAuthorizationOnRedirect": true},
+			expectAuth:   "Bearer token123",
+			expectedData: "signed content",
+		},
+		{
+			name:        "stops after max redirects",
+			config:      map[string]interface{}{"maxRedirects": 1},
+			expectError: "stopped after 1 redirects",
+		},
+		{
+			name:        "redirects disabled",
+			config:      map[string]interface{}{"maxRedirects": 0},
+			expectError: "stopped after 0 redirects",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receivedAuth = "unset"
+			config := map[string]interface{}{
+				"url":                   redirector.URL + "/start",
+				"bearerTokenSecretName": "bearer",
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+
+			generator := NewHTTPGenerator(fakeClient)
+			data, err := generator.Generate(context.Background(), GeneratorConfig{Type: "http", Config: config})
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("Expected error containing %q, got %v", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if string(data.Data) != tt.expectedData {
+				t.Errorf("Expected %q, got %q", tt.expectedData, string(data.Data))
+			}
+			if receivedAuth != tt.expectAuth {
+				t.Errorf("Expected Authorization %q at the target, got %q", tt.expectAuth, receivedAuth)
+			}
+		})
+	}
+}
+
+func TestHTTPGenerator_CheckRedirect_SameHostKeepsAuthorization(t *testing.T) {
+	var receivedAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/start" {
+			http.Redirect(w, r, "/final", http.StatusFound)
+			return
+		}
+		receivedAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	generator := NewHTTPGenerator(nil)
+	httpConfig := &HTTPConfig{MaxRedirects: defaultMaxRedirects}
+	httpClient, err := generator.configureHTTPClient(context.Background(), httpConfig)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/start", nil)
+	req.Header.Set("Authorization", "Bearer same-host")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if receivedAuth != "Bearer same-host" {
+		t.Errorf("Expected Authorization to be kept on same-host redirect, got %q", receivedAuth)
+	}
+}
+
+func TestHTTPGenerator_ParseConfig_InlineHeadersMerge(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "headers", Namespace: "default"},
+			Data: map[string][]byte{
+				"x-api-version": []byte("from-secret"),
+				"X-Api-Key":     []byte("key123"),
+			},
+		}).
+		Build()
+
+	generator := NewHTTPGenerator(fakeClient)
+	httpConfig, err := generator.parseConfig(context.Background(), map[string]interface{}{
+		"url": "https://example.com",
+		"headers": map[string]interface{}{
+			"Accept":        "application/vnd.github+json",
+			"X-API-Version": "inline",
+		},
+		"headersSecretName": "headers",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := map[string]string{
+		"Accept":        "application/vnd.github+json",
+		"x-api-version": "from-secret",
+		"X-Api-Key":     "key123",
+	}
+	if len(httpConfig.Headers) != len(expected) {
+		t.Errorf("Expected headers %v, got %v", expected, httpConfig.Headers)
+	}
+	for k, v := range expected {
+		if httpConfig.Headers[k] != v {
+			t.Errorf("Expected header %s=%q, got %q", k, v, httpConfig.Headers[k])
+		}
+	}
+}
+
+// newClientCertificate returns a self-signed client certificate and key in PEM format
+func newClientCertificate(t *testing.T, commonName string) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestHTTPGenerator_Generate_ClientCertificate(t *testing.T) {
+	clientCert, clientKey := newClientCertificate(t, "externalsource-client")
+	_, otherKey := newClientCertificate(t, "other")
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(clientCert)
+
+	var peer string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		peer = r.TLS.PeerCertificates[0].Subject.CommonName
+		_, _ = w.Write([]byte("ok"))
+	}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "server-ca", Namespace: "default"},
+				Data:       map[string][]byte{"ca.crt": serverCA},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "client-cert", Namespace: "default"},
+				Type:       corev1.SecretTypeTLS,
+				Data:       map[string][]byte{"tls.crt": clientCert, "tls.key": clientKey},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "mismatched", Namespace: "default"},
+				Type:       corev1.SecretTypeTLS,
+				Data:       map[string][]byte{"tls.crt": clientCert, "tls.key": otherKey},
+			},
+		).
+		Build()
+
+	tests := []struct {
+		name        string
+		clientCert  string
+		expectError string
+	}{
+		{
+			name:       "client certificate presented",
+			clientCert: "client-cert",
+		},
+		{
+			name:        "no client certificate",
+			expectError: "certificate required",
+		},
+		{
+			name:        "mismatched key",
+			clientCert:  "mismatched",
+			expectError: "invalid client certificate in secret default/mismatched",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			peer = ""
+			config := map[string]interface{}{
+				"url":                server.URL,
+				"caBundleSecretName": "server-ca",
+			}
+			if tt.clientCert != "" {
+				config["clientCertSecretName"] = tt.clientCert
+			}
+
+			generator := NewHTTPGenerator(fakeClient)
+			_, err := generator.Generate(context.Background(), GeneratorConfig{Type: "http", Config: config})
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("Expected error containing %q, got %v", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if peer != "externalsource-client" {
+				t.Errorf("Expected server to see client certificate, got %q", peer)
+			}
+		})
+	}
+}
+
+func TestHTTPGenerator_Generate_CABundleConfigMap(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "server-ca", Namespace: "default"},
+				Data:       map[string]string{"ca.crt": string(serverCA)},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "binary-ca", Namespace: "default"},
+				BinaryData: map[string][]byte{"bundle.pem": serverCA},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "server-ca", Namespace: "default"},
+				Data:       map[string][]byte{"ca.crt": serverCA},
+			},
+		).
+		Build()
+
+	tests := []struct {
+		name        string
+		config      map[string]interface{}
+		expectError string
+	}{
+		{
+			name: "CA bundle from ConfigMap data",
+			config: map[string]interface{}{
+				"caBundleConfigMapName": "server-ca",
+				"caBundleConfigMapKey":  "ca.crt",
+			},
+		},
+		{
+			name: "CA bundle from ConfigMap binary data",
+			config: map[string]interface{}{
+				"caBundleConfigMapName": "binary-ca",
+				"caBundleConfigMapKey":  "bundle.pem",
+			},
+		},
+		{
+			name: "missing key",
+			config: map[string]interface{}{
+				"caBundleConfigMapName": "server-ca",
+				"caBundleConfigMapKey":  "bundle.pem",
+			},
+			expectError: "key bundle.pem not found in ConfigMap default/server-ca",
+		},
+		{
+			name: "secret and ConfigMap",
+			config: map[string]interface{}{
+				"caBundleSecretName":    "server-ca",
+				"caBundleConfigMapName": "server-ca",
+			},
+			expectError: "only one of caBundleSecretRef or caBundleConfigMapRef may be set",
+		},
+		{
+			name:        "no CA bundle",
+			config:      map[string]interface{}{},
+			expectError: "certificate signed by unknown authority",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config["url"] = server.URL
+
+			generator := NewHTTPGenerator(fakeClient)
+			data, err := generator.Generate(context.Background(), GeneratorConfig{Type: "http", Config: tt.config})
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("Expected error containing %q, got %v", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if string(data.Data) != "ok" {
+				t.Errorf("Expected body %q, got %q", "ok", string(data.Data))
+			}
+		})
+	}
+}
+
+func TestHTTPGenerator_Generate_MaxResponseSize(t *testing.T) {
+	body := strings.Repeat("x", 2048)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/sized" {
+			w.Header().Set("Content-Length", "2048")
+			_, _ = w.Write([]byte(body))
+			return
+		}
+		// Stream in chunks so the body has no Content-Length
+		for i := 0; i < 4; i++ {
+			_, _ = w.Write([]byte(body[:512]))
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		path          string
+		generatorMax  int64
+		config        map[string]interface{}
+		expectError   string
+		expectedBytes int
+	}{
+		{
+			name:         "rejects declared content length over limit",
+			path:         "/sized",
+			generatorMax: 1024,
+			expectError:  "response body of 2048 bytes exceeds maximum size of 1024 bytes",
+		},
+		{
+			name:         "rejects streamed body over limit",
+			path:         "/streamed",
+			generatorMax: 1024,
+			expectError:  "response body exceeds maximum size of 1024 bytes",
+		},
+		{
+			name:          "accepts body at limit",
+			path:          "/streamed",
+			generatorMax:  2048,
+			expectedBytes: 2048,
+		},
+		{
+			name:          "unlimited when zero",
+			path:          "/sized",
+			expectedBytes: 2048,
+		},
+		{
+			name:         "per-source limit overrides generator limit",
+			path:         "/streamed",
+			generatorMax: 4096,
+			config:       map[string]interface{}{"maxResponseSize": int64(100)},
+			expectError:  "exceeds maximum size of 100 bytes",
+		},
+		{
+			name:        "negative per-source limit rejected",
+			path:        "/sized",
+			config:      map[string]interface{}{"maxResponseSize": -1},
+			expectError: "maxResponseSize must be non-negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{"url": server.URL + tt.path}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+
+			generator := NewHTTPGeneratorWithConfig(nil, &HTTPClientConfig{
+				Timeout:         5 * time.Second,
+				UserAgent:       "test",
+				MaxResponseSize: tt.generatorMax,
+			})
+			data, err := generator.Generate(context.Background(), GeneratorConfig{Type: "http", Config: config})
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("Expected error containing %q, got %v", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(data.Data) != tt.expectedBytes {
+				t.Errorf("Expected %d bytes, got %d", tt.expectedBytes, len(data.Data))
+			}
+		})
+	}
+}
+
+func TestHTTPGenerator_Generate_DefaultHeaders(t *testing.T) {
+	var received []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Clone())
+		w.Header().Set("ETag", "v1")
+		_, _ = w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	generator := NewHTTPGeneratorWithConfig(nil, &HTTPClientConfig{
+		Timeout:   10 * time.Second,
+		UserAgent: "test-agent/1.0",
+		DefaultHeaders: map[string]string{
+			"X-Org-Id": "platform",
+			"Accept":   "text/plain",
+		},
+	})
+	config := GeneratorConfig{
+		Type: "http",
+		Config: map[string]interface{}{
+			"url": server.URL,
+			"headers": map[string]string{
+				"accept": "application/json",
+			},
+		},
+	}
+
+	ctx := context.Background()
+	if _, err := generator.Generate(ctx, config); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := generator.GetLastModified(ctx, config); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(received))
+	}
+	for _, header := range received {
+		if header.Get("X-Org-Id") != "platform" {
+			t.Errorf("Expected default X-Org-Id header, got %q", header.Get("X-Org-Id"))
+		}
+		if got := header.Values("Accept"); len(got) != 1 || got[0] != "application/json" {
+			t.Errorf("Expected source Accept header to override the default, got %v", got)
+		}
+		if header.Get("User-Agent") != "test-agent/1.0" {
+			t.Errorf("Expected configured User-Agent, got %q", header.Get("User-Agent"))
+		}
+	}
+}
+
+func TestHTTPGenerator_Generate_Accept(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") == "application/yaml" {
+			w.Header().Set("Content-Type", "application/yaml")
+			_, _ = w.Write([]byte("name: app\n"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"app"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name            string
+		config          map[string]interface{}
+		wantData        string
+		wantContentType string
+	}{
+		{
+			name:            "accept field",
+			config:          map[string]interface{}{"url": server.URL, "accept": "application/yaml"},
+			wantData:        "name: app\n",
+			wantContentType: "application/yaml",
+		},
+		{
+			name: "inline header takes precedence",
+			config: map[string]interface{}{
+				"url":     server.URL,
+				"accept":  "application/yaml",
+				"headers": map[string]string{"accept": "application/json"},
+			},
+			wantData:        `{"name":"app"}`,
+			wantContentType: "application/json",
+		},
+		{
+			name:            "no accept",
+			config:          map[string]interface{}{"url": server.URL},
+			wantData:        `{"name":"app"}`,
+			wantContentType: "application/json",
+		},
+	}
+
+	generator := NewHTTPGenerator(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := generator.Generate(context.Background(), GeneratorConfig{Type: "http", Config: tt.config})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if string(data.Data) != tt.wantData {
+				t.Errorf("Expected data %q, got %q", tt.wantData, data.Data)
+			}
+			if got := data.Metadata["content-type"]; got != tt.wantContentType {
+				t.Errorf("Expected content-type %q, got %q", tt.wantContentType, got)
+			}
+		})
+	}
+}
+
+func TestHTTPGenerator_Generate_DebugLoggingRedactsSecrets(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "basic", Namespace: "default"},
+				Data: map[string][]byte{
+					"username": []byte("user"),
+					"password": []byte("s3cr3t-password"),
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "bearer", Namespace: "default"},
+				Data:       map[string][]byte{"token": []byte("s3cr3t-token")},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "headers", Namespace: "default"},
+				Data:       map[string][]byte{"X-Api-Key": []byte("s3cr3t-api-key")},
+			},
+		).
+		Build()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v2"`)
+		_, _ = w.Write([]byte("payload"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		config map[string]interface{}
+	}{
+		{
+			name:   "basic auth",
+			config: map[string]interface{}{"basicAuthSecretName": "basic"},
+		},
+		{
+			name:   "bearer token",
+			config: map[string]interface{}{"bearerTokenSecretName": "bearer"},
+		},
+		{
+			name:   "headers secret",
+			config: map[string]interface{}{"headersSecretName": "headers"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lines []string
+			logger := funcr.New(func(prefix, args string) {
+				lines = append(lines, args)
+			}, funcr.Options{Verbosity: 1})
+			ctx := logr.NewContext(context.Background(), logger)
+
+			config := map[string]interface{}{
+				"url":     strings.Replace(server.URL, "http://", "http://user:s3cr3t-url-password@", 1),
+				"headers": map[string]interface{}{"X-Trace": "visible"},
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+
+			generator := NewHTTPGenerator(fakeClient)
+			_, err := generator.Generate(ctx, GeneratorConfig{Type: "http", Config: config, LastModified: `"v1"`})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			output := strings.Join(lines, "\n")
+			for _, secret := range []string{"s3cr3t", "dXNlcjpzM2NyM3Qt"} {
+				if strings.Contains(output, secret) {
+					t.Errorf("Expected %q to be redacted from logs:\n%s", secret, output)
+				}
+			}
+			for _, expected := range []string{
+				`"If-None-Match"`, `"status"=200`, `"size"=7`, `"method"="GET"`, `"X-Trace"="visible"`, redactedValue,
+			} {
+				if !strings.Contains(output, expected) {
+					t.Errorf("Expected logs to contain %s:\n%s", expected, output)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPGenerator_Generate_NoDebugLoggingByDefault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("payload"))
+	}))
+	defer server.Close()
+
+	var lines []string
+	logger := funcr.New(func(prefix, args string) {
+		lines = append(lines, args)
+	}, funcr.Options{})
+	ctx := logr.NewContext(context.Background(), logger)
+
+	generator := NewHTTPGenerator(nil)
+	if _, err := generator.Generate(ctx, GeneratorConfig{Type: "http", Config: map[string]interface{}{"url": server.URL}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(lines) != 0 {
+		t.Errorf("Expected no logs at the default verbosity, got %v", lines)
+	}
+}
+
+func TestSourceRequestError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		notFound bool
+	}{
+		{
+			name: "host not found",
+			err: &net.OpError{Op: "dial", Net: "tcp",
+				Err: &net.DNSError{Err: "no such host", Name: "api.exmaple.com", IsNotFound: true}},
+			notFound: true,
+		},
+		{
+			name: "temporary not found",
+			err: &net.OpError{Op: "dial", Net: "tcp",
+				Err: &net.DNSError{Err: "no such host", Name: "api.example.com", IsNotFound: true, IsTemporary: true}},
+		},
+		{
+			name: "resolver timeout",
+			err: &net.OpError{Op: "dial", Net: "tcp",
+				Err: &net.DNSError{Err: "i/o timeout", Name: "api.example.com", IsTimeout: true, IsTemporary: true}},
+		},
+		{
+			name: "connection refused",
+			err:  errors.New("connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := sourceRequestError(tt.err)
+			if IsSourceHostNotFoundError(err) != tt.notFound {
+				t.Errorf("IsSourceHostNotFoundError() = %v, want %v", !tt.notFound, tt.notFound)
+			}
+			if !errors.Is(err, tt.err) || err.Error() != tt.err.Error() {
+				t.Errorf("Expected the original error to be kept, got %v", err)
+			}
+		})
+	}
+}
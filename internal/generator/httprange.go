@@ -0,0 +1,122 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ContentRange describes the part of a resource returned in a 206 Partial Content response
+type ContentRange struct {
+	// Offset is the position of the first returned byte
+	Offset int64 `json:"offset"`
+
+	// Length is the number of returned bytes
+	Length int64 `json:"length"`
+
+	// Size is the complete size of the resource, 0 when the server didn't report it
+	Size int64 `json:"size,omitempty"`
+}
+
+// rangeHeader returns the Range request header value, empty when the whole resource is fetched
+func (c *HTTPConfig) rangeHeader() string {
+	switch {
+	case c.RangeSuffix > 0:
+		return fmt.Sprintf("bytes=-%d", c.RangeSuffix)
+	case c.RangeLength > 0:
+		return fmt.Sprintf("bytes=%d-%d", c.RangeOffset, c.RangeOffset+c.RangeLength-1)
+	case c.RangeOffset > 0:
+		return fmt.Sprintf("bytes=%d-", c.RangeOffset)
+	default:
+		return ""
+	}
+}
+
+// parseContentRange parses a Content-Range header of the form "bytes first-last/size",
+// where the size may be "*" when unknown
+func parseContentRange(value string) (*ContentRange, error) {
+	spec, ok := strings.CutPrefix(strings.TrimSpace(value), "bytes ")
+	if !ok {
+		return nil, fmt.Errorf("invalid Content-Range %q: unsupported unit", value)
+	}
+	span, size, ok := strings.Cut(spec, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid Content-Range %q: missing size", value)
+	}
+	first, last, ok := strings.Cut(span, "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid Content-Range %q: missing range", value)
+	}
+
+	offset, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || offset < 0 {
+		return nil, fmt.Errorf("invalid Content-Range %q: invalid first byte", value)
+	}
+	end, err := strconv.ParseInt(last, 10, 64)
+	if err != nil || end < offset {
+		return nil, fmt.Errorf("invalid Content-Range %q: invalid last byte", value)
+	}
+
+	contentRange := &ContentRange{Offset: offset, Length: end - offset + 1}
+	if size != "*" {
+		contentRange.Size, err = strconv.ParseInt(size, 10, 64)
+		if err != nil || contentRange.Size <= end {
+			return nil, fmt.Errorf("invalid Content-Range %q: invalid size", value)
+		}
+	}
+	return contentRange, nil
+}
+
+// unsatisfiedRangeSize returns the resource size from the Content-Range header of a 416
+// response, of the form "bytes */size"
+func unsatisfiedRangeSize(value string) (int64, bool) {
+	size, ok := strings.CutPrefix(strings.TrimSpace(value), "bytes */")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(size, 10, 64)
+	return n, err == nil && n >= 0
+}
+
+// int64Option returns a non-negative integer option from the generator configuration
+func int64Option(config map[string]interface{}, key string) (int64, error) {
+	var value int64
+	switch v := config[key].(type) {
+	case nil:
+		return 0, nil
+	case int64:
+		value = v
+	case int:
+		value = int64(v)
+	case float64:
+		value = int64(v)
+	default:
+		return 0, fmt.Errorf("%s must be a number", key)
+	}
+	if value < 0 {
+		return 0, fmt.Errorf("%s must be non-negative, got %d", key, value)
+	}
+	return value, nil
+}
@@ -0,0 +1,212 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generator
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHTTPGenerator_Generate_Range(t *testing.T) {
+	content := []byte("line 1\nline 2\nline 3\n")
+	size := int64(len(content))
+
+	var lastRange string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastRange = r.Header.Get("Range")
+		http.ServeContent(w, r, "app.log", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name            string
+		config          map[string]interface{}
+		expectRange     string
+		expectData      string
+		expectContent   *ContentRange
+		expectUnchanged bool
+	}{
+		{
+			name:          "from offset",
+			config:        map[string]interface{}{"rangeOffset": 7},
+			expectRange:   "bytes=7-",
+			expectData:    "line 2\nline 3\n",
+			expectContent: &ContentRange{Offset: 7, Length: size - 7, Size: size},
+		},
+		{
+			name:          "offset and length",
+			config:        map[string]interface{}{"rangeOffset": 7, "rangeLength": 6},
+			expectRange:   "bytes=7-12",
+			expectData:    "line 2",
+			expectContent: &ContentRange{Offset: 7, Length: 6, Size: size},
+		},
+		{
+			name:          "suffix",
+			config:        map[string]interface{}{"rangeSuffix": 7},
+			expectRange:   "bytes=-7",
+			expectData:    "line 3\n",
+			expectContent: &ContentRange{Offset: size - 7, Length: 7, Size: size},
+		},
+		{
+			name:            "offset at the end",
+			config:          map[string]interface{}{"rangeOffset": size},
+			expectUnchanged: true,
+		},
+		{
+			name:       "offset beyond the end falls back to the full content",
+			config:     map[string]interface{}{"rangeOffset": size + 10},
+			expectData: string(content),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config["url"] = server.URL
+			data, err := NewHTTPGenerator(nil).Generate(context.Background(), GeneratorConfig{Type: "http", Config: tt.config})
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			if tt.expectUnchanged {
+				if !data.NotModified {
+					t.Errorf("Generate() NotModified = false, want true")
+				}
+				return
+			}
+			if lastRange != tt.expectRange {
+				t.Errorf("Range header = %q, want %q", lastRange, tt.expectRange)
+			}
+			if string(data.Data) != tt.expectData {
+				t.Errorf("Generate() data = %q, want %q", data.Data, tt.expectData)
+			}
+			if !reflect.DeepEqual(data.Range, tt.expectContent) {
+				t.Errorf("Generate() range = %+v, want %+v", data.Range, tt.expectContent)
+			}
+		})
+	}
+}
+
+func TestHTTPGenerator_Generate_RangeFallbackUnconditional(t *testing.T) {
+	content := []byte("rotated log\n")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "" {
+			w.Header().Set("Content-Range", "bytes */12")
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	// The resource shrank below the offset, the full content must be fetched even though
+	// the version sent with the range request didn't change
+	data, err := NewHTTPGenerator(nil).Generate(context.Background(), GeneratorConfig{
+		Type:         "http",
+		Config:       map[string]interface{}{"url": server.URL, "rangeOffset": 100},
+		LastModified: `"v1"`,
+	})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if data.NotModified || string(data.Data) != string(content) {
+		t.Errorf("Generate() = %q (not modified %v), want the full content", data.Data, data.NotModified)
+	}
+}
+
+func TestHTTPGenerator_Generate_RangeIgnored(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "bytes=5-" {
+			t.Errorf("Range header = %q, want bytes=5-", r.Header.Get("Range"))
+		}
+		_, _ = w.Write([]byte("full content"))
+	}))
+	defer server.Close()
+
+	data, err := NewHTTPGenerator(nil).Generate(context.Background(), GeneratorConfig{
+		Type:   "http",
+		Config: map[string]interface{}{"url": server.URL, "rangeOffset": 5},
+	})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if string(data.Data) != "full content" || data.Range != nil {
+		t.Errorf("Generate() = %q with range %+v, want the full content without range", data.Data, data.Range)
+	}
+}
+
+func TestHTTPGenerator_ParseConfig_Range(t *testing.T) {
+	generator := NewHTTPGenerator(nil)
+
+	for _, invalid := range []map[string]interface{}{
+		{"url": "https://example.com", "rangeOffset": -1},
+		{"url": "https://example.com", "rangeSuffix": "10"},
+		{"url": "https://example.com", "rangeOffset": 10, "method": "POST"},
+	} {
+		if _, err := generator.parseConfig(context.Background(), invalid); err == nil {
+			t.Errorf("parseConfig(%v) expected error", invalid)
+		}
+	}
+}
+
+func TestParseContentRange(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    *ContentRange
+		wantErr bool
+	}{
+		{value: "bytes 0-99/1000", want: &ContentRange{Offset: 0, Length: 100, Size: 1000}},
+		{value: "bytes 900-999/*", want: &ContentRange{Offset: 900, Length: 100}},
+		{value: "", wantErr: true},
+		{value: "items 0-9/10", wantErr: true},
+		{value: "bytes 10-5/100", wantErr: true},
+		{value: "bytes 0-99/50", wantErr: true},
+		{value: "bytes */100", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseContentRange(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseContentRange(%q) expected error", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseContentRange(%q) error = %v", tt.value, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseContentRange(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ package generator
 
 import (
 	"context"
+	"time"
 )
 
 // SourceGenerator defines the interface for all source generators
@@ -44,13 +45,54 @@ type SourceGenerator interface {
 type GeneratorConfig struct {
 	Type   string                 `json:"type"`
 	Config map[string]interface{} `json:"config"`
+
+	// LastModified is the identifier from the last successful fetch, used for conditional requests
+	LastModified string `json:"lastModified,omitempty"`
+
+	// RequestSigner, when set, is called for every outgoing HTTP request and the headers it
+	// returns are set on the request before it is sent
+	RequestSigner RequestSigner `json:"-"`
+}
+
+// RequestMetadata describes an outgoing HTTP request passed to a RequestSigner
+type RequestMetadata struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
 }
 
+// RequestSigner returns the headers to set on an outgoing request, for example a
+// signature computed over the method, URL and headers
+type RequestSigner func(ctx context.Context, request RequestMetadata) (map[string]string, error)
+
 // SourceData represents data fetched from an external source
 type SourceData struct {
 	Data         []byte            `json:"data"`
 	LastModified string            `json:"lastModified"`
 	Metadata     map[string]string `json:"metadata"`
+
+	// NotModified is set when the source reports no changes since GeneratorConfig.LastModified
+	NotModified bool `json:"notModified,omitempty"`
+
+	// FreshUntil is when the source considers the data stale, zero when it doesn't say.
+	// Sources that honor it aren't fetched again before then.
+	FreshUntil time.Time `json:"freshUntil,omitzero"`
+
+	// Range is the part of the resource in Data when only part of it was fetched, nil
+	// when Data holds the complete resource
+	Range *ContentRange `json:"range,omitempty"`
+
+	// Cursor is the value to send with the next request, extracted from the response.
+	// It is empty when no cursor is configured or the response holds none.
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// ConditionalRequestGenerator is implemented by generators that perform conditional
+// requests within Generate, reporting unchanged sources through SourceData.NotModified
+// instead of requiring a separate GetLastModified round trip
+type ConditionalRequestGenerator interface {
+	// SupportsConditionalRequest returns true if Generate honors GeneratorConfig.LastModified
+	SupportsConditionalRequest() bool
 }
 
 // SourceGeneratorFactory creates source generators based on type
@@ -0,0 +1,209 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// kindConfigMap reads the source data from a ConfigMap
+	kindConfigMap = "ConfigMap"
+
+	// kindSecret reads the source data from a Secret
+	kindSecret = "Secret"
+
+	// SecretExportAnnotation must be set to "true" on a Secret before the secret generator
+	// publishes its data. Artifacts are served without authentication, so the owner of the
+	// Secret has to agree to its contents leaving the namespace.
+	SecretExportAnnotation = "source.flux.oddkin.co/export"
+)
+
+// SecretNotExportedError is returned when the secret generator reads a Secret that isn't
+// annotated with SecretExportAnnotation
+type SecretNotExportedError struct {
+	Namespace string
+	Name      string
+}
+
+// Error implements the error interface
+func (e *SecretNotExportedError) Error() string {
+	return fmt.Sprintf("Secret %s/%s is not exported, annotate it with %s=true to publish its data",
+		e.Namespace, e.Name, SecretExportAnnotation)
+}
+
+// IsSecretNotExportedError returns true if the error is or wraps a SecretNotExportedError
+func IsSecretNotExportedError(err error) bool {
+	var exportErr *SecretNotExportedError
+	return errors.As(err, &exportErr)
+}
+
+// IsSecretExported returns true if the Secret opted in to being published by the secret generator
+func IsSecretExported(secret *corev1.Secret) bool {
+	return secret.GetAnnotations()[SecretExportAnnotation] == "true"
+}
+
+// KubernetesGenerator implements SourceGenerator for a key of a ConfigMap or Secret in the
+// namespace of the source
+type KubernetesGenerator struct {
+	client client.Client
+
+	// kind is the kind of object the key is read from, ConfigMap or Secret
+	kind string
+}
+
+// KubernetesConfig holds ConfigMap and Secret specific configuration
+type KubernetesConfig struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Key       string `json:"key"`
+}
+
+// NewConfigMapGenerator creates a generator reading a key of a ConfigMap
+func NewConfigMapGenerator(k8sClient client.Client) *KubernetesGenerator {
+	return &KubernetesGenerator{
+		client: k8sClient,
+		kind:   kindConfigMap,
+	}
+}
+
+// NewSecretGenerator creates a generator reading a key of a Secret
+func NewSecretGenerator(k8sClient client.Client) *KubernetesGenerator {
+	return &KubernetesGenerator{
+		client: k8sClient,
+		kind:   kindSecret,
+	}
+}
+
+// Generate reads the configured key of the object
+func (g *KubernetesGenerator) Generate(ctx context.Context, config GeneratorConfig) (*SourceData, error) {
+	kubernetesConfig, err := g.parseConfig(config.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s config: %w", g.kind, err)
+	}
+
+	obj, err := g.getObject(ctx, kubernetesConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	var found bool
+	switch o := obj.(type) {
+	case *corev1.ConfigMap:
+		var value string
+		if value, found = o.Data[kubernetesConfig.Key]; found {
+			data = []byte(value)
+		} else {
+			data, found = o.BinaryData[kubernetesConfig.Key]
+		}
+	case *corev1.Secret:
+		if !IsSecretExported(o) {
+			return nil, &SecretNotExportedError{Namespace: o.Namespace, Name: o.Name}
+		}
+		data, found = o.Data[kubernetesConfig.Key]
+	}
+	if !found {
+		return nil, fmt.Errorf("key %s not found in %s %s/%s", kubernetesConfig.Key, g.kind,
+			kubernetesConfig.Namespace, kubernetesConfig.Name)
+	}
+
+	return &SourceData{
+		Data:         data,
+		LastModified: obj.GetResourceVersion(),
+		Metadata: map[string]string{
+			"kind": g.kind,
+			"name": kubernetesConfig.Name,
+			"key":  kubernetesConfig.Key,
+			"size": strconv.Itoa(len(data)),
+		},
+	}, nil
+}
+
+// SupportsConditionalFetch returns true as the resource version changes with every update
+func (g *KubernetesGenerator) SupportsConditionalFetch() bool {
+	return true
+}
+
+// GetLastModified returns the resource version of the object. It changes with any update
+// of the object, including updates of other keys.
+func (g *KubernetesGenerator) GetLastModified(ctx context.Context, config GeneratorConfig) (string, error) {
+	kubernetesConfig, err := g.parseConfig(config.Config)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s config: %w", g.kind, err)
+	}
+
+	obj, err := g.getObject(ctx, kubernetesConfig)
+	if err != nil {
+		return "", err
+	}
+
+	return obj.GetResourceVersion(), nil
+}
+
+// getObject fetches the configured ConfigMap or Secret
+func (g *KubernetesGenerator) getObject(ctx context.Context, kubernetesConfig *KubernetesConfig) (client.Object, error) {
+	var obj client.Object = &corev1.ConfigMap{}
+	if g.kind == kindSecret {
+		obj = &corev1.Secret{}
+	}
+
+	key := client.ObjectKey{Namespace: kubernetesConfig.Namespace, Name: kubernetesConfig.Name}
+	if err := g.client.Get(ctx, key, obj); err != nil {
+		return nil, fmt.Errorf("failed to get %s %s/%s: %w", g.kind, kubernetesConfig.Namespace,
+			kubernetesConfig.Name, err)
+	}
+
+	return obj, nil
+}
+
+// parseConfig converts the generic config map to KubernetesConfig
+func (g *KubernetesGenerator) parseConfig(config map[string]interface{}) (*KubernetesConfig, error) {
+	kubernetesConfig := &KubernetesConfig{}
+
+	if name, ok := config["name"].(string); ok && name != "" {
+		kubernetesConfig.Name = name
+	} else {
+		return nil, fmt.Errorf("name is required and must be a string")
+	}
+
+	if key, ok := config["key"].(string); ok && key != "" {
+		kubernetesConfig.Key = key
+	} else {
+		return nil, fmt.Errorf("key is required and must be a string")
+	}
+
+	if namespace, ok := config["namespace"].(string); ok && namespace != "" {
+		kubernetesConfig.Namespace = namespace
+	} else {
+		kubernetesConfig.Namespace = "default"
+	}
+
+	return kubernetesConfig, nil
+}
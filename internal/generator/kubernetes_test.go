@@ -0,0 +1,172 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestKubernetesGenerator_Generate(t *testing.T) {
+	k8sClient := newFakeClient(t,
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "team-a"},
+			Data:       map[string]string{"config.json": `{"replicas":3}`},
+			BinaryData: map[string][]byte{"logo.png": {0x89, 0x50}},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "settings",
+				Namespace:   "team-a",
+				Annotations: map[string]string{SecretExportAnnotation: "true"},
+			},
+			Data: map[string][]byte{"values.yaml": []byte("token: s3cr3t\n")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "team-a"},
+			Data:       map[string][]byte{"password": []byte("s3cr3t")},
+		},
+	)
+
+	tests := []struct {
+		name        string
+		generator   *KubernetesGenerator
+		config      map[string]interface{}
+		expected    string
+		expectError string
+	}{
+		{
+			name:      "configmap data",
+			generator: NewConfigMapGenerator(k8sClient),
+			config:    map[string]interface{}{"namespace": "team-a", "name": "settings", "key": "config.json"},
+			expected:  `{"replicas":3}`,
+		},
+		{
+			name:      "configmap binary data",
+			generator: NewConfigMapGenerator(k8sClient),
+			config:    map[string]interface{}{"namespace": "team-a", "name": "settings", "key": "logo.png"},
+			expected:  "\x89\x50",
+		},
+		{
+			name:      "secret data",
+			generator: NewSecretGenerator(k8sClient),
+			config:    map[string]interface{}{"namespace": "team-a", "name": "settings", "key": "values.yaml"},
+			expected:  "token: s3cr3t\n",
+		},
+		{
+			name:        "missing key",
+			generator:   NewSecretGenerator(k8sClient),
+			config:      map[string]interface{}{"namespace": "team-a", "name": "settings", "key": "config.json"},
+			expectError: "key config.json not found in Secret team-a/settings",
+		},
+		{
+			name:        "secret not exported",
+			generator:   NewSecretGenerator(k8sClient),
+			config:      map[string]interface{}{"namespace": "team-a", "name": "credentials", "key": "password"},
+			expectError: "Secret team-a/credentials is not exported",
+		},
+		{
+			name:        "missing object",
+			generator:   NewConfigMapGenerator(k8sClient),
+			config:      map[string]interface{}{"namespace": "team-b", "name": "settings", "key": "config.json"},
+			expectError: "failed to get ConfigMap team-b/settings",
+		},
+		{
+			name:        "missing name",
+			generator:   NewConfigMapGenerator(k8sClient),
+			config:      map[string]interface{}{"namespace": "team-a", "key": "config.json"},
+			expectError: "name is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.generator.Generate(context.Background(), GeneratorConfig{Config: tt.config})
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("Expected error containing %q, got %v", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if string(data.Data) != tt.expected {
+				t.Errorf("Expected data %q, got %q", tt.expected, string(data.Data))
+			}
+			if data.LastModified == "" {
+				t.Error("Expected the resource version as last modified")
+			}
+		})
+	}
+}
+
+func TestKubernetesGenerator_GetLastModified(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"},
+		Data:       map[string]string{"config.json": `{"replicas":3}`},
+	}
+	k8sClient := newFakeClient(t, configMap)
+	generator := NewConfigMapGenerator(k8sClient)
+	config := GeneratorConfig{Config: map[string]interface{}{"name": "settings", "key": "config.json"}}
+
+	if !generator.SupportsConditionalFetch() {
+		t.Error("Expected the ConfigMap generator to support conditional fetch")
+	}
+
+	before, err := generator.GetLastModified(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, err := generator.Generate(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if data.LastModified != before {
+		t.Errorf("Expected Generate to report resource version %q, got %q", before, data.LastModified)
+	}
+
+	// Updating the ConfigMap changes its resource version
+	updated := &corev1.ConfigMap{}
+	if err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(configMap), updated); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	updated.Data["config.json"] = `{"replicas":5}`
+	if err := k8sClient.Update(context.Background(), updated); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	after, err := generator.GetLastModified(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if after == before {
+		t.Errorf("Expected the resource version to change after an update, got %q", after)
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// oauth2TokenExpiryDelta is how long before its expiry a cached token is refreshed, so it
+// doesn't expire while a request using it is in flight
+const oauth2TokenExpiryDelta = 1 * time.Minute
+
+// OAuth2TokenError is returned when a token cannot be obtained from an OAuth2 token endpoint
+type OAuth2TokenError struct {
+	TokenURL string
+	Err      error
+}
+
+// Error implements the error interface
+func (e *OAuth2TokenError) Error() string {
+	return fmt.Sprintf("failed to obtain OAuth2 token from %s: %v", e.TokenURL, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *OAuth2TokenError) Unwrap() error {
+	return e.Err
+}
+
+// IsOAuth2TokenError reports whether err was caused by a failed token request. The token
+// endpoint is expected to recover, so these errors are retried even when it rejected the request.
+func IsOAuth2TokenError(err error) bool {
+	var tokenErr *OAuth2TokenError
+	return errors.As(err, &tokenErr)
+}
+
+// OAuth2TokenCache shares client credentials tokens across sources. Tokens are keyed by token
+// URL, client ID and scopes, and refreshed shortly before they expire.
+type OAuth2TokenCache struct {
+	timeout time.Duration
+
+	mutex   sync.Mutex
+	sources map[string]*cachedTokenSource
+}
+
+// cachedTokenSource holds the reusable token source of a client and the secret it was created with
+type cachedTokenSource struct {
+	clientSecret string
+	source       *clientCredentialsSource
+	tokenSource  oauth2.TokenSource
+}
+
+// NewOAuth2TokenCache creates a token cache whose token requests time out after timeout
+func NewOAuth2TokenCache(timeout time.Duration) *OAuth2TokenCache {
+	return &OAuth2TokenCache{
+		timeout: timeout,
+		sources: make(map[string]*cachedTokenSource),
+	}
+}
+
+// Token returns a valid access token for the client, requesting a new one when there is no
+// cached token or it is about to expire. Token requests are sent with transport, so they use
+// the TLS settings of the source such as a custom CA bundle, or the default transport when nil.
+func (c *OAuth2TokenCache) Token(transport http.RoundTripper, tokenURL, clientID, clientSecret string, scopes []string) (*oauth2.Token, error) {
+	scopes = slices.Sorted(slices.Values(scopes))
+	key := strings.Join([]string{tokenURL, clientID, strings.Join(scopes, " ")}, "\x00")
+
+	c.mutex.Lock()
+	cached, exists := c.sources[key]
+	// A rotated client secret invalidates the cached token source
+	if !exists || cached.clientSecret != clientSecret {
+		source := &clientCredentialsSource{
+			timeout: c.timeout,
+			config: &clientcredentials.Config{
+				ClientID:     clientID,
+				ClientSecret: clientSecret,
+				TokenURL:     tokenURL,
+				Scopes:       scopes,
+			},
+		}
+		cached = &cachedTokenSource{
+			clientSecret: clientSecret,
+			source:       source,
+			tokenSource:  oauth2.ReuseTokenSourceWithExpiry(nil, source, oauth2TokenExpiryDelta),
+		}
+		c.sources[key] = cached
+	}
+	c.mutex.Unlock()
+
+	// Refresh the token with the current TLS settings, e.g. after a CA bundle rotation
+	cached.source.setTransport(transport)
+
+	token, err := cached.tokenSource.Token()
+	if err != nil {
+		return nil, &OAuth2TokenError{TokenURL: tokenURL, Err: err}
+	}
+	return token, nil
+}
+
+// clientCredentialsSource requests a new token from the token endpoint on every call
+type clientCredentialsSource struct {
+	config  *clientcredentials.Config
+	timeout time.Duration
+
+	mutex     sync.Mutex
+	transport http.RoundTripper
+}
+
+// setTransport sets the transport the next token requests are sent with
+func (s *clientCredentialsSource) setTransport(transport http.RoundTripper) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.transport = transport
+}
+
+// Token implements oauth2.TokenSource
+func (s *clientCredentialsSource) Token() (*oauth2.Token, error) {
+	s.mutex.Lock()
+	httpClient := &http.Client{Transport: s.transport, Timeout: s.timeout}
+	s.mutex.Unlock()
+
+	// The transport is created for a single fetch, don't keep its connections open
+	if httpClient.Transport != nil {
+		defer httpClient.CloseIdleConnections()
+	}
+
+	// Token requests outlive the reconciliation that triggered them, as the token is shared
+	return s.config.Token(context.WithValue(context.Background(), oauth2.HTTPClient, httpClient))
+}
@@ -0,0 +1,230 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newTokenServer returns a token endpoint that issues numbered tokens valid for expiresIn seconds
+func newTokenServer(t *testing.T, expiresIn int, status *atomic.Int32) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var issued atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if code := status.Load(); code != 0 {
+			w.WriteHeader(int(code))
+			return
+		}
+		clientID, clientSecret, ok := r.BasicAuth()
+		if !ok || clientID != "client" || clientSecret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if scope := r.PostForm.Get("scope"); scope != "config.read config.write" {
+			t.Errorf("scope = %q, want %q", scope, "config.read config.write")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "token-" + strconv.Itoa(int(issued.Add(1))),
+			"token_type":   "bearer",
+			"expires_in":   expiresIn,
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server, &issued
+}
+
+func TestHTTPGenerator_OAuth2(t *testing.T) {
+	var tokenStatus atomic.Int32
+	tokenServer, issued := newTokenServer(t, 3600, &tokenStatus)
+
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "oauth", Namespace: "default"},
+			Data: map[string][]byte{
+				"clientID":     []byte("client"),
+				"clientSecret": []byte("secret\n"),
+			},
+		}).
+		Build()
+
+	config := GeneratorConfig{
+		Type: "http",
+		Config: map[string]interface{}{
+			"url":              server.URL,
+			"oauth2TokenURL":   tokenServer.URL,
+			"oauth2SecretName": "oauth",
+			"oauth2Scopes":     []string{"config.write", "config.read"},
+		},
+	}
+
+	// Generators sharing a token cache reuse the token until it is about to expire
+	tokenCache := NewOAuth2TokenCache(5 * time.Second)
+	for i := 0; i < 2; i++ {
+		generator := NewHTTPGeneratorWithConfig(fakeClient, &HTTPClientConfig{
+			Timeout:          5 * time.Second,
+			OAuth2TokenCache: tokenCache,
+		})
+		if _, err := generator.Generate(context.Background(), config); err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		if received != "Bearer token-1" {
+			t.Errorf("Authorization = %q, want %q", received, "Bearer token-1")
+		}
+	}
+	if got := issued.Load(); got != 1 {
+		t.Errorf("tokens issued = %d, want 1", got)
+	}
+
+	// Token endpoint failures are reported as token errors
+	tokenStatus.Store(http.StatusUnauthorized)
+	generator := NewHTTPGenerator(fakeClient)
+	_, err := generator.Generate(context.Background(), config)
+	if !IsOAuth2TokenError(err) {
+		t.Errorf("Generate() error = %v, want an OAuth2 token error", err)
+	}
+}
+
+func TestHTTPGenerator_OAuth2CABundle(t *testing.T) {
+	// The token endpoint and the source are served with a certificate only trusted through the CA bundle
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": "private-token",
+				"token_type":   "bearer",
+				"expires_in":   3600,
+			})
+			return
+		}
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer server.Close()
+
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "oauth", Namespace: "default"},
+				Data: map[string][]byte{
+					"clientID":     []byte("client"),
+					"clientSecret": []byte("secret"),
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "server-ca", Namespace: "default"},
+				Data:       map[string][]byte{"ca.crt": serverCA},
+			},
+		).
+		Build()
+
+	generator := NewHTTPGenerator(fakeClient)
+	data, err := generator.Generate(context.Background(), GeneratorConfig{
+		Type: "http",
+		Config: map[string]interface{}{
+			"url":                server.URL + "/config.json",
+			"oauth2TokenURL":     server.URL + "/token",
+			"oauth2SecretName":   "oauth",
+			"caBundleSecretName": "server-ca",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if string(data.Data) != "Bearer private-token" {
+		t.Errorf("Authorization = %q, want %q", string(data.Data), "Bearer private-token")
+	}
+}
+
+func TestOAuth2TokenCache_RefreshesBeforeExpiry(t *testing.T) {
+	var tokenStatus atomic.Int32
+	// Tokens expiring within the refresh window are requested again on every use
+	tokenServer, issued := newTokenServer(t, 30, &tokenStatus)
+	tokenCache := NewOAuth2TokenCache(5 * time.Second)
+	scopes := []string{"config.read", "config.write"}
+
+	first, err := tokenCache.Token(nil, tokenServer.URL, "client", "secret", scopes)
+	if err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	second, err := tokenCache.Token(nil, tokenServer.URL, "client", "secret", scopes)
+	if err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+
+	if first.AccessToken == second.AccessToken {
+		t.Errorf("expected a token close to expiry to be refreshed, got %q twice", first.AccessToken)
+	}
+	if got := issued.Load(); got != 2 {
+		t.Errorf("tokens issued = %d, want 2", got)
+	}
+}
+
+func TestOAuth2TokenCache_SecretRotation(t *testing.T) {
+	var tokenStatus atomic.Int32
+	tokenServer, _ := newTokenServer(t, 3600, &tokenStatus)
+	tokenCache := NewOAuth2TokenCache(5 * time.Second)
+	scopes := []string{"config.read", "config.write"}
+
+	if _, err := tokenCache.Token(nil, tokenServer.URL, "client", "secret", scopes); err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+
+	// A changed client secret is not served the token cached for the previous secret
+	_, err := tokenCache.Token(nil, tokenServer.URL, "client", "rotated", scopes)
+	if !IsOAuth2TokenError(err) {
+		t.Errorf("Token() with rotated secret error = %v, want an OAuth2 token error", err)
+	}
+}
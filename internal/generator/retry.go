@@ -0,0 +1,102 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generator
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// defaultRetries is how many times a failed GET or HEAD request is retried within a fetch
+	defaultRetries = 2
+
+	// defaultRetryBackoff is the delay before the first retry, doubled on each further retry
+	defaultRetryBackoff = 200 * time.Millisecond
+
+	// maxRetryDrain bounds how much of a failed response body is read so the connection can be reused
+	maxRetryDrain = 64 * 1024
+)
+
+// retryTransport retries idempotent requests that fail with a connection error or a 5xx
+// response, so a short network blip doesn't fail the whole reconcile. Client errors and
+// requests short-circuited by the circuit breaker are never retried.
+type retryTransport struct {
+	retries int
+	backoff time.Duration
+	next    http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isIdempotentRequest(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	backoff := t.backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if attempt >= t.retries || !isRetryable(resp, err) {
+			return resp, err
+		}
+
+		if err != nil {
+			logf.FromContext(req.Context()).V(1).Info("HTTP request failed, retrying",
+				"url", req.URL.Redacted(), "attempt", attempt+1, "backoff", backoff, "error", err.Error())
+		} else {
+			logf.FromContext(req.Context()).V(1).Info("HTTP request failed, retrying",
+				"url", req.URL.Redacted(), "attempt", attempt+1, "backoff", backoff, "status", resp.StatusCode)
+			_, _ = io.CopyN(io.Discard, resp.Body, maxRetryDrain)
+			_ = resp.Body.Close()
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// isIdempotentRequest reports whether a request can be sent again without side effects
+func isIdempotentRequest(req *http.Request) bool {
+	return (req.Method == http.MethodGet || req.Method == http.MethodHead) &&
+		(req.Body == nil || req.Body == http.NoBody)
+}
+
+// isRetryable reports whether a round trip failed in a way another attempt may fix
+func isRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, ErrCircuitOpen) && !errors.Is(err, context.Canceled) &&
+			!errors.Is(err, context.DeadlineExceeded)
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,202 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHTTPGenerator_Generate_RetriesTransientFailures(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) <= 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("ETag", "v1")
+		_, _ = w.Write([]byte(`{"message": "test data"}`))
+	}))
+	defer server.Close()
+
+	data, err := NewHTTPGenerator(nil).Generate(context.Background(), GeneratorConfig{
+		Type:   "http",
+		Config: map[string]interface{}{"url": server.URL, "retryBackoff": "1ms"},
+	})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if string(data.Data) != `{"message": "test data"}` || data.LastModified != "v1" {
+		t.Errorf("Generate() = %q with version %q, want the data of the successful attempt", data.Data, data.LastModified)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("server requests = %d, want 3", got)
+	}
+}
+
+func TestHTTPGenerator_Generate_RetriesExhausted(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := NewHTTPGenerator(nil).Generate(context.Background(), GeneratorConfig{
+		Type:   "http",
+		Config: map[string]interface{}{"url": server.URL, "retries": 1, "retryBackoff": "1ms"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Fatalf("Generate() error = %v, want HTTP 503 error", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("server requests = %d, want 2", got)
+	}
+}
+
+func TestHTTPGenerator_Generate_NoRetryOnClientError(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := NewHTTPGenerator(nil).Generate(context.Background(), GeneratorConfig{
+		Type:   "http",
+		Config: map[string]interface{}{"url": server.URL, "retryBackoff": "1ms"},
+	})
+	if err == nil {
+		t.Fatal("Generate() expected error for HTTP 404 response")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("server requests = %d, want 1", got)
+	}
+}
+
+func TestHTTPGenerator_Generate_NoRetryForPost(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	_, err := NewHTTPGenerator(nil).Generate(context.Background(), GeneratorConfig{
+		Type: "http",
+		Config: map[string]interface{}{
+			"url":          server.URL,
+			"method":       "POST",
+			"body":         `{"query": "config"}`,
+			"retryBackoff": "1ms",
+		},
+	})
+	if err == nil {
+		t.Fatal("Generate() expected error for HTTP 500 response")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("server requests = %d, want 1", got)
+	}
+}
+
+func TestHTTPGenerator_GetLastModified_Retries(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("ETag", "v2")
+	}))
+	defer server.Close()
+
+	version, err := NewHTTPGenerator(nil).GetLastModified(context.Background(), GeneratorConfig{
+		Type:   "http",
+		Config: map[string]interface{}{"url": server.URL, "retryBackoff": "1ms"},
+	})
+	if err != nil {
+		t.Fatalf("GetLastModified() error = %v", err)
+	}
+	if version != "v2" {
+		t.Errorf("GetLastModified() = %q, want v2", version)
+	}
+}
+
+func TestRetryTransport_StopsWhenCircuitOpens(t *testing.T) {
+	var attempts int
+	transport := &retryTransport{
+		retries: 3,
+		backoff: time.Millisecond,
+		next: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			attempts++
+			return nil, ErrCircuitOpen
+		}),
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "https://api.example.com/config", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	if _, err := transport.RoundTrip(req); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("RoundTrip() error = %v, want ErrCircuitOpen", err)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestHTTPGenerator_ParseConfig_Retries(t *testing.T) {
+	generator := NewHTTPGenerator(nil)
+
+	config, err := generator.parseConfig(context.Background(), map[string]interface{}{"url": "https://example.com"})
+	if err != nil {
+		t.Fatalf("parseConfig() error = %v", err)
+	}
+	if config.Retries != defaultRetries || config.RetryBackoff != defaultRetryBackoff {
+		t.Errorf("parseConfig() retries = %d, backoff = %s, want the defaults", config.Retries, config.RetryBackoff)
+	}
+
+	for _, invalid := range []map[string]interface{}{
+		{"url": "https://example.com", "retries": -1},
+		{"url": "https://example.com", "retries": "3"},
+		{"url": "https://example.com", "retryBackoff": "soon"},
+		{"url": "https://example.com", "retryBackoff": "0s"},
+	} {
+		if _, err := generator.parseConfig(context.Background(), invalid); err == nil {
+			t.Errorf("parseConfig(%v) expected error", invalid)
+		}
+	}
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,238 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oddkinco/flux-externalsource-controller/internal/sigv4"
+)
+
+const (
+	// s3AccessKeySecretKey and s3SecretKeySecretKey are the credential keys read from
+	// the referenced secret, matching the Flux Bucket source conventions
+	s3AccessKeySecretKey = "accesskey"
+	s3SecretKeySecretKey = "secretkey"
+)
+
+// S3Generator implements SourceGenerator for objects in S3-compatible buckets
+type S3Generator struct {
+	client     client.Client
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// S3Config holds S3-specific configuration
+type S3Config struct {
+	Endpoint string `json:"endpoint"`
+	Bucket   string `json:"bucket"`
+	Key      string `json:"key"`
+	Region   string `json:"region"`
+
+	signer *sigv4.Signer
+}
+
+// NewS3Generator creates a new S3 generator
+func NewS3Generator(k8sClient client.Client) *S3Generator {
+	return &S3Generator{
+		client: k8sClient,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		now: time.Now,
+	}
+}
+
+// Generate downloads the configured object and returns its contents
+func (g *S3Generator) Generate(ctx context.Context, config GeneratorConfig) (*SourceData, error) {
+	s3Config, err := g.parseConfig(ctx, config.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse S3 config: %w", err)
+	}
+
+	resp, err := g.do(ctx, http.MethodGet, s3Config)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object s3://%s/%s: %w", s3Config.Bucket, s3Config.Key, err)
+	}
+
+	etag := resp.Header.Get("ETag")
+
+	return &SourceData{
+		Data:         data,
+		LastModified: etag,
+		Metadata: map[string]string{
+			"bucket":       s3Config.Bucket,
+			"key":          s3Config.Key,
+			"etag":         etag,
+			"content-type": resp.Header.Get("Content-Type"),
+		},
+	}, nil
+}
+
+// SupportsConditionalFetch returns true as the object ETag identifies the content
+func (g *S3Generator) SupportsConditionalFetch() bool {
+	return true
+}
+
+// GetLastModified returns the ETag of the configured object from a HEAD request
+func (g *S3Generator) GetLastModified(ctx context.Context, config GeneratorConfig) (string, error) {
+	s3Config, err := g.parseConfig(ctx, config.Config)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse S3 config: %w", err)
+	}
+
+	resp, err := g.do(ctx, http.MethodHead, s3Config)
+	if err != nil {
+		return "", err
+	}
+	_ = resp.Body.Close()
+
+	return resp.Header.Get("ETag"), nil
+}
+
+// do sends a request for the configured object, signing it when credentials are
+// configured, and returns the response if the status is successful
+func (g *S3Generator) do(ctx context.Context, method string, s3Config *S3Config) (*http.Response, error) {
+	objectURL := s3Config.objectURL()
+
+	req, err := http.NewRequestWithContext(ctx, method, objectURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if s3Config.signer != nil {
+		s3Config.signer.Sign(req, sigv4.HashSHA256(nil), g.now())
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request object s3://%s/%s: %w", s3Config.Bucket, s3Config.Key, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("S3 request for s3://%s/%s failed with status %d", s3Config.Bucket, s3Config.Key, resp.StatusCode)
+	}
+
+	return resp, nil
+}
+
+// objectURL returns the path-style URL of the configured object
+func (c *S3Config) objectURL() *url.URL {
+	u, _ := url.Parse(c.Endpoint)
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + c.Bucket + "/" + c.Key
+	u.RawPath = ""
+	u.RawQuery = ""
+	return u
+}
+
+// parseConfig converts the generic config map to S3Config
+func (g *S3Generator) parseConfig(ctx context.Context, config map[string]interface{}) (*S3Config, error) {
+	s3Config := &S3Config{}
+
+	endpoint, ok := config["endpoint"].(string)
+	if !ok || endpoint == "" {
+		return nil, fmt.Errorf("endpoint is required and must be a string")
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("endpoint must be an http or https URL")
+	}
+	s3Config.Endpoint = endpoint
+
+	if bucket, ok := config["bucket"].(string); ok && bucket != "" {
+		s3Config.Bucket = bucket
+	} else {
+		return nil, fmt.Errorf("bucket is required and must be a string")
+	}
+
+	if key, ok := config["key"].(string); ok && strings.TrimPrefix(key, "/") != "" {
+		s3Config.Key = strings.TrimPrefix(key, "/")
+	} else {
+		return nil, fmt.Errorf("key is required and must be a string")
+	}
+
+	s3Config.Region, _ = config["region"].(string)
+	if s3Config.Region == "" {
+		s3Config.Region = sigv4.DefaultRegion
+	}
+
+	// Parse namespace for secret references
+	namespace, _ := config["namespace"].(string)
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	if secretName, ok := config["secretName"].(string); ok && secretName != "" {
+		signer, err := g.loadSigner(ctx, namespace, secretName, s3Config.Region)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load S3 credentials from secret: %w", err)
+		}
+		s3Config.signer = signer
+	}
+
+	return s3Config, nil
+}
+
+// loadSigner builds a SigV4 signer from the accesskey and secretkey entries of a Kubernetes secret
+func (g *S3Generator) loadSigner(ctx context.Context, namespace, secretName, region string) (*sigv4.Signer, error) {
+	secret := &corev1.Secret{}
+	secretKey := client.ObjectKey{
+		Namespace: namespace,
+		Name:      secretName,
+	}
+
+	if err := g.client.Get(ctx, secretKey, secret); err != nil {
+		return nil, fmt.Errorf("failed to get secret %s/%s: %w", namespace, secretName, err)
+	}
+
+	accessKey := string(secret.Data[s3AccessKeySecretKey])
+	secretAccessKey := string(secret.Data[s3SecretKeySecretKey])
+	if accessKey == "" || secretAccessKey == "" {
+		return nil, fmt.Errorf("secret %s/%s must contain %s and %s", namespace, secretName, s3AccessKeySecretKey, s3SecretKeySecretKey)
+	}
+
+	return &sigv4.Signer{
+		AccessKey: accessKey,
+		SecretKey: secretAccessKey,
+		Region:    region,
+		Service:   "s3",
+	}, nil
+}
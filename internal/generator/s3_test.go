@@ -0,0 +1,271 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oddkinco/flux-externalsource-controller/internal/sigv4"
+)
+
+// newS3TestGenerator returns an S3 generator with a fixed clock and a secret holding the given credentials
+func newS3TestGenerator(t *testing.T, credentials map[string][]byte) *S3Generator {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3-credentials", Namespace: "test-namespace"},
+		Data:       credentials,
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+
+	generator := NewS3Generator(fakeClient)
+	generator.now = func() time.Time {
+		return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	}
+	return generator
+}
+
+func TestS3Generator_SupportsConditionalFetch(t *testing.T) {
+	generator := NewS3Generator(nil)
+	if !generator.SupportsConditionalFetch() {
+		t.Error("Expected S3 generator to support conditional fetch")
+	}
+}
+
+func TestS3Generator_Generate_Signed(t *testing.T) {
+	generator := newS3TestGenerator(t, map[string][]byte{
+		"accesskey": []byte("AKIDEXAMPLE"),
+		"secretkey": []byte("secret"),
+	})
+
+	var gotRequest *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRequest = r.Clone(context.Background())
+		if r.Method != http.MethodGet || r.URL.Path != "/configs/team/app config.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", `"abc123"`)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key": "value"}`))
+	}))
+	defer server.Close()
+
+	config := GeneratorConfig{
+		Type: "s3",
+		Config: map[string]interface{}{
+			"endpoint":   server.URL,
+			"bucket":     "configs",
+			"key":        "/team/app config.json",
+			"region":     "eu-west-1",
+			"namespace":  "test-namespace",
+			"secretName": "s3-credentials",
+		},
+	}
+
+	data, err := generator.Generate(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if string(data.Data) != `{"key": "value"}` {
+		t.Errorf("Expected object data, got %s", string(data.Data))
+	}
+	if data.LastModified != `"abc123"` {
+		t.Errorf("Expected ETag as last modified, got %s", data.LastModified)
+	}
+	if data.Metadata["bucket"] != "configs" || data.Metadata["key"] != "team/app config.json" {
+		t.Errorf("Unexpected metadata %v", data.Metadata)
+	}
+
+	authorization := gotRequest.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20250102/eu-west-1/s3/aws4_request") {
+		t.Errorf("Expected SigV4 authorization, got %q", authorization)
+	}
+
+	// Re-signing the received request must reproduce the signature
+	expected, _ := http.NewRequest(http.MethodGet, server.URL+"/configs/team/app%20config.json", nil)
+	signer := &sigv4.Signer{AccessKey: "AKIDEXAMPLE", SecretKey: "secret", Region: "eu-west-1", Service: "s3"}
+	signer.Sign(expected, sigv4.HashSHA256(nil), generator.now())
+	if authorization != expected.Header.Get("Authorization") {
+		t.Errorf("Expected authorization %q, got %q", expected.Header.Get("Authorization"), authorization)
+	}
+}
+
+func TestS3Generator_Generate_Anonymous(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("ETag", `"public"`)
+		_, _ = w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	generator := NewS3Generator(nil)
+	data, err := generator.Generate(context.Background(), GeneratorConfig{
+		Type: "s3",
+		Config: map[string]interface{}{
+			"endpoint": server.URL,
+			"bucket":   "public",
+			"key":      "config.yaml",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if string(data.Data) != "data" || data.LastModified != `"public"` {
+		t.Errorf("Unexpected source data %+v", data)
+	}
+}
+
+func TestS3Generator_Generate_HTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	generator := NewS3Generator(nil)
+	_, err := generator.Generate(context.Background(), GeneratorConfig{
+		Type: "s3",
+		Config: map[string]interface{}{
+			"endpoint": server.URL,
+			"bucket":   "configs",
+			"key":      "config.yaml",
+		},
+	})
+	if err == nil || !strings.Contains(err.Error(), "status 403") {
+		t.Errorf("Expected status error, got %v", err)
+	}
+}
+
+func TestS3Generator_GetLastModified(t *testing.T) {
+	generator := newS3TestGenerator(t, map[string][]byte{
+		"accesskey": []byte("AKIDEXAMPLE"),
+		"secretkey": []byte("secret"),
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead || !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("ETag", `"v2"`)
+	}))
+	defer server.Close()
+
+	etag, err := generator.GetLastModified(context.Background(), GeneratorConfig{
+		Type: "s3",
+		Config: map[string]interface{}{
+			"endpoint":   server.URL,
+			"bucket":     "configs",
+			"key":        "config.yaml",
+			"namespace":  "test-namespace",
+			"secretName": "s3-credentials",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if etag != `"v2"` {
+		t.Errorf("Expected ETag from HEAD, got %s", etag)
+	}
+}
+
+func TestS3Generator_ParseConfig_Invalid(t *testing.T) {
+	generator := newS3TestGenerator(t, map[string][]byte{
+		"accesskey": []byte("AKIDEXAMPLE"),
+	})
+
+	tests := []struct {
+		name   string
+		config map[string]interface{}
+		errMsg string
+	}{
+		{
+			name:   "missing endpoint",
+			config: map[string]interface{}{"bucket": "b", "key": "k"},
+			errMsg: "endpoint is required",
+		},
+		{
+			name:   "endpoint without scheme",
+			config: map[string]interface{}{"endpoint": "s3.amazonaws.com", "bucket": "b", "key": "k"},
+			errMsg: "endpoint must be an http or https URL",
+		},
+		{
+			name:   "missing bucket",
+			config: map[string]interface{}{"endpoint": "https://s3.amazonaws.com", "key": "k"},
+			errMsg: "bucket is required",
+		},
+		{
+			name:   "missing key",
+			config: map[string]interface{}{"endpoint": "https://s3.amazonaws.com", "bucket": "b", "key": "/"},
+			errMsg: "key is required",
+		},
+		{
+			name: "incomplete credentials",
+			config: map[string]interface{}{
+				"endpoint":   "https://s3.amazonaws.com",
+				"bucket":     "b",
+				"key":        "k",
+				"namespace":  "test-namespace",
+				"secretName": "s3-credentials",
+			},
+			errMsg: "must contain accesskey and secretkey",
+		},
+		{
+			name: "missing secret",
+			config: map[string]interface{}{
+				"endpoint":   "https://s3.amazonaws.com",
+				"bucket":     "b",
+				"key":        "k",
+				"namespace":  "test-namespace",
+				"secretName": "missing",
+			},
+			errMsg: "failed to get secret",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := generator.parseConfig(context.Background(), tt.config)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
@@ -27,10 +27,12 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"time"
+	"unicode/utf8"
 
 	sourcev1alpha1 "github.com/oddkinco/flux-externalsource-controller/api/v1alpha1"
 )
@@ -42,6 +44,15 @@ type ExecuteRequest struct {
 	Timeout string            `json:"timeout"`
 	Env     map[string]string `json:"env"`
 	Stdin   string            `json:"stdin"` // base64 encoded
+
+	// MaxMemoryBytes caps the resident memory of the command, zero uses the sidecar default
+	MaxMemoryBytes int64 `json:"maxMemoryBytes,omitempty"`
+
+	// MaxOutputBytes caps the size of stdout and stderr, zero uses the sidecar default
+	MaxOutputBytes int64 `json:"maxOutputBytes,omitempty"`
+
+	// CorrelationID identifies the reconcile the hook runs for in the sidecar logs
+	CorrelationID string `json:"correlationID,omitempty"`
 }
 
 // ExecuteResponse represents the response from the sidecar
@@ -49,23 +60,131 @@ type ExecuteResponse struct {
 	Stdout   string `json:"stdout"` // base64 encoded
 	Stderr   string `json:"stderr"` // base64 encoded
 	ExitCode int    `json:"exitCode"`
+
+	// LimitExceeded names the resource limit ("memory" or "output") that caused the
+	// command to be killed, empty if no limit was hit
+	LimitExceeded string `json:"limitExceeded,omitempty"`
+
+	// CorrelationID echoes the correlation ID of the request
+	CorrelationID string `json:"correlationID,omitempty"`
+}
+
+// correlationIDKey is the context key for the correlation ID sent to the sidecar
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a context whose hook executions send the correlation ID to the
+// sidecar, which includes it in its logs
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, correlationID)
+}
+
+// CorrelationIDFromContext returns the correlation ID set with WithCorrelationID, if any
+func CorrelationIDFromContext(ctx context.Context) string {
+	correlationID, _ := ctx.Value(correlationIDKey{}).(string)
+	return correlationID
+}
+
+const (
+	// defaultTransportAttempts is how many times a request is sent to the sidecar
+	// before a transport failure is surfaced
+	defaultTransportAttempts = 3
+
+	// defaultTransportBackoff is the delay before the first transport retry, doubled
+	// on each subsequent attempt
+	defaultTransportBackoff = 200 * time.Millisecond
+)
+
+// TransportError indicates that the sidecar could not be reached or did not
+// handle the request, as opposed to the hook command itself failing
+type TransportError struct {
+	Err error
+}
+
+// Error implements the error interface
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("sidecar unreachable: %v", e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// IsTransportError reports whether err was caused by a failure to communicate
+// with the sidecar
+func IsTransportError(err error) bool {
+	var transportErr *TransportError
+	return errors.As(err, &transportErr)
+}
+
+// MaxStderrLength caps the stderr included in hook errors. The end of the output is
+// kept, that is where commands usually report what went wrong.
+const MaxStderrLength = 1024
+
+// ExitError is returned when a hook command fails or is killed, carrying what it wrote
+// to stderr so the failure can be diagnosed without the sidecar logs
+type ExitError struct {
+	// Command that was executed
+	Command string
+
+	// ExitCode of the command
+	ExitCode int
+
+	// LimitExceeded names the resource limit that killed the command, if any
+	LimitExceeded string
+
+	// Stderr is everything the command wrote to stderr, up to the sidecar output limit
+	Stderr []byte
+}
+
+// Error implements the error interface, including the end of stderr capped at
+// MaxStderrLength bytes
+func (e *ExitError) Error() string {
+	message := fmt.Sprintf("command exited with code %d", e.ExitCode)
+	if e.LimitExceeded != "" {
+		message = fmt.Sprintf("command %s exceeded %s resource limit", e.Command, e.LimitExceeded)
+	}
+	if stderr := e.StderrTail(); stderr != "" {
+		message += ": " + stderr
+	}
+	return message
+}
+
+// StderrTail returns the end of stderr, capped at MaxStderrLength bytes and prefixed with
+// "..." when truncated
+func (e *ExitError) StderrTail() string {
+	stderr := bytes.TrimSpace(e.Stderr)
+	if len(stderr) <= MaxStderrLength {
+		return string(stderr)
+	}
+
+	tail := stderr[len(stderr)-MaxStderrLength:]
+	// Don't start in the middle of a multi-byte character
+	for len(tail) > 0 && !utf8.RuneStart(tail[0]) {
+		tail = tail[1:]
+	}
+	return "..." + string(tail)
 }
 
 // SidecarExecutor implements HookExecutor by communicating with a sidecar container
 type SidecarExecutor struct {
-	endpoint         string
-	httpClient       *http.Client
-	whitelistManager WhitelistManager
-	defaultTimeout   time.Duration
+	endpoint          string
+	httpClient        *http.Client
+	whitelistManager  WhitelistManager
+	defaultTimeout    time.Duration
+	transportAttempts int
+	transportBackoff  time.Duration
 }
 
 // NewSidecarExecutor creates a new sidecar hook executor
 func NewSidecarExecutor(endpoint string, whitelistManager WhitelistManager, defaultTimeout time.Duration) *SidecarExecutor {
 	return &SidecarExecutor{
-		endpoint:         endpoint,
-		httpClient:       &http.Client{Timeout: 5 * time.Minute}, // Overall HTTP timeout
-		whitelistManager: whitelistManager,
-		defaultTimeout:   defaultTimeout,
+		endpoint:          endpoint,
+		httpClient:        &http.Client{Timeout: 5 * time.Minute}, // Overall HTTP timeout
+		whitelistManager:  whitelistManager,
+		defaultTimeout:    defaultTimeout,
+		transportAttempts: defaultTransportAttempts,
+		transportBackoff:  defaultTransportBackoff,
 	}
 }
 
@@ -103,6 +222,14 @@ func (s *SidecarExecutor) Execute(ctx context.Context, input []byte, hook source
 		Timeout: hook.Timeout,
 		Env:     env,
 		Stdin:   base64.StdEncoding.EncodeToString(input),
+
+		CorrelationID: CorrelationIDFromContext(ctx),
+	}
+	if hook.MaxMemory != nil {
+		req.MaxMemoryBytes = hook.MaxMemory.Value()
+	}
+	if hook.MaxOutputSize != nil {
+		req.MaxOutputBytes = hook.MaxOutputSize.Value()
 	}
 
 	// Marshal request
@@ -111,8 +238,76 @@ func (s *SidecarExecutor) Execute(ctx context.Context, input []byte, hook source
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
+	// Send request, retrying transport failures with a short backoff
+	respBody, err := s.send(execCtx, reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse response
+	var execResp ExecuteResponse
+	if err := json.Unmarshal(respBody, &execResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// A resource limit takes precedence over the exit code, the process was killed
+	if execResp.LimitExceeded != "" || execResp.ExitCode != 0 {
+		stderr, _ := base64.StdEncoding.DecodeString(execResp.Stderr)
+		return nil, &ExitError{
+			Command:       hook.Command,
+			ExitCode:      execResp.ExitCode,
+			LimitExceeded: execResp.LimitExceeded,
+			Stderr:        stderr,
+		}
+	}
+
+	// Decode stdout
+	output, err := base64.StdEncoding.DecodeString(execResp.Stdout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode stdout: %w", err)
+	}
+
+	return output, nil
+}
+
+// send posts the request body to the sidecar, retrying failures to reach it.
+// Errors from the hook deadline and non-transient sidecar responses are not retried.
+func (s *SidecarExecutor) send(ctx context.Context, reqBody []byte) ([]byte, error) {
+	backoff := s.transportBackoff
+	var lastErr error
+
+	for attempt := 1; ; attempt++ {
+		respBody, err := s.sendOnce(ctx, reqBody)
+		if err == nil || !IsTransportError(err) {
+			return respBody, err
+		}
+		lastErr = err
+
+		// The hook timeout elapsed, the sidecar may have been running the command
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("failed to send request to sidecar: %w", ctx.Err())
+		}
+		if attempt >= s.transportAttempts {
+			break
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("failed to send request to sidecar: %w", ctx.Err())
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+
+	return nil, lastErr
+}
+
+// sendOnce performs a single request to the sidecar and returns the response body
+func (s *SidecarExecutor) sendOnce(ctx context.Context, reqBody []byte) ([]byte, error) {
 	// Create HTTP request
-	httpReq, err := http.NewRequestWithContext(execCtx, "POST", s.endpoint+"/execute", bytes.NewReader(reqBody))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", s.endpoint+"/execute", bytes.NewReader(reqBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
@@ -121,7 +316,7 @@ func (s *SidecarExecutor) Execute(ctx context.Context, input []byte, hook source
 	// Send request
 	resp, err := s.httpClient.Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request to sidecar: %w", err)
+		return nil, &TransportError{Err: err}
 	}
 	defer func() {
 		_ = resp.Body.Close()
@@ -130,31 +325,17 @@ func (s *SidecarExecutor) Execute(ctx context.Context, input []byte, hook source
 	// Read response
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, &TransportError{Err: fmt.Errorf("failed to read response: %w", err)}
 	}
 
-	// Check HTTP status
-	if resp.StatusCode != http.StatusOK {
+	// Check HTTP status, gateway errors mean the sidecar is not serving requests and
+	// 429 that it is running as many commands as it allows
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return respBody, nil
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return nil, &TransportError{Err: fmt.Errorf("sidecar returned status %d: %s", resp.StatusCode, string(respBody))}
+	default:
 		return nil, fmt.Errorf("sidecar returned status %d: %s", resp.StatusCode, string(respBody))
 	}
-
-	// Parse response
-	var execResp ExecuteResponse
-	if err := json.Unmarshal(respBody, &execResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	// Check exit code
-	if execResp.ExitCode != 0 {
-		stderr, _ := base64.StdEncoding.DecodeString(execResp.Stderr)
-		return nil, fmt.Errorf("command exited with code %d: %s", execResp.ExitCode, string(stderr))
-	}
-
-	// Decode stdout
-	output, err := base64.StdEncoding.DecodeString(execResp.Stdout)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode stdout: %w", err)
-	}
-
-	return output, nil
 }
@@ -26,10 +26,15 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
+
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	sourcev1alpha1 "github.com/oddkinco/flux-externalsource-controller/api/v1alpha1"
 )
@@ -248,3 +253,246 @@ func TestSidecarExecutor_Timeout(t *testing.T) {
 		t.Error("Expected timeout error, got nil")
 	}
 }
+
+func TestSidecarExecutor_ExitError(t *testing.T) {
+	stderr := strings.Repeat("progress line\n", 200) + "fatal: unexpected token at line 3\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := ExecuteResponse{
+			Stderr:   base64.StdEncoding.EncodeToString([]byte(stderr)),
+			ExitCode: 2,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	executor := NewSidecarExecutor(server.URL, &mockWhitelistManager{allowed: true}, 30*time.Second)
+	_, err := executor.Execute(context.Background(), []byte("{}"), sourcev1alpha1.HookSpec{Name: "parse", Command: "jq"})
+
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("Expected ExitError, got %v", err)
+	}
+	if exitErr.ExitCode != 2 {
+		t.Errorf("Expected exit code 2, got %d", exitErr.ExitCode)
+	}
+	if string(exitErr.Stderr) != stderr {
+		t.Error("Expected the full stderr to be kept on the error")
+	}
+
+	// The message keeps the end of stderr, where the failure is reported
+	message := err.Error()
+	if !strings.HasPrefix(message, "command exited with code 2: ...") {
+		t.Errorf("Expected truncated stderr in message, got %q", message)
+	}
+	if !strings.HasSuffix(message, "fatal: unexpected token at line 3") {
+		t.Errorf("Expected message to end with the last stderr line, got %q", message)
+	}
+	if tail := exitErr.StderrTail(); len(tail) > MaxStderrLength+len("...") {
+		t.Errorf("Expected stderr capped at %d bytes, got %d", MaxStderrLength, len(tail))
+	}
+}
+
+func TestExitError_StderrTail(t *testing.T) {
+	short := &ExitError{ExitCode: 1, Stderr: []byte("  bad input\n")}
+	if got := short.Error(); got != "command exited with code 1: bad input" {
+		t.Errorf("Unexpected message %q", got)
+	}
+
+	empty := &ExitError{Command: "jq", ExitCode: -1, LimitExceeded: "memory"}
+	if got := empty.Error(); got != "command jq exceeded memory resource limit" {
+		t.Errorf("Unexpected message %q", got)
+	}
+
+	// Truncation never splits a multi-byte character
+	multiByte := &ExitError{ExitCode: 1, Stderr: []byte(strings.Repeat("é", MaxStderrLength))}
+	if tail := multiByte.StderrTail(); !utf8.ValidString(tail) {
+		t.Errorf("Expected valid UTF-8, got %q", tail)
+	}
+}
+
+func TestSidecarExecutor_ResourceLimits(t *testing.T) {
+	var received ExecuteRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		resp := ExecuteResponse{
+			Stdout:        base64.StdEncoding.EncodeToString([]byte("partial")),
+			ExitCode:      -1,
+			LimitExceeded: "memory",
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	maxMemory := resource.MustParse("64Mi")
+	maxOutput := resource.MustParse("1Mi")
+	hook := sourcev1alpha1.HookSpec{
+		Name:          "limited",
+		Command:       "jq",
+		MaxMemory:     &maxMemory,
+		MaxOutputSize: &maxOutput,
+	}
+
+	executor := NewSidecarExecutor(server.URL, &mockWhitelistManager{allowed: true}, 30*time.Second)
+	_, err := executor.Execute(context.Background(), []byte("{}"), hook)
+	if err == nil {
+		t.Fatal("Expected error when a resource limit is exceeded")
+	}
+	if !strings.Contains(err.Error(), "exceeded memory resource limit") {
+		t.Errorf("Expected resource limit error, got %v", err)
+	}
+
+	if received.MaxMemoryBytes != 64*1024*1024 {
+		t.Errorf("Expected MaxMemoryBytes %d, got %d", 64*1024*1024, received.MaxMemoryBytes)
+	}
+	if received.MaxOutputBytes != 1024*1024 {
+		t.Errorf("Expected MaxOutputBytes %d, got %d", 1024*1024, received.MaxOutputBytes)
+	}
+}
+
+func TestSidecarExecutor_CorrelationID(t *testing.T) {
+	var received ExecuteRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = ExecuteRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		resp := ExecuteResponse{
+			Stdout:        base64.StdEncoding.EncodeToString([]byte("{}")),
+			CorrelationID: received.CorrelationID,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	executor := NewSidecarExecutor(server.URL, &mockWhitelistManager{allowed: true}, 30*time.Second)
+	hook := sourcev1alpha1.HookSpec{Name: "transform", Command: "jq"}
+
+	ctx := WithCorrelationID(context.Background(), "reconcile-1")
+	if _, err := executor.Execute(ctx, []byte("{}"), hook); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if received.CorrelationID != "reconcile-1" {
+		t.Errorf("Expected correlation ID reconcile-1, got %q", received.CorrelationID)
+	}
+
+	if _, err := executor.Execute(context.Background(), []byte("{}"), hook); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if received.CorrelationID != "" {
+		t.Errorf("Expected no correlation ID, got %q", received.CorrelationID)
+	}
+}
+
+func TestSidecarExecutor_TransportRetry(t *testing.T) {
+	tests := []struct {
+		name            string
+		failures        int
+		status          int
+		expectError     bool
+		expectTransport bool
+		expectCalls     int
+	}{
+		{
+			name:        "recovers after transient unavailability",
+			failures:    2,
+			status:      http.StatusServiceUnavailable,
+			expectCalls: 3,
+		},
+		{
+			name:        "recovers once the sidecar has a free execution slot",
+			failures:    1,
+			status:      http.StatusTooManyRequests,
+			expectCalls: 2,
+		},
+		{
+			name:            "surfaces persistent unavailability",
+			failures:        10,
+			status:          http.StatusBadGateway,
+			expectError:     true,
+			expectTransport: true,
+			expectCalls:     3,
+		},
+		{
+			name:        "does not retry rejected requests",
+			failures:    10,
+			status:      http.StatusForbidden,
+			expectError: true,
+			expectCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if calls <= tt.failures {
+					http.Error(w, "unavailable", tt.status)
+					return
+				}
+				resp := ExecuteResponse{Stdout: base64.StdEncoding.EncodeToString([]byte("ok"))}
+				w.Header().Set("Content-Type", "application/json")
+				if err := json.NewEncoder(w).Encode(resp); err != nil {
+					http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+				}
+			}))
+			defer server.Close()
+
+			executor := NewSidecarExecutor(server.URL, &mockWhitelistManager{allowed: true}, 30*time.Second)
+			executor.transportBackoff = time.Millisecond
+
+			output, err := executor.Execute(context.Background(), []byte("{}"), sourcev1alpha1.HookSpec{Name: "test", Command: "jq"})
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("Expected error, got nil")
+				}
+				if IsTransportError(err) != tt.expectTransport {
+					t.Errorf("Expected IsTransportError %v, got %v (%v)", tt.expectTransport, IsTransportError(err), err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if string(output) != "ok" {
+					t.Errorf("Expected output %q, got %q", "ok", string(output))
+				}
+			}
+
+			if calls != tt.expectCalls {
+				t.Errorf("Expected %d calls, got %d", tt.expectCalls, calls)
+			}
+		})
+	}
+}
+
+func TestSidecarExecutor_Unreachable(t *testing.T) {
+	// Reserve an address and close the listener so connections are refused
+	server := httptest.NewServer(http.NotFoundHandler())
+	endpoint := server.URL
+	server.Close()
+
+	executor := NewSidecarExecutor(endpoint, &mockWhitelistManager{allowed: true}, 30*time.Second)
+	executor.transportBackoff = time.Millisecond
+
+	_, err := executor.Execute(context.Background(), []byte("{}"), sourcev1alpha1.HookSpec{Name: "test", Command: "jq"})
+	if err == nil {
+		t.Fatal("Expected error for unreachable sidecar")
+	}
+	if !IsTransportError(err) {
+		t.Errorf("Expected transport error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "sidecar unreachable") {
+		t.Errorf("Expected sidecar unreachable message, got %v", err)
+	}
+}
@@ -43,3 +43,9 @@ type WhitelistManager interface {
 	// Reload reloads the whitelist from the configured source
 	Reload() error
 }
+
+// LimitProvider is implemented by whitelist managers that define resource limits for commands
+type LimitProvider interface {
+	// Limits returns the default and maximum resource limits for a command
+	Limits(command string) ResourceLimits
+}
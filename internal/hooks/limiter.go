@@ -0,0 +1,82 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package hooks
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrExecutorSaturated is returned when no execution slot became free in time
+var ErrExecutorSaturated = errors.New("maximum number of concurrent executions reached")
+
+// ExecutionLimiter bounds the number of commands the sidecar runs at the same time, so a
+// burst of reconciles can't start more processes than the container has memory for
+type ExecutionLimiter struct {
+	slots        chan struct{}
+	queueTimeout time.Duration
+}
+
+// NewExecutionLimiter creates a limiter allowing maxConcurrent executions, a value of 0 or
+// less allows any number. Callers wait up to queueTimeout for a free slot, 0 rejects them
+// right away when all slots are taken.
+func NewExecutionLimiter(maxConcurrent int, queueTimeout time.Duration) *ExecutionLimiter {
+	limiter := &ExecutionLimiter{queueTimeout: queueTimeout}
+	if maxConcurrent > 0 {
+		limiter.slots = make(chan struct{}, maxConcurrent)
+	}
+	return limiter
+}
+
+// Acquire takes an execution slot and returns the function releasing it. It returns
+// ErrExecutorSaturated when no slot became free within the queue timeout, or the context
+// error when the context ended first.
+func (l *ExecutionLimiter) Acquire(ctx context.Context) (func(), error) {
+	if l == nil || l.slots == nil {
+		return func() {}, nil
+	}
+
+	release := func() { <-l.slots }
+
+	select {
+	case l.slots <- struct{}{}:
+		return release, nil
+	default:
+	}
+	if l.queueTimeout <= 0 {
+		return nil, ErrExecutorSaturated
+	}
+
+	timer := time.NewTimer(l.queueTimeout)
+	defer timer.Stop()
+
+	select {
+	case l.slots <- struct{}{}:
+		return release, nil
+	case <-timer.C:
+		return nil, ErrExecutorSaturated
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
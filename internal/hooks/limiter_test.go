@@ -0,0 +1,135 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package hooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExecutionLimiter_CapsConcurrency(t *testing.T) {
+	const limit = 2
+	const requests = 8
+
+	limiter := NewExecutionLimiter(limit, 0)
+
+	var running, maxRunning, rejected atomic.Int32
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		release, err := limiter.Acquire(r.Context())
+		if err != nil {
+			rejected.Add(1)
+			http.Error(w, err.Error(), http.StatusTooManyRequests)
+			return
+		}
+		defer release()
+
+		current := running.Add(1)
+		for {
+			seen := maxRunning.Load()
+			if current <= seen || maxRunning.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		<-unblock
+		running.Add(-1)
+	}))
+	defer server.Close()
+
+	var wg sync.WaitGroup
+	statuses := make(chan int, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := http.Post(server.URL, "application/json", nil)
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+				return
+			}
+			_ = resp.Body.Close()
+			statuses <- resp.StatusCode
+		}()
+	}
+
+	// Every request beyond the limit is rejected while the first ones are still running
+	deadline := time.Now().Add(5 * time.Second)
+	for rejected.Load() < requests-limit && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	close(unblock)
+	wg.Wait()
+	close(statuses)
+
+	counts := map[int]int{}
+	for status := range statuses {
+		counts[status]++
+	}
+	if counts[http.StatusOK] != limit || counts[http.StatusTooManyRequests] != requests-limit {
+		t.Errorf("Expected %d accepted and %d rejected requests, got %v", limit, requests-limit, counts)
+	}
+	if got := maxRunning.Load(); got > limit {
+		t.Errorf("Expected at most %d concurrent executions, got %d", limit, got)
+	}
+}
+
+func TestExecutionLimiter_QueueTimeout(t *testing.T) {
+	limiter := NewExecutionLimiter(1, 50*time.Millisecond)
+
+	release, err := limiter.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// A waiting caller times out while the slot is held
+	if _, err := limiter.Acquire(context.Background()); !errors.Is(err, ErrExecutorSaturated) {
+		t.Errorf("Expected ErrExecutorSaturated, got %v", err)
+	}
+
+	// A waiting caller gets the slot once it is released
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		release()
+	}()
+	release, err = limiter.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Expected the released slot, got %v", err)
+	}
+	release()
+}
+
+func TestExecutionLimiter_Unlimited(t *testing.T) {
+	limiter := NewExecutionLimiter(0, 0)
+
+	for i := 0; i < 100; i++ {
+		if _, err := limiter.Acquire(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+}
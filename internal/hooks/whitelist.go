@@ -23,12 +23,17 @@ SOFTWARE.
 package hooks
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"sync"
 
+	"github.com/fsnotify/fsnotify"
 	"gopkg.in/yaml.v3"
 )
 
@@ -36,6 +41,9 @@ import (
 type WhitelistConfig struct {
 	// Commands is a map of command names to their configurations
 	Commands map[string]CommandConfig `yaml:"commands"`
+
+	// Limits are the default and maximum resource limits applied to every command
+	Limits ResourceLimits `yaml:"limits,omitempty"`
 }
 
 // CommandConfig represents configuration for a single command
@@ -46,11 +54,25 @@ type CommandConfig struct {
 	// ArgumentPatterns are regex patterns that arguments must match (optional)
 	// If empty, all arguments are allowed
 	ArgumentPatterns []string `yaml:"argumentPatterns,omitempty"`
+
+	// Limits override the global resource limits for this command (optional)
+	Limits *ResourceLimits `yaml:"limits,omitempty"`
 }
 
-// FileWhitelistManager implements WhitelistManager by loading from a file
+// ResourceLimits caps the resources a command may use, zero means unlimited
+type ResourceLimits struct {
+	// MaxMemoryBytes is the maximum resident memory of the command process
+	MaxMemoryBytes int64 `yaml:"maxMemoryBytes,omitempty" json:"maxMemoryBytes,omitempty"`
+
+	// MaxOutputBytes is the maximum size of each of stdout and stderr
+	MaxOutputBytes int64 `yaml:"maxOutputBytes,omitempty" json:"maxOutputBytes,omitempty"`
+}
+
+// FileWhitelistManager implements WhitelistManager by loading from a file, or from
+// every whitelist file in a directory
 type FileWhitelistManager struct {
 	path              string
+	dir               string
 	config            *WhitelistConfig
 	argPatterns       map[string][]*regexp.Regexp
 	mu                sync.RWMutex
@@ -87,24 +109,193 @@ func NewFileWhitelistManagerWithDefault(path string, allowAllByDefault bool) (*F
 	return wm, nil
 }
 
-// Reload reloads the whitelist from the file
+// NewDirWhitelistManager creates a whitelist manager that merges every *.yaml and *.yml
+// file in a directory, e.g. the whitelists of several teams mounted from separate
+// ConfigMaps. Files are merged in name order: a command is allowed when any file allows
+// it, with the union of the argument patterns of the files allowing it, and the strictest
+// resource limits apply. Files that cannot be read or parsed are skipped with a warning.
+func NewDirWhitelistManager(dir string) (*FileWhitelistManager, error) {
+	wm := &FileWhitelistManager{
+		dir:         dir,
+		argPatterns: make(map[string][]*regexp.Regexp),
+	}
+
+	if err := wm.Reload(); err != nil {
+		return nil, fmt.Errorf("failed to load whitelist: %w", err)
+	}
+
+	return wm, nil
+}
+
+// NewWhitelistManagerForPath creates a whitelist manager for a whitelist file or, when the
+// path is a directory, for the whitelist files in it
+func NewWhitelistManagerForPath(path string) (*FileWhitelistManager, error) {
+	info, err := os.Stat(path)
+	if err == nil && info.IsDir() {
+		return NewDirWhitelistManager(path)
+	}
+	return NewFileWhitelistManager(path)
+}
+
+// Reload reloads the whitelist from the file or directory. If the file cannot be
+// read or is invalid, or no file in the directory is valid, the previously loaded
+// whitelist is kept.
 func (w *FileWhitelistManager) Reload() error {
+	var config *WhitelistConfig
+	var err error
+	if w.dir != "" {
+		config, err = loadWhitelistDir(w.dir)
+	} else {
+		config, err = loadWhitelistFile(w.path)
+	}
+	if err != nil {
+		return err
+	}
+
+	argPatterns, err := compileArgPatterns(config)
+	if err != nil {
+		return err
+	}
+
+	// Swap in the new snapshot only once it is fully valid
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
+	w.config = config
+	w.argPatterns = argPatterns
+
+	return nil
+}
+
+// loadWhitelistFile reads and parses a whitelist file
+func loadWhitelistFile(path string) (*WhitelistConfig, error) {
 	// Read the file
-	data, err := os.ReadFile(w.path)
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return fmt.Errorf("failed to read whitelist file: %w", err)
+		return nil, fmt.Errorf("failed to read whitelist file: %w", err)
+	}
+
+	// An empty file is usually a write in progress, an empty whitelist must say so explicitly
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, fmt.Errorf("whitelist file is empty")
 	}
 
 	// Parse the YAML
 	var config WhitelistConfig
 	if err := yaml.Unmarshal(data, &config); err != nil {
-		return fmt.Errorf("failed to parse whitelist file: %w", err)
+		return nil, fmt.Errorf("failed to parse whitelist file: %w", err)
+	}
+
+	return &config, nil
+}
+
+// loadWhitelistDir reads, validates and merges the whitelist files in a directory
+func loadWhitelistDir(dir string) (*WhitelistConfig, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read whitelist directory: %w", err)
 	}
 
-	// Compile regex patterns
+	// ReadDir sorts by name, so files are always merged in the same order
+	var configs []*WhitelistConfig
+	found := 0
+	for _, entry := range entries {
+		if !isWhitelistFileName(entry.Name()) {
+			continue
+		}
+		found++
+
+		path := filepath.Join(dir, entry.Name())
+		config, err := loadWhitelistFile(path)
+		if err == nil {
+			_, err = compileArgPatterns(config)
+		}
+		if err != nil {
+			slog.Warn("Skipping invalid whitelist file", "path", path, "error", err)
+			continue
+		}
+		configs = append(configs, config)
+	}
+
+	if found > 0 && len(configs) == 0 {
+		return nil, fmt.Errorf("no valid whitelist file in %s", dir)
+	}
+
+	return mergeWhitelists(configs), nil
+}
+
+// isWhitelistFileName reports whether a directory entry is a whitelist file. The
+// hidden entries of ConfigMap volumes, such as ..data, never match.
+func isWhitelistFileName(name string) bool {
+	if strings.HasPrefix(name, ".") {
+		return false
+	}
+	ext := filepath.Ext(name)
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// mergeWhitelists merges whitelists into one. A command is allowed when any whitelist
+// allows it, with the union of the argument patterns of the whitelists allowing it; when
+// one of them doesn't restrict the arguments neither does the result. Resource limits
+// protect the shared sidecar, so the strictest limit of any whitelist applies.
+func mergeWhitelists(configs []*WhitelistConfig) *WhitelistConfig {
+	merged := &WhitelistConfig{Commands: make(map[string]CommandConfig)}
+	unrestricted := make(map[string]bool)
+
+	for _, config := range configs {
+		merged.Limits = strictestLimits(merged.Limits, config.Limits)
+
+		for name, cmdConfig := range config.Commands {
+			current := merged.Commands[name]
+			if cmdConfig.Allowed {
+				current.Allowed = true
+				if len(cmdConfig.ArgumentPatterns) == 0 {
+					unrestricted[name] = true
+				}
+				current.ArgumentPatterns = append(current.ArgumentPatterns, cmdConfig.ArgumentPatterns...)
+			}
+			if cmdConfig.Limits != nil {
+				var limits ResourceLimits
+				if current.Limits != nil {
+					limits = *current.Limits
+				}
+				limits = strictestLimits(limits, *cmdConfig.Limits)
+				current.Limits = &limits
+			}
+			merged.Commands[name] = current
+		}
+	}
+
+	for name := range unrestricted {
+		cmdConfig := merged.Commands[name]
+		cmdConfig.ArgumentPatterns = nil
+		merged.Commands[name] = cmdConfig
+	}
+
+	return merged
+}
+
+// strictestLimits returns the lower of each limit, zero meaning unlimited
+func strictestLimits(a, b ResourceLimits) ResourceLimits {
+	return ResourceLimits{
+		MaxMemoryBytes: strictestLimit(a.MaxMemoryBytes, b.MaxMemoryBytes),
+		MaxOutputBytes: strictestLimit(a.MaxOutputBytes, b.MaxOutputBytes),
+	}
+}
+
+// strictestLimit returns the lower limit, zero meaning unlimited
+func strictestLimit(a, b int64) int64 {
+	if a <= 0 {
+		return b
+	}
+	if b <= 0 || a < b {
+		return a
+	}
+	return b
+}
+
+// compileArgPatterns compiles the argument patterns of every command
+func compileArgPatterns(config *WhitelistConfig) (map[string][]*regexp.Regexp, error) {
 	argPatterns := make(map[string][]*regexp.Regexp)
 	for cmd, cmdConfig := range config.Commands {
 		if len(cmdConfig.ArgumentPatterns) > 0 {
@@ -112,18 +303,85 @@ func (w *FileWhitelistManager) Reload() error {
 			for _, pattern := range cmdConfig.ArgumentPatterns {
 				re, err := regexp.Compile(pattern)
 				if err != nil {
-					return fmt.Errorf("invalid regex pattern for command %s: %w", cmd, err)
+					return nil, fmt.Errorf("invalid regex pattern for command %s: %w", cmd, err)
 				}
 				patterns = append(patterns, re)
 			}
 			argPatterns[cmd] = patterns
 		}
 	}
+	return argPatterns, nil
+}
 
-	w.config = &config
-	w.argPatterns = argPatterns
+// Watch reloads the whitelist whenever the file, or a file in the whitelist
+// directory, changes until the context is cancelled. The parent directory is
+// watched rather than the file itself so that atomic replacements, such as
+// Kubernetes ConfigMap updates which swap a symlink, are picked up. Failed
+// reloads are logged and the last good whitelist stays in effect.
+func (w *FileWhitelistManager) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create whitelist watcher: %w", err)
+	}
+	defer func() { _ = watcher.Close() }()
 
-	return nil
+	dir := w.dir
+	if dir == "" {
+		dir = filepath.Dir(w.path)
+	}
+	if err := watcher.Add(dir); err != nil {
+		return fmt.Errorf("failed to watch whitelist directory %s: %w", dir, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if !w.isWhitelistEvent(event) {
+				continue
+			}
+			if err := w.Reload(); err != nil {
+				slog.Warn("Failed to reload whitelist, keeping previous whitelist", "path", w.source(), "error", err)
+				continue
+			}
+			slog.Info("Reloaded whitelist", "path", w.source())
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			slog.Warn("Whitelist watcher error", "error", err)
+		}
+	}
+}
+
+// source returns the whitelist file or directory for logging
+func (w *FileWhitelistManager) source() string {
+	if w.dir != "" {
+		return w.dir
+	}
+	return w.path
+}
+
+// isWhitelistEvent reports whether a file system event may have changed the
+// whitelist file contents
+func (w *FileWhitelistManager) isWhitelistEvent(event fsnotify.Event) bool {
+	// ConfigMap volumes update by re-pointing the ..data symlink
+	name := filepath.Base(event.Name)
+
+	// Removing a file from the directory drops its commands
+	if w.dir != "" {
+		return (event.Has(fsnotify.Write) || event.Has(fsnotify.Create) || event.Has(fsnotify.Rename) ||
+			event.Has(fsnotify.Remove)) && (isWhitelistFileName(name) || name == "..data")
+	}
+
+	if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Rename) {
+		return false
+	}
+	return filepath.Clean(event.Name) == filepath.Clean(w.path) || name == "..data"
 }
 
 // IsAllowed checks if a command with given arguments is allowed
@@ -179,3 +437,30 @@ func (w *FileWhitelistManager) IsAllowed(command string, args []string) bool {
 
 	return true
 }
+
+// Limits returns the resource limits for a command, with per-command limits
+// taking precedence over the global limits
+func (w *FileWhitelistManager) Limits(command string) ResourceLimits {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.config == nil {
+		return ResourceLimits{}
+	}
+
+	limits := w.config.Limits
+	cmdConfig, exists := w.config.Commands[command]
+	if !exists {
+		cmdConfig, exists = w.config.Commands[filepath.Base(command)]
+	}
+	if exists && cmdConfig.Limits != nil {
+		if cmdConfig.Limits.MaxMemoryBytes > 0 {
+			limits.MaxMemoryBytes = cmdConfig.Limits.MaxMemoryBytes
+		}
+		if cmdConfig.Limits.MaxOutputBytes > 0 {
+			limits.MaxOutputBytes = cmdConfig.Limits.MaxOutputBytes
+		}
+	}
+
+	return limits
+}
@@ -23,9 +23,14 @@ SOFTWARE.
 package hooks
 
 import (
+	"bytes"
+	"context"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestFileWhitelistManager(t *testing.T) {
@@ -180,6 +185,92 @@ func TestFileWhitelistManager_Reload(t *testing.T) {
 	}
 }
 
+func TestFileWhitelistManager_ReloadKeepsLastGood(t *testing.T) {
+	tmpDir := t.TempDir()
+	whitelistPath := filepath.Join(tmpDir, "whitelist.yaml")
+
+	if err := os.WriteFile(whitelistPath, []byte("commands:\n  jq:\n    allowed: true"), 0644); err != nil {
+		t.Fatalf("Failed to write initial whitelist: %v", err)
+	}
+
+	wm, err := NewFileWhitelistManager(whitelistPath)
+	if err != nil {
+		t.Fatalf("Failed to create whitelist manager: %v", err)
+	}
+
+	malformed := []string{
+		"",
+		"commands: [not, a, map",
+		"commands:\n  jq:\n    allowed: true\n    argumentPatterns:\n      - \"[[invalid\"",
+	}
+	for _, content := range malformed {
+		if err := os.WriteFile(whitelistPath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to update whitelist: %v", err)
+		}
+		if err := wm.Reload(); err == nil {
+			t.Errorf("Expected reload of %q to fail", content)
+		}
+		if !wm.IsAllowed("jq", []string{}) {
+			t.Error("Expected jq to stay allowed after a failed reload")
+		}
+	}
+}
+
+func TestFileWhitelistManager_Watch(t *testing.T) {
+	tmpDir := t.TempDir()
+	whitelistPath := filepath.Join(tmpDir, "whitelist.yaml")
+
+	if err := os.WriteFile(whitelistPath, []byte("commands:\n  jq:\n    allowed: true"), 0644); err != nil {
+		t.Fatalf("Failed to write initial whitelist: %v", err)
+	}
+
+	wm, err := NewFileWhitelistManager(whitelistPath)
+	if err != nil {
+		t.Fatalf("Failed to create whitelist manager: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- wm.Watch(ctx) }()
+	defer func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Watch returned error: %v", err)
+		}
+	}()
+
+	// waitFor polls until the condition holds, rewriting the file on every
+	// attempt in case the watcher was not registered yet
+	waitFor := func(content string, condition func() bool) bool {
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			if err := os.WriteFile(whitelistPath, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to update whitelist: %v", err)
+			}
+			time.Sleep(50 * time.Millisecond)
+			if condition() {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !waitFor("commands:\n  jq:\n    allowed: true\n  yq:\n    allowed: true", func() bool {
+		return wm.IsAllowed("yq", []string{})
+	}) {
+		t.Fatal("Expected yq to be allowed after the whitelist file changed")
+	}
+
+	// A malformed update keeps the previous whitelist
+	if err := os.WriteFile(whitelistPath, []byte("commands: [broken"), 0644); err != nil {
+		t.Fatalf("Failed to update whitelist: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if !wm.IsAllowed("yq", []string{}) {
+		t.Error("Expected yq to stay allowed after a malformed update")
+	}
+}
+
 func TestFileWhitelistManager_InvalidRegex(t *testing.T) {
 	tmpDir := t.TempDir()
 	whitelistPath := filepath.Join(tmpDir, "whitelist.yaml")
@@ -199,3 +290,211 @@ func TestFileWhitelistManager_InvalidRegex(t *testing.T) {
 		t.Error("Expected error for invalid regex pattern")
 	}
 }
+
+func TestFileWhitelistManager_Limits(t *testing.T) {
+	tmpDir := t.TempDir()
+	whitelistPath := filepath.Join(tmpDir, "whitelist.yaml")
+
+	content := `limits:
+  maxMemoryBytes: 104857600
+  maxOutputBytes: 1048576
+commands:
+  jq:
+    allowed: true
+    limits:
+      maxOutputBytes: 4096
+  yq:
+    allowed: true`
+
+	if err := os.WriteFile(whitelistPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write whitelist: %v", err)
+	}
+
+	wm, err := NewFileWhitelistManager(whitelistPath)
+	if err != nil {
+		t.Fatalf("Failed to create whitelist manager: %v", err)
+	}
+
+	tests := []struct {
+		command string
+		want    ResourceLimits
+	}{
+		{command: "jq", want: ResourceLimits{MaxMemoryBytes: 104857600, MaxOutputBytes: 4096}},
+		{command: "/usr/bin/jq", want: ResourceLimits{MaxMemoryBytes: 104857600, MaxOutputBytes: 4096}},
+		{command: "yq", want: ResourceLimits{MaxMemoryBytes: 104857600, MaxOutputBytes: 1048576}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			if got := wm.Limits(tt.command); got != tt.want {
+				t.Errorf("Limits(%s) = %+v, want %+v", tt.command, got, tt.want)
+			}
+		})
+	}
+}
+
+// writeWhitelistFiles writes each named whitelist into the directory
+func writeWhitelistFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write whitelist %s: %v", name, err)
+		}
+	}
+}
+
+func TestDirWhitelistManager_Merge(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeWhitelistFiles(t, tmpDir, map[string]string{
+		"team-a.yaml": `limits:
+  maxMemoryBytes: 104857600
+commands:
+  jq:
+    allowed: true
+    argumentPatterns:
+      - "^\\..*"
+  yq:
+    allowed: true
+    argumentPatterns:
+      - "^e$"
+  rm:
+    allowed: false`,
+		"team-b.yml": `limits:
+  maxMemoryBytes: 52428800
+  maxOutputBytes: 1048576
+commands:
+  jq:
+    allowed: true
+    argumentPatterns:
+      - "^-r$"
+    limits:
+      maxOutputBytes: 4096
+  yq:
+    allowed: true
+  rm:
+    allowed: true
+    argumentPatterns:
+      - "^/tmp/.*"`,
+		"README.md": "not a whitelist",
+	})
+
+	wm, err := NewDirWhitelistManager(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create whitelist manager: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		command string
+		args    []string
+		allowed bool
+	}{
+		{name: "patterns of both files", command: "jq", args: []string{".field", "-r"}, allowed: true},
+		{name: "argument matching no file", command: "jq", args: []string{"--arg"}, allowed: false},
+		{name: "unrestricted in one file", command: "yq", args: []string{"--anything"}, allowed: true},
+		{name: "allowed by one file", command: "rm", args: []string{"/tmp/x"}, allowed: true},
+		{name: "patterns of the allowing file", command: "rm", args: []string{"/etc/passwd"}, allowed: false},
+		{name: "not in any file", command: "curl", allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wm.IsAllowed(tt.command, tt.args); got != tt.allowed {
+				t.Errorf("IsAllowed(%s, %v) = %v, want %v", tt.command, tt.args, got, tt.allowed)
+			}
+		})
+	}
+
+	// The strictest limits of all files apply
+	if got, want := wm.Limits("jq"), (ResourceLimits{MaxMemoryBytes: 52428800, MaxOutputBytes: 4096}); got != want {
+		t.Errorf("Limits(jq) = %+v, want %+v", got, want)
+	}
+	if got, want := wm.Limits("yq"), (ResourceLimits{MaxMemoryBytes: 52428800, MaxOutputBytes: 1048576}); got != want {
+		t.Errorf("Limits(yq) = %+v, want %+v", got, want)
+	}
+}
+
+func TestDirWhitelistManager_SkipsMalformedFile(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	defer slog.SetDefault(previous)
+
+	tmpDir := t.TempDir()
+	writeWhitelistFiles(t, tmpDir, map[string]string{
+		"a-broken.yaml":  "commands: [broken",
+		"b-regex.yaml":   "commands:\n  yq:\n    allowed: true\n    argumentPatterns:\n      - \"[invalid\"",
+		"c-working.yaml": "commands:\n  jq:\n    allowed: true",
+	})
+
+	wm, err := NewDirWhitelistManager(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create whitelist manager: %v", err)
+	}
+
+	if !wm.IsAllowed("jq", nil) {
+		t.Error("Expected jq from the valid file to be allowed")
+	}
+	if wm.IsAllowed("yq", nil) {
+		t.Error("Expected yq from the file with an invalid pattern to be skipped")
+	}
+	for _, name := range []string{"a-broken.yaml", "b-regex.yaml"} {
+		if !strings.Contains(logs.String(), "Skipping invalid whitelist file") || !strings.Contains(logs.String(), name) {
+			t.Errorf("Expected a warning for %s, got logs:\n%s", name, logs.String())
+		}
+	}
+
+	// Without any valid file the directory is rejected
+	if err := os.Remove(filepath.Join(tmpDir, "c-working.yaml")); err != nil {
+		t.Fatalf("Failed to remove whitelist: %v", err)
+	}
+	if err := wm.Reload(); err == nil {
+		t.Error("Expected error when no whitelist file is valid")
+	}
+	if !wm.IsAllowed("jq", nil) {
+		t.Error("Expected the previous whitelist to stay in effect")
+	}
+}
+
+func TestDirWhitelistManager_Watch(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeWhitelistFiles(t, tmpDir, map[string]string{"team-a.yaml": "commands:\n  jq:\n    allowed: true"})
+
+	wm, err := NewDirWhitelistManager(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create whitelist manager: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- wm.Watch(ctx) }()
+	defer func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Watch returned error: %v", err)
+		}
+	}()
+
+	// Adding a team's file takes effect, rewritten on every attempt in case the
+	// watcher was not registered yet
+	deadline := time.Now().Add(5 * time.Second)
+	for !wm.IsAllowed("yq", nil) && time.Now().Before(deadline) {
+		writeWhitelistFiles(t, tmpDir, map[string]string{"team-b.yaml": "commands:\n  yq:\n    allowed: true"})
+		time.Sleep(50 * time.Millisecond)
+	}
+	if !wm.IsAllowed("yq", nil) || !wm.IsAllowed("jq", nil) {
+		t.Fatal("Expected jq and yq to be allowed after a whitelist file was added")
+	}
+
+	// Removing it drops its commands
+	if err := os.Remove(filepath.Join(tmpDir, "team-b.yaml")); err != nil {
+		t.Fatalf("Failed to remove whitelist: %v", err)
+	}
+	deadline = time.Now().Add(5 * time.Second)
+	for wm.IsAllowed("yq", nil) && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	if wm.IsAllowed("yq", nil) {
+		t.Error("Expected yq to be rejected after its whitelist file was removed")
+	}
+}
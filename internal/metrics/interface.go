@@ -40,12 +40,37 @@ type MetricsRecorder interface {
 	// RecordHookExecution records a hook execution attempt
 	RecordHookExecution(hookName, retryPolicy string, success bool, duration time.Duration)
 
-	// RecordArtifactOperation records an artifact storage operation
-	RecordArtifactOperation(operation string, success bool, duration time.Duration)
+	// RecordArtifactOperation records an artifact storage operation against the given
+	// storage backend type, such as s3 or pvc
+	RecordArtifactOperation(operation, backend string, success bool, duration time.Duration)
 
 	// IncActiveReconciliations increments the count of active reconciliations
 	IncActiveReconciliations(namespace, name string)
 
 	// DecActiveReconciliations decrements the count of active reconciliations
 	DecActiveReconciliations(namespace, name string)
+
+	// RecordArtifactSize records the size of the current artifact of a source
+	RecordArtifactSize(namespace, name string, sizeBytes int64)
+
+	// RecordContentAge records how long ago the source last confirmed the data in the current artifact
+	RecordContentAge(namespace, name string, age time.Duration)
+
+	// RecordDataFresh records whether the last reconciliation of a source fetched its data successfully
+	RecordDataFresh(namespace, name string, fresh bool)
+
+	// DeleteSourceMetrics removes the per-source gauges of a deleted source
+	DeleteSourceMetrics(namespace, name string)
+
+	// RecordFetchRateLimited records a source request that waited for the global fetch rate limiter
+	RecordFetchRateLimited(sourceType string)
+
+	// RecordNotification records an artifact change notification attempt
+	RecordNotification(namespace, name string, success bool)
+
+	// RecordCircuitBreakerState records whether the circuit breaker for an upstream host is open
+	RecordCircuitBreakerState(host string, open bool)
+
+	// RecordReconcileStuck records a reconciliation that exceeded the stuck reconcile threshold
+	RecordReconcileStuck(namespace, name string)
 }
@@ -50,7 +50,7 @@ func (r *NoOpRecorder) RecordHookExecution(_, _ string, _ bool, _ time.Duration)
 }
 
 // RecordArtifactOperation does nothing
-func (r *NoOpRecorder) RecordArtifactOperation(_ string, _ bool, _ time.Duration) {
+func (r *NoOpRecorder) RecordArtifactOperation(_, _ string, _ bool, _ time.Duration) {
 	// No-op
 }
 
@@ -63,3 +63,43 @@ func (r *NoOpRecorder) IncActiveReconciliations(_, _ string) {
 func (r *NoOpRecorder) DecActiveReconciliations(_, _ string) {
 	// No-op
 }
+
+// RecordArtifactSize does nothing
+func (r *NoOpRecorder) RecordArtifactSize(_, _ string, _ int64) {
+	// No-op
+}
+
+// RecordContentAge does nothing
+func (r *NoOpRecorder) RecordContentAge(_, _ string, _ time.Duration) {
+	// No-op
+}
+
+// RecordDataFresh does nothing
+func (r *NoOpRecorder) RecordDataFresh(_, _ string, _ bool) {
+	// No-op
+}
+
+// DeleteSourceMetrics does nothing
+func (r *NoOpRecorder) DeleteSourceMetrics(_, _ string) {
+	// No-op
+}
+
+// RecordFetchRateLimited does nothing
+func (r *NoOpRecorder) RecordFetchRateLimited(_ string) {
+	// No-op
+}
+
+// RecordNotification does nothing
+func (r *NoOpRecorder) RecordNotification(_, _ string, _ bool) {
+	// No-op
+}
+
+// RecordCircuitBreakerState does nothing
+func (r *NoOpRecorder) RecordCircuitBreakerState(_ string, _ bool) {
+	// No-op
+}
+
+// RecordReconcileStuck does nothing
+func (r *NoOpRecorder) RecordReconcileStuck(_, _ string) {
+	// No-op
+}
@@ -34,6 +34,32 @@ const (
 	successFalse = "false"
 )
 
+// SourceLabels selects the labels identifying a source on per-source metrics
+type SourceLabels string
+
+const (
+	// SourceLabelsName labels metrics with the namespace and name of the source
+	SourceLabelsName SourceLabels = "name"
+
+	// SourceLabelsNamespace labels metrics with the namespace of the source only
+	SourceLabelsNamespace SourceLabels = "namespace"
+
+	// SourceLabelsNone drops the namespace and name labels, aggregating metrics by source type
+	SourceLabelsNone SourceLabels = "none"
+)
+
+// PrometheusOptions controls the labels of the metrics recorded by PrometheusRecorder
+type PrometheusOptions struct {
+	// SourceLabels selects the labels identifying a source, empty labels metrics with the
+	// namespace and name. Gauges describing the artifact of a single source are only
+	// recorded when labeled with the name.
+	SourceLabels SourceLabels
+
+	// PerSourceActiveReconciliations keeps the namespace and name labels on the active
+	// reconciliations gauge regardless of SourceLabels
+	PerSourceActiveReconciliations bool
+}
+
 // PrometheusRecorder implements MetricsRecorder using Prometheus metrics
 type PrometheusRecorder struct {
 	reconciliationTotal       *prometheus.CounterVec
@@ -45,17 +71,62 @@ type PrometheusRecorder struct {
 	artifactOperationTotal    *prometheus.CounterVec
 	artifactOperationDuration *prometheus.HistogramVec
 	activeReconciliations     *prometheus.GaugeVec
+	artifactSize              *prometheus.GaugeVec
+	contentAge                *prometheus.GaugeVec
+	dataFresh                 *prometheus.GaugeVec
+	fetchRateLimitedTotal     *prometheus.CounterVec
+	notificationTotal         *prometheus.CounterVec
+	circuitBreakerOpen        *prometheus.GaugeVec
+	reconcileStuckTotal       *prometheus.CounterVec
+
+	// options controls the labels identifying a source, the zero value labels metrics
+	// with the namespace and name
+	options PrometheusOptions
 }
 
 // NewPrometheusRecorder creates a new PrometheusRecorder and registers metrics
-func NewPrometheusRecorder() *PrometheusRecorder {
-	recorder := &PrometheusRecorder{
+func NewPrometheusRecorder(options PrometheusOptions) *PrometheusRecorder {
+	recorder := newPrometheusRecorder(options)
+
+	// Register all metrics with controller-runtime metrics registry
+	metrics.Registry.MustRegister(
+		recorder.reconciliationTotal,
+		recorder.reconciliationDuration,
+		recorder.sourceRequestTotal,
+		recorder.sourceRequestDuration,
+		recorder.hookExecutionTotal,
+		recorder.hookExecutionDuration,
+		recorder.artifactOperationTotal,
+		recorder.artifactOperationDuration,
+		recorder.activeReconciliations,
+		recorder.artifactSize,
+		recorder.contentAge,
+		recorder.dataFresh,
+		recorder.fetchRateLimitedTotal,
+		recorder.notificationTotal,
+		recorder.circuitBreakerOpen,
+		recorder.reconcileStuckTotal,
+	)
+
+	return recorder
+}
+
+// newPrometheusRecorder creates the metrics of a PrometheusRecorder without registering them
+func newPrometheusRecorder(options PrometheusOptions) *PrometheusRecorder {
+	sourceLabels := sourceLabelNames(options.SourceLabels)
+	activeLabels := sourceLabels
+	if options.PerSourceActiveReconciliations {
+		activeLabels = []string{"namespace", "name"}
+	}
+
+	return &PrometheusRecorder{
+		options: options,
 		reconciliationTotal: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "externalsource_reconciliation_total",
 				Help: "Total number of reconciliations performed",
 			},
-			[]string{"namespace", "name", "source_type", "success"},
+			append(sourceLabels, "source_type", "success"),
 		),
 		reconciliationDuration: prometheus.NewHistogramVec(
 			prometheus.HistogramOpts{
@@ -63,7 +134,7 @@ func NewPrometheusRecorder() *PrometheusRecorder {
 				Help:    "Duration of reconciliation operations in seconds",
 				Buckets: prometheus.DefBuckets,
 			},
-			[]string{"namespace", "name", "source_type", "success"},
+			append(sourceLabels, "source_type", "success"),
 		),
 		sourceRequestTotal: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
@@ -100,7 +171,7 @@ func NewPrometheusRecorder() *PrometheusRecorder {
 				Name: "externalsource_artifact_operation_total",
 				Help: "Total number of artifact operations performed",
 			},
-			[]string{"operation", "success"},
+			[]string{"operation", "backend", "success"},
 		),
 		artifactOperationDuration: prometheus.NewHistogramVec(
 			prometheus.HistogramOpts{
@@ -108,31 +179,103 @@ func NewPrometheusRecorder() *PrometheusRecorder {
 				Help:    "Duration of artifact operations in seconds",
 				Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
 			},
-			[]string{"operation", "success"},
+			[]string{"operation", "backend", "success"},
 		),
 		activeReconciliations: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "externalsource_active_reconciliations",
 				Help: "Number of currently active reconciliations",
 			},
+			activeLabels,
+		),
+		artifactSize: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "externalsource_artifact_size_bytes",
+				Help: "Size of the current artifact in bytes",
+			},
+			[]string{"namespace", "name"},
+		),
+		contentAge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "externalsource_content_age_seconds",
+				Help: "Seconds since the source last confirmed the data in the current artifact, as of the last reconciliation",
+			},
+			[]string{"namespace", "name"},
+		),
+		dataFresh: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "externalsource_data_fresh",
+				Help: "Whether the last reconciliation of a source fetched its data successfully (1) or failed (0)",
+			},
 			[]string{"namespace", "name"},
 		),
+		fetchRateLimitedTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "externalsource_fetch_rate_limited_total",
+				Help: "Total number of source requests delayed by the global fetch rate limiter",
+			},
+			[]string{"source_type"},
+		),
+		notificationTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "externalsource_notification_total",
+				Help: "Total number of artifact change notifications sent",
+			},
+			append(sourceLabels, "success"),
+		),
+		circuitBreakerOpen: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "externalsource_circuit_breaker_open",
+				Help: "Whether the circuit breaker for an upstream host is open (1) or closed (0)",
+			},
+			[]string{"host"},
+		),
+		reconcileStuckTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "externalsource_reconcile_stuck_total",
+				Help: "Total number of reconciliations that ran longer than the stuck reconcile threshold",
+			},
+			sourceLabels,
+		),
 	}
+}
 
-	// Register all metrics with controller-runtime metrics registry
-	metrics.Registry.MustRegister(
-		recorder.reconciliationTotal,
-		recorder.reconciliationDuration,
-		recorder.sourceRequestTotal,
-		recorder.sourceRequestDuration,
-		recorder.hookExecutionTotal,
-		recorder.hookExecutionDuration,
-		recorder.artifactOperationTotal,
-		recorder.artifactOperationDuration,
-		recorder.activeReconciliations,
-	)
+// sourceLabelNames returns the names of the labels identifying a source
+func sourceLabelNames(sourceLabels SourceLabels) []string {
+	switch sourceLabels {
+	case SourceLabelsNamespace:
+		return []string{"namespace"}
+	case SourceLabelsNone:
+		return []string{}
+	default:
+		return []string{"namespace", "name"}
+	}
+}
 
-	return recorder
+// sourceLabelValues returns the values of the labels identifying a source
+func (r *PrometheusRecorder) sourceLabelValues(namespace, name string) []string {
+	switch r.options.SourceLabels {
+	case SourceLabelsNamespace:
+		return []string{namespace}
+	case SourceLabelsNone:
+		return []string{}
+	default:
+		return []string{namespace, name}
+	}
+}
+
+// activeLabelValues returns the label values of the active reconciliations gauge
+func (r *PrometheusRecorder) activeLabelValues(namespace, name string) []string {
+	if r.options.PerSourceActiveReconciliations {
+		return []string{namespace, name}
+	}
+	return r.sourceLabelValues(namespace, name)
+}
+
+// perSourceGauges returns true if gauges describing the artifact of a single source are
+// recorded, which requires the name label
+func (r *PrometheusRecorder) perSourceGauges() bool {
+	return r.options.SourceLabels == "" || r.options.SourceLabels == SourceLabelsName
 }
 
 // RecordReconciliation records a reconciliation attempt with its outcome
@@ -142,8 +285,9 @@ func (r *PrometheusRecorder) RecordReconciliation(namespace, name, sourceType st
 		successLabel = successTrue
 	}
 
-	r.reconciliationTotal.WithLabelValues(namespace, name, sourceType, successLabel).Inc()
-	r.reconciliationDuration.WithLabelValues(namespace, name, sourceType, successLabel).Observe(duration.Seconds())
+	labels := append(r.sourceLabelValues(namespace, name), sourceType, successLabel)
+	r.reconciliationTotal.WithLabelValues(labels...).Inc()
+	r.reconciliationDuration.WithLabelValues(labels...).Observe(duration.Seconds())
 }
 
 // RecordSourceRequest records a request to an external source
@@ -169,22 +313,89 @@ func (r *PrometheusRecorder) RecordHookExecution(hookName, retryPolicy string, s
 }
 
 // RecordArtifactOperation records an artifact storage operation
-func (r *PrometheusRecorder) RecordArtifactOperation(operation string, success bool, duration time.Duration) {
+func (r *PrometheusRecorder) RecordArtifactOperation(operation, backend string, success bool, duration time.Duration) {
 	successLabel := successFalse
 	if success {
 		successLabel = successTrue
 	}
 
-	r.artifactOperationTotal.WithLabelValues(operation, successLabel).Inc()
-	r.artifactOperationDuration.WithLabelValues(operation, successLabel).Observe(duration.Seconds())
+	r.artifactOperationTotal.WithLabelValues(operation, backend, successLabel).Inc()
+	r.artifactOperationDuration.WithLabelValues(operation, backend, successLabel).Observe(duration.Seconds())
 }
 
 // IncActiveReconciliations increments the count of active reconciliations
 func (r *PrometheusRecorder) IncActiveReconciliations(namespace, name string) {
-	r.activeReconciliations.WithLabelValues(namespace, name).Inc()
+	r.activeReconciliations.WithLabelValues(r.activeLabelValues(namespace, name)...).Inc()
 }
 
 // DecActiveReconciliations decrements the count of active reconciliations
 func (r *PrometheusRecorder) DecActiveReconciliations(namespace, name string) {
-	r.activeReconciliations.WithLabelValues(namespace, name).Dec()
+	r.activeReconciliations.WithLabelValues(r.activeLabelValues(namespace, name)...).Dec()
+}
+
+// RecordArtifactSize records the size of the current artifact of a source
+func (r *PrometheusRecorder) RecordArtifactSize(namespace, name string, sizeBytes int64) {
+	if !r.perSourceGauges() {
+		return
+	}
+	r.artifactSize.WithLabelValues(namespace, name).Set(float64(sizeBytes))
+}
+
+// RecordContentAge records how long ago the source last confirmed the data in the current artifact
+func (r *PrometheusRecorder) RecordContentAge(namespace, name string, age time.Duration) {
+	if !r.perSourceGauges() {
+		return
+	}
+	r.contentAge.WithLabelValues(namespace, name).Set(age.Seconds())
+}
+
+// RecordDataFresh records whether the last reconciliation of a source fetched its data successfully
+func (r *PrometheusRecorder) RecordDataFresh(namespace, name string, fresh bool) {
+	if !r.perSourceGauges() {
+		return
+	}
+
+	value := 0.0
+	if fresh {
+		value = 1
+	}
+
+	r.dataFresh.WithLabelValues(namespace, name).Set(value)
+}
+
+// DeleteSourceMetrics removes the per-source gauges of a deleted source
+func (r *PrometheusRecorder) DeleteSourceMetrics(namespace, name string) {
+	r.artifactSize.DeleteLabelValues(namespace, name)
+	r.contentAge.DeleteLabelValues(namespace, name)
+	r.dataFresh.DeleteLabelValues(namespace, name)
+}
+
+// RecordFetchRateLimited records a source request that waited for the global fetch rate limiter
+func (r *PrometheusRecorder) RecordFetchRateLimited(sourceType string) {
+	r.fetchRateLimitedTotal.WithLabelValues(sourceType).Inc()
+}
+
+// RecordNotification records an artifact change notification attempt
+func (r *PrometheusRecorder) RecordNotification(namespace, name string, success bool) {
+	successLabel := successFalse
+	if success {
+		successLabel = successTrue
+	}
+
+	r.notificationTotal.WithLabelValues(append(r.sourceLabelValues(namespace, name), successLabel)...).Inc()
+}
+
+// RecordCircuitBreakerState records whether the circuit breaker for an upstream host is open
+func (r *PrometheusRecorder) RecordCircuitBreakerState(host string, open bool) {
+	value := 0.0
+	if open {
+		value = 1
+	}
+
+	r.circuitBreakerOpen.WithLabelValues(host).Set(value)
+}
+
+// RecordReconcileStuck records a reconciliation that exceeded the stuck reconcile threshold
+func (r *PrometheusRecorder) RecordReconcileStuck(namespace, name string) {
+	r.reconcileStuckTotal.WithLabelValues(r.sourceLabelValues(namespace, name)...).Inc()
 }
@@ -241,7 +241,7 @@ func TestPrometheusRecorder_RecordArtifactOperation(t *testing.T) {
 				Name: "externalsource_artifact_operation_total",
 				Help: "Total number of artifact operations performed",
 			},
-			[]string{"operation", "success"},
+			[]string{"operation", "backend", "success"},
 		),
 		artifactOperationDuration: prometheus.NewHistogramVec(
 			prometheus.HistogramOpts{
@@ -249,7 +249,7 @@ func TestPrometheusRecorder_RecordArtifactOperation(t *testing.T) {
 				Help:    "Duration of artifact operations in seconds",
 				Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
 			},
-			[]string{"operation", "success"},
+			[]string{"operation", "backend", "success"},
 		),
 	}
 
@@ -258,32 +258,43 @@ func TestPrometheusRecorder_RecordArtifactOperation(t *testing.T) {
 	tests := []struct {
 		name      string
 		operation string
+		backend   string
 		success   bool
 		duration  time.Duration
 	}{
 		{
 			name:      "successful package operation",
 			operation: "package",
+			backend:   "s3",
 			success:   true,
 			duration:  100 * time.Millisecond,
 		},
 		{
 			name:      "successful store operation",
 			operation: "store",
+			backend:   "s3",
 			success:   true,
 			duration:  500 * time.Millisecond,
 		},
 		{
 			name:      "failed store operation",
 			operation: "store",
+			backend:   "s3",
 			success:   false,
 			duration:  2 * time.Second,
 		},
+		{
+			name:      "store operation on another backend",
+			operation: "store",
+			backend:   "pvc",
+			success:   true,
+			duration:  50 * time.Millisecond,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			recorder.RecordArtifactOperation(tt.operation, tt.success, tt.duration)
+			recorder.RecordArtifactOperation(tt.operation, tt.backend, tt.success, tt.duration)
 
 			successLabel := successFalse
 			if tt.success {
@@ -291,7 +302,7 @@ func TestPrometheusRecorder_RecordArtifactOperation(t *testing.T) {
 			}
 
 			// Check counter metric
-			counter := recorder.artifactOperationTotal.WithLabelValues(tt.operation, successLabel)
+			counter := recorder.artifactOperationTotal.WithLabelValues(tt.operation, tt.backend, successLabel)
 			if got := testutil.ToFloat64(counter); got != 1 {
 				t.Errorf("RecordArtifactOperation() counter = %v, want 1", got)
 			}
@@ -350,9 +361,70 @@ func TestPrometheusRecorder_ActiveReconciliations(t *testing.T) {
 	}
 }
 
+func TestPrometheusRecorder_ContentGauges(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	recorder := &PrometheusRecorder{
+		artifactSize: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "externalsource_artifact_size_bytes",
+				Help: "Size of the current artifact in bytes",
+			},
+			[]string{"namespace", "name"},
+		),
+		contentAge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "externalsource_content_age_seconds",
+				Help: "Seconds since the source last confirmed the data in the current artifact, as of the last reconciliation",
+			},
+			[]string{"namespace", "name"},
+		),
+		dataFresh: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "externalsource_data_fresh",
+				Help: "Whether the last reconciliation of a source fetched its data successfully (1) or failed (0)",
+			},
+			[]string{"namespace", "name"},
+		),
+	}
+
+	registry.MustRegister(recorder.artifactSize, recorder.contentAge, recorder.dataFresh)
+
+	recorder.RecordArtifactSize("default", "test-source", 4096)
+	if got := testutil.ToFloat64(recorder.artifactSize.WithLabelValues("default", "test-source")); got != 4096 {
+		t.Errorf("artifact size = %v, want 4096", got)
+	}
+
+	recorder.RecordContentAge("default", "test-source", 90*time.Second)
+	if got := testutil.ToFloat64(recorder.contentAge.WithLabelValues("default", "test-source")); got != 90 {
+		t.Errorf("content age = %v, want 90", got)
+	}
+
+	recorder.RecordDataFresh("default", "test-source", false)
+	if got := testutil.ToFloat64(recorder.dataFresh.WithLabelValues("default", "test-source")); got != 0 {
+		t.Errorf("data fresh = %v, want 0", got)
+	}
+
+	// Later values replace earlier ones
+	recorder.RecordDataFresh("default", "test-source", true)
+	if got := testutil.ToFloat64(recorder.dataFresh.WithLabelValues("default", "test-source")); got != 1 {
+		t.Errorf("data fresh = %v, want 1", got)
+	}
+
+	recorder.RecordArtifactSize("default", "test-source", 1024)
+	if got := testutil.ToFloat64(recorder.artifactSize.WithLabelValues("default", "test-source")); got != 1024 {
+		t.Errorf("artifact size = %v, want 1024", got)
+	}
+
+	recorder.DeleteSourceMetrics("default", "test-source")
+	if got := testutil.CollectAndCount(registry); got != 0 {
+		t.Errorf("series after delete = %v, want 0", got)
+	}
+}
+
 func TestNewPrometheusRecorder(t *testing.T) {
 	// This test verifies that NewPrometheusRecorder creates all metrics without panicking
-	recorder := NewPrometheusRecorder()
+	recorder := NewPrometheusRecorder(PrometheusOptions{})
 
 	if recorder == nil {
 		t.Fatal("NewPrometheusRecorder() returned nil")
@@ -386,14 +458,27 @@ func TestNewPrometheusRecorder(t *testing.T) {
 	if recorder.activeReconciliations == nil {
 		t.Error("activeReconciliations metric not initialized")
 	}
+	if recorder.artifactSize == nil {
+		t.Error("artifactSize metric not initialized")
+	}
+	if recorder.contentAge == nil {
+		t.Error("contentAge metric not initialized")
+	}
+	if recorder.dataFresh == nil {
+		t.Error("dataFresh metric not initialized")
+	}
 
 	// Test that we can record metrics without panicking
 	recorder.RecordReconciliation("default", "test", "http", true, 100*time.Millisecond)
 	recorder.RecordSourceRequest("http", true, 200*time.Millisecond)
 	recorder.RecordHookExecution("test-hook", "retry", true, 10*time.Millisecond)
-	recorder.RecordArtifactOperation("package", true, 50*time.Millisecond)
+	recorder.RecordArtifactOperation("package", "memory", true, 50*time.Millisecond)
 	recorder.IncActiveReconciliations("default", "test")
 	recorder.DecActiveReconciliations("default", "test")
+	recorder.RecordArtifactSize("default", "test", 1024)
+	recorder.RecordContentAge("default", "test", time.Minute)
+	recorder.RecordDataFresh("default", "test", true)
+	recorder.DeleteSourceMetrics("default", "test")
 }
 
 func TestPrometheusRecorder_MetricNames(t *testing.T) {
@@ -451,3 +536,149 @@ func TestPrometheusRecorder_MetricNames(t *testing.T) {
 		}
 	}
 }
+
+func TestPrometheusRecorder_RecordFetchRateLimited(t *testing.T) {
+	recorder := &PrometheusRecorder{
+		fetchRateLimitedTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "externalsource_fetch_rate_limited_total",
+				Help: "Total number of source requests delayed by the global fetch rate limiter",
+			},
+			[]string{"source_type"},
+		),
+	}
+
+	recorder.RecordFetchRateLimited("http")
+	recorder.RecordFetchRateLimited("http")
+	recorder.RecordFetchRateLimited("git")
+
+	if got := testutil.ToFloat64(recorder.fetchRateLimitedTotal.WithLabelValues("http")); got != 2 {
+		t.Errorf("http rate limited = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(recorder.fetchRateLimitedTotal.WithLabelValues("git")); got != 1 {
+		t.Errorf("git rate limited = %v, want 1", got)
+	}
+}
+
+func TestPrometheusRecorder_RecordNotification(t *testing.T) {
+	recorder := &PrometheusRecorder{
+		notificationTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "externalsource_notification_total",
+				Help: "Total number of artifact change notifications sent",
+			},
+			[]string{"namespace", "name", "success"},
+		),
+	}
+
+	recorder.RecordNotification("default", "config", true)
+	recorder.RecordNotification("default", "config", false)
+	recorder.RecordNotification("default", "config", true)
+
+	if got := testutil.ToFloat64(recorder.notificationTotal.WithLabelValues("default", "config", "true")); got != 2 {
+		t.Errorf("successful notifications = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(recorder.notificationTotal.WithLabelValues("default", "config", "false")); got != 1 {
+		t.Errorf("failed notifications = %v, want 1", got)
+	}
+}
+
+func TestPrometheusRecorder_RecordCircuitBreakerState(t *testing.T) {
+	recorder := &PrometheusRecorder{
+		circuitBreakerOpen: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "externalsource_circuit_breaker_open",
+				Help: "Whether the circuit breaker for an upstream host is open (1) or closed (0)",
+			},
+			[]string{"host"},
+		),
+	}
+
+	recorder.RecordCircuitBreakerState("api.example.com", true)
+	if got := testutil.ToFloat64(recorder.circuitBreakerOpen.WithLabelValues("api.example.com")); got != 1 {
+		t.Errorf("open breaker state = %v, want 1", got)
+	}
+
+	recorder.RecordCircuitBreakerState("api.example.com", false)
+	if got := testutil.ToFloat64(recorder.circuitBreakerOpen.WithLabelValues("api.example.com")); got != 0 {
+		t.Errorf("closed breaker state = %v, want 0", got)
+	}
+}
+
+func TestPrometheusRecorder_SourceLabels(t *testing.T) {
+	tests := []struct {
+		name          string
+		options       PrometheusOptions
+		labels        []string
+		activeLabels  []string
+		contentGauges int
+	}{
+		{
+			name:          "namespace and name",
+			options:       PrometheusOptions{},
+			labels:        []string{"default", "app"},
+			activeLabels:  []string{"default", "app"},
+			contentGauges: 1,
+		},
+		{
+			name:         "namespace only",
+			options:      PrometheusOptions{SourceLabels: SourceLabelsNamespace},
+			labels:       []string{"default"},
+			activeLabels: []string{"default"},
+		},
+		{
+			name:         "aggregated by source type",
+			options:      PrometheusOptions{SourceLabels: SourceLabelsNone},
+			labels:       []string{},
+			activeLabels: []string{},
+		},
+		{
+			name:         "aggregated with per-source active reconciliations",
+			options:      PrometheusOptions{SourceLabels: SourceLabelsNone, PerSourceActiveReconciliations: true},
+			labels:       []string{},
+			activeLabels: []string{"default", "app"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := newPrometheusRecorder(tt.options)
+
+			recorder.RecordReconciliation("default", "app", "http", true, time.Millisecond)
+			recorder.RecordReconciliation("default", "other", "http", true, time.Millisecond)
+			recorder.IncActiveReconciliations("default", "app")
+			recorder.RecordNotification("default", "app", true)
+			recorder.RecordArtifactSize("default", "app", 1024)
+			recorder.RecordReconcileStuck("default", "app")
+
+			// Sources sharing the remaining labels are aggregated into the same series
+			wantReconciliations := 2.0
+			if len(tt.labels) == 2 {
+				wantReconciliations = 1
+			}
+			counter := recorder.reconciliationTotal.WithLabelValues(append(tt.labels, "http", successTrue)...)
+			if got := testutil.ToFloat64(counter); got != wantReconciliations {
+				t.Errorf("Reconciliations = %v, want %v", got, wantReconciliations)
+			}
+
+			gauge := recorder.activeReconciliations.WithLabelValues(tt.activeLabels...)
+			if got := testutil.ToFloat64(gauge); got != 1 {
+				t.Errorf("Active reconciliations = %v, want 1", got)
+			}
+
+			notifications := recorder.notificationTotal.WithLabelValues(append(tt.labels, successTrue)...)
+			if got := testutil.ToFloat64(notifications); got != 1 {
+				t.Errorf("Notifications = %v, want 1", got)
+			}
+
+			if got := testutil.ToFloat64(recorder.reconcileStuckTotal.WithLabelValues(tt.labels...)); got != 1 {
+				t.Errorf("Stuck reconciliations = %v, want 1", got)
+			}
+
+			// Gauges of a single artifact are only recorded per source
+			if got := testutil.CollectAndCount(recorder.artifactSize); got != tt.contentGauges {
+				t.Errorf("Artifact size series = %v, want %v", got, tt.contentGauges)
+			}
+		})
+	}
+}
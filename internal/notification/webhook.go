@@ -0,0 +1,132 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultTimeout bounds a single notification request
+const DefaultTimeout = 10 * time.Second
+
+// Event describes a change of the artifact revision of an ExternalSource
+type Event struct {
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace"`
+	OldRevision string `json:"oldRevision,omitempty"`
+	Revision    string `json:"revision"`
+	URL         string `json:"url"`
+}
+
+// Webhook is the endpoint a notification is sent to
+type Webhook struct {
+	// URL receives the event as a JSON POST request
+	URL string
+
+	// Token is sent as a bearer token when set
+	Token string
+}
+
+// Notifier sends artifact change notifications
+type Notifier interface {
+	// Notify delivers the event to the webhook
+	Notify(ctx context.Context, webhook Webhook, event Event) error
+}
+
+// WebhookNotifier implements Notifier by POSTing the event as JSON
+type WebhookNotifier struct {
+	httpClient *http.Client
+	userAgent  string
+}
+
+// NewWebhookNotifier creates a notifier that sends requests with the given timeout and User-Agent
+func NewWebhookNotifier(timeout time.Duration, userAgent string) *WebhookNotifier {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &WebhookNotifier{
+		httpClient: &http.Client{Timeout: timeout},
+		userAgent:  userAgent,
+	}
+}
+
+// Notify POSTs the event to the webhook URL and fails unless it responds with a 2xx status
+func (n *WebhookNotifier) Notify(ctx context.Context, webhook Webhook, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create notification request: %w", withoutURL(err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.userAgent != "" {
+		req.Header.Set("User-Agent", n.userAgent)
+	}
+	if webhook.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+webhook.Token)
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("notification request to %s failed: %w", redactURL(req.URL), withoutURL(err))
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// redactURL returns the URL without user info, query and fragment, webhook URLs may embed
+// credentials in any of them
+func redactURL(u *url.URL) string {
+	redacted := url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}
+	return redacted.String()
+}
+
+// withoutURL drops the request URL that net/url and net/http include in their errors
+func withoutURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
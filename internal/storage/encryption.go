@@ -0,0 +1,165 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// EncryptionKeySize is the size in bytes of the AES-256 key used for artifact encryption
+const EncryptionKeySize = 32
+
+// EncryptedBackend wraps a StorageBackend and encrypts objects at rest with AES-GCM.
+// Every object is sealed with a random nonce that is prepended to the ciphertext, and the
+// object key is authenticated so stored objects cannot be swapped between keys.
+type EncryptedBackend struct {
+	backend StorageBackend
+	aead    cipher.AEAD
+	baseURL string
+}
+
+// NewEncryptedBackend creates a backend that encrypts objects before storing them in the
+// wrapped backend and decrypts them on retrieval. If baseURL is set, object URLs point to
+// it instead of the wrapped backend, for backends whose objects are not served decrypted.
+func NewEncryptedBackend(backend StorageBackend, key []byte, baseURL string) (*EncryptedBackend, error) {
+	if len(key) != EncryptionKeySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes, got %d", EncryptionKeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	return &EncryptedBackend{
+		backend: backend,
+		aead:    aead,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+// ParseEncryptionKey decodes a base64 encoded AES-256 key, surrounding whitespace is ignored
+func ParseEncryptionKey(data []byte) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+	if err != nil {
+		return nil, fmt.Errorf("encryption key must be base64 encoded: %w", err)
+	}
+	if len(key) != EncryptionKeySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes, got %d", EncryptionKeySize, len(key))
+	}
+	return key, nil
+}
+
+// Unwrap returns the wrapped backend
+func (e *EncryptedBackend) Unwrap() StorageBackend {
+	return e.backend
+}
+
+// Store encrypts the data and stores it in the wrapped backend
+func (e *EncryptedBackend) Store(ctx context.Context, key string, data []byte) (string, error) {
+	nonce := make([]byte, e.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := e.aead.Seal(nonce, nonce, data, []byte(key))
+	url, err := e.backend.Store(ctx, key, sealed)
+	if err != nil {
+		return "", err
+	}
+
+	if e.baseURL != "" {
+		return e.GetURL(key), nil
+	}
+	return url, nil
+}
+
+// Retrieve retrieves the data from the wrapped backend and decrypts it
+func (e *EncryptedBackend) Retrieve(ctx context.Context, key string) ([]byte, error) {
+	sealed, err := e.backend.Retrieve(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := e.aead.NonceSize()
+	if len(sealed) < nonceSize+e.aead.Overhead() {
+		return nil, fmt.Errorf("failed to decrypt artifact %s: data too short", key)
+	}
+
+	data, err := e.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt artifact %s: %w", key, err)
+	}
+	return data, nil
+}
+
+// List returns a list of keys with the given prefix
+func (e *EncryptedBackend) List(ctx context.Context, prefix string) ([]string, error) {
+	return e.backend.List(ctx, prefix)
+}
+
+// ListObjects returns the objects with the given prefix from the wrapped backend
+func (e *EncryptedBackend) ListObjects(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	lister, ok := e.backend.(ObjectInfoLister)
+	if !ok {
+		return nil, fmt.Errorf("storage backend does not report object modification times")
+	}
+	return lister.ListObjects(ctx, prefix)
+}
+
+// Delete removes an object from the wrapped backend
+func (e *EncryptedBackend) Delete(ctx context.Context, key string) error {
+	return e.backend.Delete(ctx, key)
+}
+
+// BackendType reports the type of the wrapped backend
+func (e *EncryptedBackend) BackendType() string {
+	return BackendType(e.backend)
+}
+
+// GetURL returns the URL for accessing the stored object
+func (e *EncryptedBackend) GetURL(key string) string {
+	if e.baseURL != "" {
+		return fmt.Sprintf("%s/%s", e.baseURL, key)
+	}
+	return e.backend.GetURL(key)
+}
+
+// CheckHealth checks the wrapped backend when it depends on external storage
+func (e *EncryptedBackend) CheckHealth(ctx context.Context) error {
+	if checker, ok := e.backend.(HealthChecker); ok {
+		return checker.CheckHealth(ctx)
+	}
+	return nil
+}
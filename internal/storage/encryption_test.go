@@ -0,0 +1,155 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEncryptionKey() []byte {
+	return bytes.Repeat([]byte{0x42}, EncryptionKeySize)
+}
+
+func TestEncryptedBackend_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	memory := NewMemoryBackend("http://artifacts.example.com")
+	backend, err := NewEncryptedBackend(memory, testEncryptionKey(), "")
+	require.NoError(t, err)
+
+	data := []byte("artifact archive data")
+	url, err := backend.Store(ctx, testArtifactKey, data)
+	require.NoError(t, err)
+	assert.Equal(t, "http://artifacts.example.com/"+testArtifactKey, url)
+
+	// The wrapped backend only ever sees ciphertext
+	stored, ok := memory.GetData(testArtifactKey)
+	require.True(t, ok)
+	assert.NotContains(t, string(stored), string(data))
+	assert.Len(t, stored, len(data)+backend.aead.NonceSize()+backend.aead.Overhead())
+
+	retrieved, err := backend.Retrieve(ctx, testArtifactKey)
+	require.NoError(t, err)
+	assert.Equal(t, data, retrieved)
+
+	// Storing the same data again uses a fresh nonce
+	_, err = backend.Store(ctx, testArtifactKey, data)
+	require.NoError(t, err)
+	restored, _ := memory.GetData(testArtifactKey)
+	assert.NotEqual(t, stored, restored)
+
+	objects, err := backend.ListObjects(ctx, "artifacts/")
+	require.NoError(t, err)
+	assert.Len(t, objects, 1)
+
+	require.NoError(t, backend.Delete(ctx, testArtifactKey))
+	_, err = backend.Retrieve(ctx, testArtifactKey)
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestEncryptedBackend_RetrieveErrors(t *testing.T) {
+	ctx := context.Background()
+	memory := NewMemoryBackend()
+	backend, err := NewEncryptedBackend(memory, testEncryptionKey(), "")
+	require.NoError(t, err)
+
+	_, err = backend.Store(ctx, testArtifactKey, []byte("data"))
+	require.NoError(t, err)
+	sealed, _ := memory.GetData(testArtifactKey)
+
+	t.Run("tampered data", func(t *testing.T) {
+		tampered := bytes.Clone(sealed)
+		tampered[len(tampered)-1] ^= 0xff
+		_, err := memory.Store(ctx, "tampered.tar.gz", tampered)
+		require.NoError(t, err)
+
+		_, err = backend.Retrieve(ctx, "tampered.tar.gz")
+		assert.ErrorContains(t, err, "failed to decrypt artifact")
+	})
+
+	t.Run("object moved to another key", func(t *testing.T) {
+		_, err := memory.Store(ctx, "moved.tar.gz", sealed)
+		require.NoError(t, err)
+
+		_, err = backend.Retrieve(ctx, "moved.tar.gz")
+		assert.ErrorContains(t, err, "failed to decrypt artifact")
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		other, err := NewEncryptedBackend(memory, bytes.Repeat([]byte{0x24}, EncryptionKeySize), "")
+		require.NoError(t, err)
+
+		_, err = other.Retrieve(ctx, testArtifactKey)
+		assert.ErrorContains(t, err, "failed to decrypt artifact")
+	})
+
+	t.Run("truncated data", func(t *testing.T) {
+		_, err := memory.Store(ctx, "short.tar.gz", []byte("short"))
+		require.NoError(t, err)
+
+		_, err = backend.Retrieve(ctx, "short.tar.gz")
+		assert.ErrorContains(t, err, "data too short")
+	})
+}
+
+func TestEncryptedBackend_BaseURL(t *testing.T) {
+	backend, err := NewEncryptedBackend(NewMemoryBackend(), testEncryptionKey(), "http://artifact-server:8080/")
+	require.NoError(t, err)
+
+	url, err := backend.Store(context.Background(), testArtifactKey, []byte("data"))
+	require.NoError(t, err)
+	assert.Equal(t, "http://artifact-server:8080/"+testArtifactKey, url)
+	assert.Equal(t, url, backend.GetURL(testArtifactKey))
+}
+
+func TestEncryptedBackend_BackendType(t *testing.T) {
+	encrypted, err := NewEncryptedBackend(NewS3Backend(S3Config{Bucket: "artifacts"}), testEncryptionKey(), "")
+	require.NoError(t, err)
+
+	// The encrypted backend reports the type of the backend it wraps
+	assert.Equal(t, "s3", BackendType(encrypted))
+}
+
+func TestNewEncryptedBackend_InvalidKey(t *testing.T) {
+	_, err := NewEncryptedBackend(NewMemoryBackend(), []byte("too short"), "")
+	assert.ErrorContains(t, err, "encryption key must be 32 bytes")
+}
+
+func TestParseEncryptionKey(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString(testEncryptionKey())
+
+	key, err := ParseEncryptionKey([]byte(encoded + "\n"))
+	require.NoError(t, err)
+	assert.Equal(t, testEncryptionKey(), key)
+
+	_, err = ParseEncryptionKey([]byte("not base64!"))
+	assert.ErrorContains(t, err, "must be base64 encoded")
+
+	_, err = ParseEncryptionKey([]byte(base64.StdEncoding.EncodeToString([]byte("short"))))
+	assert.ErrorContains(t, err, "must be 32 bytes")
+}
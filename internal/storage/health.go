@@ -0,0 +1,56 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// DefaultHealthCheckTimeout bounds a single storage health check so that a slow backend
+// is reported as not ready instead of stalling the probe
+const DefaultHealthCheckTimeout = 3 * time.Second
+
+// ReadyzCheck returns a readiness check that reports an error while the backend's storage
+// is unreachable. Backends that do not implement HealthChecker are always ready.
+func ReadyzCheck(backend StorageBackend, timeout time.Duration) func(req *http.Request) error {
+	return func(req *http.Request) error {
+		checker, ok := backend.(HealthChecker)
+		if !ok {
+			return nil
+		}
+
+		ctx := context.Background()
+		if req != nil {
+			ctx = req.Context()
+		}
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		return checker.CheckHealth(ctx)
+	}
+}
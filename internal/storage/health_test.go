@@ -0,0 +1,143 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadyzCheck_Memory(t *testing.T) {
+	check := ReadyzCheck(NewMemoryBackend(), DefaultHealthCheckTimeout)
+	assert.NoError(t, check(httptest.NewRequest(http.MethodGet, "/readyz", nil)))
+}
+
+func TestReadyzCheck_PVC(t *testing.T) {
+	basePath := filepath.Join(t.TempDir(), "artifacts")
+	backend, err := NewPVCBackend(basePath, "")
+	require.NoError(t, err)
+
+	check := ReadyzCheck(backend, DefaultHealthCheckTimeout)
+	require.NoError(t, check(httptest.NewRequest(http.MethodGet, "/readyz", nil)))
+
+	_, err = os.Stat(filepath.Join(basePath, ".write-test"))
+	assert.True(t, os.IsNotExist(err), "write test file should be removed")
+
+	require.NoError(t, os.RemoveAll(basePath))
+	err = check(httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to stat base path")
+}
+
+func TestReadyzCheck_S3(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		delay   time.Duration
+		wantErr string
+	}{
+		{name: "bucket reachable", status: http.StatusOK},
+		{name: "access denied", status: http.StatusForbidden, wantErr: "failed with status 403"},
+		{name: "slow backend", status: http.StatusOK, delay: 200 * time.Millisecond, wantErr: "failed to reach bucket"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotRequest *http.Request
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotRequest = r
+				if tt.delay > 0 {
+					select {
+					case <-time.After(tt.delay):
+					case <-r.Context().Done():
+					}
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			backend := NewS3Backend(S3Config{
+				Endpoint:  strings.TrimPrefix(server.URL, "http://"),
+				Bucket:    "artifacts",
+				AccessKey: "access-key",
+				SecretKey: "secret-key",
+			})
+
+			err := ReadyzCheck(backend, 50*time.Millisecond)(httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, http.MethodHead, gotRequest.Method)
+			assert.Equal(t, "/artifacts/", gotRequest.URL.Path)
+			assert.True(t, strings.HasPrefix(gotRequest.Header.Get("Authorization"), "AWS4-HMAC-SHA256"))
+		})
+	}
+}
+
+func TestReadyzCheck_OCI(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "repository exists", status: http.StatusOK},
+		{name: "repository not pushed yet", status: http.StatusNotFound},
+		{name: "registry error", status: http.StatusInternalServerError, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"tags": []}`))
+			}))
+			defer server.Close()
+
+			backend, err := NewOCIBackend(OCIConfig{
+				Registry:   strings.TrimPrefix(server.URL, "http://"),
+				Repository: "flux/sources",
+				Insecure:   true,
+			})
+			require.NoError(t, err)
+
+			err = ReadyzCheck(backend, DefaultHealthCheckTimeout)(httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ package storage
 
 import (
 	"context"
+	"time"
 )
 
 // StorageBackend defines the interface for artifact storage backends
@@ -46,3 +47,51 @@ type StorageBackend interface {
 	// Retrieve retrieves data from the storage backend by key
 	Retrieve(ctx context.Context, key string) ([]byte, error)
 }
+
+// ObjectInfo describes a stored object
+type ObjectInfo struct {
+	// Key of the object
+	Key string
+
+	// LastModified is when the object was last stored
+	LastModified time.Time
+}
+
+// ObjectInfoLister is implemented by backends that can report when objects were stored
+type ObjectInfoLister interface {
+	// ListObjects returns the objects with the given prefix and their modification times
+	ListObjects(ctx context.Context, prefix string) ([]ObjectInfo, error)
+}
+
+// HealthChecker is implemented by backends that depend on external storage and can
+// verify it is reachable
+type HealthChecker interface {
+	// CheckHealth performs a lightweight request against the storage and returns an
+	// error if it is unusable
+	CheckHealth(ctx context.Context) error
+}
+
+// ContentDigester is implemented by backends that address stored objects by the digest of
+// their data, such as OCI registries. Consumers pulling from them verify that digest.
+type ContentDigester interface {
+	// ContentDigest returns the digest the backend addresses the data by, e.g. sha256:<hex>
+	ContentDigest(data []byte) string
+}
+
+// UnknownBackendType is reported for backends that do not implement TypeReporter
+const UnknownBackendType = "unknown"
+
+// TypeReporter is implemented by backends that report their type, such as s3 or pvc,
+// for labelling metrics
+type TypeReporter interface {
+	// BackendType returns the short name of the backend type
+	BackendType() string
+}
+
+// BackendType returns the type reported by the backend, or UnknownBackendType
+func BackendType(backend StorageBackend) string {
+	if reporter, ok := backend.(TypeReporter); ok {
+		return reporter.BackendType()
+	}
+	return UnknownBackendType
+}
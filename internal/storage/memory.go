@@ -28,12 +28,14 @@ import (
 	"log"
 	"strings"
 	"sync"
+	"time"
 )
 
 // MemoryBackend implements StorageBackend for in-memory storage
 // WARNING: This backend is non-persistent and data will be lost on controller restart
 type MemoryBackend struct {
 	data    map[string][]byte
+	stored  map[string]time.Time
 	mutex   sync.RWMutex
 	warned  bool
 	baseURL string
@@ -44,7 +46,8 @@ type MemoryBackend struct {
 // Otherwise, URLs will use the memory:// scheme
 func NewMemoryBackend(baseURL ...string) *MemoryBackend {
 	backend := &MemoryBackend{
-		data: make(map[string][]byte),
+		data:   make(map[string][]byte),
+		stored: make(map[string]time.Time),
 	}
 
 	if len(baseURL) > 0 && baseURL[0] != "" {
@@ -71,6 +74,7 @@ func (m *MemoryBackend) Store(_ context.Context, key string, data []byte) (strin
 	copy(dataCopy, data)
 
 	m.data[key] = dataCopy
+	m.stored[key] = time.Now()
 
 	// Return URL based on baseURL if set, otherwise use memory:// scheme
 	return m.GetURL(key), nil
@@ -91,15 +95,36 @@ func (m *MemoryBackend) List(_ context.Context, prefix string) ([]string, error)
 	return keys, nil
 }
 
+// ListObjects returns the objects with the given prefix and the time they were stored
+func (m *MemoryBackend) ListObjects(_ context.Context, prefix string) ([]ObjectInfo, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var objects []ObjectInfo
+	for key := range m.data {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, ObjectInfo{Key: key, LastModified: m.stored[key]})
+		}
+	}
+
+	return objects, nil
+}
+
 // Delete removes an object from memory
 func (m *MemoryBackend) Delete(_ context.Context, key string) error {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
 	delete(m.data, key)
+	delete(m.stored, key)
 	return nil
 }
 
+// BackendType returns "memory"
+func (m *MemoryBackend) BackendType() string {
+	return "memory"
+}
+
 // GetURL returns the URL for accessing the stored object
 func (m *MemoryBackend) GetURL(key string) string {
 	if m.baseURL != "" {
@@ -294,6 +294,17 @@ func TestMemoryBackend_WithBaseURL(t *testing.T) {
 	}
 }
 
+func TestMemoryBackend_BackendType(t *testing.T) {
+	if got := BackendType(NewMemoryBackend()); got != "memory" {
+		t.Errorf("BackendType() = %v, want %v", got, "memory")
+	}
+
+	// Backends that don't report a type are unknown
+	if got := BackendType(struct{ StorageBackend }{NewMemoryBackend()}); got != UnknownBackendType {
+		t.Errorf("BackendType() = %v, want %v", got, UnknownBackendType)
+	}
+}
+
 func TestMemoryBackend_WithoutBaseURL(t *testing.T) {
 	// Test backward compatibility with empty baseURL
 	backend := NewMemoryBackend()
@@ -0,0 +1,634 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// OCI media types used for ExternalSource artifacts, matching the Flux conventions
+	ociManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	ociConfigMediaType   = "application/vnd.cncf.flux.config.v1+json"
+	ociContentMediaType  = "application/vnd.cncf.flux.content.v1.tar+gzip"
+
+	// ociZstdContentMediaType is the layer media type of zstd compressed artifacts
+	ociZstdContentMediaType = "application/vnd.cncf.flux.content.v1.tar+zstd"
+
+	ociRevisionAnnotation = "org.opencontainers.image.revision"
+	ociCreatedAnnotation  = "org.opencontainers.image.created"
+	ociTitleAnnotation    = "org.opencontainers.image.title"
+)
+
+// ociCreated is the created annotation of every manifest. A fixed time keeps the manifest of
+// a revision, and so its digest, the same every time it is pushed.
+var ociCreated = time.Unix(0, 0).UTC().Format(time.RFC3339)
+
+// invalidTagChars matches characters that are not allowed in OCI tags
+var invalidTagChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]`)
+
+// OCIBackend implements StorageBackend for OCI registries
+type OCIBackend struct {
+	registry   string
+	repository string
+	insecure   bool
+	username   string
+	password   string
+	httpClient *http.Client
+
+	tokens     map[string]string
+	tokenMutex sync.Mutex
+}
+
+// OCIConfig holds configuration for OCI registry storage
+type OCIConfig struct {
+	// Registry is the registry host, e.g. ghcr.io
+	Registry string
+	// Repository is the base repository artifacts are pushed under
+	Repository string
+	// Insecure uses plain HTTP to talk to the registry
+	Insecure bool
+	// DockerConfigJSON is the content of a docker config secret used for authentication
+	DockerConfigJSON []byte
+}
+
+// ociDescriptor describes content stored in a registry
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ociManifest is an OCI image manifest
+type ociManifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	Config        ociDescriptor     `json:"config"`
+	Layers        []ociDescriptor   `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// dockerConfig is the format of a kubernetes.io/dockerconfigjson secret
+type dockerConfig struct {
+	Auths map[string]struct {
+		Auth     string `json:"auth"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+	} `json:"auths"`
+}
+
+// NewOCIBackend creates a new OCI registry storage backend
+func NewOCIBackend(config OCIConfig) (*OCIBackend, error) {
+	if config.Registry == "" {
+		return nil, fmt.Errorf("OCI registry is required")
+	}
+	if config.Repository == "" {
+		return nil, fmt.Errorf("OCI repository is required")
+	}
+
+	backend := &OCIBackend{
+		registry:   strings.TrimSuffix(config.Registry, "/"),
+		repository: strings.Trim(config.Repository, "/"),
+		insecure:   config.Insecure,
+		httpClient: &http.Client{
+			Timeout: 60 * time.Second,
+		},
+		tokens: make(map[string]string),
+	}
+
+	if len(config.DockerConfigJSON) > 0 {
+		username, password, err := parseDockerConfig(config.DockerConfigJSON, backend.registry)
+		if err != nil {
+			return nil, err
+		}
+		backend.username = username
+		backend.password = password
+	}
+
+	return backend, nil
+}
+
+// Store pushes the data as a single layer OCI artifact tagged with the artifact revision
+func (o *OCIBackend) Store(ctx context.Context, key string, data []byte) (string, error) {
+	repo, tag := o.reference(key)
+
+	mediaType := ociContentMediaType
+	if strings.HasSuffix(key, ".tar.zst") {
+		mediaType = ociZstdContentMediaType
+	}
+
+	layer := ociDescriptor{
+		MediaType: mediaType,
+		Digest:    o.ContentDigest(data),
+		Size:      int64(len(data)),
+		Annotations: map[string]string{
+			ociTitleAnnotation: path.Base(key),
+		},
+	}
+	if err := o.pushBlob(ctx, repo, layer.Digest, data); err != nil {
+		return "", fmt.Errorf("failed to push artifact layer: %w", err)
+	}
+
+	configData := []byte("{}")
+	config := ociDescriptor{
+		MediaType: ociConfigMediaType,
+		Digest:    o.ContentDigest(configData),
+		Size:      int64(len(configData)),
+	}
+	if err := o.pushBlob(ctx, repo, config.Digest, configData); err != nil {
+		return "", fmt.Errorf("failed to push artifact config: %w", err)
+	}
+
+	manifest, err := json.Marshal(ociManifest{
+		SchemaVersion: 2,
+		MediaType:     ociManifestMediaType,
+		Config:        config,
+		Layers:        []ociDescriptor{layer},
+		Annotations: map[string]string{
+			ociRevisionAnnotation: tag,
+			ociCreatedAnnotation:  ociCreated,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	resp, err := o.do(ctx, http.MethodPut, o.registryURL(repo, "manifests", tag), manifest, map[string]string{
+		"Content-Type": ociManifestMediaType,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to push manifest: %w", err)
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("OCI manifest push failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return o.GetURL(key), nil
+}
+
+// List returns the keys of all tagged artifacts in the repository for the given prefix
+func (o *OCIBackend) List(ctx context.Context, prefix string) ([]string, error) {
+	repo := o.repositoryFor(prefix)
+
+	resp, err := o.do(ctx, http.MethodGet, o.registryURL(repo, "tags", "list"), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return []string{}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("OCI tag list failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var tagList struct {
+		Tags []string `json:"tags"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tagList); err != nil {
+		return nil, fmt.Errorf("failed to decode tag list: %w", err)
+	}
+
+	dir := strings.TrimSuffix(prefix, "/")
+	keys := make([]string, 0, len(tagList.Tags))
+	for _, tag := range tagList.Tags {
+		keys = append(keys, fmt.Sprintf("%s/%s.tar.gz", dir, tag))
+	}
+
+	return keys, nil
+}
+
+// CheckHealth lists the tags of the base repository to verify the registry is reachable
+// and accepts the configured credentials
+func (o *OCIBackend) CheckHealth(ctx context.Context) error {
+	if _, err := o.List(ctx, ""); err != nil {
+		return fmt.Errorf("OCI registry check failed: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the manifest tagged for the key from the registry
+func (o *OCIBackend) Delete(ctx context.Context, key string) error {
+	repo, tag := o.reference(key)
+
+	resp, err := o.do(ctx, http.MethodHead, o.registryURL(repo, "manifests", tag), nil, map[string]string{
+		"Accept": ociManifestMediaType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to resolve manifest: %w", err)
+	}
+	closeBody(resp)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("OCI manifest lookup failed with status %d", resp.StatusCode)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return fmt.Errorf("registry did not return a digest for %s:%s", repo, tag)
+	}
+
+	resp, err = o.do(ctx, http.MethodDelete, o.registryURL(repo, "manifests", digest), nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete manifest: %w", err)
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("OCI manifest delete failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// ContentDigest returns the digest of the layer the data is pushed as, which Flux verifies
+// when pulling the artifact
+func (o *OCIBackend) ContentDigest(data []byte) string {
+	return "sha256:" + hashSHA256(data)
+}
+
+// BackendType returns "oci"
+func (o *OCIBackend) BackendType() string {
+	return "oci"
+}
+
+// GetURL returns the OCI reference for the stored artifact
+func (o *OCIBackend) GetURL(key string) string {
+	repo, tag := o.reference(key)
+	return fmt.Sprintf("oci://%s/%s:%s", o.registry, repo, tag)
+}
+
+// Retrieve pulls the layer of the artifact tagged for the key and verifies its digest
+func (o *OCIBackend) Retrieve(ctx context.Context, key string) ([]byte, error) {
+	repo, tag := o.reference(key)
+
+	resp, err := o.do(ctx, http.MethodGet, o.registryURL(repo, "manifests", tag), nil, map[string]string{
+		"Accept": ociManifestMediaType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch manifest: %w", err)
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("artifact not found: %s", key)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("OCI manifest fetch failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var manifest ociManifest
+	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+	if len(manifest.Layers) != 1 {
+		return nil, fmt.Errorf("artifact %s:%s has %d layers, expected 1", repo, tag, len(manifest.Layers))
+	}
+	layer := manifest.Layers[0]
+
+	blobResp, err := o.do(ctx, http.MethodGet, o.registryURL(repo, "blobs", layer.Digest), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch artifact layer: %w", err)
+	}
+	defer closeBody(blobResp)
+
+	if blobResp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(blobResp.Body)
+		return nil, fmt.Errorf("OCI blob fetch failed with status %d: %s", blobResp.StatusCode, string(body))
+	}
+
+	data, err := io.ReadAll(io.LimitReader(blobResp.Body, layer.Size+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifact layer: %w", err)
+	}
+	if digest := o.ContentDigest(data); digest != layer.Digest {
+		return nil, fmt.Errorf("artifact layer digest mismatch: expected %s, got %s", layer.Digest, digest)
+	}
+
+	return data, nil
+}
+
+// reference maps a storage key such as artifacts/<namespace>/<name>/<revision>.tar.gz
+// to a repository and tag, the tag is the same for every compression of a revision
+func (o *OCIBackend) reference(key string) (string, string) {
+	dir, file := path.Split(strings.TrimPrefix(key, "/"))
+	file = strings.TrimSuffix(strings.TrimSuffix(file, ".tar.gz"), ".tar.zst")
+	tag := invalidTagChars.ReplaceAllString(file, "-")
+	if len(tag) > 128 {
+		tag = tag[:128]
+	}
+	return o.repositoryFor(dir), tag
+}
+
+// repositoryFor returns the repository for a key prefix
+func (o *OCIBackend) repositoryFor(prefix string) string {
+	dir := strings.Trim(prefix, "/")
+	dir = strings.TrimPrefix(strings.TrimPrefix(dir, "artifacts"), "/")
+	return strings.ToLower(path.Join(o.repository, dir))
+}
+
+// registryURL builds a registry API URL
+func (o *OCIBackend) registryURL(repo, kind, reference string) string {
+	scheme := "https"
+	if o.insecure {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/v2/%s/%s/%s", scheme, o.registry, repo, kind, reference)
+}
+
+// pushBlob uploads a blob unless the registry already has it
+func (o *OCIBackend) pushBlob(ctx context.Context, repo, digest string, data []byte) error {
+	resp, err := o.do(ctx, http.MethodHead, o.registryURL(repo, "blobs", digest), nil, nil)
+	if err != nil {
+		return err
+	}
+	closeBody(resp)
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	resp, err = o.do(ctx, http.MethodPost, o.registryURL(repo, "blobs", "uploads/"), nil, nil)
+	if err != nil {
+		return err
+	}
+	closeBody(resp)
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("blob upload initiation failed with status %d", resp.StatusCode)
+	}
+
+	location, err := resp.Request.URL.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return fmt.Errorf("invalid upload location: %w", err)
+	}
+	query := location.Query()
+	query.Set("digest", digest)
+	location.RawQuery = query.Encode()
+
+	resp, err = o.do(ctx, http.MethodPut, location.String(), data, map[string]string{
+		"Content-Type": "application/octet-stream",
+	})
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("blob upload failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// do executes a registry request, answering basic and bearer token challenges
+func (o *OCIBackend) do(ctx context.Context, method, requestURL string, body []byte, headers map[string]string) (*http.Response, error) {
+	newRequest := func() (*http.Request, error) {
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, requestURL, reader)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		return req, nil
+	}
+
+	req, err := newRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	scope := o.scopeFor(requestURL)
+	o.tokenMutex.Lock()
+	token := o.tokens[scope]
+	o.tokenMutex.Unlock()
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	challenge := resp.Header.Get("WWW-Authenticate")
+	closeBody(resp)
+
+	req, err = newRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	scheme, params := parseChallenge(challenge)
+	switch scheme {
+	case "bearer":
+		if params["scope"] == "" {
+			params["scope"] = scope
+		}
+		token, err := o.fetchToken(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		o.tokenMutex.Lock()
+		o.tokens[scope] = token
+		o.tokenMutex.Unlock()
+		req.Header.Set("Authorization", "Bearer "+token)
+	case "basic":
+		if o.username == "" && o.password == "" {
+			return nil, fmt.Errorf("registry requires authentication but no credentials are configured")
+		}
+		req.SetBasicAuth(o.username, o.password)
+	default:
+		return nil, fmt.Errorf("unsupported registry authentication challenge: %q", challenge)
+	}
+
+	return o.httpClient.Do(req)
+}
+
+// fetchToken requests a bearer token from the registry token service
+func (o *OCIBackend) fetchToken(ctx context.Context, params map[string]string) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return "", fmt.Errorf("invalid token realm %q", params["realm"])
+	}
+
+	query := realm.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	for _, scope := range strings.Fields(params["scope"]) {
+		query.Add("scope", scope)
+	}
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	if o.username != "" || o.password != "" {
+		req.SetBasicAuth(o.username, o.password)
+	}
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("token request failed: %w", err)
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token request failed with status %d", resp.StatusCode)
+	}
+
+	var tokenResponse struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
+		return "", fmt.Errorf("failed to decode token response: %w", err)
+	}
+
+	if tokenResponse.Token != "" {
+		return tokenResponse.Token, nil
+	}
+	if tokenResponse.AccessToken != "" {
+		return tokenResponse.AccessToken, nil
+	}
+	return "", fmt.Errorf("token response did not contain a token")
+}
+
+// scopeFor returns the token scope needed for a registry API URL
+func (o *OCIBackend) scopeFor(requestURL string) string {
+	parsed, err := url.Parse(requestURL)
+	if err != nil {
+		return ""
+	}
+	repo := strings.TrimPrefix(parsed.Path, "/v2/")
+	for _, kind := range []string{"/manifests/", "/blobs/", "/tags/"} {
+		if idx := strings.Index(repo, kind); idx >= 0 {
+			repo = repo[:idx]
+			break
+		}
+	}
+	return fmt.Sprintf("repository:%s:pull,push,delete", repo)
+}
+
+// parseChallenge parses a WWW-Authenticate header into its scheme and parameters
+func parseChallenge(header string) (string, map[string]string) {
+	params := make(map[string]string)
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+
+	for rest != "" {
+		var pair string
+		rest = strings.TrimSpace(rest)
+		key, value, found := strings.Cut(rest, "=")
+		if !found {
+			break
+		}
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				break
+			}
+			pair = value[1 : end+1]
+			rest = strings.TrimPrefix(value[end+2:], ",")
+		} else {
+			pair, rest, _ = strings.Cut(value, ",")
+		}
+		params[strings.ToLower(strings.TrimSpace(key))] = pair
+	}
+
+	return strings.ToLower(scheme), params
+}
+
+// parseDockerConfig returns the credentials for the registry from docker config JSON
+func parseDockerConfig(data []byte, registry string) (string, string, error) {
+	var config dockerConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return "", "", fmt.Errorf("failed to parse docker config: %w", err)
+	}
+
+	for server, auth := range config.Auths {
+		host := strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+		host, _, _ = strings.Cut(host, "/")
+		if host != registry {
+			continue
+		}
+
+		if auth.Username != "" || auth.Password != "" {
+			return auth.Username, auth.Password, nil
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to decode docker config auth for %s: %w", server, err)
+		}
+		username, password, found := strings.Cut(string(decoded), ":")
+		if !found {
+			return "", "", fmt.Errorf("invalid docker config auth for %s", server)
+		}
+		return username, password, nil
+	}
+
+	return "", "", fmt.Errorf("no credentials found for registry %s in docker config", registry)
+}
+
+// closeBody closes a response body, ignoring errors
+func closeBody(resp *http.Response) {
+	if resp != nil && resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+}
+
+// hashSHA256 returns the hex-encoded SHA-256 digest of data
+func hashSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,403 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRegistry is a minimal in-memory OCI distribution registry
+type fakeRegistry struct {
+	mu        sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	tags      map[string]map[string]string
+	token     string
+	username  string
+	password  string
+}
+
+func newFakeRegistry() *fakeRegistry {
+	return &fakeRegistry{
+		blobs:     make(map[string][]byte),
+		manifests: make(map[string][]byte),
+		tags:      make(map[string]map[string]string),
+	}
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.URL.Path == "/token" {
+		username, password, _ := r.BasicAuth()
+		if username != f.username || password != f.password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": f.token})
+		return
+	}
+
+	if f.token != "" && r.Header.Get("Authorization") != "Bearer "+f.token {
+		w.Header().Set("WWW-Authenticate",
+			fmt.Sprintf(`Bearer realm="http://%s/token",service="fake",scope="repository:test:pull,push"`, r.Host))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	p := strings.TrimPrefix(r.URL.Path, "/v2/")
+	switch {
+	case strings.HasSuffix(p, "/blobs/uploads/") && r.Method == http.MethodPost:
+		w.Header().Set("Location", "/v2/"+p+"session?state=abc")
+		w.WriteHeader(http.StatusAccepted)
+	case strings.Contains(p, "/blobs/uploads/") && r.Method == http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		digest := r.URL.Query().Get("digest")
+		if digest != "sha256:"+hashSHA256(data) || r.URL.Query().Get("state") != "abc" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.blobs[digest] = data
+		w.WriteHeader(http.StatusCreated)
+	case strings.Contains(p, "/blobs/"):
+		digest := p[strings.LastIndex(p, "/")+1:]
+		data, ok := f.blobs[digest]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+	case strings.Contains(p, "/manifests/"):
+		idx := strings.Index(p, "/manifests/")
+		repo, reference := p[:idx], p[idx+len("/manifests/"):]
+		f.serveManifest(w, r, repo, reference)
+	case strings.HasSuffix(p, "/tags/list"):
+		repo := strings.TrimSuffix(p, "/tags/list")
+		if _, ok := f.tags[repo]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		tags := []string{}
+		for tag := range f.tags[repo] {
+			tags = append(tags, tag)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": repo, "tags": tags})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeRegistry) serveManifest(w http.ResponseWriter, r *http.Request, repo, reference string) {
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		digest := "sha256:" + hashSHA256(data)
+		f.manifests[digest] = data
+		if f.tags[repo] == nil {
+			f.tags[repo] = make(map[string]string)
+		}
+		f.tags[repo][reference] = digest
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodHead, http.MethodGet:
+		digest, ok := f.tags[repo][reference]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Docker-Content-Digest", digest)
+		w.Header().Set("Content-Type", ociManifestMediaType)
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(f.manifests[digest])
+		}
+	case http.MethodDelete:
+		if _, ok := f.manifests[reference]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.manifests, reference)
+		for tag, digest := range f.tags[repo] {
+			if digest == reference {
+				delete(f.tags[repo], tag)
+			}
+		}
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newTestOCIBackend(t *testing.T, registry *fakeRegistry, dockerConfig []byte) *OCIBackend {
+	server := httptest.NewServer(registry)
+	t.Cleanup(server.Close)
+
+	backend, err := NewOCIBackend(OCIConfig{
+		Registry:         strings.TrimPrefix(server.URL, "http://"),
+		Repository:       "flux/sources",
+		Insecure:         true,
+		DockerConfigJSON: dockerConfig,
+	})
+	require.NoError(t, err)
+	return backend
+}
+
+func TestNewOCIBackend(t *testing.T) {
+	auth := base64.StdEncoding.EncodeToString([]byte("user:pass"))
+
+	tests := []struct {
+		name         string
+		config       OCIConfig
+		wantUsername string
+		wantPassword string
+		wantErr      string
+	}{
+		{
+			name:   "anonymous",
+			config: OCIConfig{Registry: "ghcr.io", Repository: "org/artifacts"},
+		},
+		{
+			name: "auth field",
+			config: OCIConfig{
+				Registry:         "ghcr.io",
+				Repository:       "org/artifacts",
+				DockerConfigJSON: []byte(`{"auths":{"ghcr.io":{"auth":"` + auth + `"}}}`),
+			},
+			wantUsername: "user",
+			wantPassword: "pass",
+		},
+		{
+			name: "username and password with scheme",
+			config: OCIConfig{
+				Registry:         "registry.example.com",
+				Repository:       "artifacts",
+				DockerConfigJSON: []byte(`{"auths":{"https://registry.example.com/v1/":{"username":"u","password":"p"}}}`),
+			},
+			wantUsername: "u",
+			wantPassword: "p",
+		},
+		{
+			name: "no matching registry",
+			config: OCIConfig{
+				Registry:         "ghcr.io",
+				Repository:       "org/artifacts",
+				DockerConfigJSON: []byte(`{"auths":{"docker.io":{"auth":"` + auth + `"}}}`),
+			},
+			wantErr: "no credentials found",
+		},
+		{
+			name:    "missing registry",
+			config:  OCIConfig{Repository: "org/artifacts"},
+			wantErr: "registry is required",
+		},
+		{
+			name:    "missing repository",
+			config:  OCIConfig{Registry: "ghcr.io"},
+			wantErr: "repository is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, err := NewOCIBackend(tt.config)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantUsername, backend.username)
+			assert.Equal(t, tt.wantPassword, backend.password)
+		})
+	}
+}
+
+func TestOCIBackend_GetURL(t *testing.T) {
+	backend, err := NewOCIBackend(OCIConfig{Registry: "ghcr.io", Repository: "org/artifacts"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "oci://ghcr.io/org/artifacts/default/my-source:abc123",
+		backend.GetURL("artifacts/default/my-source/abc123.tar.gz"))
+}
+
+func TestOCIBackend_BackendType(t *testing.T) {
+	backend, err := NewOCIBackend(OCIConfig{Registry: "ghcr.io", Repository: "org/artifacts"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "oci", BackendType(backend))
+}
+
+func TestOCIBackend_Store(t *testing.T) {
+	registry := newFakeRegistry()
+	backend := newTestOCIBackend(t, registry, nil)
+	data := []byte("artifact content")
+
+	url, err := backend.Store(context.Background(), "artifacts/default/my-source/abc123.tar.gz", data)
+	require.NoError(t, err)
+	assert.Equal(t, backend.GetURL("artifacts/default/my-source/abc123.tar.gz"), url)
+
+	digest, ok := registry.tags["flux/sources/default/my-source"]["abc123"]
+	require.True(t, ok, "manifest should be tagged with the revision")
+
+	var manifest ociManifest
+	require.NoError(t, json.Unmarshal(registry.manifests[digest], &manifest))
+	assert.Equal(t, ociManifestMediaType, manifest.MediaType)
+	assert.Equal(t, ociConfigMediaType, manifest.Config.MediaType)
+	assert.Equal(t, "abc123", manifest.Annotations[ociRevisionAnnotation])
+	require.Len(t, manifest.Layers, 1)
+	assert.Equal(t, ociContentMediaType, manifest.Layers[0].MediaType)
+	assert.Equal(t, "sha256:"+hashSHA256(data), manifest.Layers[0].Digest)
+	assert.Equal(t, data, registry.blobs[manifest.Layers[0].Digest])
+
+	// The published digest is the digest of the layer consumers pull
+	assert.Equal(t, manifest.Layers[0].Digest, backend.ContentDigest(data))
+}
+
+func TestOCIBackend_Store_BearerAuth(t *testing.T) {
+	registry := newFakeRegistry()
+	registry.token = "registry-token"
+	registry.username = "user"
+	registry.password = "pass"
+
+	server := httptest.NewServer(registry)
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	dockerConfig := fmt.Sprintf(`{"auths":{%q:{"username":"user","password":"pass"}}}`, host)
+	backend, err := NewOCIBackend(OCIConfig{
+		Registry:         host,
+		Repository:       "flux/sources",
+		Insecure:         true,
+		DockerConfigJSON: []byte(dockerConfig),
+	})
+	require.NoError(t, err)
+
+	_, err = backend.Store(context.Background(), "artifacts/default/my-source/abc123.tar.gz", []byte("data"))
+	require.NoError(t, err)
+	assert.Contains(t, registry.tags["flux/sources/default/my-source"], "abc123")
+
+	anonymous, err := NewOCIBackend(OCIConfig{Registry: host, Repository: "flux/sources", Insecure: true})
+	require.NoError(t, err)
+	_, err = anonymous.Store(context.Background(), "artifacts/default/my-source/def456.tar.gz", []byte("data"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "token request failed with status 401")
+}
+
+func TestOCIBackend_ListAndDelete(t *testing.T) {
+	registry := newFakeRegistry()
+	backend := newTestOCIBackend(t, registry, nil)
+	ctx := context.Background()
+
+	keys, err := backend.List(ctx, "artifacts/default/my-source/")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	for _, rev := range []string{"rev1", "rev2"} {
+		_, err := backend.Store(ctx, "artifacts/default/my-source/"+rev+".tar.gz", []byte(rev))
+		require.NoError(t, err)
+	}
+
+	keys, err = backend.List(ctx, "artifacts/default/my-source/")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"artifacts/default/my-source/rev1.tar.gz",
+		"artifacts/default/my-source/rev2.tar.gz",
+	}, keys)
+
+	require.NoError(t, backend.Delete(ctx, "artifacts/default/my-source/rev1.tar.gz"))
+	require.NoError(t, backend.Delete(ctx, "artifacts/default/my-source/missing.tar.gz"))
+
+	keys, err = backend.List(ctx, "artifacts/default/my-source/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"artifacts/default/my-source/rev2.tar.gz"}, keys)
+}
+
+func TestOCIBackend_Retrieve(t *testing.T) {
+	registry := newFakeRegistry()
+	backend := newTestOCIBackend(t, registry, nil)
+	ctx := context.Background()
+	key := "artifacts/default/my-source/abc123.tar.gz"
+	data := []byte("artifact content")
+
+	_, err := backend.Retrieve(ctx, key)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "artifact not found")
+
+	_, err = backend.Store(ctx, key, data)
+	require.NoError(t, err)
+
+	retrieved, err := backend.Retrieve(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, data, retrieved)
+
+	// A corrupted layer is rejected
+	digest := "sha256:" + hashSHA256(data)
+	registry.blobs[digest] = []byte("tampered content")
+	_, err = backend.Retrieve(ctx, key)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "digest mismatch")
+}
+
+func TestOCIBackend_Store_Idempotent(t *testing.T) {
+	registry := newFakeRegistry()
+	backend := newTestOCIBackend(t, registry, nil)
+	ctx := context.Background()
+	key := "artifacts/default/my-source/abc123.tar.gz"
+
+	_, err := backend.Store(ctx, key, []byte("artifact content"))
+	require.NoError(t, err)
+	first := registry.tags["flux/sources/default/my-source"]["abc123"]
+
+	// Pushing the same revision again produces the same manifest
+	_, err = backend.Store(ctx, key, []byte("artifact content"))
+	require.NoError(t, err)
+	assert.Equal(t, first, registry.tags["flux/sources/default/my-source"]["abc123"])
+	assert.Len(t, registry.manifests, 1)
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://ghcr.io/token",service="ghcr.io",scope="repository:org/repo:pull,push"`)
+	assert.Equal(t, "bearer", scheme)
+	assert.Equal(t, "https://ghcr.io/token", params["realm"])
+	assert.Equal(t, "ghcr.io", params["service"])
+	assert.Equal(t, "repository:org/repo:pull,push", params["scope"])
+
+	scheme, params = parseChallenge(`Basic realm="Registry"`)
+	assert.Equal(t, "basic", scheme)
+	assert.Equal(t, "Registry", params["realm"])
+}
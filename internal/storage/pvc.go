@@ -27,9 +27,12 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 )
 
 // PVCBackend implements StorageBackend for PVC-based file storage
@@ -49,12 +52,9 @@ func NewPVCBackend(basePath, baseURL string) (*PVCBackend, error) {
 		return nil, fmt.Errorf("failed to create base path %s: %w", basePath, err)
 	}
 
-	// Verify we can write to the directory
-	testFile := filepath.Join(basePath, ".write-test")
-	if err := os.WriteFile(testFile, []byte("test"), 0644); err != nil {
-		return nil, fmt.Errorf("base path %s is not writable: %w", basePath, err)
+	if err := checkWritable(basePath); err != nil {
+		return nil, err
 	}
-	_ = os.Remove(testFile)
 
 	return &PVCBackend{
 		basePath: basePath,
@@ -62,6 +62,30 @@ func NewPVCBackend(basePath, baseURL string) (*PVCBackend, error) {
 	}, nil
 }
 
+// CheckHealth verifies the base path is still a writable directory, detecting
+// unmounted or read-only volumes
+func (p *PVCBackend) CheckHealth(_ context.Context) error {
+	info, err := os.Stat(p.basePath)
+	if err != nil {
+		return fmt.Errorf("failed to stat base path %s: %w", p.basePath, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("base path %s is not a directory", p.basePath)
+	}
+
+	return checkWritable(p.basePath)
+}
+
+// checkWritable verifies a file can be written to the directory
+func checkWritable(dir string) error {
+	testFile := filepath.Join(dir, ".write-test")
+	if err := os.WriteFile(testFile, []byte("test"), 0644); err != nil {
+		return fmt.Errorf("base path %s is not writable: %w", dir, err)
+	}
+	_ = os.Remove(testFile)
+	return nil
+}
+
 // Store saves data to a file and returns the URL
 func (p *PVCBackend) Store(ctx context.Context, key string, data []byte) (string, error) {
 	p.mutex.Lock()
@@ -132,6 +156,28 @@ func (p *PVCBackend) List(ctx context.Context, prefix string) ([]string, error)
 	return keys, nil
 }
 
+// ListObjects returns the files with the given prefix and their modification times
+func (p *PVCBackend) ListObjects(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	keys, err := p.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]ObjectInfo, 0, len(keys))
+	for _, key := range keys {
+		info, err := os.Stat(filepath.Join(p.basePath, filepath.FromSlash(key)))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat %s: %w", key, err)
+		}
+		objects = append(objects, ObjectInfo{Key: key, LastModified: info.ModTime()})
+	}
+
+	return objects, nil
+}
+
 // Delete removes a file from storage
 func (p *PVCBackend) Delete(ctx context.Context, key string) error {
 	p.mutex.Lock()
@@ -161,6 +207,11 @@ func (p *PVCBackend) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
+// BackendType returns "pvc"
+func (p *PVCBackend) BackendType() string {
+	return "pvc"
+}
+
 // GetURL returns the URL for accessing the stored object
 func (p *PVCBackend) GetURL(key string) string {
 	if p.baseURL != "" {
@@ -225,3 +276,153 @@ func (p *PVCBackend) cleanupEmptyDirs(dir string) {
 		p.cleanupEmptyDirs(filepath.Dir(dir))
 	}
 }
+
+// PVCGCConfig configures garbage collection of stale artifacts
+type PVCGCConfig struct {
+	// Interval between garbage collection runs
+	Interval time.Duration
+
+	// MaxAge removes artifacts older than this, zero disables age-based collection
+	MaxAge time.Duration
+
+	// MaxRevisions keeps at most this many artifacts per source, zero disables count-based collection
+	MaxRevisions int
+}
+
+// GCReferences describes the artifacts garbage collection must keep
+type GCReferences struct {
+	// Keys are the storage keys of the artifacts currently referenced, they are never collected
+	Keys map[string]bool
+
+	// HistoryLimits is the number of most recent revisions kept per source, keyed by the
+	// directory holding the artifacts of the source relative to the storage root. They are
+	// kept whatever the configured age or count limits.
+	HistoryLimits map[string]int
+}
+
+// ReferencedKeysFunc returns the artifacts that are currently referenced or retained as history
+type ReferencedKeysFunc func(ctx context.Context) (*GCReferences, error)
+
+// gcCandidate is an artifact file considered for garbage collection
+type gcCandidate struct {
+	key     string
+	path    string
+	modTime time.Time
+}
+
+// RunGarbageCollector periodically garbage collects stale artifacts until the context is cancelled
+func (p *PVCBackend) RunGarbageCollector(ctx context.Context, config PVCGCConfig, referenced ReferencedKeysFunc,
+	onError func(error)) {
+	if config.Interval <= 0 || (config.MaxAge <= 0 && config.MaxRevisions <= 0) {
+		return
+	}
+
+	ticker := time.NewTicker(config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			var references *GCReferences
+			if referenced != nil {
+				var err error
+				references, err = referenced(ctx)
+				if err != nil {
+					// Without knowing what is referenced nothing can be safely deleted
+					if onError != nil {
+						onError(fmt.Errorf("failed to list referenced artifacts: %w", err))
+					}
+					continue
+				}
+			}
+			if _, err := p.GarbageCollect(ctx, config, references, time.Now()); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// GarbageCollect removes stale .tar.gz and .tar.zst artifacts exceeding the configured age or count per source,
+// never removing referenced keys or the history retained for a source. When referenced is nil the newest
+// artifact of each source is kept. It returns the keys that were deleted.
+func (p *PVCBackend) GarbageCollect(ctx context.Context, config PVCGCConfig, referenced *GCReferences,
+	now time.Time) ([]string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	// Group artifacts by the source directory they belong to
+	sources := make(map[string][]gcCandidate)
+	err := filepath.WalkDir(p.basePath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() || !(strings.HasSuffix(d.Name(), ".tar.gz") || strings.HasSuffix(d.Name(), ".tar.zst")) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(p.basePath, path)
+		if err != nil {
+			return err
+		}
+
+		dir := filepath.Dir(path)
+		sources[dir] = append(sources[dir], gcCandidate{
+			key:     filepath.ToSlash(relPath),
+			path:    path,
+			modTime: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan artifacts in %s: %w", p.basePath, err)
+	}
+
+	var deleted []string
+	var gcErrors []error
+	for dir, candidates := range sources {
+		// Newest first, so count-based collection keeps the most recent revisions
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].modTime.After(candidates[j].modTime)
+		})
+
+		for i, candidate := range candidates {
+			if referenced != nil && (referenced.Keys[candidate.key] ||
+				i < referenced.HistoryLimits[path.Dir(candidate.key)]) {
+				continue
+			}
+			if referenced == nil && i == 0 {
+				continue
+			}
+
+			tooOld := config.MaxAge > 0 && now.Sub(candidate.modTime) > config.MaxAge
+			tooMany := config.MaxRevisions > 0 && i >= config.MaxRevisions
+			if !tooOld && !tooMany {
+				continue
+			}
+
+			if err := os.Remove(candidate.path); err != nil && !os.IsNotExist(err) {
+				gcErrors = append(gcErrors, fmt.Errorf("failed to delete %s: %w", candidate.key, err))
+				continue
+			}
+			deleted = append(deleted, candidate.key)
+		}
+
+		// Try to remove empty parent directories (best effort)
+		p.cleanupEmptyDirs(dir)
+	}
+
+	if len(gcErrors) > 0 {
+		return deleted, fmt.Errorf("garbage collection completed with %d errors: %v", len(gcErrors), gcErrors)
+	}
+
+	return deleted, nil
+}
@@ -29,6 +29,7 @@ import (
 	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -71,6 +72,13 @@ func TestNewPVCBackend(t *testing.T) {
 	}
 }
 
+func TestPVCBackend_BackendType(t *testing.T) {
+	backend, err := NewPVCBackend(t.TempDir(), "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "pvc", BackendType(backend))
+}
+
 func TestPVCBackend_Store(t *testing.T) {
 	tempDir := t.TempDir()
 	backend, err := NewPVCBackend(tempDir, "http://test.local:8080")
@@ -261,6 +269,31 @@ func TestPVCBackend_List(t *testing.T) {
 	}
 }
 
+func TestPVCBackend_ListObjects(t *testing.T) {
+	tempDir := t.TempDir()
+	backend, err := NewPVCBackend(tempDir, "http://test.local:8080")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	modified := map[string]time.Time{
+		"source1/rev1.tar.gz": time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		"source1/rev2.tar.gz": time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		"source2/rev1.tar.gz": time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+	}
+	for key, modTime := range modified {
+		_, err := backend.Store(ctx, key, []byte(key))
+		require.NoError(t, err)
+		require.NoError(t, os.Chtimes(filepath.Join(tempDir, key), modTime, modTime))
+	}
+
+	objects, err := backend.ListObjects(ctx, "source1/")
+	require.NoError(t, err)
+	assert.Len(t, objects, 2)
+	for _, object := range objects {
+		assert.True(t, modified[object.Key].Equal(object.LastModified), "unexpected modification time for %s", object.Key)
+	}
+}
+
 func TestPVCBackend_Delete(t *testing.T) {
 	tempDir := t.TempDir()
 	backend, err := NewPVCBackend(tempDir, "http://test.local:8080")
@@ -407,3 +440,109 @@ func TestPVCBackend_CleanupEmptyDirs(t *testing.T) {
 	_, err = backend.Retrieve(ctx, testKey)
 	assert.Error(t, err)
 }
+
+func TestPVCBackend_GarbageCollect(t *testing.T) {
+	now := time.Now()
+
+	storeAged := func(t *testing.T, backend *PVCBackend, key string, age time.Duration) {
+		_, err := backend.Store(context.Background(), key, []byte(key))
+		require.NoError(t, err)
+		modTime := now.Add(-age)
+		require.NoError(t, os.Chtimes(filepath.Join(backend.basePath, key), modTime, modTime))
+	}
+
+	tests := []struct {
+		name        string
+		config      PVCGCConfig
+		referenced  *GCReferences
+		wantDeleted []string
+	}{
+		{
+			name:       "max age keeps referenced revision",
+			config:     PVCGCConfig{MaxAge: 24 * time.Hour},
+			referenced: &GCReferences{Keys: map[string]bool{"artifacts/default/app/rev1.tar.gz": true}},
+			wantDeleted: []string{
+				"artifacts/default/app/rev2.tar.gz",
+				"artifacts/default/deleted/rev1.tar.gz",
+			},
+		},
+		{
+			name:       "max revisions per source",
+			config:     PVCGCConfig{MaxRevisions: 1},
+			referenced: &GCReferences{Keys: map[string]bool{"artifacts/default/app/rev3.tar.gz": true}},
+			wantDeleted: []string{
+				"artifacts/default/app/rev1.tar.gz",
+				"artifacts/default/app/rev2.tar.gz",
+			},
+		},
+		{
+			name:   "history limit above max revisions keeps the retained revisions",
+			config: PVCGCConfig{MaxRevisions: 1},
+			referenced: &GCReferences{
+				Keys:          map[string]bool{"artifacts/default/app/rev3.tar.gz": true},
+				HistoryLimits: map[string]int{"artifacts/default/app": 2},
+			},
+			wantDeleted: []string{
+				"artifacts/default/app/rev1.tar.gz",
+			},
+		},
+		{
+			name:   "history limit keeps revisions older than max age",
+			config: PVCGCConfig{MaxAge: 24 * time.Hour},
+			referenced: &GCReferences{
+				Keys:          map[string]bool{"artifacts/default/app/rev3.tar.gz": true},
+				HistoryLimits: map[string]int{"artifacts/default/app": 3},
+			},
+			wantDeleted: []string{
+				"artifacts/default/deleted/rev1.tar.gz",
+			},
+		},
+		{
+			name:   "without references the newest artifact of each source is kept",
+			config: PVCGCConfig{MaxAge: time.Hour},
+			wantDeleted: []string{
+				"artifacts/default/app/rev1.tar.gz",
+				"artifacts/default/app/rev2.tar.gz",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, err := NewPVCBackend(t.TempDir(), "")
+			require.NoError(t, err)
+
+			storeAged(t, backend, "artifacts/default/app/rev1.tar.gz", 72*time.Hour)
+			storeAged(t, backend, "artifacts/default/app/rev2.tar.gz", 48*time.Hour)
+			storeAged(t, backend, "artifacts/default/app/rev3.tar.gz", time.Minute)
+			storeAged(t, backend, "artifacts/default/deleted/rev1.tar.gz", 96*time.Hour)
+
+			deleted, err := backend.GarbageCollect(context.Background(), tt.config, tt.referenced, now)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.wantDeleted, deleted)
+
+			for _, key := range deleted {
+				_, err := backend.Retrieve(context.Background(), key)
+				assert.Error(t, err, "deleted artifact %s should be gone", key)
+			}
+		})
+	}
+}
+
+func TestPVCBackend_GarbageCollect_CleansEmptyDirs(t *testing.T) {
+	tempDir := t.TempDir()
+	backend, err := NewPVCBackend(tempDir, "")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	key := "artifacts/default/removed/rev1.tar.gz"
+	_, err = backend.Store(ctx, key, []byte("data"))
+	require.NoError(t, err)
+
+	deleted, err := backend.GarbageCollect(ctx, PVCGCConfig{MaxAge: time.Hour}, &GCReferences{}, time.Now().Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []string{key}, deleted)
+
+	_, err = os.Stat(filepath.Join(tempDir, "artifacts"))
+	assert.True(t, os.IsNotExist(err), "empty source directories should be removed after GC")
+}
@@ -25,12 +25,15 @@ package storage
 import (
 	"bytes"
 	"context"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/oddkinco/flux-externalsource-controller/internal/sigv4"
 )
 
 // S3Backend implements StorageBackend for S3-compatible storage
@@ -42,6 +45,16 @@ type S3Backend struct {
 	secretKey  string
 	useSSL     bool
 	httpClient *http.Client
+	now        func() time.Time
+
+	// presignURLs makes GetURL return presigned GET URLs valid for presignExpiry
+	presignURLs   bool
+	presignExpiry time.Duration
+
+	// multipartThreshold is the size above which objects are uploaded in parts of
+	// multipartPartSize, 0 always uses a single PUT
+	multipartThreshold int64
+	multipartPartSize  int64
 }
 
 // S3Config holds configuration for S3-compatible storage
@@ -52,10 +65,40 @@ type S3Config struct {
 	AccessKey string
 	SecretKey string
 	UseSSL    bool
+
+	// PresignURLs makes GetURL return SigV4 presigned GET URLs instead of plain object URLs
+	PresignURLs bool
+
+	// PresignExpiry is how long presigned URLs stay valid, defaulting to one hour
+	PresignExpiry time.Duration
+
+	// MultipartThreshold uploads objects larger than this many bytes with a multipart upload
+	// instead of a single PUT, 0 always uses a single PUT
+	MultipartThreshold int64
+
+	// MultipartPartSize is the size of each part of a multipart upload, defaulting to 16MiB
+	// and raised to MinMultipartPartSize when smaller
+	MultipartPartSize int64
 }
 
+// defaultPresignExpiry is used when presigning is enabled without an expiry
+const defaultPresignExpiry = time.Hour
+
 // NewS3Backend creates a new S3-compatible storage backend
 func NewS3Backend(config S3Config) *S3Backend {
+	presignExpiry := config.PresignExpiry
+	if presignExpiry <= 0 {
+		presignExpiry = defaultPresignExpiry
+	}
+	if presignExpiry > sigv4.MaxPresignExpiry {
+		presignExpiry = sigv4.MaxPresignExpiry
+	}
+	partSize := config.MultipartPartSize
+	if partSize <= 0 {
+		partSize = defaultMultipartPartSize
+	}
+	partSize = max(partSize, MinMultipartPartSize)
+
 	return &S3Backend{
 		endpoint:  config.Endpoint,
 		bucket:    config.Bucket,
@@ -66,11 +109,22 @@ func NewS3Backend(config S3Config) *S3Backend {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		presignURLs:   config.PresignURLs,
+		presignExpiry: presignExpiry,
+		now:           time.Now,
+
+		multipartThreshold: config.MultipartThreshold,
+		multipartPartSize:  partSize,
 	}
 }
 
-// Store uploads data to S3-compatible storage
+// Store uploads data to S3-compatible storage, with a multipart upload when the data is larger
+// than the multipart threshold
 func (s *S3Backend) Store(ctx context.Context, key string, data []byte) (string, error) {
+	if s.multipartThreshold > 0 && int64(len(data)) > s.multipartThreshold {
+		return s.storeMultipart(ctx, key, data)
+	}
+
 	// Construct the URL
 	objectURL := s.buildObjectURL(key)
 
@@ -84,11 +138,8 @@ func (s *S3Backend) Store(ctx context.Context, key string, data []byte) (string,
 	req.Header.Set("Content-Type", "application/octet-stream")
 	req.Header.Set("Content-Length", fmt.Sprintf("%d", len(data)))
 
-	// Add authentication headers (simplified - in production use proper AWS signature)
-	if s.accessKey != "" && s.secretKey != "" {
-		// This is a simplified auth approach - in production, implement proper AWS Signature V4
-		req.Header.Set("Authorization", fmt.Sprintf("AWS %s:%s", s.accessKey, s.secretKey))
-	}
+	// Sign the request
+	s.signRequest(req, data)
 
 	// Execute request
 	resp, err := s.httpClient.Do(req)
@@ -107,13 +158,86 @@ func (s *S3Backend) Store(ctx context.Context, key string, data []byte) (string,
 		return "", fmt.Errorf("S3 upload failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
-	return objectURL, nil
+	return s.GetURL(key), nil
 }
 
 // List returns a list of keys with the given prefix
 func (s *S3Backend) List(ctx context.Context, prefix string) ([]string, error) {
+	pages, err := s.listBucket(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	// This is a simplified parser - in production, use proper XML parsing
+	var keys []string
+	for _, body := range pages {
+		keys = append(keys, s.parseListResponse(string(body), prefix)...)
+	}
+	return keys, nil
+}
+
+// ListObjects returns the objects with the given prefix and their modification times
+func (s *S3Backend) ListObjects(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	pages, err := s.listBucket(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []ObjectInfo
+	for _, body := range pages {
+		var result struct {
+			Contents []struct {
+				Key          string    `xml:"Key"`
+				LastModified time.Time `xml:"LastModified"`
+			} `xml:"Contents"`
+		}
+		if err := xml.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse list response: %w", err)
+		}
+
+		for _, object := range result.Contents {
+			if strings.HasPrefix(object.Key, prefix) {
+				objects = append(objects, ObjectInfo{Key: object.Key, LastModified: object.LastModified})
+			}
+		}
+	}
+
+	return objects, nil
+}
+
+// listBucket sends ListObjectsV2 requests for the prefix, following continuation tokens until
+// the listing is no longer truncated, and returns the body of every page
+func (s *S3Backend) listBucket(ctx context.Context, prefix string) ([][]byte, error) {
+	var pages [][]byte
+	continuationToken := ""
+	for {
+		body, err := s.listBucketPage(ctx, prefix, continuationToken)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, body)
+
+		var page struct {
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+		}
+		if err := xml.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse list response: %w", err)
+		}
+		if !page.IsTruncated {
+			return pages, nil
+		}
+		if page.NextContinuationToken == "" || page.NextContinuationToken == continuationToken {
+			return nil, fmt.Errorf("S3 list response for prefix %q is truncated without a new continuation token", prefix)
+		}
+		continuationToken = page.NextContinuationToken
+	}
+}
+
+// listBucketPage sends a single ListObjectsV2 request and returns the response body
+func (s *S3Backend) listBucketPage(ctx context.Context, prefix, continuationToken string) ([]byte, error) {
 	// Construct the list URL
-	listURL := s.buildListURL(prefix)
+	listURL := s.buildListURL(prefix, continuationToken)
 
 	// Create HTTP request
 	req, err := http.NewRequestWithContext(ctx, "GET", listURL, nil)
@@ -121,10 +245,8 @@ func (s *S3Backend) List(ctx context.Context, prefix string) ([]string, error) {
 		return nil, fmt.Errorf("failed to create list request: %w", err)
 	}
 
-	// Add authentication headers
-	if s.accessKey != "" && s.secretKey != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("AWS %s:%s", s.accessKey, s.secretKey))
-	}
+	// Sign the request
+	s.signRequest(req, nil)
 
 	// Execute request
 	resp, err := s.httpClient.Do(req)
@@ -143,15 +265,34 @@ func (s *S3Backend) List(ctx context.Context, prefix string) ([]string, error) {
 		return nil, fmt.Errorf("S3 list failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
-	// Parse response (simplified - in production, parse XML response properly)
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read list response: %w", err)
 	}
 
-	// This is a simplified parser - in production, use proper XML parsing
-	keys := s.parseListResponse(string(body), prefix)
-	return keys, nil
+	return body, nil
+}
+
+// CheckHealth sends a HEAD request for the bucket to verify it is reachable with the configured credentials
+func (s *S3Backend) CheckHealth(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, s.buildObjectURL(""), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create bucket request: %w", err)
+	}
+
+	s.signRequest(req, nil)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach bucket %s: %w", s.bucket, err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("S3 bucket check for %s failed with status %d", s.bucket, resp.StatusCode)
+	}
+
+	return nil
 }
 
 // Delete removes an object from S3-compatible storage
@@ -165,10 +306,8 @@ func (s *S3Backend) Delete(ctx context.Context, key string) error {
 		return fmt.Errorf("failed to create delete request: %w", err)
 	}
 
-	// Add authentication headers
-	if s.accessKey != "" && s.secretKey != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("AWS %s:%s", s.accessKey, s.secretKey))
-	}
+	// Sign the request
+	s.signRequest(req, nil)
 
 	// Execute request
 	resp, err := s.httpClient.Do(req)
@@ -190,9 +329,57 @@ func (s *S3Backend) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
-// GetURL returns the URL for accessing the stored object
+// BackendType returns "s3"
+func (s *S3Backend) BackendType() string {
+	return "s3"
+}
+
+// GetURL returns the URL for accessing the stored object. When presigning is enabled
+// and credentials are configured, the URL carries a time-limited SigV4 signature.
 func (s *S3Backend) GetURL(key string) string {
-	return s.buildObjectURL(key)
+	objectURL := s.buildObjectURL(key)
+
+	signer := s.signer()
+	if !s.presignURLs || signer == nil {
+		return objectURL
+	}
+
+	u, err := url.Parse(objectURL)
+	if err != nil {
+		return objectURL
+	}
+
+	return signer.Presign(http.MethodGet, u, s.presignExpiry, s.now()).String()
+}
+
+// signRequest signs the request with AWS Signature Version 4 when credentials are configured.
+// Requests to anonymous buckets are sent unsigned.
+func (s *S3Backend) signRequest(req *http.Request, payload []byte) {
+	signer := s.signer()
+	if signer == nil {
+		return
+	}
+
+	signer.Sign(req, sigv4.HashSHA256(payload), s.now())
+}
+
+// signer returns the SigV4 signer for the backend, or nil when no credentials are configured
+func (s *S3Backend) signer() *sigv4.Signer {
+	if s.accessKey == "" || s.secretKey == "" {
+		return nil
+	}
+
+	region := s.region
+	if region == "" {
+		region = sigv4.DefaultRegion
+	}
+
+	return &sigv4.Signer{
+		AccessKey: s.accessKey,
+		SecretKey: s.secretKey,
+		Region:    region,
+		Service:   "s3",
+	}
 }
 
 // buildObjectURL constructs the URL for an object
@@ -208,8 +395,9 @@ func (s *S3Backend) buildObjectURL(key string) string {
 	return fmt.Sprintf("%s://%s/%s/%s", scheme, s.endpoint, s.bucket, cleanKey)
 }
 
-// buildListURL constructs the URL for listing objects
-func (s *S3Backend) buildListURL(prefix string) string {
+// buildListURL constructs the URL for listing objects, continuing a truncated listing when
+// continuationToken is set
+func (s *S3Backend) buildListURL(prefix, continuationToken string) string {
 	scheme := "https"
 	if !s.useSSL {
 		scheme = "http"
@@ -223,6 +411,9 @@ func (s *S3Backend) buildListURL(prefix string) string {
 		params.Set("prefix", prefix)
 	}
 	params.Set("list-type", "2") // Use ListObjectsV2
+	if continuationToken != "" {
+		params.Set("continuation-token", continuationToken)
+	}
 
 	if len(params) > 0 {
 		return baseURL + "?" + params.Encode()
@@ -0,0 +1,219 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	// MinMultipartPartSize is the smallest part size S3 accepts for all but the last part
+	MinMultipartPartSize = 5 << 20
+
+	// defaultMultipartPartSize is used when multipart uploads are enabled without a part size
+	defaultMultipartPartSize = 16 << 20
+
+	// multipartAbortTimeout bounds the request aborting a failed multipart upload, which is
+	// sent even when the upload failed because its context was cancelled
+	multipartAbortTimeout = 30 * time.Second
+)
+
+// completedPart identifies an uploaded part in a CompleteMultipartUpload request
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// storeMultipart uploads data in parts of s.multipartPartSize, aborting the upload when any
+// step fails so the parts uploaded so far are not left behind in the bucket
+func (s *S3Backend) storeMultipart(ctx context.Context, key string, data []byte) (string, error) {
+	objectURL := s.buildObjectURL(key)
+
+	uploadID, err := s.initiateMultipartUpload(ctx, objectURL)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.uploadParts(ctx, objectURL, uploadID, data); err != nil {
+		abortCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), multipartAbortTimeout)
+		defer cancel()
+		if abortErr := s.abortMultipartUpload(abortCtx, objectURL, uploadID); abortErr != nil {
+			return "", fmt.Errorf("%w (abort failed: %v)", err, abortErr)
+		}
+		return "", err
+	}
+
+	return s.GetURL(key), nil
+}
+
+// uploadParts uploads all parts of data and completes the multipart upload
+func (s *S3Backend) uploadParts(ctx context.Context, objectURL, uploadID string, data []byte) error {
+	partSize := s.multipartPartSize
+	if partSize <= 0 {
+		partSize = defaultMultipartPartSize
+	}
+
+	var parts []completedPart
+	for offset := int64(0); offset < int64(len(data)); offset += partSize {
+		end := min(offset+partSize, int64(len(data)))
+		partNumber := len(parts) + 1
+		etag, err := s.uploadPart(ctx, objectURL, uploadID, partNumber, data[offset:end])
+		if err != nil {
+			return err
+		}
+		parts = append(parts, completedPart{PartNumber: partNumber, ETag: etag})
+	}
+
+	return s.completeMultipartUpload(ctx, objectURL, uploadID, parts)
+}
+
+// initiateMultipartUpload starts a multipart upload and returns its upload ID
+func (s *S3Backend) initiateMultipartUpload(ctx context.Context, objectURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, objectURL+"?uploads", nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create multipart upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	body, _, err := s.doMultipartRequest(req, nil, "initiate")
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to parse multipart upload response: %w", err)
+	}
+	if result.UploadID == "" {
+		return "", fmt.Errorf("S3 multipart upload response has no upload ID")
+	}
+	return result.UploadID, nil
+}
+
+// uploadPart uploads a single part and returns its ETag
+func (s *S3Backend) uploadPart(ctx context.Context, objectURL, uploadID string, partNumber int, part []byte) (string, error) {
+	params := url.Values{}
+	params.Set("partNumber", strconv.Itoa(partNumber))
+	params.Set("uploadId", uploadID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, objectURL+"?"+params.Encode(), bytes.NewReader(part))
+	if err != nil {
+		return "", fmt.Errorf("failed to create upload part request: %w", err)
+	}
+	req.Header.Set("Content-Length", strconv.Itoa(len(part)))
+
+	_, header, err := s.doMultipartRequest(req, part, fmt.Sprintf("part %d", partNumber))
+	if err != nil {
+		return "", err
+	}
+
+	etag := header.Get("ETag")
+	if etag == "" {
+		return "", fmt.Errorf("S3 multipart upload part %d response has no ETag", partNumber)
+	}
+	return etag, nil
+}
+
+// completeMultipartUpload assembles the uploaded parts into the object
+func (s *S3Backend) completeMultipartUpload(ctx context.Context, objectURL, uploadID string, parts []completedPart) error {
+	payload, err := xml.Marshal(struct {
+		XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+		Parts   []completedPart `xml:"Part"`
+	}{Parts: parts})
+	if err != nil {
+		return fmt.Errorf("failed to encode multipart upload parts: %w", err)
+	}
+
+	params := url.Values{}
+	params.Set("uploadId", uploadID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, objectURL+"?"+params.Encode(), bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create complete multipart upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/xml")
+	req.Header.Set("Content-Length", strconv.Itoa(len(payload)))
+
+	body, _, err := s.doMultipartRequest(req, payload, "complete")
+	if err != nil {
+		return err
+	}
+
+	// S3 reports errors that occur while assembling the parts in a 200 response
+	var result struct {
+		XMLName xml.Name
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if err := xml.Unmarshal(body, &result); err == nil && result.XMLName.Local == "Error" {
+		return fmt.Errorf("S3 multipart upload complete failed: %s: %s", result.Code, result.Message)
+	}
+	return nil
+}
+
+// abortMultipartUpload discards the parts of a multipart upload
+func (s *S3Backend) abortMultipartUpload(ctx context.Context, objectURL, uploadID string) error {
+	params := url.Values{}
+	params.Set("uploadId", uploadID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, objectURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create abort multipart upload request: %w", err)
+	}
+
+	_, _, err = s.doMultipartRequest(req, nil, "abort")
+	return err
+}
+
+// doMultipartRequest signs and sends a multipart upload request, returning the response body
+// and headers of a successful response
+func (s *S3Backend) doMultipartRequest(req *http.Request, payload []byte, step string) ([]byte, http.Header, error) {
+	s.signRequest(req, payload)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to send S3 multipart upload %s request: %w", step, err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil { //nolint:staticcheck,revive // SA9003: Intentionally empty - we don't want to fail S3 operations due to close errors
+			// Log error but don't fail the operation
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read S3 multipart upload %s response: %w", step, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, nil, fmt.Errorf("S3 multipart upload %s failed with status %d: %s", step, resp.StatusCode, string(body))
+	}
+	return body, resp.Header, nil
+}
@@ -0,0 +1,189 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockMultipartS3 records the requests of multipart uploads and assembles completed uploads
+type mockMultipartS3 struct {
+	mu       sync.Mutex
+	requests []string
+	parts    map[string][]byte
+	objects  map[string][]byte
+
+	// failPart makes uploads of this part number fail with a 500
+	failPart string
+}
+
+func (m *mockMultipartS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	query := r.URL.Query()
+	body, _ := io.ReadAll(r.Body)
+	switch {
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		m.requests = append(m.requests, "initiate")
+		_, _ = w.Write([]byte(`<InitiateMultipartUploadResult><UploadId>upload-1</UploadId></InitiateMultipartUploadResult>`))
+	case r.Method == http.MethodPut && query.Get("uploadId") != "":
+		partNumber := query.Get("partNumber")
+		m.requests = append(m.requests, fmt.Sprintf("part %s (%d bytes)", partNumber, len(body)))
+		if partNumber == m.failPart {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		m.parts[partNumber] = body
+		w.Header().Set("ETag", fmt.Sprintf(`"etag-%s"`, partNumber))
+	case r.Method == http.MethodPost && query.Get("uploadId") != "":
+		m.requests = append(m.requests, "complete")
+		var complete struct {
+			Parts []completedPart `xml:"Part"`
+		}
+		if err := xml.Unmarshal(body, &complete); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var object []byte
+		for _, part := range complete.Parts {
+			if part.ETag != fmt.Sprintf(`"etag-%d"`, part.PartNumber) {
+				_, _ = w.Write([]byte(`<Error><Code>InvalidPart</Code><Message>unknown part</Message></Error>`))
+				return
+			}
+			object = append(object, m.parts[fmt.Sprint(part.PartNumber)]...)
+		}
+		m.objects[r.URL.Path] = object
+		_, _ = w.Write([]byte(`<CompleteMultipartUploadResult><ETag>"object"</ETag></CompleteMultipartUploadResult>`))
+	case r.Method == http.MethodDelete && query.Get("uploadId") != "":
+		m.requests = append(m.requests, "abort")
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut:
+		m.requests = append(m.requests, fmt.Sprintf("put (%d bytes)", len(body)))
+		m.objects[r.URL.Path] = body
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newMultipartTestBackend(t *testing.T, mock *mockMultipartS3) *S3Backend {
+	t.Helper()
+	mock.parts = map[string][]byte{}
+	mock.objects = map[string][]byte{}
+	server := httptest.NewServer(mock)
+	t.Cleanup(server.Close)
+
+	return NewS3Backend(S3Config{
+		Endpoint:           strings.TrimPrefix(server.URL, "http://"),
+		Bucket:             "test-bucket",
+		AccessKey:          "test-key",
+		SecretKey:          "test-secret",
+		MultipartThreshold: MinMultipartPartSize,
+		MultipartPartSize:  MinMultipartPartSize,
+	})
+}
+
+func TestS3Backend_StoreMultipart(t *testing.T) {
+	mock := &mockMultipartS3{}
+	backend := newMultipartTestBackend(t, mock)
+
+	data := bytes.Repeat([]byte("0123456789abcdef"), (2*MinMultipartPartSize+1024)/16)
+	url, err := backend.Store(context.Background(), "default/app/rev.tar.gz", data)
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(url, "/test-bucket/default/app/rev.tar.gz"))
+
+	assert.Equal(t, []string{
+		"initiate",
+		fmt.Sprintf("part 1 (%d bytes)", MinMultipartPartSize),
+		fmt.Sprintf("part 2 (%d bytes)", MinMultipartPartSize),
+		"part 3 (1024 bytes)",
+		"complete",
+	}, mock.requests)
+	assert.Equal(t, data, mock.objects["/test-bucket/default/app/rev.tar.gz"])
+}
+
+func TestS3Backend_StoreMultipart_BelowThreshold(t *testing.T) {
+	mock := &mockMultipartS3{}
+	backend := newMultipartTestBackend(t, mock)
+
+	_, err := backend.Store(context.Background(), "default/app/rev.tar.gz", []byte("small artifact"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"put (14 bytes)"}, mock.requests)
+}
+
+func TestS3Backend_StoreMultipart_AbortsOnFailure(t *testing.T) {
+	mock := &mockMultipartS3{failPart: "2"}
+	backend := newMultipartTestBackend(t, mock)
+
+	data := bytes.Repeat([]byte{'x'}, 3*MinMultipartPartSize)
+	_, err := backend.Store(context.Background(), "default/app/rev.tar.gz", data)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "S3 multipart upload part 2 failed with status 500")
+
+	assert.Equal(t, []string{
+		"initiate",
+		fmt.Sprintf("part 1 (%d bytes)", MinMultipartPartSize),
+		fmt.Sprintf("part 2 (%d bytes)", MinMultipartPartSize),
+		"abort",
+	}, mock.requests)
+	assert.Empty(t, mock.objects)
+}
+
+func TestS3Backend_StoreMultipart_CompleteError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Query().Has("uploads"):
+			_, _ = w.Write([]byte(`<InitiateMultipartUploadResult><UploadId>upload-1</UploadId></InitiateMultipartUploadResult>`))
+		case r.Method == http.MethodPut:
+			w.Header().Set("ETag", `"etag"`)
+		case r.Method == http.MethodPost:
+			// Errors while assembling the parts are reported in a 200 response
+			_, _ = w.Write([]byte(`<Error><Code>InternalError</Code><Message>We encountered an internal error.</Message></Error>`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	backend := NewS3Backend(S3Config{
+		Endpoint:           strings.TrimPrefix(server.URL, "http://"),
+		Bucket:             "test-bucket",
+		MultipartThreshold: 1,
+	})
+
+	_, err := backend.Store(context.Background(), "default/app/rev.tar.gz", []byte("data"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "S3 multipart upload complete failed: InternalError")
+}
@@ -0,0 +1,159 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package validation checks fetched content before it is packaged into an artifact.
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// FormatJSON requires the content to be a single JSON document
+	FormatJSON = "json"
+
+	// FormatYAML requires every document in the content to be a YAML mapping or sequence
+	FormatYAML = "yaml"
+)
+
+// schemaURL is the resource name the schema is registered under when compiling
+const schemaURL = "externalsource://schema.json"
+
+// Validate checks that data is in the given format, defaulting to JSON, and matches
+// the JSON schema when one is provided. YAML documents are validated against the
+// schema individually.
+func Validate(data []byte, format string, schema []byte) error {
+	documents, err := parse(data, format)
+	if err != nil {
+		return err
+	}
+
+	if len(schema) == 0 {
+		return nil
+	}
+
+	compiled, err := compileSchema(schema)
+	if err != nil {
+		return err
+	}
+
+	for i, document := range documents {
+		if err := compiled.Validate(document); err != nil {
+			if len(documents) > 1 {
+				return fmt.Errorf("document %d does not match schema: %w", i, err)
+			}
+			return fmt.Errorf("content does not match schema: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// parse decodes data into the generic values the schema validator expects
+func parse(data []byte, format string) ([]any, error) {
+	switch format {
+	case "", FormatJSON:
+		document, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("content is not valid JSON: %w", err)
+		}
+		return []any{document}, nil
+	case FormatYAML:
+		return parseYAML(data)
+	default:
+		return nil, fmt.Errorf("unsupported content format: %s", format)
+	}
+}
+
+// parseYAML decodes every YAML document, rejecting scalars so plain text such as an
+// HTML error page isn't accepted as YAML
+func parseYAML(data []byte) ([]any, error) {
+	var documents []any
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+
+	for {
+		var node yaml.Node
+		if err := decoder.Decode(&node); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("content is not valid YAML: %w", err)
+		}
+
+		root := &node
+		if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+			root = root.Content[0]
+		}
+		if root.Kind != yaml.MappingNode && root.Kind != yaml.SequenceNode {
+			return nil, fmt.Errorf("content is not valid YAML: document %d is not a mapping or sequence", len(documents))
+		}
+
+		// Round trip through JSON so the schema validator sees JSON types
+		var value any
+		if err := root.Decode(&value); err != nil {
+			return nil, fmt.Errorf("content is not valid YAML: %w", err)
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("YAML document %d cannot be represented as JSON: %w", len(documents), err)
+		}
+		document, err := jsonschema.UnmarshalJSON(bytes.NewReader(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("YAML document %d cannot be represented as JSON: %w", len(documents), err)
+		}
+		documents = append(documents, document)
+	}
+
+	if len(documents) == 0 {
+		return nil, fmt.Errorf("content is not valid YAML: no documents")
+	}
+
+	return documents, nil
+}
+
+// compileSchema compiles a JSON schema without loading any external references
+func compileSchema(schema []byte) (*jsonschema.Schema, error) {
+	document, err := jsonschema.UnmarshalJSON(bytes.NewReader(schema))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.UseLoader(jsonschema.SchemeURLLoader{})
+	if err := compiler.AddResource(schemaURL, document); err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+
+	compiled, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+
+	return compiled, nil
+}
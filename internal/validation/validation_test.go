@@ -0,0 +1,132 @@
+/*
+Copyright (c) 2025 Odd Kin <oddkin@oddkin.co>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+const testSchema = `{
+	"type": "object",
+	"required": ["name"],
+	"properties": {
+		"name": {"type": "string"},
+		"replicas": {"type": "integer", "minimum": 1}
+	}
+}`
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		format      string
+		schema      string
+		expectError string
+	}{
+		{
+			name: "valid JSON",
+			data: `{"name": "app"}`,
+		},
+		{
+			name:        "HTML error page as JSON",
+			data:        "<!DOCTYPE html><html><body>Service Unavailable</body></html>",
+			format:      FormatJSON,
+			expectError: "content is not valid JSON",
+		},
+		{
+			name:   "valid YAML",
+			data:   "name: app\nreplicas: 2\n",
+			format: FormatYAML,
+		},
+		{
+			name:   "multi-document YAML",
+			data:   "name: app\n---\nname: other\n",
+			format: FormatYAML,
+			schema: testSchema,
+		},
+		{
+			name:        "HTML error page as YAML",
+			data:        "<!DOCTYPE html>\n<html><body>Service Unavailable</body></html>\n",
+			format:      FormatYAML,
+			expectError: "not a mapping or sequence",
+		},
+		{
+			name:        "empty YAML",
+			data:        "",
+			format:      FormatYAML,
+			expectError: "no documents",
+		},
+		{
+			name:   "JSON matching schema",
+			data:   `{"name": "app", "replicas": 3}`,
+			schema: testSchema,
+		},
+		{
+			name:        "JSON not matching schema",
+			data:        `{"replicas": 0}`,
+			schema:      testSchema,
+			expectError: "content does not match schema",
+		},
+		{
+			name:        "YAML document not matching schema",
+			data:        "name: app\n---\nreplicas: 2\n",
+			format:      FormatYAML,
+			schema:      testSchema,
+			expectError: "document 1 does not match schema",
+		},
+		{
+			name:        "invalid schema",
+			data:        `{"name": "app"}`,
+			schema:      `{"type": 12}`,
+			expectError: "invalid JSON schema",
+		},
+		{
+			name:        "remote references are not loaded",
+			data:        `{"name": "app"}`,
+			schema:      `{"$ref": "https://example.com/schema.json"}`,
+			expectError: "invalid JSON schema",
+		},
+		{
+			name:        "unsupported format",
+			data:        `{"name": "app"}`,
+			format:      "toml",
+			expectError: "unsupported content format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate([]byte(tt.data), tt.format, []byte(tt.schema))
+			if tt.expectError == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+				t.Errorf("Expected error containing %q, got %v", tt.expectError, err)
+			}
+		})
+	}
+}